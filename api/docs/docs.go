@@ -15,24 +15,36 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/files/cleanup": {
+        "/admin/cache": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Admin-only endpoint to delete files older than N days",
+                "description": "Natijalar keshi: yozuvlar soni, keshdan berilgan joblar (hits) va natija fayllari hajmi, amal turlari bo‘yicha (faqat admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "file"
+                    "admin"
                 ],
-                "summary": "Cleanup old files",
+                "summary": "Job result cache stats",
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobCacheStats"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -44,53 +56,50 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/logs/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get logs by job ID (for debugging or audit)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Kesh yozuvlarini o‘chiradi (masalan, amal kutubxonasi yangilangandan keyin). Foydalanuvchilarga berilgan natija fayllari o‘chmaydi (faqat admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "logs"
+                    "admin"
                 ],
-                "summary": "Get logs for a specific job",
+                "summary": "Purge job result cache",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Faqat shu amal turi (masalan, compress)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true bo‘lsa faqat muddati o‘tgan yozuvlar",
+                        "name": "expired",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Log"
-                            }
+                            "$ref": "#/definitions/models.JobCachePurgeResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -104,37 +113,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/add-page-numbers": {
-            "post": {
-                "consumes": [
-                    "application/json"
+        "/admin/cleanup/runs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Avtomatik va qo‘lda ishga tushirilgan tozalashlar tarixi, yangisi birinchi (faqat admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PDF"
+                    "admin"
                 ],
-                "summary": "Add page numbers to PDF",
+                "summary": "Cleanup history",
                 "parameters": [
                     {
-                        "description": "PDF ID and font settings",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.AddPageNumbersRequest"
-                        }
+                        "type": "integer",
+                        "description": "Nechta yozuv (standart 50, maksimal 500)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.CleanupRunListResponse"
                         }
                     },
                     "400": {
@@ -143,6 +149,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -152,33 +170,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/add-page-numbers/{id}": {
+        "/admin/queue": {
             "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Navbat chuqurligi: yo‘laklar (priority, guest), kechiktirilgan vazifalar va amal turlari bo‘yicha pending/processing joblar hamda worker chegaralari (faqat admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PDF"
-                ],
-                "summary": "Get add page numbers job by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Job ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "Queue stats",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.AddPageNumberJob"
+                            "$ref": "#/definitions/models.QueueStats"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -186,13 +213,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/compress": {
-            "post": {
+        "/admin/queue/limits/{type}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Amal turi uchun bir vaqtda bajariladigan joblar soni (barcha instansiyalar bo‘yicha; faqat admin)",
                 "consumes": [
                     "application/json"
                 ],
@@ -200,28 +228,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-compress"
+                    "admin"
                 ],
-                "summary": "Create compress job",
+                "summary": "Set job type limit",
                 "parameters": [
                     {
-                        "description": "compress job",
+                        "type": "string",
+                        "description": "Job turi (masalan, pdf_to_jpg)",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "chegara",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CompressRequest"
+                            "$ref": "#/definitions/models.SetJobTypeLimitRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.JobTypeLimit"
                         }
                     },
                     "400": {
@@ -230,6 +262,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -237,30 +281,26 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/pdf/compress/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Amal turi chegarasini olib tashlash - shu turdagi joblar umumiy workerlar soni bilan cheklanadi (faqat admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-compress"
+                    "admin"
                 ],
-                "summary": "Get compress job by ID",
+                "summary": "Delete job type limit",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "compress job ID",
-                        "name": "id",
+                        "description": "Job turi",
+                        "name": "type",
                         "in": "path",
                         "required": true
                     }
@@ -269,7 +309,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.CompressJob"
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
@@ -287,42 +339,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/crop": {
-            "post": {
-                "description": "PDF sahifalarini belgilangan tomonlardan qirqadi (top, bottom, left, right)",
-                "consumes": [
-                    "application/json"
+        "/admin/quotas/plans": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Tariflar va ularning standart chegaralari (faqat admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PDF Edit"
+                    "admin"
                 ],
-                "summary": "Crop PDF pages",
-                "parameters": [
-                    {
-                        "description": "Crop request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                "summary": "Quota plans",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.CropPDFRequest"
+                            "$ref": "#/definitions/models.QuotaPlanListResponse"
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -336,21 +382,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/crop/{id}": {
+        "/admin/quotas/{user_id}": {
             "get": {
-                "description": "Crop PDF jarayonining natijasini olish",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Foydalanuvchining egallagan joyi va amaldagi kvotasi (faqat admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PDF Edit"
+                    "admin"
                 ],
-                "summary": "Get Crop Job info",
+                "summary": "Get user quota",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
-                        "name": "id",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     }
@@ -359,26 +410,36 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.CropPDFJob"
+                            "$ref": "#/definitions/models.UserUsage"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/pdf/detect-blank": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "PDF fayldagi bo‘sh sahifalarni aniqlaydi",
+                "description": "Foydalanuvchi tarifini va/yoki chegaralarini belgilaydi (avvalgi override almashtiriladi). Berilmagan chegara tarifdan olinadi, 0 - cheklanmagan (faqat admin)",
                 "consumes": [
                     "application/json"
                 ],
@@ -386,28 +447,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-detect-blank"
+                    "admin"
                 ],
-                "summary": "Detect blank pages in PDF",
+                "summary": "Set user quota",
                 "parameters": [
                     {
-                        "description": "Input PDF file ID",
-                        "name": "inputFileID",
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tarif va chegaralar",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.DetectBlankPagesRequest"
+                            "$ref": "#/definitions/models.SetUserQuotaRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.UserUsage"
                         }
                     },
                     "400": {
@@ -416,35 +481,51 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/api/pdf/detect-blank/{id}": {
-            "get": {
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Detect blank pages job holatini oladi",
+                "description": "Admin belgilagan tarif va chegaralarni olib tashlaydi - foydalanuvchi standart tarifga qaytadi (faqat admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-detect-blank"
+                    "admin"
                 ],
-                "summary": "Get Detect Blank Pages Job Status",
+                "summary": "Reset user quota",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
-                        "name": "id",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     }
@@ -453,7 +534,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.DetectBlankPagesJob"
+                            "$ref": "#/definitions/models.UserUsage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
@@ -471,9 +564,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/excel-to-pdf": {
+        "/admin/reconcile": {
             "post": {
-                "description": "Excel faylni PDF formatga o‘tkazish",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mazmuni yo‘qolgan files yozuvlarini va diskdagi hech qaysi yozuvga tegishli bo‘lmagan fayllarni (storage/, tmp/, lokal blob papkalari) topuvchi job yaratadi.\ndry_run (standart true) da faqat hisobot tuziladi; false bo‘lsa yozuvlar va yetim fayllar o‘chiriladi. JSON hisobot /admin/reconcile/{id} yoki /api/jobs/{id} dagi result da (faqat admin)",
                 "consumes": [
                     "application/json"
                 ],
@@ -481,37 +579,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Excel to PDF"
+                    "admin"
                 ],
-                "summary": "Excel → PDF konvertatsiya qilish",
+                "summary": "Reconcile disk and files table",
                 "parameters": [
                     {
-                        "description": "Excel fayl IDsi",
+                        "description": "Reconcile parametrlari",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ExcelToPDFRequest"
+                            "$ref": "#/definitions/models.ReconcileRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Yaratilgan job ID",
+                        "description": "Created",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -520,6 +608,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -529,20 +629,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/excel-to-pdf/{id}": {
+        "/admin/reconcile/{id}": {
             "get": {
-                "description": "Job statusini ko‘rish",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Reconcile job holati; tugagach result da models.ReconcileReport (faqat admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Excel to PDF"
+                    "admin"
                 ],
-                "summary": "Excel→PDF Job holatini olish",
+                "summary": "Get reconcile job",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
+                        "description": "Reconcile Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -552,17 +657,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ExcelToPDFJob"
+                            "$ref": "#/definitions/models.Job"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -570,8 +681,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/extract": {
+        "/api/batches": {
             "post": {
+                "description": "Bitta amalni ko‘p faylga qo‘llash (masalan, 50 ta hisob-fakturani siqish). params - shu amalning odatiy so‘rov modeli, kiruvchi fayl maydoni har bir fayl uchun input_file_ids dan to‘ldiriladi. zip=true bo‘lsa barcha natijalar bitta ZIP ga yig‘iladi.",
                 "consumes": [
                     "application/json"
                 ],
@@ -579,18 +691,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-extract"
+                    "batches"
                 ],
-                "summary": "Create extract job",
+                "summary": "Create batch",
                 "parameters": [
                     {
-                        "description": "extract request body",
+                        "description": "batch so‘rovi",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ExtractPagesRequest"
+                            "$ref": "#/definitions/models.CreateBatchRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -609,6 +727,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -618,42 +748,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/extract/{id}": {
+        "/api/batches/{id}": {
             "get": {
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Batch holati: result.items - har bir fayl natijasi va job IDsi, result.zip_file_id - barcha natijalar ZIP arxivi (zip=true bo‘lsa)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-extract"
+                    "batches"
                 ],
-                "summary": "Get extract job by ID",
+                "summary": "Get batch",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "extract job ID",
+                        "description": "Batch job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ExtractJob"
+                            "$ref": "#/definitions/models.Job"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -661,43 +795,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/header-footer": {
-            "post": {
+        "/api/jobs": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "PDF faylga yuqori yoki quyi matn qo‘shish",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Foydalanuvchining joblari ro‘yxati (turi va holati bo‘yicha filtrlash mumkin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-header-footer"
+                    "jobs"
                 ],
-                "summary": "Add header and/or footer to PDF",
+                "summary": "List jobs",
                 "parameters": [
                     {
-                        "description": "Header/Footer parametrlari",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateAddHeaderFooterRequest"
-                        }
+                        "type": "string",
+                        "description": "Job turi (merge, split, compress, ...)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job holati (pending, processing, done, failed, dead, cancelled)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.JobListResponse"
                         }
                     },
                     "400": {
@@ -706,6 +837,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -715,21 +852,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/header-footer/{id}": {
+        "/api/jobs/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "PDF faylga header/footer qo‘shish jarayonining holatini olish",
+                "description": "Istalgan turdagi job holatini ID orqali olish (job egasi, admin yoki job yaratilganda qaytarilgan access_token bilan)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-header-footer"
+                    "jobs"
                 ],
-                "summary": "Get header/footer job status",
+                "summary": "Get job",
                 "parameters": [
                     {
                         "type": "string",
@@ -737,98 +874,61 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.AddHeaderFooterJob"
+                            "$ref": "#/definitions/models.Job"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/api/pdf/html-to-pdf": {
-            "post": {
-                "description": "HTML kontentni PDF formatga o‘tkazish",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "HTML to PDF"
-                ],
-                "summary": "Convert HTML to PDF",
-                "parameters": [
-                    {
-                        "description": "HTML to PDF request",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateHTMLToPDFRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "job_id qaytadi",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/pdf/html-to-pdf/{id}": {
-            "get": {
-                "description": "Konvertatsiya holatini olish",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Jobni o‘chirish (faqat job egasi yoki admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "HTML to PDF"
+                    "jobs"
                 ],
-                "summary": "Get HTML→PDF Job Status",
+                "summary": "Delete job",
                 "parameters": [
                     {
                         "type": "string",
@@ -842,71 +942,87 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.HTMLToPDFJob"
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
-                    }
-                }
-            }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
         },
-        "/api/pdf/inspect": {
+        "/api/jobs/{id}/cancel": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "PDF faylning strukturasi, sahifa soni, sarlavha, muallif va boshqa metadata’larni olish",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Pending yoki processing holatidagi jobni bekor qilish; bajarilayotgan jarayon to‘xtatiladi va qisman natijalar o‘chiriladi (faqat job egasi yoki admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "inspect"
+                    "jobs"
                 ],
-                "summary": "Inspect PDF metadata",
+                "summary": "Cancel job",
                 "parameters": [
                     {
-                        "description": "Inspect request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.InspectRequest"
-                        }
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.Job"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -920,39 +1036,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/inspect/{id}": {
+        "/api/jobs/{id}/events": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Inspect ishining natijasini ko‘rish",
+                "description": "Job holati o‘zgarishlari va sahifa jarayonini (masalan, 14/120) Server-Sent Events orqali uzatadi. Birinchi hodisa - jobning joriy holati; job yakunlanganda oqim yopiladi (job egasi, admin yoki ?access_token= bilan; EventSource header yubora olmaydi).",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "inspect"
+                    "jobs"
                 ],
-                "summary": "Get Inspect Job",
+                "summary": "Job events (SSE)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Inspect Job ID",
+                        "description": "Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.InspectJob"
+                            "$ref": "#/definitions/models.JobEvent"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -972,47 +1100,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/jpg-to-pdf": {
+        "/api/jobs/{id}/retry": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Convert a JPG to PDF",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Failed, dead yoki cancelled holatidagi jobni qaytadan navbatga qo‘yish (faqat job egasi yoki admin). Parolli joblar (protect, unlock) qayta ishga tushirilmaydi - parol job tugashi bilan o‘chiriladi, ularni qayta yuborish kerak (409)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "jpg-to-pdf"
+                    "jobs"
                 ],
-                "summary": "Convert JPG to PDF",
+                "summary": "Retry job",
                 "parameters": [
                     {
-                        "description": "JPG to PDF request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateJPGToPDFRequest"
-                        }
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.Job"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1026,21 +1164,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/jpg-to-pdf/{id}": {
+        "/api/jobs/{id}/webhooks": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "JPG to PDF konversiya ishining holatini olish",
+                "description": "callback_url ga yuborilgan webhooklar logi (har bir urinish alohida yozuv; faqat job egasi yoki admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "jpg-to-pdf"
+                    "jobs"
                 ],
-                "summary": "Get JPG to PDF job",
+                "summary": "Job webhook deliveries",
                 "parameters": [
                     {
                         "type": "string",
@@ -1054,59 +1192,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.JPGToPDFJob"
+                            "$ref": "#/definitions/models.WebhookDeliveryListResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/api/pdf/merge": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "pdf-merge"
-                ],
-                "summary": "Create merge job",
-                "parameters": [
-                    {
-                        "description": "merge job",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateMergeJobRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1120,41 +1222,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/merge/process/{id}": {
+        "/api/jobs/{id}/ws": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "SSE bilan bir xil hodisalarni WebSocket orqali JSON xabarlar ko‘rinishida uzatadi (job egasi, admin yoki ?access_token= bilan).\nBrauzerdan ulanishda Origin API ning o‘z manzili yoki WS_ALLOWED_ORIGINS dagi manzil bo‘lishi kerak, aks holda 403.",
                 "tags": [
-                    "pdf-merge"
+                    "jobs"
                 ],
-                "summary": "Process merge job",
+                "summary": "Job events (WebSocket)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "merge job ID",
+                        "description": "Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobEvent"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1162,13 +1277,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/merge/{id}": {
+        "/api/logs/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "Get logs by job ID (for debugging or audit)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1176,13 +1292,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-merge"
+                    "logs"
                 ],
-                "summary": "Get merge job",
+                "summary": "Get logs for a specific job",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "merge job ID",
+                        "description": "Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1192,7 +1308,16 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.MergeJob"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Log"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
@@ -1210,9 +1335,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/pdf-to-jpg": {
+        "/api/pdf/add-background": {
             "post": {
-                "description": "Convert a PDF file's pages into JPG format",
+                "description": "PDF sahifalari ortiga yuklangan rasm (background_image_file_id, o‘zingiz yoki mehmon sifatida yuklagan PNG/JPEG; boshqa foydalanuvchining fayli 403) yoki bir xil rang (color) qo‘yadi. Fon kontent ortida joylashadi. scale_to_fit=true - rasm sahifaga to‘liq sig‘adi, aks holda scale - sahifa kengligiga nisbatan o‘lcham.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1220,18 +1345,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-to-jpg"
+                    "PDF Edit"
                 ],
-                "summary": "Convert PDF to JPG",
+                "summary": "Add background to PDF",
                 "parameters": [
                     {
-                        "description": "PDF file ID",
+                        "description": "Add background request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.PDFToJPGRequest"
+                            "$ref": "#/definitions/models.CreateAddBackgroundRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -1250,6 +1381,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1259,16 +1408,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/pdf-to-jpg/{id}": {
+        "/api/pdf/add-background/{id}": {
             "get": {
-                "description": "Retrieve the status of the conversion job by Job ID",
+                "description": "Orqa fon qo‘shish jarayonining natijasini olish",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-to-jpg"
+                    "PDF Edit"
                 ],
-                "summary": "Get PDF to JPG conversion job",
+                "summary": "Get Add Background Job info",
                 "parameters": [
                     {
                         "type": "string",
@@ -1276,23 +1425,29 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.PDFToJPGJob"
+                            "$ref": "#/definitions/models.Job"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1300,9 +1455,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/pdf-to-word": {
+        "/api/pdf/add-page-numbers": {
             "post": {
-                "description": "PDF faylni Word formatiga o‘tkazish",
                 "consumes": [
                     "application/json"
                 ],
@@ -1310,18 +1464,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PDF to Word"
+                    "PDF"
                 ],
-                "summary": "Convert PDF to Word",
+                "summary": "Add page numbers to PDF",
                 "parameters": [
                     {
-                        "description": "PDF to Word request",
-                        "name": "request",
-                        "in": "body",
+                        "description": "PDF ID and font settings",
+                        "name": "data",
+                        "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.PDFToWordRequest"
+                            "$ref": "#/definitions/models.AddPageNumbersRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -1340,6 +1500,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1349,16 +1521,15 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/pdf-to-word/{id}": {
+        "/api/pdf/add-page-numbers/{id}": {
             "get": {
-                "description": "PDF to Word ish holatini ko‘rish",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PDF to Word"
+                    "PDF"
                 ],
-                "summary": "Get PDF to Word Job",
+                "summary": "Get add page numbers job by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -1366,23 +1537,29 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.PDFToWordJob"
+                            "$ref": "#/definitions/models.Job"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1390,9 +1567,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/ppt-to-pdf": {
+        "/api/pdf/compress": {
             "post": {
-                "description": "PowerPoint faylni PDF formatga o‘tkazish",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -1400,37 +1581,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PowerPoint to PDF"
+                    "pdf-compress"
                 ],
-                "summary": "Convert PowerPoint to PDF",
+                "summary": "Create compress job",
                 "parameters": [
                     {
-                        "description": "PowerPoint to PDF request",
-                        "name": "data",
+                        "description": "compress job",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.PowerPointToPDFRequest"
+                            "$ref": "#/definitions/models.CompressRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "job_id qaytadi",
+                        "description": "Created",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -1448,30 +1626,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/ppt-to-pdf/{id}": {
+        "/api/pdf/compress/{id}": {
             "get": {
-                "description": "Konvertatsiya holatini olish",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PowerPoint to PDF"
+                    "pdf-compress"
                 ],
-                "summary": "Get PowerPoint→PDF Job Status",
+                "summary": "Get compress job by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
+                        "description": "compress job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.PowerPointToPDFJob"
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
@@ -1480,6 +1677,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1489,9 +1698,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/protect": {
+        "/api/pdf/crop": {
             "post": {
-                "description": "PDF fayliga parol qo‘shadi (foydalanuvchi tomonidan berilgan parol asosida)",
+                "description": "PDF sahifalarini belgilangan tomonlardan qirqadi (top, bottom, left, right)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1499,18 +1708,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PDF Security"
+                    "PDF Edit"
                 ],
-                "summary": "Protect PDF with password",
+                "summary": "Crop PDF pages",
                 "parameters": [
                     {
-                        "description": "Protect request",
+                        "description": "Crop request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ProtectPDFRequest"
+                            "$ref": "#/definitions/models.CropPDFRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -1529,6 +1744,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1538,16 +1765,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/protect/{id}": {
+        "/api/pdf/crop/{id}": {
             "get": {
-                "description": "Parollangan PDF faylga oid ish holatini qaytaradi",
+                "description": "Crop PDF jarayonining natijasini olish",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PDF Security"
+                    "PDF Edit"
                 ],
-                "summary": "Get protected PDF job by ID",
+                "summary": "Get Crop Job info",
                 "parameters": [
                     {
                         "type": "string",
@@ -1555,13 +1782,25 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ProtectPDFJob"
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
@@ -1573,14 +1812,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/qr-code": {
+        "/api/pdf/detect-blank": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "PDF faylga QR kod joylashtirish",
+                "description": "PDF fayldagi bo‘sh sahifalarni aniqlaydi",
                 "consumes": [
                     "application/json"
                 ],
@@ -1588,18 +1827,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-qr-code"
+                    "pdf-detect-blank"
                 ],
-                "summary": "Add QR code to PDF",
+                "summary": "Detect blank pages in PDF",
                 "parameters": [
                     {
-                        "description": "QR Code request",
-                        "name": "request",
+                        "description": "Input PDF file ID",
+                        "name": "inputFileID",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateQRCodeRequest"
+                            "$ref": "#/definitions/models.DetectBlankPagesRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -1618,6 +1863,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1627,21 +1884,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/qr-code/{id}": {
+        "/api/pdf/detect-blank/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "QR kod joylashtirish ishining holatini olish",
+                "description": "Detect blank pages job holatini oladi",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-qr-code"
+                    "pdf-detect-blank"
                 ],
-                "summary": "Get QR code job status",
+                "summary": "Get Detect Blank Pages Job Status",
                 "parameters": [
                     {
                         "type": "string",
@@ -1649,13 +1906,25 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.QRCodeJob"
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
@@ -1673,8 +1942,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/removepage": {
+        "/api/pdf/excel-to-pdf": {
             "post": {
+                "description": "Excel faylni PDF formatga o‘tkazish",
                 "consumes": [
                     "application/json"
                 ],
@@ -1682,28 +1952,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-remove"
+                    "Excel to PDF"
                 ],
-                "summary": "Create remove pages job",
+                "summary": "Excel → PDF konvertatsiya qilish",
                 "parameters": [
                     {
-                        "description": "remove pages job",
+                        "description": "Excel fayl IDsi",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.RemovePagesRequest"
+                            "$ref": "#/definitions/models.ExcelToPDFRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created",
+                        "description": "Yaratilgan job ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1712,12 +1997,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1727,32 +2006,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/removepage/{id}": {
+        "/api/pdf/excel-to-pdf/{id}": {
             "get": {
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Job statusini ko‘rish",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-remove"
+                    "Excel to PDF"
                 ],
-                "summary": "Get remove pages job by ID",
+                "summary": "Excel→PDF Job holatini olish",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "remove job ID",
+                        "description": "Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.RemoveJob"
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
@@ -1761,6 +2050,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1770,9 +2071,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/rotate": {
+        "/api/pdf/extract": {
             "post": {
-                "description": "PDF fayl sahifalarini aylantiradi (90, 180 yoki 270 gradus)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1780,18 +2080,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PDF Edit"
+                    "pdf-extract"
                 ],
-                "summary": "Rotate PDF pages",
+                "summary": "Create extract job",
                 "parameters": [
                     {
-                        "description": "Rotate request",
+                        "description": "extract request body",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.RotatePDFRequest"
+                            "$ref": "#/definitions/models.ExtractPagesRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -1819,30 +2125,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/rotate/{id}": {
+        "/api/pdf/extract/{id}": {
             "get": {
-                "description": "Rotate PDF jarayonining natijasini olish",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PDF Edit"
+                    "pdf-extract"
                 ],
-                "summary": "Get Rotate Job info",
+                "summary": "Get extract job by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
+                        "description": "extract job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.RotateJob"
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
@@ -1850,13 +2170,36 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
                     }
                 }
             }
         },
-        "/api/pdf/share": {
+        "/api/pdf/header-footer": {
             "post": {
-                "description": "PDF faylni boshqalar bilan ulashish uchun link yaratish",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "PDF faylga yuqori yoki quyi matn qo‘shish",
                 "consumes": [
                     "application/json"
                 ],
@@ -1864,18 +2207,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share"
+                    "pdf-header-footer"
                 ],
-                "summary": "Faylga ulashish havolasi yaratish",
+                "summary": "Add header and/or footer to PDF",
                 "parameters": [
                     {
-                        "description": "File ID and expiration date",
+                        "description": "Header/Footer parametrlari",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateSharedLinkRequest"
+                            "$ref": "#/definitions/models.CreateAddHeaderFooterRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -1894,6 +2243,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1903,30 +2264,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/share/{token}": {
+        "/api/pdf/header-footer/{id}": {
             "get": {
-                "description": "Token orqali faylga kirish",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "PDF faylga header/footer qo‘shish jarayonining holatini olish",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "share"
+                    "pdf-header-footer"
                 ],
-                "summary": "Ulashilgan faylni olish",
+                "summary": "Get header/footer job status",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Shared link token",
-                        "name": "token",
+                        "description": "Job ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SharedLink"
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
@@ -1944,8 +2322,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/split": {
+        "/api/pdf/html-to-pdf": {
             "post": {
+                "description": "HTML kontentni PDF formatga o‘tkazish",
                 "consumes": [
                     "application/json"
                 ],
@@ -1953,28 +2332,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-split"
+                    "HTML to PDF"
                 ],
-                "summary": "Create split job",
+                "summary": "Convert HTML to PDF",
                 "parameters": [
                     {
-                        "description": "split job",
-                        "name": "request",
+                        "description": "HTML to PDF request",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateSplitJobRequest"
+                            "$ref": "#/definitions/models.CreateHTMLToPDFRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created",
+                        "description": "job_id qaytadi",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1992,32 +2386,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/split/{id}": {
+        "/api/pdf/html-to-pdf/{id}": {
             "get": {
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Konvertatsiya holatini olish",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pdf-split"
+                    "HTML to PDF"
                 ],
-                "summary": "Get split job by ID",
+                "summary": "Get HTML→PDF Job Status",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "split job ID",
+                        "description": "Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SplitJob"
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
@@ -2035,9 +2439,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/unlock": {
+        "/api/pdf/inspect": {
             "post": {
-                "description": "Qulflangan PDF faylni ochish (parolsiz qilish)",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "PDF faylning strukturasi, sahifa soni, sarlavha, muallif va boshqa metadata’larni olish",
                 "consumes": [
                     "application/json"
                 ],
@@ -2045,18 +2454,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PDF Unlock"
+                    "inspect"
                 ],
-                "summary": "Unlock PDF file",
+                "summary": "Inspect PDF metadata",
                 "parameters": [
                     {
-                        "description": "Unlock PDF request",
+                        "description": "Inspect request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UnlockPDFRequest"
+                            "$ref": "#/definitions/models.InspectRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -2075,6 +2490,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2084,30 +2517,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/unlock/{id}": {
+        "/api/pdf/inspect/{id}": {
             "get": {
-                "description": "Unlock PDF ish holatini ko‘rish",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Inspect ishining natijasini ko‘rish",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PDF Unlock"
+                    "inspect"
                 ],
-                "summary": "Get Unlock Job status",
+                "summary": "Get Inspect Job",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Job ID",
+                        "description": "Inspect Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UnlockPDFJob"
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
@@ -2125,9 +2581,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/word-to-pdf": {
+        "/api/pdf/jpg-to-pdf": {
             "post": {
-                "description": "Word hujjatni PDF formatga o‘tkazish",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Convert a JPG to PDF",
                 "consumes": [
                     "application/json"
                 ],
@@ -2135,18 +2596,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Word to PDF"
+                    "jpg-to-pdf"
                 ],
-                "summary": "Convert Word to PDF",
+                "summary": "Convert JPG to PDF",
                 "parameters": [
                     {
-                        "description": "Word to PDF so‘rovi",
+                        "description": "JPG to PDF request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.WordToPDFRequest"
+                            "$ref": "#/definitions/models.CreateJPGToPDFRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -2165,6 +2632,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2174,16 +2653,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/pdf/word-to-pdf/{id}": {
+        "/api/pdf/jpg-to-pdf/{id}": {
             "get": {
-                "description": "Konvertatsiya holatini olish",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "JPG to PDF konversiya ishining holatini olish",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Word to PDF"
+                    "jpg-to-pdf"
                 ],
-                "summary": "Get Word→PDF Job Status",
+                "summary": "Get JPG to PDF job",
                 "parameters": [
                     {
                         "type": "string",
@@ -2191,13 +2675,25 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.WordToPDFJob"
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
@@ -2215,14 +2711,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/stats/user": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Foydalanuvchining PDF bo‘yicha statistikasi (birlashtirish, bo‘lish va h.k.)",
+        "/api/pdf/merge": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -2230,88 +2720,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stats"
+                    "pdf-merge"
                 ],
-                "summary": "Foydalanuvchi statistikasi",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.UserStats"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/file/list": {
-            "get": {
-                "security": [
+                "summary": "Create merge job",
+                "parameters": [
                     {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "file"
-                ],
-                "summary": "List all user's files",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                        "description": "merge job",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.File"
-                            }
+                            "$ref": "#/definitions/models.CreateMergeJobRequest"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/file/upload": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "file"
-                ],
-                "summary": "Upload file",
-                "parameters": [
                     {
-                        "type": "file",
-                        "description": "Upload file",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -2330,6 +2756,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2339,34 +2777,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/file/{id}": {
+        "/api/pdf/merge/process/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Jobni bajarmaydi - merge job navbatdagi worker tomonidan bajariladi. Job tugagan bo‘lsa natija fayli IDsi, aks holda 202 va joriy holat qaytariladi.",
                 "tags": [
-                    "file"
+                    "pdf-merge"
                 ],
-                "summary": "Get file by ID",
+                "summary": "Merge job result (eski manzil)",
+                "deprecated": true,
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "File ID",
+                        "description": "merge job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.File"
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
@@ -2374,36 +2829,65 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/pdf/merge/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "file"
+                    "pdf-merge"
                 ],
-                "summary": "Delete file by ID",
+                "summary": "Get merge job",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "File ID",
+                        "description": "merge job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "500": {
@@ -2415,14 +2899,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
+        "/api/pdf/organize": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "User login with email and password",
+                "description": "PDF sahifalarini new_order bo‘yicha qayta tartiblaydi: sahifa raqamini takrorlash uni nusxalaydi, 0 - bo‘sh sahifa qo‘yadi, ro‘yxatda yo‘q sahifalar olib tashlanadi. rotations - natijadagi o‘rin bo‘yicha burish (90 ga karrali). Har bir sahifa raqami hujjatda bo‘lishi shart.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2430,25 +2909,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "PDF Edit"
                 ],
-                "summary": "Login user",
+                "summary": "Organize PDF pages",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "login",
+                        "description": "Organize request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
+                            "$ref": "#/definitions/models.CreateOrganizeJobRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.LoginResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -2457,8 +2945,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2472,39 +2966,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/me": {
+        "/api/pdf/organize/{id}": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Foydalanuvchining o‘z profilini olish (JWT token orqali)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Sahifalarni tartiblash jarayonining natijasini olish",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "PDF Edit"
+                ],
+                "summary": "Get Organize Job info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get my profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Job"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2512,13 +3013,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/otp/confirm": {
+        "/api/pdf/pdf-to-jpg": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
+                "description": "Convert a PDF file's pages into JPG format",
                 "consumes": [
                     "application/json"
                 ],
@@ -2526,23 +3023,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "otp"
+                    "pdf-to-jpg"
                 ],
-                "summary": "Confirm OTP code",
+                "summary": "Convert PDF to JPG",
                 "parameters": [
                     {
-                        "description": "otp info",
+                        "description": "PDF file ID",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ConfirmOtpRequest"
+                            "$ref": "#/definitions/models.PDFToJPGRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2556,12 +3059,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2571,46 +3068,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/otp/send": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/pdf/pdf-to-jpg/{id}": {
+            "get": {
+                "description": "Retrieve the status of the conversion job by Job ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "otp"
+                    "pdf-to-jpg"
                 ],
-                "summary": "Send OTP to email",
+                "summary": "Get PDF to JPG conversion job",
                 "parameters": [
                     {
-                        "description": "email info",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.SendOtpRequest"
-                        }
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.Job"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2624,13 +3133,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/role": {
+        "/api/pdf/pdf-to-word": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
+                "description": "PDF faylni Word formatiga o‘tkazish",
                 "consumes": [
                     "application/json"
                 ],
@@ -2638,18 +3143,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "role"
+                    "PDF to Word"
                 ],
-                "summary": "Create role",
+                "summary": "Convert PDF to Word",
                 "parameters": [
                     {
-                        "description": "role",
+                        "description": "PDF to Word request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateRole"
+                            "$ref": "#/definitions/models.PDFToWordRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -2677,50 +3188,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/role/{id}": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/pdf/pdf-to-word/{id}": {
+            "get": {
+                "description": "PDF to Word ish holatini ko‘rish",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "role"
+                    "PDF to Word"
                 ],
-                "summary": "Update role",
+                "summary": "Get PDF to Word Job",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "role_id",
+                        "description": "Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "update info",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateRole"
-                        }
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2734,13 +3253,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/roles": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
+        "/api/pdf/ppt-to-pdf": {
+            "post": {
+                "description": "PowerPoint faylni PDF formatga o‘tkazish",
                 "consumes": [
                     "application/json"
                 ],
@@ -2748,14 +3263,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "role"
+                    "PowerPoint to PDF"
+                ],
+                "summary": "Convert PowerPoint to PDF",
+                "parameters": [
+                    {
+                        "description": "PowerPoint to PDF request",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PowerPointToPDFRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
                 ],
-                "summary": "List all roles",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "job_id qaytadi",
                         "schema": {
-                            "$ref": "#/definitions/models.RoleListResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "500": {
@@ -2767,53 +3317,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/signup": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Register a new user after OTP confirmation",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/pdf/ppt-to-pdf/{id}": {
+            "get": {
+                "description": "Konvertatsiya holatini olish",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "PowerPoint to PDF"
                 ],
-                "summary": "Create new user",
+                "summary": "Get PowerPoint→PDF Job Status",
                 "parameters": [
                     {
-                        "description": "Signup data",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateUser"
-                        }
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.Job"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2827,14 +3382,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysuser": {
+        "/api/pdf/protect": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Superadmin tomonidan tizim user (admin, buxgalter) yaratish",
+                "description": "PDF fayliga parol qo‘shadi (foydalanuvchi tomonidan berilgan parol asosida)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2842,18 +3392,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sysuser"
+                    "PDF Security"
                 ],
-                "summary": "Create system user",
+                "summary": "Protect PDF with password",
                 "parameters": [
                     {
-                        "description": "sysuser info",
+                        "description": "Protect request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateSysUser"
+                            "$ref": "#/definitions/models.ProtectPDFRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -2872,6 +3428,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2880,159 +3448,3182 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.AddHeaderFooterJob": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "font_color": {
-                    "type": "string"
-                },
-                "font_size": {
-                    "type": "integer"
-                },
-                "footer_text": {
-                    "type": "string"
-                },
-                "header_text": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "input_file_id": {
-                    "type": "string"
-                },
-                "output_file_id": {
-                    "type": "string"
-                },
-                "position": {
-                    "type": "string"
-                },
-                "status": {
-                    "description": "pending, done, failed",
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "string"
+        },
+        "/api/pdf/protect/{id}": {
+            "get": {
+                "description": "Parollangan PDF faylga oid ish holatini qaytaradi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PDF Security"
+                ],
+                "summary": "Get protected PDF job by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/qr-code": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "PDF faylga QR kod joylashtirish",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pdf-qr-code"
+                ],
+                "summary": "Add QR code to PDF",
+                "parameters": [
+                    {
+                        "description": "QR Code request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateQRCodeRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/qr-code/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "QR kod joylashtirish ishining holatini olish",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pdf-qr-code"
+                ],
+                "summary": "Get QR code job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/removepage": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pdf-remove"
+                ],
+                "summary": "Create remove pages job",
+                "parameters": [
+                    {
+                        "description": "remove pages job",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RemovePagesRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/removepage/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pdf-remove"
+                ],
+                "summary": "Get remove pages job by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "remove job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/rotate": {
+            "post": {
+                "description": "PDF fayl sahifalarini aylantiradi (90, 180 yoki 270 gradus)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PDF Edit"
+                ],
+                "summary": "Rotate PDF pages",
+                "parameters": [
+                    {
+                        "description": "Rotate request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RotatePDFRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/rotate/{id}": {
+            "get": {
+                "description": "Rotate PDF jarayonining natijasini olish",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PDF Edit"
+                ],
+                "summary": "Get Rotate Job info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/share": {
+            "post": {
+                "description": "PDF faylni boshqalar bilan ulashish uchun link yaratish",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "Faylga ulashish havolasi yaratish",
+                "parameters": [
+                    {
+                        "description": "File ID and expiration date",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateSharedLinkRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/share/{token}": {
+            "get": {
+                "description": "Token orqali faylga kirish",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "Ulashilgan faylni olish",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shared link token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SharedLink"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/split": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pdf-split"
+                ],
+                "summary": "Create split job",
+                "parameters": [
+                    {
+                        "description": "split job",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateSplitJobRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/split/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pdf-split"
+                ],
+                "summary": "Get split job by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "split job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/unlock": {
+            "post": {
+                "description": "Qulflangan PDF faylni ochish (parolsiz qilish)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PDF Unlock"
+                ],
+                "summary": "Unlock PDF file",
+                "parameters": [
+                    {
+                        "description": "Unlock PDF request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UnlockPDFRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/unlock/{id}": {
+            "get": {
+                "description": "Unlock PDF ish holatini ko‘rish",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PDF Unlock"
+                ],
+                "summary": "Get Unlock Job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/watermark": {
+            "post": {
+                "description": "PDF ga matnli (type=text) yoki rasmli (type=image, image_file_id - o‘zingiz yoki mehmon sifatida yuklangan PNG/JPEG; boshqa foydalanuvchining fayli 403) suv belgisi qo‘shadi. mode=stamp - kontent ustida, mode=watermark - kontent ortida. tile=true - butun sahifa bo‘ylab takrorlanadi, diagonal=true - chap pastdan o‘ng yuqoriga.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PDF Edit"
+                ],
+                "summary": "Add watermark to PDF",
+                "parameters": [
+                    {
+                        "description": "Watermark request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddWatermarkRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/watermark/{id}": {
+            "get": {
+                "description": "Suv belgisi qo‘shish jarayonining natijasini olish",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PDF Edit"
+                ],
+                "summary": "Get Watermark Job info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/word-to-pdf": {
+            "post": {
+                "description": "Word hujjatni PDF formatga o‘tkazish",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Word to PDF"
+                ],
+                "summary": "Convert Word to PDF",
+                "parameters": [
+                    {
+                        "description": "Word to PDF so‘rovi",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WordToPDFRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pdf/word-to-pdf/{id}": {
+            "get": {
+                "description": "Konvertatsiya holatini olish",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Word to PDF"
+                ],
+                "summary": "Get Word→PDF Job Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pipelines": {
+            "post": {
+                "description": "Bir nechta amalni ketma-ket bajarish (masalan, merge → compress → add_page_numbers → protect). Har bir qadam params i shu amalning odatiy so‘rov modeli; ikkinchi qadamdan boshlab kiruvchi fayl oldingi qadam natijasidan olinadi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pipelines"
+                ],
+                "summary": "Create pipeline",
+                "parameters": [
+                    {
+                        "description": "pipeline qadamlari",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreatePipelineRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pipelines/{id}": {
+            "get": {
+                "description": "Pipeline holati: result.steps - har bir qadam holati va job IDsi, result.output_file_ids - yakuniy fayllar",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pipelines"
+                ],
+                "summary": "Get pipeline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pipeline job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job yaratilganda qaytarilgan token (mehmon joblari uchun)",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stats/user": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Foydalanuvchining PDF bo‘yicha statistikasi (birlashtirish, bo‘lish va h.k.)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Foydalanuvchi statistikasi",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserStats"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/by-hash/{sha256}": {
+            "head": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Foydalanuvchi shu SHA-256 xeshli faylni avval yuklaganmi. Topilsa 200 va X-File-Id header (GET da fayl ma’lumoti ham) qaytadi - faylni qayta yuklash shart emas.",
+                "tags": [
+                    "file"
+                ],
+                "summary": "Check file by content hash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fayl mazmunining SHA-256 xeshi (hex)",
+                        "name": "sha256",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.File"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/cleanup": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Saqlash muddati bo‘yicha tozalashni darhol ishga tushiradi: muddati o‘tgan mehmon va foydalanuvchi fayllari, ulashish havolalari va ishchi papkalardagi yetim fayllar o‘chiriladi.\nBiriktirilgan, amaldagi havolasi bor va bajarilayotgan job kiruvchi fayllari o‘chirilmaydi (faqat admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Run retention cleanup",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CleanupRun"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/folders": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Papka ichidagi papkalar; parent_id berilmasa ildizdagilar",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "List folders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ota papka ID",
+                        "name": "parent_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FolderListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fayllar kutubxonasida papka yaratadi; parent_id berilmasa ildizda. Bitta papka ichida nomlar takrorlanmaydi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Create folder",
+                "parameters": [
+                    {
+                        "description": "Papka",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateFolderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Folder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/folders/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Papkani ichki papkalari bilan o‘chiradi. Fayllar o‘chirilmaydi - ular ildizga o‘tadi.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Delete folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Papkani qayta nomlaydi va/yoki boshqa papkaga ko‘chiradi (parent_id \"\" - ildizga). Papkani o‘zining ichiga ko‘chirib bo‘lmaydi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Rename or move folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "O‘zgarishlar",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateFolderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Folder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Foydalanuvchi fayllari kutubxonasi: papka, teg, tur, sana, hajm, nom va manba bo‘yicha filtr, saralash va sahifalash.\nsource_file_id shu fayldan olingan natijalarni (masalan, job_type=compress bilan - uning siqilgan nusxasini) topadi.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "List user's files",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Papka ID yoki root (faqat papkasiz fayllar)",
+                        "name": "folder_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Teg",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fayl turi: MIME (application/pdf) yoki kengaytma (pdf)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Shu vaqtdan keyin yuklangan (RFC3339 yoki YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Shu vaqtgacha yuklangan (RFC3339 yoki YYYY-MM-DD, kun kiradi)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimal hajm (bayt)",
+                        "name": "min_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maksimal hajm (bayt)",
+                        "name": "max_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nom bo‘yicha qidiruv",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Shu fayldan olingan natijalar",
+                        "name": "source_file_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Shu amal natijalari (masalan, compress)",
+                        "name": "job_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name, size, type yoki uploaded_at (standart)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc yoki desc (uploaded_at uchun standart desc, qolganlari uchun asc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sahifa (1 dan)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sahifadagi fayllar (standart 50, maksimal 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FileListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/tags": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Foydalanuvchi fayllaridagi teglar va ular qo‘yilgan fayllar soni",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "List my tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TagCount"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/upload": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fayl turi kengaytmadan emas, mazmunidan aniqlanadi (PDF, JPEG/PNG/GIF, Word, Excel, PowerPoint). Kengaytmasi mazmuniga mos kelmagan fayl 422, boshqa turlar 415 bilan rad etiladi. Foydalanuvchi xotira kvotasidan oshsa 403, fayl hajmi chegaradan (UPLOAD_GUEST_MAX_SIZE / UPLOAD_USER_MAX_SIZE) katta bo‘lsa 413.\nPDF pdfcpu bilan tekshiriladi: buzilgan yoki sahifalari juda ko‘p PDF rad etiladi, parol bilan himoyalangani \"encrypted\" deb belgilanadi.\nSkaner yoqilgan bo‘lsa fayl zararli dasturga tekshiriladi: topilsa fayl karantinda saqlanadi (422, uni jobga berib bo‘lmaydi), skaner ishlamasa 503.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Upload file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Upload file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/uploads": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Bo‘laklab yuklashni boshlaydi. Javobdagi Location ga PATCH so‘rovlari bilan bo‘laklar yuboriladi.\nUpload-Metadata: \"filename \u003cbase64\u003e\" (tus formati). Hajm chegarasi: mehmon va ro‘yxatdan o‘tgan foydalanuvchi uchun alohida; fayl foydalanuvchi kvotasiga ham sig‘ishi kerak (aks holda 403).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Create resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Faylning to‘liq hajmi (bayt)",
+                        "name": "Upload-Length",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Masalan: filename c2Nhbi5wZGY=",
+                        "name": "Upload-Metadata",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Upload"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "options": {
+                "description": "tus protokoli imkoniyatlari: versiya, kengaytmalar va shu foydalanuvchi uchun maksimal hajm (Tus-Max-Size)",
+                "tags": [
+                    "file"
+                ],
+                "summary": "Resumable upload capabilities",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/file/uploads/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Yuklash holati; completed bo‘lganda file_id yig‘ilgan faylni ko‘rsatadi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Get upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Upload"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Yuklashni bekor qiladi va yuklangan bo‘laklarni o‘chiradi",
+                "tags": [
+                    "file"
+                ],
+                "summary": "Terminate upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Uzilgan yuklashni davom ettirish uchun serverga yetib kelgan baytlar soni (Upload-Offset header)",
+                "tags": [
+                    "file"
+                ],
+                "summary": "Get upload offset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "410": {
+                        "description": "Gone"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upload-Offset dan boshlab navbatdagi bo‘lakni yozadi. Oxirgi bo‘lakdan keyin fayl avtomatik yig‘iladi (file_id ni GET orqali olish mumkin).",
+                "consumes": [
+                    "application/offset+octet-stream"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Upload chunk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bo‘lak boshlanadigan bayt (HEAD dagi Upload-Offset bilan bir xil bo‘lishi kerak)",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "411": {
+                        "description": "Length Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/uploads/{id}/finalize": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "To‘liq yuklangan bo‘laklarni faylga yig‘adi. Odatda oxirgi PATCH buni o‘zi bajaradi; bu endpoint yig‘ish xato bilan tugaganda qayta urinish uchun. Qayta chaqirish xavfsiz. Fayl mazmuni tekshiruvdan o‘tmasa (415/422), upload o‘chiriladi; skaner ishlamasa (503) qayta urinish mumkin. Zararli dastur topilgan fayl karantinda saqlanadi.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Finalize upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Upload"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Get file by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.File"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Delete file by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Faylni qayta nomlaydi, papkaga ko‘chiradi (folder_id \"\" - ildizga) va teglarini almashtiradi. Berilmagan maydonlar o‘zgarmaydi. Faqat egasi yoki admin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Rename, move or tag file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "O‘zgarishlar",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateFileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.File"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/{id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fayl mazmunini asl nomi bilan yuklab berish. Range (qisman yuklab olish) va ETag/If-None-Match qo‘llab-quvvatlanadi.\nEgasi bor faylni faqat egasi yoki admin yuklab oladi; guest fayllari ID orqali ochiq. Karantindagi fayl hech kimga berilmaydi (403).",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Download file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "1 bo‘lsa brauzerda ochiladi (Content-Disposition: inline); faqat PDF, PNG va JPEG uchun, qolganlari doim attachment",
+                        "name": "inline",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Masalan: bytes=0-1023",
+                        "name": "Range",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "416": {
+                        "description": "Range Not Satisfiable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/{id}/pin": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Faylni biriktiradi - saqlash muddati o‘tsa ham avtomatik tozalashda o‘chirilmaydi. Faqat egasi yoki admin; mehmon fayllarini faqat admin biriktiradi.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Pin file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.File"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fayl biriktirilishini bekor qiladi - u yana saqlash muddati bo‘yicha o‘chiriladi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Unpin file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.File"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "User login with email and password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Foydalanuvchining o‘z profilini olish (JWT token orqali)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get my profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/usage": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Foydalanuvchi fayllari egallagan joy, fayllar soni va tarif bo‘yicha chegaralar (0 - cheklanmagan). Natija fayllari ham kvotaga kiradi.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "My storage usage",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserUsage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/otp/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "otp"
+                ],
+                "summary": "Confirm OTP code",
+                "parameters": [
+                    {
+                        "description": "otp info",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ConfirmOtpRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/otp/send": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "otp"
+                ],
+                "summary": "Send OTP to email",
+                "parameters": [
+                    {
+                        "description": "email info",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SendOtpRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/role": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "role"
+                ],
+                "summary": "Create role",
+                "parameters": [
+                    {
+                        "description": "role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateRole"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/role/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "role"
+                ],
+                "summary": "Update role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "role_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "update info",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateRole"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "role"
+                ],
+                "summary": "List all roles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RoleListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/signup": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Register a new user after OTP confirmation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Create new user",
+                "parameters": [
+                    {
+                        "description": "Signup data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
                 }
             }
         },
-        "models.AddPageNumberJob": {
+        "/sysuser": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Superadmin tomonidan tizim user (admin, buxgalter) yaratish",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sysuser"
+                ],
+                "summary": "Create system user",
+                "parameters": [
+                    {
+                        "description": "sysuser info",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateSysUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.AddPageNumbersRequest": {
             "type": "object",
+            "required": [
+                "color",
+                "first_number",
+                "font_size",
+                "input_file_id",
+                "page_range",
+                "position"
+            ],
             "properties": {
-                "color": {
-                    "type": "string"
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
                 },
-                "created_at": {
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
+                "color": {
                     "type": "string"
                 },
                 "first_number": {
-                    "type": "integer"
+                    "description": "\u003c-- bu yer o‘zgardi",
+                    "type": "integer",
+                    "minimum": 1
                 },
                 "font_size": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "string"
+                    "description": "\u003c-- bu yer o‘zgardi",
+                    "type": "integer",
+                    "minimum": 1
                 },
                 "input_file_id": {
                     "type": "string"
                 },
-                "output_file_id": {
-                    "type": "string"
-                },
                 "page_range": {
                     "type": "string"
                 },
                 "position": {
                     "type": "string"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "string"
                 }
             }
         },
-        "models.AddPageNumbersRequest": {
+        "models.AddWatermarkRequest": {
             "type": "object",
             "required": [
-                "color",
-                "first_number",
-                "font_size",
                 "input_file_id",
-                "page_range",
-                "position"
+                "type"
             ],
             "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
                 "color": {
-                    "type": "string"
+                    "description": "\"#RRGGBB\"",
+                    "type": "string",
+                    "example": "#808080"
                 },
-                "first_number": {
-                    "description": "\u003c-- bu yer o‘zgardi",
-                    "type": "integer",
-                    "minimum": 1
+                "diagonal": {
+                    "description": "chap pastdan o‘ng yuqoriga; rotation e'tiborga olinmaydi",
+                    "type": "boolean"
+                },
+                "font_name": {
+                    "description": "pdfcpu asosiy shriftlari: Helvetica, Times-Roman, Courier, ...",
+                    "type": "string",
+                    "example": "Helvetica"
                 },
                 "font_size": {
-                    "description": "\u003c-- bu yer o‘zgardi",
+                    "description": "0 - o‘lcham scale bo‘yicha",
                     "type": "integer",
-                    "minimum": 1
+                    "maximum": 400,
+                    "minimum": 0,
+                    "example": 48
+                },
+                "image_file_id": {
+                    "description": "type=image uchun: yuklangan PNG/JPEG fayl IDsi",
+                    "type": "string"
                 },
                 "input_file_id": {
                     "type": "string"
                 },
+                "mode": {
+                    "description": "\"stamp\" - kontent ustida, \"watermark\" - kontent ortida",
+                    "type": "string",
+                    "example": "stamp"
+                },
+                "opacity": {
+                    "description": "0 dan katta, 1 gacha",
+                    "type": "number",
+                    "maximum": 1,
+                    "example": 0.5
+                },
                 "page_range": {
-                    "type": "string"
+                    "description": "bo‘sh - barcha sahifalar",
+                    "type": "string",
+                    "example": "1-3,5"
                 },
                 "position": {
-                    "type": "string"
+                    "description": "top-left, top-center, ..., center, ..., bottom-right",
+                    "type": "string",
+                    "example": "center"
+                },
+                "rotation": {
+                    "description": "gradus, soat miliga teskari",
+                    "type": "integer",
+                    "maximum": 180,
+                    "minimum": -180,
+                    "example": 45
+                },
+                "scale": {
+                    "description": "sahifa kengligiga nisbatan o‘lcham",
+                    "type": "number",
+                    "maximum": 1,
+                    "example": 0.5
+                },
+                "text": {
+                    "description": "type=text uchun",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "CONFIDENTIAL"
+                },
+                "tile": {
+                    "description": "butun sahifa bo‘ylab takrorlash; position e'tiborga olinmaydi",
+                    "type": "boolean"
+                },
+                "type": {
+                    "description": "\"text\" yoki \"image\"",
+                    "type": "string",
+                    "example": "text"
                 }
             }
         },
-        "models.CompressJob": {
+        "models.CleanupRun": {
             "type": "object",
             "properties": {
-                "compression": {
-                    "description": "\"low\", \"medium\", \"high\"",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.CompressionLevel"
-                        }
-                    ]
+                "deleted_files": {
+                    "type": "integer"
                 },
-                "created_at": {
+                "deleted_links": {
+                    "type": "integer"
+                },
+                "error": {
                     "type": "string"
                 },
-                "id": {
+                "finished_at": {
                     "type": "string"
                 },
-                "input_file_id": {
+                "freed_bytes": {
+                    "type": "integer"
+                },
+                "id": {
                     "type": "string"
                 },
-                "output_file_id": {
-                    "description": "Output file, can be nil",
+                "removed_paths": {
+                    "description": "storage/ va tmp/ dan o'chirilgan yetim fayl va papkalar",
+                    "type": "integer"
+                },
+                "started_at": {
                     "type": "string"
                 },
                 "status": {
-                    "description": "\"pending\", \"processing\", \"done\", \"failed\"",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.JobStatus"
-                        }
-                    ]
+                    "type": "string"
                 },
-                "user_id": {
-                    "description": "Can be nil for guest users",
+                "trigger": {
                     "type": "string"
                 }
             }
         },
+        "models.CleanupRunListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "runs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CleanupRun"
+                    }
+                }
+            }
+        },
         "models.CompressRequest": {
             "type": "object",
             "required": [
@@ -3040,6 +6631,15 @@ const docTemplate = `{
                 "input_file_id"
             ],
             "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
                 "compression": {
                     "description": "\"low\", \"medium\", \"high\"",
                     "allOf": [
@@ -3077,12 +6677,75 @@ const docTemplate = `{
                 }
             }
         },
+        "models.CreateAddBackgroundRequest": {
+            "type": "object",
+            "required": [
+                "input_file_id"
+            ],
+            "properties": {
+                "background_image_file_id": {
+                    "type": "string"
+                },
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
+                "color": {
+                    "description": "\"#RRGGBB\" - butun sahifani to‘ldiradi",
+                    "type": "string",
+                    "example": "#FFF8E1"
+                },
+                "input_file_id": {
+                    "type": "string"
+                },
+                "opacity": {
+                    "description": "0.0 - 1.0, standart 1",
+                    "type": "number",
+                    "maximum": 1,
+                    "example": 1
+                },
+                "page_range": {
+                    "description": "bo‘sh - barcha sahifalar",
+                    "type": "string",
+                    "example": "1-3"
+                },
+                "position": {
+                    "description": "center, top-left, bottom-right; standart center",
+                    "type": "string",
+                    "example": "center"
+                },
+                "scale": {
+                    "description": "sahifa kengligiga nisbatan o‘lcham, standart 1",
+                    "type": "number",
+                    "maximum": 1,
+                    "example": 0.5
+                },
+                "scale_to_fit": {
+                    "description": "rasm sahifaga to‘liq sig‘adigan eng katta o‘lchamda; scale e'tiborga olinmaydi",
+                    "type": "boolean"
+                }
+            }
+        },
         "models.CreateAddHeaderFooterRequest": {
             "type": "object",
             "required": [
                 "input_file_id"
             ],
             "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
                 "font_color": {
                     "description": "Agar bo‘lmasa, \"black\" deb olinadi",
                     "type": "string"
@@ -3106,12 +6769,73 @@ const docTemplate = `{
                 }
             }
         },
+        "models.CreateBatchRequest": {
+            "type": "object",
+            "required": [
+                "input_file_ids",
+                "type"
+            ],
+            "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
+                "input_file_ids": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "params": {
+                    "type": "object"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "compress"
+                },
+                "zip": {
+                    "description": "true bo‘lsa, barcha natijalar bitta ZIP ga yig‘iladi",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.CreateFolderRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Hisobotlar"
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
         "models.CreateHTMLToPDFRequest": {
             "type": "object",
             "required": [
                 "html_content"
             ],
             "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
                 "html_content": {
                     "type": "string"
                 }
@@ -3123,6 +6847,15 @@ const docTemplate = `{
                 "input_file_ids"
             ],
             "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
                 "input_file_ids": {
                     "description": "JPG fayllar bir nechta bo‘ladi",
                     "type": "array",
@@ -3135,6 +6868,15 @@ const docTemplate = `{
         "models.CreateMergeJobRequest": {
             "type": "object",
             "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
                 "input_file_ids": {
                     "description": "birlashtirish uchun kerakli fayl IDlar",
                     "type": "array",
@@ -3148,6 +6890,73 @@ const docTemplate = `{
                 }
             }
         },
+        "models.CreateOrganizeJobRequest": {
+            "type": "object",
+            "required": [
+                "input_file_id",
+                "new_order"
+            ],
+            "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
+                "input_file_id": {
+                    "type": "string",
+                    "example": "f680eafd-4c56-435d-8a3b-60f0d15b7f4e"
+                },
+                "new_order": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        1,
+                        0,
+                        2,
+                        2
+                    ]
+                },
+                "rotations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.CreatePipelineRequest": {
+            "type": "object",
+            "required": [
+                "steps"
+            ],
+            "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
+                "steps": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.PipelineStep"
+                    }
+                }
+            }
+        },
         "models.CreateQRCodeRequest": {
             "type": "object",
             "required": [
@@ -3157,6 +6966,15 @@ const docTemplate = `{
                 "size"
             ],
             "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
                 "input_file_id": {
                     "type": "string"
                 },
@@ -3207,6 +7025,15 @@ const docTemplate = `{
                 "split_ranges"
             ],
             "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
                 "input_file_id": {
                     "type": "string"
                 },
@@ -3265,136 +7092,207 @@ const docTemplate = `{
                 }
             }
         },
-        "models.CropPDFJob": {
+        "models.CropPDFRequest": {
             "type": "object",
+            "required": [
+                "input_file_id"
+            ],
             "properties": {
                 "bottom": {
-                    "description": "Pastki chegara",
                     "type": "integer"
                 },
                 "box": {
-                    "description": "Box turi",
+                    "description": "optional: mediabox, cropbox, etc",
                     "type": "string"
                 },
-                "created_at": {
-                    "description": "Yaratilgan vaqt",
-                    "type": "string"
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
                 },
-                "id": {
-                    "description": "Ish IDsi",
-                    "type": "string"
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
                 },
                 "input_file_id": {
-                    "description": "Kirish fayl IDsi",
                     "type": "string"
                 },
-                "input_file_ids": {
-                    "description": "Fayl IDlari ro'yxati (bir nechta fayl uchun)",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
                 "left": {
-                    "description": "Chap chegara",
                     "type": "integer"
                 },
-                "output_file_id": {
-                    "description": "Chiqish fayli IDsi",
-                    "type": "string"
-                },
                 "pages": {
-                    "description": "Sahifalar",
+                    "description": "optional: qaysi sahifalarni crop qilish",
                     "type": "string"
                 },
                 "right": {
-                    "description": "O'ng chegara",
                     "type": "integer"
                 },
-                "status": {
-                    "description": "Ish holati (masalan, \"pending\", \"done\")",
-                    "type": "string"
-                },
                 "top": {
-                    "description": "Yuqori chegara",
                     "type": "integer"
+                }
+            }
+        },
+        "models.DetectBlankPagesRequest": {
+            "type": "object",
+            "required": [
+                "input_file_id"
+            ],
+            "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
                 },
-                "user_id": {
-                    "description": "Foydalanuvchi IDsi (guest uchun nil bo'lishi mumkin)",
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
+                "input_file_id": {
                     "type": "string"
                 }
             }
         },
-        "models.CropPDFRequest": {
+        "models.ExcelToPDFRequest": {
             "type": "object",
             "required": [
                 "input_file_id"
             ],
             "properties": {
-                "bottom": {
-                    "type": "integer"
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
                 },
-                "box": {
-                    "description": "optional: mediabox, cropbox, etc",
-                    "type": "string"
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
                 },
                 "input_file_id": {
                     "type": "string"
+                }
+            }
+        },
+        "models.ExtractPagesRequest": {
+            "type": "object",
+            "required": [
+                "input_file_id",
+                "page_ranges"
+            ],
+            "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
                 },
-                "left": {
-                    "type": "integer"
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
                 },
-                "pages": {
-                    "description": "optional: qaysi sahifalarni crop qilish",
+                "input_file_id": {
                     "type": "string"
                 },
-                "right": {
-                    "type": "integer"
-                },
-                "top": {
-                    "type": "integer"
+                "page_ranges": {
+                    "type": "string"
                 }
             }
         },
-        "models.DetectBlankPagesJob": {
+        "models.File": {
             "type": "object",
             "properties": {
-                "blank_pages": {
+                "encrypted": {
+                    "description": "PDF parol bilan himoyalangan",
+                    "type": "boolean"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "filePath": {
+                    "type": "string"
+                },
+                "fileSize": {
+                    "type": "integer"
+                },
+                "fileType": {
+                    "type": "string"
+                },
+                "folderID": {
+                    "description": "NULL - ildizda",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "pageCount": {
+                    "description": "PDF sahifalari soni; PDF bo‘lmasa yoki shifrlangan bo‘lsa NULL",
+                    "type": "integer"
+                },
+                "pinned": {
+                    "description": "true bo‘lsa saqlash muddati tugasa ham o‘chirilmaydi",
+                    "type": "boolean"
+                },
+                "scanSignature": {
+                    "description": "karantindagi faylda topilgan zararli dastur nomi",
+                    "type": "string"
+                },
+                "scanStatus": {
+                    "type": "string"
+                },
+                "scannedAt": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "description": "mazmun xeshi; eski yozuvlarda bo‘sh",
+                    "type": "string"
+                },
+                "sourceFileIDs": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
                 },
-                "created_at": {
+                "sourceJobID": {
+                    "description": "Fayl job natijasi bo‘lsa: qaysi job, qaysi amal va qaysi fayllardan olingan",
                     "type": "string"
                 },
-                "id": {
+                "sourceJobType": {
                     "type": "string"
                 },
-                "input_file_id": {
-                    "type": "string"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "status": {
-                    "description": "pending, processing, done, failed",
+                "uploadedAt": {
                     "type": "string"
                 },
-                "user_id": {
+                "userID": {
+                    "description": "NULL bo‘lishi mumkin",
                     "type": "string"
                 }
             }
         },
-        "models.DetectBlankPagesRequest": {
+        "models.FileListResponse": {
             "type": "object",
-            "required": [
-                "input_file_id"
-            ],
             "properties": {
-                "input_file_id": {
-                    "type": "string"
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.File"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "models.ExcelToPDFJob": {
+        "models.Folder": {
             "type": "object",
             "properties": {
                 "created_at": {
@@ -3403,13 +7301,13 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
-                "input_file_id": {
+                "name": {
                     "type": "string"
                 },
-                "output_file_id": {
+                "parent_id": {
                     "type": "string"
                 },
-                "status": {
+                "updated_at": {
                     "type": "string"
                 },
                 "user_id": {
@@ -3417,185 +7315,218 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ExcelToPDFRequest": {
+        "models.FolderListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "folders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Folder"
+                    }
+                }
+            }
+        },
+        "models.InspectRequest": {
             "type": "object",
             "required": [
-                "input_file_id"
+                "file_id"
             ],
             "properties": {
-                "input_file_id": {
-                    "type": "string"
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
+                "file_id": {
+                    "type": "string",
+                    "example": "1e2b3c4d-5f6a-7b89-cd01-23456789abcd"
                 }
             }
         },
-        "models.ExtractJob": {
+        "models.Job": {
             "type": "object",
             "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "cache_hit": {
+                    "description": "natija keshdan olingan (amal qayta bajarilmagan)",
+                    "type": "boolean"
+                },
+                "callback_url": {
+                    "description": "job tugagach webhook yuboriladigan manzil",
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
+                "error": {
                     "type": "string"
                 },
-                "input_file_id": {
+                "error_code": {
+                    "description": "masalan: timeout, upstream_unavailable, invalid_input",
                     "type": "string"
                 },
-                "output_file_id": {
+                "finished_at": {
                     "type": "string"
                 },
-                "pages_to_extract": {
+                "id": {
                     "type": "string"
                 },
-                "status": {
+                "input_file_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_attempts": {
+                    "type": "integer"
+                },
+                "next_retry_at": {
+                    "description": "navbatdagi avtomatik urinish vaqti",
                     "type": "string"
                 },
-                "user_id": {
+                "output_file_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "params": {
+                    "type": "object"
+                },
+                "parent_id": {
+                    "description": "pipeline qadami bo'lsa, pipeline job IDsi",
                     "type": "string"
-                }
-            }
-        },
-        "models.ExtractPagesRequest": {
-            "type": "object",
-            "required": [
-                "input_file_id",
-                "page_ranges"
-            ],
-            "properties": {
-                "input_file_id": {
+                },
+                "result": {
+                    "description": "amalga xos natija (masalan, inspect metadata)",
+                    "type": "object"
+                },
+                "started_at": {
                     "type": "string"
                 },
-                "page_ranges": {
+                "status": {
+                    "$ref": "#/definitions/models.JobStatus"
+                },
+                "type": {
+                    "description": "merge, split, compress, ...",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "guest uchun nil",
                     "type": "string"
                 }
             }
         },
-        "models.File": {
+        "models.JobCachePurgeResponse": {
             "type": "object",
             "properties": {
-                "fileName": {
-                    "type": "string"
-                },
-                "filePath": {
-                    "type": "string"
+                "purged": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.JobCacheStats": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
                 },
-                "fileSize": {
+                "entries": {
                     "type": "integer"
                 },
-                "fileType": {
-                    "type": "string"
+                "hits": {
+                    "type": "integer"
                 },
-                "id": {
-                    "type": "string"
+                "size": {
+                    "description": "keshdagi natija fayllari hajmi (bayt)",
+                    "type": "integer"
                 },
-                "uploadedAt": {
+                "ttl": {
                     "type": "string"
                 },
-                "userID": {
-                    "description": "NULL bo‘lishi mumkin",
-                    "type": "string"
+                "types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JobCacheTypeStat"
+                    }
                 }
             }
         },
-        "models.HTMLToPDFJob": {
+        "models.JobCacheTypeStat": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "html_content": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "entries": {
+                    "type": "integer"
                 },
-                "output_file_id": {
-                    "type": "string"
+                "hits": {
+                    "type": "integer"
                 },
-                "status": {
+                "job_type": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string"
+                "size": {
+                    "type": "integer"
                 }
             }
         },
-        "models.InspectJob": {
+        "models.JobEvent": {
             "type": "object",
             "properties": {
-                "author": {
-                    "type": "string",
-                    "example": "John Doe"
-                },
-                "created_at": {
+                "error": {
                     "type": "string"
                 },
-                "file_id": {
+                "error_code": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "d4f56987-e123-4567-abcd-7890abcdef12"
+                "event": {
+                    "description": "status yoki progress",
+                    "type": "string"
                 },
-                "keywords": {
-                    "type": "string",
-                    "example": "report,2025"
+                "job_id": {
+                    "type": "string"
                 },
-                "page_count": {
-                    "type": "integer",
-                    "example": 5
+                "progress": {
+                    "$ref": "#/definitions/models.JobProgress"
                 },
                 "status": {
-                    "type": "string",
-                    "example": "done"
-                },
-                "subject": {
-                    "type": "string",
-                    "example": "Report"
-                },
-                "title": {
-                    "type": "string",
-                    "example": "My PDF Document"
+                    "$ref": "#/definitions/models.JobStatus"
                 },
-                "user_id": {
+                "time": {
                     "type": "string"
                 }
             }
         },
-        "models.InspectRequest": {
+        "models.JobListResponse": {
             "type": "object",
-            "required": [
-                "file_id"
-            ],
             "properties": {
-                "file_id": {
-                    "type": "string",
-                    "example": "1e2b3c4d-5f6a-7b89-cd01-23456789abcd"
+                "count": {
+                    "type": "integer"
+                },
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Job"
+                    }
                 }
             }
         },
-        "models.JPGToPDFJob": {
+        "models.JobProgress": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "input_file_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "output_file_id": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
+                "current": {
+                    "type": "integer"
                 },
-                "user_id": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
@@ -3605,15 +7536,57 @@ const docTemplate = `{
                 "pending",
                 "processing",
                 "done",
-                "failed"
+                "failed",
+                "dead",
+                "cancelled"
+            ],
+            "x-enum-comments": {
+                "Dead": "vaqtinchalik xato, lekin urinishlar tugagan (dead-letter)"
+            },
+            "x-enum-descriptions": [
+                "vaqtinchalik xato, lekin urinishlar tugagan (dead-letter)"
             ],
             "x-enum-varnames": [
                 "Pending",
                 "Processing",
                 "Done",
-                "Failed"
+                "Failed",
+                "Dead",
+                "Cancelled"
             ]
         },
+        "models.JobTypeLimit": {
+            "type": "object",
+            "properties": {
+                "job_type": {
+                    "type": "string"
+                },
+                "max_workers": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.JobTypeStat": {
+            "type": "object",
+            "properties": {
+                "job_type": {
+                    "type": "string"
+                },
+                "max_workers": {
+                    "description": "0 - cheklanmagan",
+                    "type": "integer"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "processing": {
+                    "type": "integer"
+                }
+            }
+        },
         "models.Log": {
             "type": "object",
             "properties": {
@@ -3666,103 +7639,23 @@ const docTemplate = `{
                 }
             }
         },
-        "models.MergeJob": {
-            "type": "object",
-            "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "inputFileIDs": {
-                    "description": "input fayllar (JOIN orqali olinadi, db da yo‘q)",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "outputFileID": {
-                    "description": "NULL bo'lishi mumkin (hozircha natija yo'q)",
-                    "type": "string"
-                },
-                "status": {
-                    "description": "pending, done, failed",
-                    "type": "string"
-                },
-                "userID": {
-                    "description": "NULL bo'lishi mumkin (guest)",
-                    "type": "string"
-                }
-            }
-        },
-        "models.PDFToJPGJob": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "description": "Job creation timestamp",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "Job ID",
-                    "type": "string"
-                },
-                "input_file_id": {
-                    "description": "Input file ID",
-                    "type": "string"
-                },
-                "output_file_ids": {
-                    "description": "List of generated JPG file IDs",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "status": {
-                    "description": "Status of the job (pending, processing, done, failed)",
-                    "type": "string"
-                },
-                "user_id": {
-                    "description": "User ID (nullable)",
-                    "type": "string"
-                },
-                "zip_file_id": {
-                    "description": "ZIP file ID (nullable, can be nil)",
-                    "type": "string"
-                }
-            }
-        },
         "models.PDFToJPGRequest": {
             "type": "object",
             "required": [
                 "input_file_id"
             ],
             "properties": {
-                "input_file_id": {
-                    "description": "Required input file ID",
-                    "type": "string"
-                }
-            }
-        },
-        "models.PDFToWordJob": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
                 },
-                "id": {
-                    "type": "string"
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
                 },
                 "input_file_id": {
-                    "type": "string"
-                },
-                "output_file_id": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "user_id": {
+                    "description": "Required input file ID",
                     "type": "string"
                 }
             }
@@ -3773,31 +7666,32 @@ const docTemplate = `{
                 "input_file_id"
             ],
             "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
                 "input_file_id": {
                     "type": "string"
                 }
             }
         },
-        "models.PowerPointToPDFJob": {
+        "models.PipelineStep": {
             "type": "object",
+            "required": [
+                "type"
+            ],
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "input_file_id": {
-                    "type": "string"
-                },
-                "output_file_id": {
-                    "type": "string"
+                "params": {
+                    "type": "object"
                 },
-                "status": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "string"
+                "type": {
+                    "type": "string",
+                    "example": "compress"
                 }
             }
         },
@@ -3807,35 +7701,17 @@ const docTemplate = `{
                 "input_file_id"
             ],
             "properties": {
-                "input_file_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.ProtectPDFJob": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
                 },
-                "id": {
-                    "type": "string"
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
                 },
                 "input_file_id": {
                     "type": "string"
-                },
-                "output_file_id": {
-                    "description": "← *pointer (✔ to‘g‘ri)",
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "string"
                 }
             }
         },
@@ -3846,6 +7722,15 @@ const docTemplate = `{
                 "password"
             ],
             "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
                 "input_file_id": {
                     "description": "PDF fayl ID",
                     "type": "string"
@@ -3856,70 +7741,100 @@ const docTemplate = `{
                 }
             }
         },
-        "models.QRCodeJob": {
+        "models.QueueStats": {
+            "type": "object",
+            "properties": {
+                "delayed": {
+                    "description": "qayta urinish yoki slot kutayotgan vazifalar",
+                    "type": "integer"
+                },
+                "lanes": {
+                    "description": "yo‘lak bo‘yicha Redisda kutayotgan vazifalar",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JobTypeStat"
+                    }
+                },
+                "user_concurrency": {
+                    "description": "bitta foydalanuvchi uchun bir vaqtda bajariladigan joblar (0 - cheklanmagan)",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.QuotaPlan": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "input_file_id": {
-                    "type": "string"
-                },
-                "output_file_id": {
-                    "type": "string"
-                },
-                "position": {
-                    "type": "string"
-                },
-                "qr_content": {
-                    "type": "string"
+                "is_default": {
+                    "type": "boolean"
                 },
-                "size": {
+                "max_bytes": {
                     "type": "integer"
                 },
-                "status": {
-                    "description": "pending, processing, done, failed",
-                    "type": "string"
+                "max_files": {
+                    "type": "integer"
                 },
-                "user_id": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "models.RemoveJob": {
+        "models.QuotaPlanListResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "input_file_id": {
-                    "type": "string"
+                "count": {
+                    "type": "integer"
                 },
-                "output_file_id": {
-                    "description": "NULL bo‘lishi mumkin",
-                    "type": "string"
+                "plans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.QuotaPlan"
+                    }
+                }
+            }
+        },
+        "models.ReconcileRequest": {
+            "type": "object",
+            "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
                 },
-                "pages_to_remove": {
-                    "type": "string"
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
                 },
-                "status": {
-                    "type": "string"
+                "dry_run": {
+                    "description": "DryRun - true (standart) bo'lsa faqat hisobot tuziladi, hech narsa o'chirilmaydi",
+                    "type": "boolean",
+                    "example": true
                 },
-                "user_id": {
-                    "description": "NULL bo‘lishi mumkin",
-                    "type": "string"
+                "min_age_minutes": {
+                    "description": "MinAgeMinutes - diskdagi fayl shundan eski bo'lsagina yetim hisoblanadi (standart 60), shunda\nhali bajarilayotgan job yozayotgan fayllarga tegilmaydi",
+                    "type": "integer",
+                    "example": 60
                 }
             }
         },
         "models.RemovePagesRequest": {
             "type": "object",
             "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
                 "input_file_id": {
                     "type": "string"
                 },
@@ -3966,40 +7881,6 @@ const docTemplate = `{
                 }
             }
         },
-        "models.RotateJob": {
-            "type": "object",
-            "properties": {
-                "angle": {
-                    "description": "Burilish burchagi",
-                    "type": "integer"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "input_file_id": {
-                    "type": "string"
-                },
-                "output_file_id": {
-                    "type": "string"
-                },
-                "output_path": {
-                    "type": "string"
-                },
-                "pages": {
-                    "description": "Tanlangan sahifalar",
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "string"
-                }
-            }
-        },
         "models.RotatePDFRequest": {
             "type": "object",
             "required": [
@@ -4012,6 +7893,15 @@ const docTemplate = `{
                     "description": "Burchak: 90, 180, 270",
                     "type": "integer"
                 },
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
                 "input_file_id": {
                     "type": "string"
                 },
@@ -4032,6 +7922,33 @@ const docTemplate = `{
                 }
             }
         },
+        "models.SetJobTypeLimitRequest": {
+            "type": "object",
+            "required": [
+                "max_workers"
+            ],
+            "properties": {
+                "max_workers": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                }
+            }
+        },
+        "models.SetUserQuotaRequest": {
+            "type": "object",
+            "properties": {
+                "max_bytes": {
+                    "type": "integer"
+                },
+                "max_files": {
+                    "type": "integer"
+                },
+                "plan": {
+                    "type": "string"
+                }
+            }
+        },
         "models.SharedLink": {
             "type": "object",
             "properties": {
@@ -4052,93 +7969,136 @@ const docTemplate = `{
                 }
             }
         },
-        "models.SplitJob": {
+        "models.TagCount": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "count": {
+                    "type": "integer"
                 },
-                "id": {
+                "tag": {
                     "type": "string"
+                }
+            }
+        },
+        "models.UnlockPDFRequest": {
+            "type": "object",
+            "required": [
+                "input_file_id",
+                "password"
+            ],
+            "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
                 },
                 "input_file_id": {
+                    "description": "Kiruvchi PDF fayl IDsi",
                     "type": "string"
                 },
-                "output_file_ids": {
+                "password": {
+                    "description": "PDF paroli",
+                    "type": "string"
+                }
+            }
+        },
+        "models.UpdateFileRequest": {
+            "type": "object",
+            "properties": {
+                "file_name": {
+                    "type": "string",
+                    "example": "hisobot.pdf"
+                },
+                "folder_id": {
+                    "type": "string"
+                },
+                "tags": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
+                }
+            }
+        },
+        "models.UpdateFolderRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "2025"
                 },
-                "split_ranges": {
+                "parent_id": {
                     "type": "string"
-                },
-                "status": {
+                }
+            }
+        },
+        "models.UpdateRole": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "string"
                 },
-                "user_id": {
-                    "description": "❗️ string emas, *string bo‘lishi kerak",
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "models.UnlockPDFJob": {
+        "models.Upload": {
             "type": "object",
             "properties": {
                 "created_at": {
-                    "description": "Yaratilgan vaqti",
                     "type": "string"
                 },
-                "id": {
-                    "description": "Job ID",
+                "expires_at": {
                     "type": "string"
                 },
-                "input_file_id": {
-                    "description": "Kiruvchi fayl IDsi",
+                "file_id": {
+                    "description": "completed bo'lganda yig'ilgan fayl",
                     "type": "string"
                 },
-                "output_file_id": {
-                    "description": "Natijaviy fayl IDsi",
+                "file_name": {
                     "type": "string"
                 },
-                "status": {
-                    "description": "pending, done, failed",
+                "file_type": {
                     "type": "string"
                 },
-                "user_id": {
-                    "description": "Foydalanuvchi IDsi",
-                    "type": "string"
-                }
-            }
-        },
-        "models.UnlockPDFRequest": {
-            "type": "object",
-            "required": [
-                "input_file_id",
-                "password"
-            ],
-            "properties": {
-                "input_file_id": {
-                    "description": "Kiruvchi PDF fayl IDsi",
+                "id": {
                     "type": "string"
                 },
-                "password": {
-                    "description": "PDF paroli",
-                    "type": "string"
-                }
-            }
-        },
-        "models.UpdateRole": {
-            "type": "object",
-            "properties": {
-                "id": {
+                "length": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.UploadStatus"
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "name": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
+        "models.UploadStatus": {
+            "type": "string",
+            "enum": [
+                "uploading",
+                "finalizing",
+                "completed"
+            ],
+            "x-enum-varnames": [
+                "UploadUploading",
+                "UploadFinalizing",
+                "UploadCompleted"
+            ]
+        },
         "models.User": {
             "type": "object",
             "properties": {
@@ -4162,9 +8122,32 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UserQuota": {
+            "type": "object",
+            "properties": {
+                "max_bytes": {
+                    "description": "0 - cheklanmagan",
+                    "type": "integer"
+                },
+                "max_files": {
+                    "description": "0 - cheklanmagan",
+                    "type": "integer"
+                },
+                "overridden": {
+                    "type": "boolean"
+                },
+                "plan": {
+                    "type": "string"
+                }
+            }
+        },
         "models.UserStats": {
             "type": "object",
             "properties": {
+                "added_backgrounds": {
+                    "description": "Orqa fon qo‘shilganlar",
+                    "type": "integer"
+                },
                 "added_page_numbers": {
                     "description": "Sahifalarga raqam qo‘shish",
                     "type": "integer"
@@ -4226,7 +8209,7 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "used_storage_mb": {
-                    "description": "MB’da ishlatilgan xotira (hisoblash kerak)",
+                    "description": "MB’da ishlatilgan xotira",
                     "type": "integer"
                 },
                 "watermarked": {
@@ -4235,35 +8218,93 @@ const docTemplate = `{
                 }
             }
         },
-        "models.WordToPDFJob": {
+        "models.UserUsage": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "file_count": {
+                    "type": "integer"
+                },
+                "quota": {
+                    "$ref": "#/definitions/models.UserQuota"
+                },
+                "remaining_bytes": {
+                    "description": "null - cheklanmagan",
+                    "type": "integer"
+                },
+                "remaining_files": {
+                    "description": "null - cheklanmagan",
+                    "type": "integer"
+                },
+                "used_bytes": {
+                    "type": "integer"
+                },
+                "user_id": {
                     "type": "string"
+                }
+            }
+        },
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
                 },
-                "id": {
+                "created_at": {
                     "type": "string"
                 },
-                "input_file_id": {
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
                     "type": "string"
                 },
-                "output_file_id": {
+                "id": {
                     "type": "string"
                 },
-                "status": {
+                "job_id": {
                     "type": "string"
                 },
-                "user_id": {
+                "status_code": {
+                    "description": "javob kelmagan bo‘lsa nil",
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
+        "models.WebhookDeliveryListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WebhookDelivery"
+                    }
+                }
+            }
+        },
         "models.WordToPDFRequest": {
             "type": "object",
             "required": [
                 "input_file_id"
             ],
             "properties": {
+                "callback_secret": {
+                    "description": "berilsa, payload HMAC-SHA256 bilan imzolanadi",
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "callback_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/pdfninja"
+                },
                 "input_file_id": {
                     "type": "string"
                 }
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"test/api/models"
+	"test/service"
+)
+
+// CreateAddBackgroundJob godoc
+// @Summary      Add background to PDF
+// @Description  PDF sahifalari ortiga yuklangan rasm (background_image_file_id, o‘zingiz yoki mehmon sifatida yuklagan PNG/JPEG; boshqa foydalanuvchining fayli 403) yoki bir xil rang (color) qo‘yadi. Fon kontent ortida joylashadi. scale_to_fit=true - rasm sahifaga to‘liq sig‘adi, aks holda scale - sahifa kengligiga nisbatan o‘lcham.
+// @Tags         PDF Edit
+// @Accept       json
+// @Produce      json
+// @Param        request body models.CreateAddBackgroundRequest true "Add background request"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
+// @Success      201 {object} map[string]string
+// @Failure      400 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
+// @Failure      500 {object} models.Response
+// @Router       /api/pdf/add-background [post]
+func (h *Handler) CreateAddBackgroundJob(c *gin.Context) {
+	var req models.CreateAddBackgroundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleResponse(c, h.log, "invalid request", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var userID *string
+	if uid := c.GetString("user_id"); uid != "" {
+		userID = &uid
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ctx = jobContext(ctx, &req.JobCallback)
+	jobID, err := h.services.AddBackground().Create(ctx, req, userID)
+	if errors.Is(err, service.ErrInvalidBackground) {
+		handleResponse(c, h.log, "invalid background", http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.handleJobError(c, "add background job failed", err)
+		return
+	}
+
+	handleResponse(c, h.log, "add background job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
+}
+
+// GetAddBackgroundJob godoc
+// @Summary      Get Add Background Job info
+// @Description  Orqa fon qo‘shish jarayonining natijasini olish
+// @Tags         PDF Edit
+// @Produce      json
+// @Param        id path string true "Job ID"
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Router       /api/pdf/add-background/{id} [get]
+func (h *Handler) GetAddBackgroundJob(c *gin.Context) {
+	jobID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := h.services.AddBackground().GetByID(ctx, jobID)
+	if err != nil {
+		handleResponse(c, h.log, "add background job not found", http.StatusNotFound, err.Error())
+		return
+	}
+
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
+	handleResponse(c, h.log, "add background job found", http.StatusOK, job)
+}
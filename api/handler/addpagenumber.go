@@ -17,8 +17,11 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        data body models.AddPageNumbersRequest true "PDF ID and font settings"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 func (h *Handler) CreateAddPageNumbersJob(c *gin.Context) {
 	var req models.AddPageNumbersRequest
@@ -44,13 +47,14 @@ func (h *Handler) CreateAddPageNumbersJob(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.AddPageNumber().Create(ctx, req, userID)
 	if err != nil {
-		handleResponse(c, h.log, "failed to create add-page-numbers job", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "failed to create add-page-numbers job", err)
 		return
 	}
 
-	handleResponse(c, h.log, "job created", http.StatusCreated, gin.H{"id": jobID})
+	handleResponse(c, h.log, "job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetAddPageNumbersJob godoc
@@ -58,8 +62,10 @@ func (h *Handler) CreateAddPageNumbersJob(c *gin.Context) {
 // @Summary      Get add page numbers job by ID
 // @Tags         PDF
 // @Param        id path string true "Job ID"
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
 // @Produce      json
-// @Success      200 {object} models.AddPageNumberJob
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
 func (h *Handler) GetAddPageNumbersJob(c *gin.Context) {
 	id := c.Param("id")
@@ -73,5 +79,10 @@ func (h *Handler) GetAddPageNumbersJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "job found", http.StatusOK, job)
 }
@@ -67,3 +67,13 @@ func (h Handler) AdminMiddleware(c *gin.Context) {
 
 	c.Next()
 }
+
+// OptionalAuthMiddleware – token yuborilgan bo‘lsa uni AuthorizerMiddleware kabi tekshiradi, yuborilmagan bo‘lsa
+// so‘rov mehmon sifatida davom etadi. Shu orqali tokenli joblar foydalanuvchiga bog‘lanadi va priority navbatga tushadi.
+func (h Handler) OptionalAuthMiddleware(c *gin.Context) {
+	if c.GetHeader("Authorization") == "" {
+		c.Next()
+		return
+	}
+	h.AuthorizerMiddleware(c)
+}
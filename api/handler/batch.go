@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"test/api/models"
+	"test/service"
+)
+
+// CreateBatch godoc
+// @Router       /api/batches [POST]
+// @Summary      Create batch
+// @Description  Bitta amalni ko‘p faylga qo‘llash (masalan, 50 ta hisob-fakturani siqish). params - shu amalning odatiy so‘rov modeli, kiruvchi fayl maydoni har bir fayl uchun input_file_ids dan to‘ldiriladi. zip=true bo‘lsa barcha natijalar bitta ZIP ga yig‘iladi.
+// @Tags         batches
+// @Accept       json
+// @Produce      json
+// @Param        request body models.CreateBatchRequest true "batch so‘rovi"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
+// @Success      201 {object} map[string]string
+// @Failure      400 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) CreateBatch(c *gin.Context) {
+	var req models.CreateBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleResponse(c, h.log, "invalid request body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var userID *string
+	if uid := c.GetString("user_id"); uid != "" {
+		userID = &uid
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ctx = jobContext(ctx, &req.JobCallback)
+	jobID, err := h.services.Batch().Create(ctx, req, userID)
+	if errors.Is(err, service.ErrInvalidBatch) {
+		handleResponse(c, h.log, "invalid batch", http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.handleJobError(c, "failed to create batch", err)
+		return
+	}
+
+	handleResponse(c, h.log, "batch created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
+}
+
+// GetBatch godoc
+// @Router       /api/batches/{id} [GET]
+// @Summary      Get batch
+// @Description  Batch holati: result.items - har bir fayl natijasi va job IDsi, result.zip_file_id - barcha natijalar ZIP arxivi (zip=true bo‘lsa)
+// @Tags         batches
+// @Produce      json
+// @Param        id path string true "Batch job ID"
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+func (h Handler) GetBatch(c *gin.Context) {
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := h.services.Batch().GetByID(ctx, id)
+	if err != nil {
+		handleResponse(c, h.log, "batch not found", http.StatusNotFound, err.Error())
+		return
+	}
+
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
+	handleResponse(c, h.log, "batch fetched", http.StatusOK, job)
+}
@@ -18,6 +18,7 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.CompressRequest true "compress job"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
 // @Failure      500 {object} models.Response
@@ -52,13 +53,14 @@ func (h *Handler) CreateCompressJob(c *gin.Context) {
 	defer cancel()
 
 	// Create the compression job
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.Compress().Create(ctx, req, userID)
 	if err != nil {
-		handleResponse(c, h.log, "failed to create compress job", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "failed to create compress job", err)
 		return
 	}
 
-	handleResponse(c, h.log, "compression job created", http.StatusCreated, gin.H{"id": jobID})
+	handleResponse(c, h.log, "compression job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetCompressJob godoc
@@ -69,8 +71,12 @@ func (h *Handler) CreateCompressJob(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "compress job ID"
-// @Success      200 {object} models.CompressJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 // @Router       /api/pdf/compress/{id} [get]
 func (h *Handler) GetCompressJob(c *gin.Context) {
@@ -91,5 +97,10 @@ func (h *Handler) GetCompressJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "compress job fetched", http.StatusOK, job)
 }
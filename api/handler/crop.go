@@ -17,8 +17,11 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.CropPDFRequest true "Crop request"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 // @Router       /api/pdf/crop [post]
 func (h *Handler) CreateCropJob(c *gin.Context) {
@@ -49,14 +52,15 @@ func (h *Handler) CreateCropJob(c *gin.Context) {
 	defer cancel()
 
 	// Crop jobni yaratish
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.Crop().Create(ctx, req, userID)
 	if err != nil {
-		handleResponse(c, h.log, "crop job failed", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "crop job failed", err)
 		return
 	}
 
 	// Yaratilgan job haqida response
-	handleResponse(c, h.log, "crop job created", http.StatusCreated, gin.H{"id": jobID})
+	handleResponse(c, h.log, "crop job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetCropJob godoc
@@ -65,7 +69,9 @@ func (h *Handler) CreateCropJob(c *gin.Context) {
 // @Tags         PDF Edit
 // @Produce      json
 // @Param        id path string true "Job ID"
-// @Success      200 {object} models.CropPDFJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
 // @Router       /api/pdf/crop/{id} [get]
 func (h *Handler) GetCropJob(c *gin.Context) {
@@ -80,5 +86,10 @@ func (h *Handler) GetCropJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "crop job found", http.StatusOK, job)
 }
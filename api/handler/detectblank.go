@@ -19,8 +19,11 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        inputFileID body models.DetectBlankPagesRequest true "Input PDF file ID"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 func (h Handler) CreateDetectBlankPagesJob(c *gin.Context) {
 	var req models.DetectBlankPagesRequest
@@ -39,13 +42,14 @@ func (h Handler) CreateDetectBlankPagesJob(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.DetectBlank().Create(ctx, req.InputFileID, userID)
 	if err != nil {
-		handleResponse(c, h.log, "failed to create detect blank pages job", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "failed to create detect blank pages job", err)
 		return
 	}
 
-	handleResponse(c, h.log, "detect blank pages job created", http.StatusCreated, gin.H{"id": jobID})
+	handleResponse(c, h.log, "detect blank pages job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetDetectBlankPagesJob godoc
@@ -56,7 +60,9 @@ func (h Handler) CreateDetectBlankPagesJob(c *gin.Context) {
 // @Tags         pdf-detect-blank
 // @Produce      json
 // @Param        id path string true "Job ID"
-// @Success      200 {object} models.DetectBlankPagesJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
 // @Failure      500 {object} models.Response
 func (h Handler) GetDetectBlankPagesJob(c *gin.Context) {
@@ -75,5 +81,10 @@ func (h Handler) GetDetectBlankPagesJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "detect blank pages job fetched", http.StatusOK, job)
 }
@@ -17,6 +17,7 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.ExcelToPDFRequest true "Excel fayl IDsi"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} models.Response{data=string} "Yaratilgan job ID"
 // @Failure      400 {object} models.Response
 // @Failure      500 {object} models.Response
@@ -44,12 +45,14 @@ func (h *Handler) CreateExcelToPDF(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.ExcelToPDF().Create(ctx, req, userID)
 	if err != nil {
-		handleResponse(c, h.log, "failed to convert Excel to PDF", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "failed to convert Excel to PDF", err)
 		return
 	}
 
+	h.jobAccessToken(c, jobID)
 	handleResponse(c, h.log, "excel to pdf job created", http.StatusCreated, jobID)
 }
 
@@ -59,8 +62,12 @@ func (h *Handler) CreateExcelToPDF(c *gin.Context) {
 // @Tags         Excel to PDF
 // @Produce      json
 // @Param        id path string true "Job ID"
-// @Success      200 {object} models.ExcelToPDFJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 // @Router       /api/pdf/excel-to-pdf/{id} [get]
 func (h *Handler) GetExcelToPDFJob(c *gin.Context) {
@@ -75,5 +82,10 @@ func (h *Handler) GetExcelToPDFJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "job found", http.StatusOK, job)
 }
@@ -17,6 +17,7 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.ExtractPagesRequest true "extract request body"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
 // @Failure      500 {object} models.Response
@@ -45,13 +46,14 @@ func (h *Handler) CreateExtractJob(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.ExtractPage().Create(ctx, req, userID)
 	if err != nil {
-		handleResponse(c, h.log, "failed to create extract job", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "failed to create extract job", err)
 		return
 	}
 
-	handleResponse(c, h.log, "extract job created", http.StatusCreated, gin.H{"id": jobID})
+	handleResponse(c, h.log, "extract job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetExtractJob godoc
@@ -61,8 +63,12 @@ func (h *Handler) CreateExtractJob(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "extract job ID"
-// @Success      200 {object} models.ExtractJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 func (h *Handler) GetExtractJob(c *gin.Context) {
 	id := c.Param("id")
@@ -80,5 +86,10 @@ func (h *Handler) GetExtractJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "extract job fetched", http.StatusOK, job)
 }
@@ -2,28 +2,44 @@ package handler
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"mime"
 	"net/http"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 
 	"test/api/models"
+	"test/pkg/blobstore"
+	"test/service"
 )
 
 // UploadFile godoc
 // @Router       /file/upload [POST]
 // @Security     ApiKeyAuth
 // @Summary      Upload file
+// @Description  Fayl turi kengaytmadan emas, mazmunidan aniqlanadi (PDF, JPEG/PNG/GIF, Word, Excel, PowerPoint). Kengaytmasi mazmuniga mos kelmagan fayl 422, boshqa turlar 415 bilan rad etiladi. Foydalanuvchi xotira kvotasidan oshsa 403, fayl hajmi chegaradan (UPLOAD_GUEST_MAX_SIZE / UPLOAD_USER_MAX_SIZE) katta bo‘lsa 413.
+// @Description  PDF pdfcpu bilan tekshiriladi: buzilgan yoki sahifalari juda ko‘p PDF rad etiladi, parol bilan himoyalangani "encrypted" deb belgilanadi.
+// @Description  Skaner yoqilgan bo‘lsa fayl zararli dasturga tekshiriladi: topilsa fayl karantinda saqlanadi (422, uni jobga berib bo‘lmaydi), skaner ishlamasa 503.
 // @Tags         file
 // @Accept       multipart/form-data
 // @Produce      json
 // @Param        file formData file true "Upload file"
-// @Success      201  {object}  map[string]string
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
+// @Success      201  {object}  map[string]interface{}
 // @Failure      400  {object}  models.Response
+// @Failure      403  {object}  models.Response
+// @Failure      413  {object}  models.Response
+// @Failure      415  {object}  models.Response
+// @Failure      422  {object}  models.Response
 // @Failure      500  {object}  models.Response
+// @Failure      503  {object}  models.Response
 func (h Handler) UploadFile(c *gin.Context) {
 	// Auth optional: user_id bo'lishi shart emas
 	var ptrUserID *string
@@ -40,53 +56,70 @@ func (h Handler) UploadFile(c *gin.Context) {
 		return
 	}
 
-	// Fayl parametrlari
+	// Fayl parametrlari (file_type ni service fayl mazmunidan aniqlaydi)
 	fileName := fileHeader.Filename
-	fileType := filepath.Ext(fileName)
 	fileSize := fileHeader.Size
-	fileID := uuid.NewString()
-	savePath := fmt.Sprintf("uploads/%s%s", fileID, fileType)
 
-	// 🔒 Fayl hajmi cheklovi
-	const guestMaxSize = 20 * 1024 * 1024      // 30 MB
-	const registeredMaxSize = 30 * 1024 * 1024 // 50 MB
-
-	if ptrUserID == nil && fileSize > guestMaxSize {
-		handleResponse(c, h.log, "Guests can upload files up to 30MB only", http.StatusBadRequest, nil)
-		return
-	}
-	if ptrUserID != nil && fileSize > registeredMaxSize {
-		handleResponse(c, h.log, "Registered users can upload files up to 50MB only", http.StatusBadRequest, nil)
+	// 🔒 Fayl hajmi cheklovi: bo'laklab yuklash bilan bir xil (mehmon yoki ro'yxatdan o'tgan foydalanuvchi)
+	if maxSize := h.services.Upload().MaxSize(ptrUserID); fileSize > maxSize {
+		handleResponse(c, h.log, "file is too large", http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("files up to %d MB can be uploaded", maxSize>>20))
 		return
 	}
 
-	// Faylni saqlash
-	if err := c.SaveUploadedFile(fileHeader, savePath); err != nil {
-		handleResponse(c, h.log, "failed to save file", http.StatusInternalServerError, err.Error())
+	content, err := fileHeader.Open()
+	if err != nil {
+		handleResponse(c, h.log, "failed to read file", http.StatusBadRequest, err.Error())
 		return
 	}
+	defer content.Close()
 
-	// Bazaga yozish uchun model
+	// Bazaga yozish uchun model (ID va saqlash yo'lini service beradi)
 	file := models.File{
-		ID:         fileID,
-		UserID:     ptrUserID, // <-- pointer bo'lishi kerak
-		FileName:   fileName,
-		FilePath:   savePath,
-		FileType:   fileType,
-		FileSize:   fileSize,
-		UploadedAt: time.Now(),
+		UserID:   ptrUserID, // <-- pointer bo'lishi kerak
+		FileName: fileName,
+		FileSize: fileSize,
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// Fayl blob storagega (S3 bo'lishi mumkin) yuklanadi, shuning uchun vaqt ko'proq beriladi
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 
-	id, err := h.services.File().Upload(ctx, file)
+	saved, err := h.services.File().Upload(ctx, file, content)
+	if errors.Is(err, service.ErrQuotaExceeded) {
+		handleResponse(c, h.log, "storage quota exceeded", http.StatusForbidden, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrScanUnavailable) {
+		handleResponse(c, h.log, "file could not be scanned", http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if status, rejected := fileRejectionStatus(err); rejected {
+		handleResponse(c, h.log, "file rejected", status, err.Error())
+		return
+	}
 	if err != nil {
 		handleResponse(c, h.log, "upload failed", http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	handleResponse(c, h.log, "file uploaded", http.StatusCreated, gin.H{"id": id})
+	if saved.ScanStatus == models.ScanQuarantined {
+		handleResponse(c, h.log, "file quarantined", http.StatusUnprocessableEntity, gin.H{
+			"id":             saved.ID,
+			"scan_status":    saved.ScanStatus,
+			"scan_signature": saved.ScanSignature,
+		})
+		return
+	}
+
+	handleResponse(c, h.log, "file uploaded", http.StatusCreated, gin.H{
+		"id":          saved.ID,
+		"sha256":      saved.SHA256,
+		"file_type":   saved.FileType,
+		"page_count":  saved.PageCount,
+		"encrypted":   saved.Encrypted,
+		"scan_status": saved.ScanStatus,
+	})
 }
 
 // GetFile godoc
@@ -113,6 +146,162 @@ func (h Handler) GetFile(c *gin.Context) {
 	handleResponse(c, h.log, "file found", http.StatusOK, file)
 }
 
+// DownloadFile godoc
+// @Router       /file/{id}/download [GET]
+// @Security     ApiKeyAuth
+// @Summary      Download file
+// @Description  Fayl mazmunini asl nomi bilan yuklab berish. Range (qisman yuklab olish) va ETag/If-None-Match qo‘llab-quvvatlanadi.
+// @Description  Egasi bor faylni faqat egasi yoki admin yuklab oladi; guest fayllari ID orqali ochiq. Karantindagi fayl hech kimga berilmaydi (403).
+// @Tags         file
+// @Produce      application/octet-stream
+// @Param        id     path   string true  "File ID"
+// @Param        inline query  bool   false "1 bo‘lsa brauzerda ochiladi (Content-Disposition: inline); faqat PDF, PNG va JPEG uchun, qolganlari doim attachment"
+// @Param        Range  header string false "Masalan: bytes=0-1023"
+// @Success      200  {file}    file
+// @Success      206  {file}    file
+// @Success      304  {string}  string "Not Modified"
+// @Failure      403  {object}  models.Response
+// @Failure      404  {object}  models.Response
+// @Failure      416  {string}  string "Range Not Satisfiable"
+// @Failure      500  {object}  models.Response
+func (h Handler) DownloadFile(c *gin.Context) {
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	file, err := h.services.File().Get(ctx, id)
+	cancel()
+	if err != nil {
+		handleResponse(c, h.log, "file not found", http.StatusNotFound, err.Error())
+		return
+	}
+
+	if !canAccessFile(c, file) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, "you can only download your own files")
+		return
+	}
+	if file.ScanStatus == models.ScanQuarantined {
+		handleResponse(c, h.log, "file quarantined", http.StatusForbidden, "file is quarantined: malware was detected on upload")
+		return
+	}
+
+	// Katta fayl uzoq uzatilishi mumkin, shuning uchun timeout yo'q: mijoz uzilsa so'rov ctx si bekor bo'ladi
+	content, err := h.services.File().Open(c.Request.Context(), file)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		handleResponse(c, h.log, "file content not found", http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		handleResponse(c, h.log, "failed to open file", http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer content.Content.Close()
+
+	disposition := "attachment"
+	if (c.Query("inline") == "1" || c.Query("inline") == "true") && inlineAllowed(content.ContentType) {
+		disposition = "inline"
+	}
+	name := file.FileName
+	if name == "" {
+		name = filepath.Base(file.FilePath)
+	}
+	// FormatMediaType lotin bo'lmagan nomlarni RFC 2231 (filename*=utf-8'') bo'yicha kodlaydi
+	if header := mime.FormatMediaType(disposition, map[string]string{"filename": name}); header != "" {
+		disposition = header
+	}
+
+	c.Header("Content-Type", content.ContentType)
+	c.Header("Content-Disposition", disposition)
+	c.Header("Cache-Control", "private, no-cache")
+	c.Header("X-Content-Type-Options", "nosniff")
+	if content.ETag != "" {
+		c.Header("ETag", content.ETag)
+	}
+
+	// ServeContent Range, If-Range, If-None-Match va If-Modified-Since ni o'zi hal qiladi (206, 304, 416)
+	http.ServeContent(c.Writer, c.Request, name, content.ModTime, content.Content)
+}
+
+// inlineContentTypes - brauzerda ochilsa ham skript bajarilmaydigan turlar (HTML, SVG va h.k. doim yuklab olinadi)
+var inlineContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/png":       true,
+	"image/jpeg":      true,
+}
+
+func inlineAllowed(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && inlineContentTypes[mediaType]
+}
+
+// fileRejectionStatus - fayl mazmuni tekshiruvidan o'tmagan bo'lsa, mos HTTP status
+func fileRejectionStatus(err error) (int, bool) {
+	switch {
+	case err == nil:
+		return 0, false
+	case errors.Is(err, service.ErrUnsupportedFileType):
+		return http.StatusUnsupportedMediaType, true
+	case errors.Is(err, service.ErrFileTypeMismatch), errors.Is(err, service.ErrInvalidPDF), errors.Is(err, service.ErrPDFTooManyPages):
+		return http.StatusUnprocessableEntity, true
+	}
+	return 0, false
+}
+
+// canAccessFile - egasi bor faylni faqat egasi yoki admin oladi; guest fayllari ID orqali ochiq
+func canAccessFile(c *gin.Context, file models.File) bool {
+	return canAccessOwned(c, file.UserID)
+}
+
+// canAccessOwned - userID nil bo'lsa (mehmon yaratgan) ID ni bilgan har kim, aks holda faqat egasi yoki admin
+func canAccessOwned(c *gin.Context, userID *string) bool {
+	if userID == nil || c.GetString("user_role") == "admin" {
+		return true
+	}
+	return *userID == c.GetString("user_id")
+}
+
+// GetFileByHash godoc
+// @Router       /file/by-hash/{sha256} [HEAD]
+// @Security     ApiKeyAuth
+// @Summary      Check file by content hash
+// @Description  Foydalanuvchi shu SHA-256 xeshli faylni avval yuklaganmi. Topilsa 200 va X-File-Id header (GET da fayl ma’lumoti ham) qaytadi - faylni qayta yuklash shart emas.
+// @Tags         file
+// @Param        sha256 path string true "Fayl mazmunining SHA-256 xeshi (hex)"
+// @Success      200 {object} models.File
+// @Failure      400 {object} models.Response
+// @Failure      401 {object} models.Response
+// @Failure      404 {object} models.Response
+func (h Handler) GetFileByHash(c *gin.Context) {
+	sum := strings.ToLower(c.Param("sha256"))
+	if !isSHA256Hex(sum) {
+		handleResponse(c, h.log, "invalid hash", http.StatusBadRequest, "sha256 must be 64 hex characters")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	file, err := h.services.File().FindByHash(ctx, c.GetString("user_id"), sum)
+	if errors.Is(err, service.ErrFileNotFound) {
+		handleResponse(c, h.log, "file not found", http.StatusNotFound, "no file with this hash")
+		return
+	}
+	if err != nil {
+		handleResponse(c, h.log, "failed to find file", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Header("X-File-Id", file.ID)
+	handleResponse(c, h.log, "file found", http.StatusOK, file)
+}
+
+func isSHA256Hex(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
 // DeleteFile godoc
 // @Router       /file/{id} [DELETE]
 // @Security     ApiKeyAuth
@@ -139,46 +328,205 @@ func (h Handler) DeleteFile(c *gin.Context) {
 // ListUserFiles godoc
 // @Router       /file/list [GET]
 // @Security     ApiKeyAuth
-// @Summary      List all user's files
+// @Summary      List user's files
+// @Description  Foydalanuvchi fayllari kutubxonasi: papka, teg, tur, sana, hajm, nom va manba bo‘yicha filtr, saralash va sahifalash.
+// @Description  source_file_id shu fayldan olingan natijalarni (masalan, job_type=compress bilan - uning siqilgan nusxasini) topadi.
 // @Tags         file
 // @Produce      json
-// @Success      200  {array}  models.File
+// @Param        folder_id      query string false "Papka ID yoki root (faqat papkasiz fayllar)"
+// @Param        tag            query string false "Teg"
+// @Param        type           query string false "Fayl turi: MIME (application/pdf) yoki kengaytma (pdf)"
+// @Param        from           query string false "Shu vaqtdan keyin yuklangan (RFC3339 yoki YYYY-MM-DD)"
+// @Param        to             query string false "Shu vaqtgacha yuklangan (RFC3339 yoki YYYY-MM-DD, kun kiradi)"
+// @Param        min_size       query int    false "Minimal hajm (bayt)"
+// @Param        max_size       query int    false "Maksimal hajm (bayt)"
+// @Param        q              query string false "Nom bo‘yicha qidiruv"
+// @Param        source_file_id query string false "Shu fayldan olingan natijalar"
+// @Param        job_type       query string false "Shu amal natijalari (masalan, compress)"
+// @Param        sort           query string false "name, size, type yoki uploaded_at (standart)"
+// @Param        order          query string false "asc yoki desc (uploaded_at uchun standart desc, qolganlari uchun asc)"
+// @Param        page           query int    false "Sahifa (1 dan)"
+// @Param        limit          query int    false "Sahifadagi fayllar (standart 50, maksimal 200)"
+// @Success      200  {object}  models.FileListResponse
+// @Failure      400  {object}  models.Response
+// @Failure      404  {object}  models.Response
 // @Failure      500  {object}  models.Response
 func (h Handler) ListUserFiles(c *gin.Context) {
-	userID := c.GetString("user_id")
+	filter, err := parseFileFilter(c)
+	if err != nil {
+		handleResponse(c, h.log, "invalid filter", http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.UserID = c.GetString("user_id")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	files, err := h.services.File().List(ctx, userID)
+	files, err := h.services.File().List(ctx, filter)
 	if err != nil {
-		handleResponse(c, h.log, "list failed", http.StatusInternalServerError, err.Error())
+		h.handleLibraryError(c, "list failed", err)
 		return
 	}
 
 	handleResponse(c, h.log, "user files", http.StatusOK, files)
 }
 
-// CleanupOldFiles godoc
-// @Summary      Cleanup old files
-// @Description  Admin-only endpoint to delete files older than N days
-// @Tags         file
+// parseFileFilter - GET /file/list query parametrlari
+func parseFileFilter(c *gin.Context) (models.FileFilter, error) {
+	filter := models.FileFilter{
+		FolderID:     c.Query("folder_id"),
+		Tag:          strings.ToLower(strings.TrimSpace(c.Query("tag"))),
+		Query:        strings.TrimSpace(c.Query("q")),
+		SourceFileID: c.Query("source_file_id"),
+		JobType:      c.Query("job_type"),
+		Sort:         c.DefaultQuery("sort", "uploaded_at"),
+	}
+	if t := c.Query("type"); t != "" {
+		filter.FileTypes = []string{t}
+	}
+
+	switch filter.Sort {
+	case "name", "size", "type", "uploaded_at":
+	default:
+		return filter, errors.New("sort must be one of name, size, type, uploaded_at")
+	}
+	switch c.Query("order") {
+	case "":
+		filter.Desc = filter.Sort == "uploaded_at"
+	case "asc":
+	case "desc":
+		filter.Desc = true
+	default:
+		return filter, errors.New("order must be asc or desc")
+	}
+
+	var err error
+	if filter.Page, err = queryInt(c, "page"); err != nil {
+		return filter, err
+	}
+	if filter.Limit, err = queryInt(c, "limit"); err != nil {
+		return filter, err
+	}
+	if filter.MinSize, err = queryInt64(c, "min_size"); err != nil {
+		return filter, err
+	}
+	if filter.MaxSize, err = queryInt64(c, "max_size"); err != nil {
+		return filter, err
+	}
+	if filter.From, err = queryTime(c, "from", false); err != nil {
+		return filter, err
+	}
+	if filter.To, err = queryTime(c, "to", true); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+func queryInt(c *gin.Context, name string) (int, error) {
+	v := c.Query(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer", name)
+	}
+	return n, nil
+}
+
+func queryInt64(c *gin.Context, name string) (*int64, error) {
+	v := c.Query(name)
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("%s must be a non-negative integer", name)
+	}
+	return &n, nil
+}
+
+// queryTime - RFC3339 yoki YYYY-MM-DD; endOfDay bo‘lsa sana keyingi kun boshiga aylanadi (kun o‘zi kiradi)
+func queryTime(c *gin.Context, name string, endOfDay bool) (*time.Time, error) {
+	v := c.Query(name)
+	if v == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be RFC3339 or YYYY-MM-DD", name)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+// UpdateFile godoc
+// @Router       /file/{id} [PATCH]
 // @Security     ApiKeyAuth
+// @Summary      Rename, move or tag file
+// @Description  Faylni qayta nomlaydi, papkaga ko‘chiradi (folder_id "" - ildizga) va teglarini almashtiradi. Berilmagan maydonlar o‘zgarmaydi. Faqat egasi yoki admin.
+// @Tags         file
+// @Accept       json
 // @Produce      json
-// @Success      200 {object} models.Response
+// @Param        id      path string                   true "File ID"
+// @Param        request body models.UpdateFileRequest true "O‘zgarishlar"
+// @Success      200 {object} models.File
+// @Failure      400 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
 // @Failure      500 {object} models.Response
-// @Router       /api/files/cleanup [get]
-func (h *Handler) CleanupOldFiles(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (h Handler) UpdateFile(c *gin.Context) {
+	var req models.UpdateFileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleResponse(c, h.log, "invalid request", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	const olderThanDays = 7
+	file, err := h.services.File().Get(ctx, c.Param("id"))
+	if err != nil {
+		handleResponse(c, h.log, "file not found", http.StatusNotFound, err.Error())
+		return
+	}
+	if !canManageFile(c, file) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, "you can only change your own files")
+		return
+	}
+
+	file, err = h.services.File().Update(ctx, file, req)
+	if err != nil {
+		h.handleLibraryError(c, "failed to update file", err)
+		return
+	}
+
+	handleResponse(c, h.log, "file updated", http.StatusOK, file)
+}
+
+// ListFileTags godoc
+// @Router       /file/tags [GET]
+// @Security     ApiKeyAuth
+// @Summary      List my tags
+// @Description  Foydalanuvchi fayllaridagi teglar va ular qo‘yilgan fayllar soni
+// @Tags         file
+// @Produce      json
+// @Success      200 {array}  models.TagCount
+// @Failure      500 {object} models.Response
+func (h Handler) ListFileTags(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	count, err := h.services.File().CleanupOldFiles(ctx, olderThanDays)
+	tags, err := h.services.File().ListTags(ctx, c.GetString("user_id"))
 	if err != nil {
-		handleResponse(c, h.log, "failed to cleanup old files", http.StatusInternalServerError, err.Error())
+		handleResponse(c, h.log, "failed to list tags", http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	handleResponse(c, h.log, "old files cleaned up", http.StatusOK, gin.H{"deleted_files": count})
+	handleResponse(c, h.log, "tags fetched", http.StatusOK, tags)
 }
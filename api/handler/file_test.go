@@ -0,0 +1,29 @@
+package handler
+
+import "testing"
+
+func TestInlineAllowed(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        bool
+	}{
+		{"application/pdf", true},
+		{"image/png", true},
+		{"image/jpeg", true},
+		{"Image/JPEG", true},
+		{"application/pdf; charset=binary", true},
+		{"text/html", false},
+		{"text/html; charset=utf-8", false},
+		{"image/svg+xml", false},
+		{"application/octet-stream", false},
+		{"text/plain", false},
+		{"", false},
+		{"application/pdf;;", false},
+	}
+
+	for _, tt := range tests {
+		if got := inlineAllowed(tt.contentType); got != tt.want {
+			t.Errorf("inlineAllowed(%q) = %v, want %v", tt.contentType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"test/api/models"
+	"test/service"
+)
+
+// CreateFolder godoc
+// @Router       /file/folders [POST]
+// @Security     ApiKeyAuth
+// @Summary      Create folder
+// @Description  Fayllar kutubxonasida papka yaratadi; parent_id berilmasa ildizda. Bitta papka ichida nomlar takrorlanmaydi.
+// @Tags         file
+// @Accept       json
+// @Produce      json
+// @Param        request body models.CreateFolderRequest true "Papka"
+// @Success      201 {object} models.Folder
+// @Failure      400 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      409 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) CreateFolder(c *gin.Context) {
+	var req models.CreateFolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleResponse(c, h.log, "invalid request", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	folder, err := h.services.Folder().Create(ctx, c.GetString("user_id"), req)
+	if err != nil {
+		h.handleLibraryError(c, "failed to create folder", err)
+		return
+	}
+
+	handleResponse(c, h.log, "folder created", http.StatusCreated, folder)
+}
+
+// ListFolders godoc
+// @Router       /file/folders [GET]
+// @Security     ApiKeyAuth
+// @Summary      List folders
+// @Description  Papka ichidagi papkalar; parent_id berilmasa ildizdagilar
+// @Tags         file
+// @Produce      json
+// @Param        parent_id query string false "Ota papka ID"
+// @Success      200 {object} models.FolderListResponse
+// @Failure      404 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) ListFolders(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	parentID := c.Query("parent_id")
+	folders, err := h.services.Folder().GetList(ctx, c.GetString("user_id"), &parentID)
+	if err != nil {
+		h.handleLibraryError(c, "failed to list folders", err)
+		return
+	}
+
+	handleResponse(c, h.log, "folders fetched", http.StatusOK, models.FolderListResponse{
+		Folders: folders,
+		Count:   len(folders),
+	})
+}
+
+// UpdateFolder godoc
+// @Router       /file/folders/{id} [PATCH]
+// @Security     ApiKeyAuth
+// @Summary      Rename or move folder
+// @Description  Papkani qayta nomlaydi va/yoki boshqa papkaga ko‘chiradi (parent_id "" - ildizga). Papkani o‘zining ichiga ko‘chirib bo‘lmaydi.
+// @Tags         file
+// @Accept       json
+// @Produce      json
+// @Param        id      path string                     true "Folder ID"
+// @Param        request body models.UpdateFolderRequest true "O‘zgarishlar"
+// @Success      200 {object} models.Folder
+// @Failure      400 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      409 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) UpdateFolder(c *gin.Context) {
+	var req models.UpdateFolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleResponse(c, h.log, "invalid request", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	folder, err := h.services.Folder().Update(ctx, c.GetString("user_id"), c.Param("id"), req)
+	if err != nil {
+		h.handleLibraryError(c, "failed to update folder", err)
+		return
+	}
+
+	handleResponse(c, h.log, "folder updated", http.StatusOK, folder)
+}
+
+// DeleteFolder godoc
+// @Router       /file/folders/{id} [DELETE]
+// @Security     ApiKeyAuth
+// @Summary      Delete folder
+// @Description  Papkani ichki papkalari bilan o‘chiradi. Fayllar o‘chirilmaydi - ular ildizga o‘tadi.
+// @Tags         file
+// @Produce      json
+// @Param        id path string true "Folder ID"
+// @Success      200 {object} map[string]string
+// @Failure      404 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) DeleteFolder(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	id := c.Param("id")
+	if err := h.services.Folder().Delete(ctx, c.GetString("user_id"), id); err != nil {
+		h.handleLibraryError(c, "failed to delete folder", err)
+		return
+	}
+
+	handleResponse(c, h.log, "folder deleted", http.StatusOK, gin.H{"id": id})
+}
+
+func (h Handler) handleLibraryError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, service.ErrFolderNotFound):
+		handleResponse(c, h.log, "folder not found", http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrFolderExists):
+		handleResponse(c, h.log, "folder already exists", http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidFolderMove), errors.Is(err, service.ErrInvalidName),
+		errors.Is(err, service.ErrInvalidTag), errors.Is(err, service.ErrInvalidFileFilter):
+		handleResponse(c, h.log, msg, http.StatusBadRequest, err.Error())
+	default:
+		handleResponse(c, h.log, msg, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -17,6 +17,7 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        data body models.CreateHTMLToPDFRequest true "HTML to PDF request"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} models.Response{data=string} "job_id qaytadi"
 // @Failure      400 {object} models.Response
 // @Failure      500 {object} models.Response
@@ -46,12 +47,14 @@ func (h *Handler) CreateHTMLToPDF(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.HTMLToPDF().Create(ctx, req, userID)
 	if err != nil {
-		handleResponse(c, h.log, "conversion failed", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "conversion failed", err)
 		return
 	}
 
+	h.jobAccessToken(c, jobID)
 	handleResponse(c, h.log, "conversion started", http.StatusCreated, jobID)
 }
 
@@ -61,7 +64,9 @@ func (h *Handler) CreateHTMLToPDF(c *gin.Context) {
 // @Tags         HTML to PDF
 // @Produce      json
 // @Param        id path string true "Job ID"
-// @Success      200 {object} models.HTMLToPDFJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
 // @Failure      500 {object} models.Response
 // @Router       /api/pdf/html-to-pdf/{id} [get]
@@ -77,5 +82,10 @@ func (h *Handler) GetHTMLToPDFJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "job found", http.StatusOK, job)
 }
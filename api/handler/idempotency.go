@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"test/api/models"
+	"test/pkg/logger"
+	"test/service"
+)
+
+const (
+	idempotencyHeader       = "Idempotency-Key"
+	idempotencyReplayHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength = 255
+
+	// multipartOverhead - fayldan tashqari maydonlar va boundary lar uchun qo'shimcha joy
+	multipartOverhead = 1 << 20
+	// maxIdempotentBody - multipart bo'lmagan (JSON) body xotiraga to'liq o'qiladi, shuning uchun cheklanadi
+	maxIdempotentBody = 1 << 20
+)
+
+// errIdempotentBodyTooLarge - Idempotency-Key bilan kelgan body ruxsat etilgan chegaradan katta
+var errIdempotentBodyTooLarge = errors.New("request body is too large")
+
+// IdempotencyMiddleware – Idempotency-Key headeri bilan kelgan POST so'rovning 2xx javobini saqlaydi.
+// Shu kalit bilan qayta kelgan so'rov qayta bajarilmaydi, asl javob (job ID) qaytariladi.
+func (h Handler) IdempotencyMiddleware(c *gin.Context) {
+	key := strings.TrimSpace(c.GetHeader(idempotencyHeader))
+	if key == "" || c.Request.Method != http.MethodPost {
+		c.Next()
+		return
+	}
+
+	if len(key) > maxIdempotencyKeyLength {
+		handleResponse(c, h.log, "invalid idempotency key", http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+		c.Abort()
+		return
+	}
+
+	var userID *string
+	if uid := c.GetString("user_id"); uid != "" {
+		userID = &uid
+	}
+
+	fingerprint, cleanup, err := requestFingerprint(c, h.services.Upload().MaxSize(userID)+multipartOverhead)
+	if errors.Is(err, errIdempotentBodyTooLarge) {
+		handleResponse(c, h.log, "request too large", http.StatusRequestEntityTooLarge, err.Error())
+		c.Abort()
+		return
+	}
+	if err != nil {
+		handleResponse(c, h.log, "failed to read request body", http.StatusBadRequest, err.Error())
+		c.Abort()
+		return
+	}
+	defer cleanup()
+
+	scope := idempotencyScope(userID, fingerprint)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	record, err := h.services.Idempotency().Begin(ctx, scope, key, fingerprint)
+	switch {
+	case errors.Is(err, service.ErrIdempotencyInProgress):
+		handleResponse(c, h.log, "idempotent request in progress", http.StatusConflict, err.Error())
+		c.Abort()
+		return
+	case errors.Is(err, service.ErrIdempotencyKeyReused):
+		handleResponse(c, h.log, "idempotency key reused", http.StatusUnprocessableEntity, err.Error())
+		c.Abort()
+		return
+	case err != nil:
+		// Redis ishlamasa so'rovni to'xtatmaymiz, faqat idempotentlik kafolati yo'qoladi
+		h.log.Error("idempotency check failed", logger.String("key", key), logger.Error(err))
+		c.Next()
+		return
+	case record != nil:
+		c.Header(idempotencyReplayHeader, "true")
+		c.Data(record.StatusCode, record.ContentType, record.Body)
+		c.Abort()
+		return
+	}
+
+	recorder := &responseRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+
+	c.Next()
+
+	// So'rov ctx si allaqachon tugagan bo'lishi mumkin (uzoq upload), shuning uchun yangi ctx
+	saveCtx, saveCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer saveCancel()
+
+	status := recorder.Status()
+	if status < http.StatusOK || status >= http.StatusMultipleChoices {
+		h.services.Idempotency().Release(saveCtx, scope, key)
+		return
+	}
+
+	_ = h.services.Idempotency().Complete(saveCtx, scope, key, models.IdempotencyRecord{
+		Fingerprint: fingerprint,
+		StatusCode:  status,
+		ContentType: recorder.Header().Get("Content-Type"),
+		Body:        recorder.body.Bytes(),
+	})
+}
+
+// idempotencyScope - kalitlar foydalanuvchi bo'yicha ajratiladi. Mehmonda foydalanuvchi yo'q, shuning uchun
+// kalit so'rov xeshi bilan bog'lanadi: IP o'zgarsa ham qayta urinish ishlaydi, bir NAT ortidagi mehmonlar esa
+// bir-birining javobini (masalan, file_id) faqat kalitni ham, so'rovni ham aynan bilgandagina olishi mumkin
+func idempotencyScope(userID *string, fingerprint string) string {
+	if userID != nil {
+		return "user:" + *userID
+	}
+	return "guest:" + fingerprint
+}
+
+// requestFingerprint - method, path, query va body xeshi. Oddiy body maxIdempotentBody gacha o'qiladi.
+// Multipart bodyda boundary har safar boshqacha bo'lgani uchun har bir qism (maydon nomi, fayl nomi va mazmuni)
+// alohida xeshlanadi; body vaqtinchalik faylga yoziladi (maxMultipart gacha) va handler uni shu fayldan o'qiydi.
+// cleanup c.Next() dan keyin chaqiriladi.
+func requestFingerprint(c *gin.Context, maxMultipart int64) (string, func(), error) {
+	cleanup := func() {}
+
+	hash := sha256.New()
+	hash.Write([]byte(c.Request.Method + " " + c.Request.URL.Path + "?" + c.Request.URL.RawQuery + "\n"))
+
+	if c.Request.Body == nil || c.Request.Body == http.NoBody {
+		return hex.EncodeToString(hash.Sum(nil)), cleanup, nil
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if mediaType != "multipart/form-data" || params["boundary"] == "" {
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentBody))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return "", cleanup, errIdempotentBodyTooLarge
+		}
+		if err != nil {
+			return "", cleanup, err
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		hash.Write(body)
+		return hex.EncodeToString(hash.Sum(nil)), cleanup, nil
+	}
+
+	spool, err := os.CreateTemp("", "idempotency-*")
+	if err != nil {
+		return "", cleanup, err
+	}
+	cleanup = func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}
+
+	n, err := io.Copy(spool, io.LimitReader(c.Request.Body, maxMultipart+1))
+	if err == nil && n > maxMultipart {
+		err = errIdempotentBodyTooLarge
+	}
+	if err == nil {
+		_, err = spool.Seek(0, io.SeekStart)
+	}
+	if err == nil {
+		err = hashMultipart(hash, spool, params["boundary"])
+	}
+	if err == nil {
+		_, err = spool.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		cleanup()
+		return "", func() {}, err
+	}
+
+	c.Request.Body = spool
+	c.Request.ContentLength = n
+	return hex.EncodeToString(hash.Sum(nil)), cleanup, nil
+}
+
+// hashMultipart - har bir qismning nomi, fayl nomi va mazmun xeshini yozadi (boundary hisobga olinmaydi)
+func hashMultipart(w io.Writer, r io.Reader, boundary string) error {
+	mr := multipart.NewReader(r, boundary)
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid multipart body: %w", err)
+		}
+
+		content := sha256.New()
+		if _, err := io.Copy(content, part); err != nil {
+			return fmt.Errorf("invalid multipart body: %w", err)
+		}
+		fmt.Fprintf(w, "%q %q %x\n", part.FormName(), part.FileName(), content.Sum(nil))
+	}
+}
+
+// responseRecorder - javob bodysini mijozga yuborish bilan birga nusxalab oladi
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
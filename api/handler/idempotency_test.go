@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func multipartRequest(t *testing.T, fields map[string]string, fileName, content string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body) // har safar yangi tasodifiy boundary
+	for name, value := range fields {
+		if err := w.WriteField(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	part, err := w.CreateFormFile("file", fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write([]byte(content))
+	w.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/file/upload", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func fingerprintOf(t *testing.T, req *http.Request, limit int64) (string, *gin.Context, func(), error) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = req
+	fp, cleanup, err := requestFingerprint(c, limit)
+	return fp, c, cleanup, err
+}
+
+func TestRequestFingerprint(t *testing.T) {
+	jsonReq := func(path, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	tests := []struct {
+		name string
+		a, b func() *http.Request
+		same bool
+	}{
+		{
+			name: "same json body",
+			a:    func() *http.Request { return jsonReq("/api/pdf/compress", `{"input_file_id":"a"}`) },
+			b:    func() *http.Request { return jsonReq("/api/pdf/compress", `{"input_file_id":"a"}`) },
+			same: true,
+		},
+		{
+			name: "different json body",
+			a:    func() *http.Request { return jsonReq("/api/pdf/compress", `{"input_file_id":"a"}`) },
+			b:    func() *http.Request { return jsonReq("/api/pdf/compress", `{"input_file_id":"b"}`) },
+		},
+		{
+			name: "different path",
+			a:    func() *http.Request { return jsonReq("/api/pdf/compress", `{}`) },
+			b:    func() *http.Request { return jsonReq("/api/pdf/split", `{}`) },
+		},
+		{
+			name: "different query",
+			a:    func() *http.Request { return jsonReq("/api/pdf/compress?x=1", `{}`) },
+			b:    func() *http.Request { return jsonReq("/api/pdf/compress?x=2", `{}`) },
+		},
+		{
+			name: "same upload, different boundary",
+			a:    func() *http.Request { return multipartRequest(t, nil, "a.pdf", "%PDF-1.7 one") },
+			b:    func() *http.Request { return multipartRequest(t, nil, "a.pdf", "%PDF-1.7 one") },
+			same: true,
+		},
+		{
+			name: "different file content",
+			a:    func() *http.Request { return multipartRequest(t, nil, "a.pdf", "%PDF-1.7 one") },
+			b:    func() *http.Request { return multipartRequest(t, nil, "a.pdf", "%PDF-1.7 two") },
+		},
+		{
+			name: "different file name",
+			a:    func() *http.Request { return multipartRequest(t, nil, "a.pdf", "%PDF-1.7 one") },
+			b:    func() *http.Request { return multipartRequest(t, nil, "b.pdf", "%PDF-1.7 one") },
+		},
+		{
+			name: "different form field",
+			a:    func() *http.Request { return multipartRequest(t, map[string]string{"folder_id": "x"}, "a.pdf", "%PDF") },
+			b:    func() *http.Request { return multipartRequest(t, map[string]string{"folder_id": "y"}, "a.pdf", "%PDF") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fa, _, cleanA, err := fingerprintOf(t, tt.a(), 1<<20)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cleanA()
+			fb, _, cleanB, err := fingerprintOf(t, tt.b(), 1<<20)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cleanB()
+
+			if (fa == fb) != tt.same {
+				t.Errorf("fingerprints equal = %v, want %v", fa == fb, tt.same)
+			}
+		})
+	}
+}
+
+func TestRequestFingerprintRestoresBody(t *testing.T) {
+	_, c, cleanup, err := fingerprintOf(t, multipartRequest(t, nil, "a.pdf", "%PDF-1.7 content"), 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	// Handler body ni xeshlangandan keyin ham to'liq o'qiy olishi kerak
+	header, err := c.FormFile("file")
+	if err != nil {
+		t.Fatalf("FormFile after fingerprint: %v", err)
+	}
+	f, _ := header.Open()
+	defer f.Close()
+	if data, _ := io.ReadAll(f); string(data) != "%PDF-1.7 content" {
+		t.Errorf("file content = %q", data)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/pdf/compress", strings.NewReader(`{"a":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	_, c, cleanup, err = fingerprintOf(t, req, 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+	if data, _ := io.ReadAll(c.Request.Body); string(data) != `{"a":1}` {
+		t.Errorf("json body = %q", data)
+	}
+}
+
+func TestRequestFingerprintTooLarge(t *testing.T) {
+	_, _, cleanup, err := fingerprintOf(t, multipartRequest(t, nil, "a.pdf", strings.Repeat("x", 4096)), 1024)
+	defer cleanup()
+	if !errors.Is(err, errIdempotentBodyTooLarge) {
+		t.Fatalf("err = %v, want errIdempotentBodyTooLarge", err)
+	}
+}
+
+func TestRequestFingerprintJSONTooLarge(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/pdf/compress", strings.NewReader(`{"a":"`+strings.Repeat("x", maxIdempotentBody)+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	_, _, cleanup, err := fingerprintOf(t, req, 1<<30)
+	defer cleanup()
+	if !errors.Is(err, errIdempotentBodyTooLarge) {
+		t.Fatalf("err = %v, want errIdempotentBodyTooLarge", err)
+	}
+}
+
+func TestIdempotencyScope(t *testing.T) {
+	uid := "u1"
+	if got := idempotencyScope(&uid, "fp"); got != "user:u1" {
+		t.Errorf("user scope = %q", got)
+	}
+
+	// Mehmon kaliti IP ga emas, so'rov xeshiga bog'lanadi
+	if idempotencyScope(nil, "fp-a") == idempotencyScope(nil, "fp-b") {
+		t.Error("guest scopes for different requests must differ")
+	}
+	if idempotencyScope(nil, "fp-a") != idempotencyScope(nil, "fp-a") {
+		t.Error("guest scope must not depend on anything but the request")
+	}
+}
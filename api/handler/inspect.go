@@ -19,9 +19,12 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.InspectRequest true "Inspect request"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
 // @Failure      401 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 func (h Handler) CreateInspectJob(c *gin.Context) {
 	var req models.InspectRequest
@@ -44,13 +47,14 @@ func (h Handler) CreateInspectJob(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.Inspect().Create(ctx, req.FileID, userID)
 	if err != nil {
-		handleResponse(c, h.log, "failed to inspect PDF", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "failed to inspect PDF", err)
 		return
 	}
 
-	handleResponse(c, h.log, "inspect job created", http.StatusCreated, gin.H{"id": jobID})
+	handleResponse(c, h.log, "inspect job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetInspectJob godoc
@@ -61,8 +65,10 @@ func (h Handler) CreateInspectJob(c *gin.Context) {
 // @Tags         inspect
 // @Produce      json
 // @Param        id path string true "Inspect Job ID"
-// @Success      200 {object} models.InspectJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
 // @Failure      400 {object} models.Response
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
 // @Failure      500 {object} models.Response
 func (h Handler) GetInspectJob(c *gin.Context) {
@@ -81,5 +87,10 @@ func (h Handler) GetInspectJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "inspect job fetched", http.StatusOK, job)
 }
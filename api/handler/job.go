@@ -0,0 +1,329 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"test/api/models"
+	"test/service"
+)
+
+// jobReadForbidden - token ham, job yaratilganda berilgan access_token ham mos kelmadi
+const jobReadForbidden = "job can only be read by its owner or with the access_token returned when it was created"
+
+// Job yaratilganda beriladigan o'qish tokeni: javobda access_token, so'rovda ?access_token= yoki shu header
+const (
+	jobTokenQuery  = "access_token"
+	jobTokenHeader = "Job-Access-Token"
+)
+
+// GetJob godoc
+// @Router       /api/jobs/{id} [GET]
+// @Security     ApiKeyAuth
+// @Summary      Get job
+// @Description  Istalgan turdagi job holatini ID orqali olish (job egasi, admin yoki job yaratilganda qaytarilgan access_token bilan)
+// @Tags         jobs
+// @Produce      json
+// @Param        id path string true "Job ID"
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      400 {object} models.Response
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+func (h Handler) GetJob(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		handleResponse(c, h.log, "missing job ID", http.StatusBadRequest, "id is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := h.services.Job().GetByID(ctx, id)
+	if err != nil {
+		handleResponse(c, h.log, "job not found", http.StatusNotFound, err.Error())
+		return
+	}
+
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
+	handleResponse(c, h.log, "job fetched", http.StatusOK, job)
+}
+
+// GetJobList godoc
+// @Router       /api/jobs [GET]
+// @Security     ApiKeyAuth
+// @Summary      List jobs
+// @Description  Foydalanuvchining joblari ro‘yxati (turi va holati bo‘yicha filtrlash mumkin)
+// @Tags         jobs
+// @Produce      json
+// @Param        type   query string false "Job turi (merge, split, compress, ...)"
+// @Param        status query string false "Job holati (pending, processing, done, failed, dead, cancelled)"
+// @Success      200 {object} models.JobListResponse
+// @Failure      400 {object} models.Response
+// @Failure      401 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) GetJobList(c *gin.Context) {
+	filter := models.JobFilter{
+		UserID: c.GetString("user_id"),
+		Type:   c.Query("type"),
+		Status: c.Query("status"),
+	}
+
+	switch models.JobStatus(filter.Status) {
+	case "", models.Pending, models.Processing, models.Done, models.Failed, models.Dead, models.Cancelled:
+	default:
+		handleResponse(c, h.log, "invalid status", http.StatusBadRequest, "status must be one of pending, processing, done, failed, dead, cancelled")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	jobs, err := h.services.Job().GetList(ctx, filter)
+	if err != nil {
+		handleResponse(c, h.log, "failed to list jobs", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "jobs fetched", http.StatusOK, models.JobListResponse{
+		Jobs:  jobs,
+		Count: len(jobs),
+	})
+}
+
+// DeleteJob godoc
+// @Router       /api/jobs/{id} [DELETE]
+// @Security     ApiKeyAuth
+// @Summary      Delete job
+// @Description  Jobni o‘chirish (faqat job egasi yoki admin)
+// @Tags         jobs
+// @Produce      json
+// @Param        id path string true "Job ID"
+// @Success      200 {object} models.Response
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) DeleteJob(c *gin.Context) {
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := h.services.Job().GetByID(ctx, id)
+	if err != nil {
+		handleResponse(c, h.log, "job not found", http.StatusNotFound, err.Error())
+		return
+	}
+
+	if !canManageJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, "you can only delete your own jobs")
+		return
+	}
+
+	if err := h.services.Job().Delete(ctx, id); err != nil {
+		handleResponse(c, h.log, "failed to delete job", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "job deleted", http.StatusOK, gin.H{"id": id})
+}
+
+// RetryJob godoc
+// @Router       /api/jobs/{id}/retry [POST]
+// @Security     ApiKeyAuth
+// @Summary      Retry job
+// @Description  Failed, dead yoki cancelled holatidagi jobni qaytadan navbatga qo‘yish (faqat job egasi yoki admin). Parolli joblar (protect, unlock) qayta ishga tushirilmaydi - parol job tugashi bilan o‘chiriladi, ularni qayta yuborish kerak (409)
+// @Tags         jobs
+// @Produce      json
+// @Param        id path string true "Job ID"
+// @Success      200 {object} models.Job
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      409 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) RetryJob(c *gin.Context) {
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := h.services.Job().GetByID(ctx, id)
+	if err != nil {
+		handleResponse(c, h.log, "job not found", http.StatusNotFound, err.Error())
+		return
+	}
+
+	if !canManageJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, "you can only retry your own jobs")
+		return
+	}
+
+	job, err = h.services.Job().Retry(ctx, id)
+	if errors.Is(err, service.ErrJobNotRetryable) {
+		handleResponse(c, h.log, "job cannot be retried", http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		handleResponse(c, h.log, "failed to retry job", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "job queued for retry", http.StatusOK, job)
+}
+
+// CancelJob godoc
+// @Router       /api/jobs/{id}/cancel [POST]
+// @Security     ApiKeyAuth
+// @Summary      Cancel job
+// @Description  Pending yoki processing holatidagi jobni bekor qilish; bajarilayotgan jarayon to‘xtatiladi va qisman natijalar o‘chiriladi (faqat job egasi yoki admin)
+// @Tags         jobs
+// @Produce      json
+// @Param        id path string true "Job ID"
+// @Success      200 {object} models.Job
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      409 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) CancelJob(c *gin.Context) {
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := h.services.Job().GetByID(ctx, id)
+	if err != nil {
+		handleResponse(c, h.log, "job not found", http.StatusNotFound, err.Error())
+		return
+	}
+
+	if !canManageJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, "you can only cancel your own jobs")
+		return
+	}
+
+	job, err = h.services.Job().Cancel(ctx, id)
+	if errors.Is(err, service.ErrJobNotCancellable) {
+		handleResponse(c, h.log, "job cannot be cancelled", http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		handleResponse(c, h.log, "failed to cancel job", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "job cancelled", http.StatusOK, job)
+}
+
+// GetJobWebhooks godoc
+// @Router       /api/jobs/{id}/webhooks [GET]
+// @Security     ApiKeyAuth
+// @Summary      Job webhook deliveries
+// @Description  callback_url ga yuborilgan webhooklar logi (har bir urinish alohida yozuv; faqat job egasi yoki admin)
+// @Tags         jobs
+// @Produce      json
+// @Param        id path string true "Job ID"
+// @Success      200 {object} models.WebhookDeliveryListResponse
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) GetJobWebhooks(c *gin.Context) {
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := h.services.Job().GetByID(ctx, id)
+	if err != nil {
+		handleResponse(c, h.log, "job not found", http.StatusNotFound, err.Error())
+		return
+	}
+
+	if !canManageJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, "you can only view webhooks of your own jobs")
+		return
+	}
+
+	deliveries, err := h.services.Webhook().GetDeliveries(ctx, id)
+	if err != nil {
+		handleResponse(c, h.log, "failed to get webhook deliveries", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "webhook deliveries fetched", http.StatusOK, models.WebhookDeliveryListResponse{
+		Deliveries: deliveries,
+		Count:      len(deliveries),
+	})
+}
+
+// jobContext - so'rovdagi callback sozlamalarini ctx ga ko'chiradi va so'rovdan tozalaydi,
+// shunda secret job params ichida saqlanib, GET /api/jobs/:id da ko'rinib qolmaydi
+func jobContext(ctx context.Context, cb *models.JobCallback) context.Context {
+	opts := *cb
+	*cb = models.JobCallback{}
+	return service.WithCallback(ctx, opts)
+}
+
+// handleJobError - job yaratishdagi xato: callback_url ruxsat etilmagan bo'lsa 400, kiruvchi fayl boshqa
+// foydalanuvchiniki bo'lsa 403, turi amalga mos kelmasa 415, fayl karantinda bo'lsa 422, aks holda 500
+func (h Handler) handleJobError(c *gin.Context, msg string, err error) {
+	if errors.Is(err, service.ErrInvalidCallbackURL) {
+		handleResponse(c, h.log, "invalid callback url", http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrFileAccessDenied) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrUnsupportedFileType) {
+		handleResponse(c, h.log, "unsupported input file type", http.StatusUnsupportedMediaType, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrFileQuarantined) {
+		handleResponse(c, h.log, "input file is quarantined", http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	handleResponse(c, h.log, msg, http.StatusInternalServerError, err.Error())
+}
+
+// canReadJob - job holati, natijasi va hodisalarini egasi, admin yoki job yaratilganda berilgan access_token
+// egasi (mehmon ham) o'qiy oladi. Brauzer EventSource va WebSocket header yubora olmagani uchun token query
+// parametri orqali ham qabul qilinadi
+func (h Handler) canReadJob(c *gin.Context, job *models.Job) bool {
+	if canManageJob(c, job) {
+		return true
+	}
+	token := c.Query(jobTokenQuery)
+	if token == "" {
+		token = c.GetHeader(jobTokenHeader)
+	}
+	return h.services.Job().CheckAccessToken(job.ID, token)
+}
+
+// jobAccessToken - yangi job uchun o'qish tokeni; javob headeriga ham yoziladi (javob bodysi faqat ID bo'lgan handlerlar uchun)
+func (h Handler) jobAccessToken(c *gin.Context, jobID string) string {
+	token := h.services.Job().AccessToken(jobID)
+	c.Header(jobTokenHeader, token)
+	return token
+}
+
+// canManageJob - jobni faqat uning egasi yoki admin boshqara oladi
+func canManageJob(c *gin.Context, job *models.Job) bool {
+	if c.GetString("user_role") == "admin" {
+		return true
+	}
+	return job.UserID != nil && *job.UserID == c.GetString("user_id")
+}
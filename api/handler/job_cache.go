@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"test/api/models"
+)
+
+// GetJobCacheStats godoc
+// @Router       /admin/cache [GET]
+// @Security     ApiKeyAuth
+// @Summary      Job result cache stats
+// @Description  Natijalar keshi: yozuvlar soni, keshdan berilgan joblar (hits) va natija fayllari hajmi, amal turlari bo‘yicha (faqat admin)
+// @Tags         admin
+// @Produce      json
+// @Success      200 {object} models.JobCacheStats
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) GetJobCacheStats(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stats, err := h.services.JobCache().Stats(ctx)
+	if err != nil {
+		handleResponse(c, h.log, "failed to get job cache stats", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "job cache stats fetched", http.StatusOK, stats)
+}
+
+// PurgeJobCache godoc
+// @Router       /admin/cache [DELETE]
+// @Security     ApiKeyAuth
+// @Summary      Purge job result cache
+// @Description  Kesh yozuvlarini o‘chiradi (masalan, amal kutubxonasi yangilangandan keyin). Foydalanuvchilarga berilgan natija fayllari o‘chmaydi (faqat admin)
+// @Tags         admin
+// @Produce      json
+// @Param        type    query string false "Faqat shu amal turi (masalan, compress)"
+// @Param        expired query bool   false "true bo‘lsa faqat muddati o‘tgan yozuvlar"
+// @Success      200 {object} models.JobCachePurgeResponse
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) PurgeJobCache(c *gin.Context) {
+	filter := models.JobCacheFilter{
+		JobType:     c.Query("type"),
+		ExpiredOnly: c.Query("expired") == "true" || c.Query("expired") == "1",
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	purged, err := h.services.JobCache().Purge(ctx, filter)
+	if err != nil {
+		handleResponse(c, h.log, "failed to purge job cache", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "job cache purged", http.StatusOK, models.JobCachePurgeResponse{Purged: purged})
+}
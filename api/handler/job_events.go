@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+
+	"test/api/models"
+	"test/pkg/logger"
+)
+
+// sseHeartbeat - proxylar ulanishni yopib qo'ymasligi uchun bo'sh izoh yuborish oralig'i
+const sseHeartbeat = 15 * time.Second
+
+// GetJobEvents godoc
+// @Router       /api/jobs/{id}/events [GET]
+// @Security     ApiKeyAuth
+// @Summary      Job events (SSE)
+// @Description  Job holati o‘zgarishlari va sahifa jarayonini (masalan, 14/120) Server-Sent Events orqali uzatadi. Birinchi hodisa - jobning joriy holati; job yakunlanganda oqim yopiladi (job egasi, admin yoki ?access_token= bilan; EventSource header yubora olmaydi).
+// @Tags         jobs
+// @Produce      text/event-stream
+// @Param        id path string true "Job ID"
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.JobEvent
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) GetJobEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	events, closeSub, snapshot, err := h.subscribeJob(ctx, id)
+	if err != nil {
+		handleResponse(c, h.log, "job not found", http.StatusNotFound, err.Error())
+		return
+	}
+	defer closeSub()
+
+	if !h.canReadJob(c, snapshot) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	send := func(event models.JobEvent) error {
+		c.SSEvent(event.Event, event)
+		c.Writer.Flush()
+		return nil
+	}
+	ping := func() error {
+		if _, err := c.Writer.WriteString(": ping\n\n"); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+
+	if err := streamJobEvents(ctx, snapshot, events, send, ping); err != nil {
+		h.log.Error("job event stream closed", logger.String("jobID", id), logger.Error(err))
+	}
+}
+
+// GetJobEventsWS godoc
+// @Router       /api/jobs/{id}/ws [GET]
+// @Security     ApiKeyAuth
+// @Summary      Job events (WebSocket)
+// @Description  SSE bilan bir xil hodisalarni WebSocket orqali JSON xabarlar ko‘rinishida uzatadi (job egasi, admin yoki ?access_token= bilan).
+// @Description  Brauzerdan ulanishda Origin API ning o‘z manzili yoki WS_ALLOWED_ORIGINS dagi manzil bo‘lishi kerak, aks holda 403.
+// @Tags         jobs
+// @Param        id path string true "Job ID"
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      101 {object} models.JobEvent
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+func (h Handler) GetJobEventsWS(c *gin.Context) {
+	id := c.Param("id")
+
+	events, closeSub, snapshot, err := h.subscribeJob(c.Request.Context(), id)
+	if err != nil {
+		handleResponse(c, h.log, "job not found", http.StatusNotFound, err.Error())
+		return
+	}
+	defer closeSub()
+
+	if !h.canReadJob(c, snapshot) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
+	// Handshake xato qaytarsa, websocket.Server 403 bilan javob beradi
+	server := websocket.Server{Handshake: h.checkOrigin, Handler: func(ws *websocket.Conn) {
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+
+		// Klient ulanishni yopganini bilish uchun kiruvchi xabarlarni o'qib turamiz
+		go func() {
+			defer cancel()
+			var discard string
+			for websocket.Message.Receive(ws, &discard) == nil {
+			}
+		}()
+
+		send := func(event models.JobEvent) error {
+			return websocket.JSON.Send(ws, event)
+		}
+
+		if err := streamJobEvents(ctx, snapshot, events, send, nil); err != nil {
+			h.log.Error("job websocket closed", logger.String("jobID", id), logger.Error(err))
+		}
+	}}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// checkOrigin - boshqa sayt sahifasidan ochilgan WebSocket rad etiladi. Origin siz klientlar (brauzer emas)
+// qabul qilinadi, brauzer esa API ning o'z manzilidan yoki WS_ALLOWED_ORIGINS dagi manzildan ulanadi.
+func (h Handler) checkOrigin(_ *websocket.Config, req *http.Request) error {
+	return originAllowed(req.Header.Get("Origin"), req.Host, h.services.JobEvents().AllowedOrigin)
+}
+
+func originAllowed(origin, host string, allowed func(string) bool) error {
+	if origin == "" {
+		return nil
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid origin %q", origin)
+	}
+	if strings.EqualFold(u.Host, host) || allowed(origin) {
+		return nil
+	}
+	return fmt.Errorf("origin %q is not allowed", origin)
+}
+
+// subscribeJob - avval obuna bo'lib, keyin jobni o'qiydi, shunda oradagi hodisa yo'qolmaydi
+func (h Handler) subscribeJob(ctx context.Context, id string) (<-chan models.JobEvent, func(), *models.Job, error) {
+	events, closeSub, err := h.services.JobEvents().Subscribe(ctx, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	getCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	job, err := h.services.Job().GetByID(getCtx, id)
+	if err != nil {
+		closeSub()
+		return nil, nil, nil, err
+	}
+
+	return events, closeSub, job, nil
+}
+
+// streamJobEvents - joriy holatni, so'ng job yakunlanguncha kelgan hodisalarni yuboradi
+func streamJobEvents(ctx context.Context, job *models.Job, events <-chan models.JobEvent, send func(models.JobEvent) error, ping func() error) error {
+	if err := send(job.StatusEvent()); err != nil {
+		return err
+	}
+	if job.Status.IsFinal() {
+		return nil
+	}
+
+	ticker := time.NewTicker(sseHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+			if event.Event == models.JobEventStatus && event.Status.IsFinal() {
+				return nil
+			}
+		case <-ticker.C:
+			if ping == nil {
+				continue
+			}
+			if err := ping(); err != nil {
+				return err
+			}
+		}
+	}
+}
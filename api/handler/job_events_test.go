@@ -0,0 +1,32 @@
+package handler
+
+import "testing"
+
+func TestOriginAllowed(t *testing.T) {
+	allowed := func(origin string) bool { return origin == "https://app.example.uz" }
+
+	tests := []struct {
+		name   string
+		origin string
+		host   string
+		wantOK bool
+	}{
+		{"no origin", "", "api.example.uz", true},
+		{"same host", "https://api.example.uz", "api.example.uz", true},
+		{"same host with port", "http://localhost:8080", "localhost:8080", true},
+		{"same host different case", "https://API.example.uz", "api.example.uz", true},
+		{"configured origin", "https://app.example.uz", "api.example.uz", true},
+		{"other site", "https://evil.example.com", "api.example.uz", false},
+		{"other port", "http://localhost:3000", "localhost:8080", false},
+		{"null origin", "null", "api.example.uz", false},
+		{"malformed", "://bad", "api.example.uz", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := originAllowed(tt.origin, tt.host, allowed)
+			if (err == nil) != tt.wantOK {
+				t.Errorf("originAllowed(%q, %q) = %v, want ok = %v", tt.origin, tt.host, err, tt.wantOK)
+			}
+		})
+	}
+}
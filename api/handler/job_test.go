@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"test/api/models"
+	"test/config"
+	"test/service"
+	"test/storage"
+)
+
+// jobOnlyServices - faqat Job() kerak bo'lgan handler testlari uchun
+type jobOnlyServices struct {
+	service.IServiceManager
+	jobs service.JobService
+}
+
+func (s jobOnlyServices) Job() service.JobService { return s.jobs }
+
+type nilJobStorage struct{ storage.IStorage }
+
+func (nilJobStorage) Job() storage.IJobStorage { return nil }
+
+func TestCanReadJob(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jobs := service.NewJobService(nilJobStorage{}, nil, config.Config{JobTokenSecret: "secret"}, nil)
+	h := Handler{services: jobOnlyServices{jobs: jobs}}
+
+	owner := "u1"
+	userJob := &models.Job{ID: "job-1", UserID: &owner}
+	guestJob := &models.Job{ID: "job-2"}
+
+	tests := []struct {
+		name   string
+		job    *models.Job
+		userID string
+		role   string
+		query  string
+		header string
+		want   bool
+	}{
+		{name: "owner", job: userJob, userID: "u1", want: true},
+		{name: "other user", job: userJob, userID: "u2"},
+		{name: "admin", job: userJob, userID: "u2", role: "admin", want: true},
+		{name: "guest job without token", job: guestJob},
+		{name: "guest job with query token", job: guestJob, query: jobs.AccessToken("job-2"), want: true},
+		{name: "guest job with header token", job: guestJob, header: jobs.AccessToken("job-2"), want: true},
+		{name: "token of another job", job: guestJob, query: jobs.AccessToken("job-1")},
+		{name: "user job with token, no login", job: userJob, query: jobs.AccessToken("job-1"), want: true},
+		{name: "forged token", job: guestJob, query: "abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/jobs/"+tt.job.ID+"/events", nil)
+			if tt.query != "" {
+				c.Request.URL.RawQuery = jobTokenQuery + "=" + tt.query
+			}
+			if tt.header != "" {
+				c.Request.Header.Set(jobTokenHeader, tt.header)
+			}
+			if tt.userID != "" {
+				c.Set("user_id", tt.userID)
+			}
+			if tt.role != "" {
+				c.Set("user_role", tt.role)
+			}
+
+			if got := h.canReadJob(c, tt.job); got != tt.want {
+				t.Errorf("canReadJob = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
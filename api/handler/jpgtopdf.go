@@ -19,8 +19,11 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.CreateJPGToPDFRequest true "JPG to PDF request"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 // @Router       /api/pdf/jpg-to-pdf [post]
 func (h Handler) CreateJPGToPDF(c *gin.Context) {
@@ -50,13 +53,14 @@ func (h Handler) CreateJPGToPDF(c *gin.Context) {
 	defer cancel()
 
 	// Create the JPG to PDF job
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.JPGToPDF().CreateJob(ctx, userID, req.InputFileIDs)
 	if err != nil {
-		handleResponse(c, h.log, "failed to create jpg to pdf job", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "failed to create jpg to pdf job", err)
 		return
 	}
 
-	handleResponse(c, h.log, "jpg to pdf job created", http.StatusCreated, gin.H{"id": jobID})
+	handleResponse(c, h.log, "jpg to pdf job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetJPGToPDFJob godoc
@@ -67,7 +71,9 @@ func (h Handler) CreateJPGToPDF(c *gin.Context) {
 // @Tags         jpg-to-pdf
 // @Produce      json
 // @Param        id path string true "Job ID"
-// @Success      200 {object} models.JPGToPDFJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
 // @Failure      500 {object} models.Response
 // @Router       /api/pdf/jpg-to-pdf/{id} [get]
@@ -89,6 +95,11 @@ func (h Handler) GetJPGToPDFJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	// Return job details
 	handleResponse(c, h.log, "jpg to pdf job fetched", http.StatusOK, job)
 }
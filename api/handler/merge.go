@@ -17,8 +17,11 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.CreateMergeJobRequest true "merge job"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 func (h Handler) CreateMergeJob(c *gin.Context) {
 	var req models.CreateMergeJobRequest
@@ -37,13 +40,14 @@ func (h Handler) CreateMergeJob(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	ctx = jobContext(ctx, &req.JobCallback)
 	id, err := h.services.Merge().Create(ctx, userID, req.InputFileIDs)
 	if err != nil {
-		handleResponse(c, h.log, "failed to create merge job", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "failed to create merge job", err)
 		return
 	}
 
-	handleResponse(c, h.log, "merge job created", http.StatusCreated, gin.H{"id": id})
+	handleResponse(c, h.log, "merge job created", http.StatusCreated, gin.H{"id": id, "access_token": h.jobAccessToken(c, id)})
 }
 
 // GetMergeJob godoc
@@ -54,7 +58,9 @@ func (h Handler) CreateMergeJob(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "merge job ID"
-// @Success      200 {object} models.MergeJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
 // @Failure      500 {object} models.Response
 func (h Handler) GetMergeJob(c *gin.Context) {
@@ -73,18 +79,28 @@ func (h Handler) GetMergeJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "merge job fetched", http.StatusOK, job)
 }
 
 // ProcessMergeJob godoc
 // @Router /api/pdf/merge/process/{id} [get]
 // @Security ApiKeyAuth
-// @Summary Process merge job
+// @Summary Merge job result (eski manzil)
+// @Description Jobni bajarmaydi - merge job navbatdagi worker tomonidan bajariladi. Job tugagan bo‘lsa natija fayli IDsi, aks holda 202 va joriy holat qaytariladi.
 // @Tags pdf-merge
 // @Param id path string true "merge job ID"
+// @Param access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
 // @Success 200 {object} models.Response
+// @Success 202 {object} models.Response
+// @Failure 403 {object} models.Response
 // @Failure 404 {object} models.Response
-// @Failure 500 {object} models.Response
+// @Failure 409 {object} models.Response
+// @Deprecated
 func (h Handler) ProcessMergeJob(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -92,16 +108,32 @@ func (h Handler) ProcessMergeJob(c *gin.Context) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	outputID, err := h.services.Merge().ProcessJob(ctx, id)
+	job, err := h.services.Merge().GetByID(ctx, id)
 	if err != nil {
-		handleResponse(c, h.log, "failed to process merge job", http.StatusInternalServerError, err.Error())
+		handleResponse(c, h.log, "merge job not found", http.StatusNotFound, err.Error())
 		return
 	}
 
-	handleResponse(c, h.log, "merge job processed successfully", http.StatusOK, gin.H{
-		"output_file_id": outputID,
-	})
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
+	switch {
+	case job.Status == models.Done && len(job.OutputFileIDs) > 0:
+		handleResponse(c, h.log, "merge job processed successfully", http.StatusOK, gin.H{
+			"output_file_id": job.OutputFileIDs[0],
+		})
+	case job.Status == models.Pending || job.Status == models.Processing:
+		handleResponse(c, h.log, "merge job is still running", http.StatusAccepted, gin.H{"status": job.Status})
+	default:
+		msg := "merge job is " + string(job.Status)
+		if job.Error != nil {
+			msg += ": " + *job.Error
+		}
+		handleResponse(c, h.log, "merge job did not finish", http.StatusConflict, msg)
+	}
 }
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"test/api/models"
+	"test/service"
+)
+
+// CreateOrganizeJob godoc
+// @Summary      Organize PDF pages
+// @Description  PDF sahifalarini new_order bo‘yicha qayta tartiblaydi: sahifa raqamini takrorlash uni nusxalaydi, 0 - bo‘sh sahifa qo‘yadi, ro‘yxatda yo‘q sahifalar olib tashlanadi. rotations - natijadagi o‘rin bo‘yicha burish (90 ga karrali). Har bir sahifa raqami hujjatda bo‘lishi shart.
+// @Tags         PDF Edit
+// @Accept       json
+// @Produce      json
+// @Param        request body models.CreateOrganizeJobRequest true "Organize request"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
+// @Success      201 {object} map[string]string
+// @Failure      400 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
+// @Failure      500 {object} models.Response
+// @Router       /api/pdf/organize [post]
+func (h *Handler) CreateOrganizeJob(c *gin.Context) {
+	var req models.CreateOrganizeJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleResponse(c, h.log, "invalid request", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var userID *string
+	if uid := c.GetString("user_id"); uid != "" {
+		userID = &uid
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ctx = jobContext(ctx, &req.JobCallback)
+	jobID, err := h.services.Organize().Create(ctx, req, userID)
+	if errors.Is(err, service.ErrInvalidOrganize) {
+		handleResponse(c, h.log, "invalid page order", http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.handleJobError(c, "organize job failed", err)
+		return
+	}
+
+	handleResponse(c, h.log, "organize job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
+}
+
+// GetOrganizeJob godoc
+// @Summary      Get Organize Job info
+// @Description  Sahifalarni tartiblash jarayonining natijasini olish
+// @Tags         PDF Edit
+// @Produce      json
+// @Param        id path string true "Job ID"
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Router       /api/pdf/organize/{id} [get]
+func (h *Handler) GetOrganizeJob(c *gin.Context) {
+	jobID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := h.services.Organize().GetByID(ctx, jobID)
+	if err != nil {
+		handleResponse(c, h.log, "organize job not found", http.StatusNotFound, err.Error())
+		return
+	}
+
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
+	handleResponse(c, h.log, "organize job found", http.StatusOK, job)
+}
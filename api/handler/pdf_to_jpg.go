@@ -17,6 +17,7 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.PDFToJPGRequest true "PDF file ID"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
 // @Failure      500 {object} models.Response
@@ -38,13 +39,14 @@ func (h *Handler) CreatePDFToJPG(c *gin.Context) {
 	defer cancel()
 
 	// PDF to JPG conversion xizmatiga murojaat qilish
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.PDFToJPG().Create(ctx, req, userID)
 	if err != nil {
-		handleResponse(c, h.log, "conversion failed", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "conversion failed", err)
 		return
 	}
 
-	handleResponse(c, h.log, "PDF to JPG conversion started", http.StatusCreated, gin.H{"job_id": jobID})
+	handleResponse(c, h.log, "PDF to JPG conversion started", http.StatusCreated, gin.H{"job_id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetPDFToJPG godoc
@@ -53,8 +55,12 @@ func (h *Handler) CreatePDFToJPG(c *gin.Context) {
 // @Tags         pdf-to-jpg
 // @Produce      json
 // @Param        id path string true "Job ID"
-// @Success      200 {object} models.PDFToJPGJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 // @Router       /api/pdf/pdf-to-jpg/{id} [get]
 func (h *Handler) GetPDFToJPG(c *gin.Context) {
@@ -77,6 +83,11 @@ func (h *Handler) GetPDFToJPG(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	// Return the job details as the response
 	handleResponse(c, h.log, "job fetched", http.StatusOK, job)
 }
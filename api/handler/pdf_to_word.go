@@ -17,6 +17,7 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.PDFToWordRequest true "PDF to Word request"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
 // @Failure      500 {object} models.Response
@@ -43,13 +44,14 @@ func (h *Handler) CreatePDFToWordJob(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 	defer cancel()
 
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.PDFToWord().Create(ctx, req, userID)
 	if err != nil {
-		handleResponse(c, h.log, "conversion failed", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "conversion failed", err)
 		return
 	}
 
-	handleResponse(c, h.log, "pdf to word job created", http.StatusCreated, gin.H{"id": jobID})
+	handleResponse(c, h.log, "pdf to word job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetPDFToWordJob godoc
@@ -58,8 +60,12 @@ func (h *Handler) CreatePDFToWordJob(c *gin.Context) {
 // @Tags         PDF to Word
 // @Produce      json
 // @Param        id path string true "Job ID"
-// @Success      200 {object} models.PDFToWordJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 // @Router       /api/pdf/pdf-to-word/{id} [get]
 func (h *Handler) GetPDFToWordJob(c *gin.Context) {
@@ -74,5 +80,10 @@ func (h *Handler) GetPDFToWordJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "job found", http.StatusOK, job)
 }
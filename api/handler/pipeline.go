@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"test/api/models"
+	"test/service"
+)
+
+// CreatePipeline godoc
+// @Router       /api/pipelines [POST]
+// @Summary      Create pipeline
+// @Description  Bir nechta amalni ketma-ket bajarish (masalan, merge → compress → add_page_numbers → protect). Har bir qadam params i shu amalning odatiy so‘rov modeli; ikkinchi qadamdan boshlab kiruvchi fayl oldingi qadam natijasidan olinadi.
+// @Tags         pipelines
+// @Accept       json
+// @Produce      json
+// @Param        request body models.CreatePipelineRequest true "pipeline qadamlari"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
+// @Success      201 {object} map[string]string
+// @Failure      400 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) CreatePipeline(c *gin.Context) {
+	var req models.CreatePipelineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleResponse(c, h.log, "invalid request body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var userID *string
+	if uid := c.GetString("user_id"); uid != "" {
+		userID = &uid
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ctx = jobContext(ctx, &req.JobCallback)
+	jobID, err := h.services.Pipeline().Create(ctx, req, userID)
+	if errors.Is(err, service.ErrInvalidPipeline) {
+		handleResponse(c, h.log, "invalid pipeline", http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.handleJobError(c, "failed to create pipeline", err)
+		return
+	}
+
+	handleResponse(c, h.log, "pipeline created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
+}
+
+// GetPipeline godoc
+// @Router       /api/pipelines/{id} [GET]
+// @Summary      Get pipeline
+// @Description  Pipeline holati: result.steps - har bir qadam holati va job IDsi, result.output_file_ids - yakuniy fayllar
+// @Tags         pipelines
+// @Produce      json
+// @Param        id path string true "Pipeline job ID"
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+func (h Handler) GetPipeline(c *gin.Context) {
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := h.services.Pipeline().GetByID(ctx, id)
+	if err != nil {
+		handleResponse(c, h.log, "pipeline not found", http.StatusNotFound, err.Error())
+		return
+	}
+
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
+	handleResponse(c, h.log, "pipeline fetched", http.StatusOK, job)
+}
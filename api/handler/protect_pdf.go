@@ -17,8 +17,11 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.ProtectPDFRequest true "Protect request"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 // @Router       /api/pdf/protect [post]
 func (h *Handler) CreateProtectJob(c *gin.Context) {
@@ -44,13 +47,14 @@ func (h *Handler) CreateProtectJob(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.Protect().Create(ctx, req, userID)
 	if err != nil {
-		handleResponse(c, h.log, "failed to protect PDF", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "failed to protect PDF", err)
 		return
 	}
 
-	handleResponse(c, h.log, "PDF protected successfully", http.StatusCreated, gin.H{"id": jobID})
+	handleResponse(c, h.log, "PDF protected successfully", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetProtectJob godoc
@@ -59,7 +63,9 @@ func (h *Handler) CreateProtectJob(c *gin.Context) {
 // @Tags         PDF Security
 // @Produce      json
 // @Param        id path string true "Job ID"
-// @Success      200 {object} models.ProtectPDFJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
 // @Router       /api/pdf/protect/{id} [get]
 func (h *Handler) GetProtectJob(c *gin.Context) {
@@ -74,5 +80,10 @@ func (h *Handler) GetProtectJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "protect job found", http.StatusOK, job)
 }
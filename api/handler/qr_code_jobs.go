@@ -19,8 +19,11 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.CreateQRCodeRequest true "QR Code request"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 func (h Handler) CreateQRCodeJob(c *gin.Context) {
 	var req models.CreateQRCodeRequest
@@ -38,13 +41,14 @@ func (h Handler) CreateQRCodeJob(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.QRCode().Create(ctx, req, userID)
 	if err != nil {
-		handleResponse(c, h.log, "failed to create qr code job", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "failed to create qr code job", err)
 		return
 	}
 
-	handleResponse(c, h.log, "qr code job created", http.StatusCreated, gin.H{"id": jobID})
+	handleResponse(c, h.log, "qr code job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetQRCodeJob godoc
@@ -55,7 +59,9 @@ func (h Handler) CreateQRCodeJob(c *gin.Context) {
 // @Tags         pdf-qr-code
 // @Produce      json
 // @Param        id path string true "Job ID"
-// @Success      200 {object} models.QRCodeJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
 // @Failure      500 {object} models.Response
 func (h Handler) GetQRCodeJob(c *gin.Context) {
@@ -74,5 +80,10 @@ func (h Handler) GetQRCodeJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "qr code job fetched", http.StatusOK, job)
 }
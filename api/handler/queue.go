@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"test/api/models"
+	"test/service"
+)
+
+// GetQueueStats godoc
+// @Router       /admin/queue [GET]
+// @Security     ApiKeyAuth
+// @Summary      Queue stats
+// @Description  Navbat chuqurligi: yo‘laklar (priority, guest), kechiktirilgan vazifalar va amal turlari bo‘yicha pending/processing joblar hamda worker chegaralari (faqat admin)
+// @Tags         admin
+// @Produce      json
+// @Success      200 {object} models.QueueStats
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) GetQueueStats(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stats, err := h.services.Queue().Stats(ctx)
+	if err != nil {
+		handleResponse(c, h.log, "failed to get queue stats", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "queue stats fetched", http.StatusOK, stats)
+}
+
+// SetJobTypeLimit godoc
+// @Router       /admin/queue/limits/{type} [PUT]
+// @Security     ApiKeyAuth
+// @Summary      Set job type limit
+// @Description  Amal turi uchun bir vaqtda bajariladigan joblar soni (barcha instansiyalar bo‘yicha; faqat admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        type    path string                        true "Job turi (masalan, pdf_to_jpg)"
+// @Param        request body models.SetJobTypeLimitRequest true "chegara"
+// @Success      200 {object} models.JobTypeLimit
+// @Failure      400 {object} models.Response
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) SetJobTypeLimit(c *gin.Context) {
+	var req models.SetJobTypeLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleResponse(c, h.log, "invalid request body", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	limit, err := h.services.Queue().SetTypeLimit(ctx, c.Param("type"), req.MaxWorkers)
+	if errors.Is(err, service.ErrUnknownJobType) {
+		handleResponse(c, h.log, "unknown job type", http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		handleResponse(c, h.log, "failed to set job type limit", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "job type limit updated", http.StatusOK, limit)
+}
+
+// DeleteJobTypeLimit godoc
+// @Router       /admin/queue/limits/{type} [DELETE]
+// @Security     ApiKeyAuth
+// @Summary      Delete job type limit
+// @Description  Amal turi chegarasini olib tashlash - shu turdagi joblar umumiy workerlar soni bilan cheklanadi (faqat admin)
+// @Tags         admin
+// @Produce      json
+// @Param        type path string true "Job turi"
+// @Success      200 {object} models.Response
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) DeleteJobTypeLimit(c *gin.Context) {
+	jobType := c.Param("type")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := h.services.Queue().DeleteTypeLimit(ctx, jobType)
+	if errors.Is(err, service.ErrUnknownJobType) {
+		handleResponse(c, h.log, "job type limit not found", http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		handleResponse(c, h.log, "failed to delete job type limit", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "job type limit removed", http.StatusOK, gin.H{"job_type": jobType})
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"test/api/models"
+	"test/service"
+)
+
+// GetMyUsage godoc
+// @Router       /me/usage [GET]
+// @Security     ApiKeyAuth
+// @Summary      My storage usage
+// @Description  Foydalanuvchi fayllari egallagan joy, fayllar soni va tarif bo‘yicha chegaralar (0 - cheklanmagan). Natija fayllari ham kvotaga kiradi.
+// @Tags         user
+// @Produce      json
+// @Success      200 {object} models.UserUsage
+// @Failure      401 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) GetMyUsage(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	usage, err := h.services.Quota().GetUsage(ctx, c.GetString("user_id"))
+	if err != nil {
+		handleResponse(c, h.log, "failed to get usage", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "usage fetched", http.StatusOK, usage)
+}
+
+// GetQuotaPlans godoc
+// @Router       /admin/quotas/plans [GET]
+// @Security     ApiKeyAuth
+// @Summary      Quota plans
+// @Description  Tariflar va ularning standart chegaralari (faqat admin)
+// @Tags         admin
+// @Produce      json
+// @Success      200 {object} models.QuotaPlanListResponse
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) GetQuotaPlans(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	plans, err := h.services.Quota().GetPlans(ctx)
+	if err != nil {
+		handleResponse(c, h.log, "failed to get quota plans", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "quota plans fetched", http.StatusOK, models.QuotaPlanListResponse{
+		Plans: plans,
+		Count: len(plans),
+	})
+}
+
+// GetUserQuota godoc
+// @Router       /admin/quotas/{user_id} [GET]
+// @Security     ApiKeyAuth
+// @Summary      Get user quota
+// @Description  Foydalanuvchining egallagan joyi va amaldagi kvotasi (faqat admin)
+// @Tags         admin
+// @Produce      json
+// @Param        user_id path string true "User ID"
+// @Success      200 {object} models.UserUsage
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) GetUserQuota(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	usage, err := h.services.Quota().GetUsage(ctx, c.Param("user_id"))
+	if err != nil {
+		handleResponse(c, h.log, "failed to get user quota", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "user quota fetched", http.StatusOK, usage)
+}
+
+// SetUserQuota godoc
+// @Router       /admin/quotas/{user_id} [PUT]
+// @Security     ApiKeyAuth
+// @Summary      Set user quota
+// @Description  Foydalanuvchi tarifini va/yoki chegaralarini belgilaydi (avvalgi override almashtiriladi). Berilmagan chegara tarifdan olinadi, 0 - cheklanmagan (faqat admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        user_id path string                     true "User ID"
+// @Param        request body models.SetUserQuotaRequest true "Tarif va chegaralar"
+// @Success      200 {object} models.UserUsage
+// @Failure      400 {object} models.Response
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) SetUserQuota(c *gin.Context) {
+	var req models.SetUserQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleResponse(c, h.log, "invalid request", http.StatusBadRequest, err.Error())
+		return
+	}
+	if (req.MaxBytes != nil && *req.MaxBytes < 0) || (req.MaxFiles != nil && *req.MaxFiles < 0) {
+		handleResponse(c, h.log, "invalid quota", http.StatusBadRequest, "max_bytes and max_files must not be negative")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	usage, err := h.services.Quota().SetQuota(ctx, c.Param("user_id"), req)
+	if err != nil {
+		h.handleQuotaError(c, "failed to set user quota", err)
+		return
+	}
+
+	handleResponse(c, h.log, "user quota updated", http.StatusOK, usage)
+}
+
+// ResetUserQuota godoc
+// @Router       /admin/quotas/{user_id} [DELETE]
+// @Security     ApiKeyAuth
+// @Summary      Reset user quota
+// @Description  Admin belgilagan tarif va chegaralarni olib tashlaydi - foydalanuvchi standart tarifga qaytadi (faqat admin)
+// @Tags         admin
+// @Produce      json
+// @Param        user_id path string true "User ID"
+// @Success      200 {object} models.UserUsage
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) ResetUserQuota(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	usage, err := h.services.Quota().ResetQuota(ctx, c.Param("user_id"))
+	if err != nil {
+		h.handleQuotaError(c, "failed to reset user quota", err)
+		return
+	}
+
+	handleResponse(c, h.log, "user quota reset", http.StatusOK, usage)
+}
+
+func (h Handler) handleQuotaError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		handleResponse(c, h.log, "user not found", http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrUnknownQuotaPlan):
+		handleResponse(c, h.log, "unknown plan", http.StatusBadRequest, err.Error())
+	default:
+		handleResponse(c, h.log, msg, http.StatusInternalServerError, err.Error())
+	}
+}
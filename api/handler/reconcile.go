@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"test/api/models"
+)
+
+// CreateReconcileJob godoc
+// @Router       /admin/reconcile [POST]
+// @Security     ApiKeyAuth
+// @Summary      Reconcile disk and files table
+// @Description  Mazmuni yo‘qolgan files yozuvlarini va diskdagi hech qaysi yozuvga tegishli bo‘lmagan fayllarni (storage/, tmp/, lokal blob papkalari) topuvchi job yaratadi.
+// @Description  dry_run (standart true) da faqat hisobot tuziladi; false bo‘lsa yozuvlar va yetim fayllar o‘chiriladi. JSON hisobot /admin/reconcile/{id} yoki /api/jobs/{id} dagi result da (faqat admin)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request body models.ReconcileRequest false "Reconcile parametrlari"
+// @Success      201 {object} map[string]string
+// @Failure      400 {object} models.Response
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) CreateReconcileJob(c *gin.Context) {
+	var req models.ReconcileRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handleResponse(c, h.log, "invalid request", http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if req.MinAgeMinutes < 0 {
+		handleResponse(c, h.log, "invalid min_age_minutes", http.StatusBadRequest, "min_age_minutes must not be negative")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ctx = jobContext(ctx, &req.JobCallback)
+	jobID, err := h.services.Reconcile().Create(ctx, req, c.GetString("user_id"))
+	if err != nil {
+		h.handleJobError(c, "failed to create reconcile job", err)
+		return
+	}
+
+	handleResponse(c, h.log, "reconcile job created", http.StatusCreated, gin.H{"id": jobID})
+}
+
+// GetReconcileJob godoc
+// @Router       /admin/reconcile/{id} [GET]
+// @Security     ApiKeyAuth
+// @Summary      Get reconcile job
+// @Description  Reconcile job holati; tugagach result da models.ReconcileReport (faqat admin)
+// @Tags         admin
+// @Produce      json
+// @Param        id path string true "Reconcile Job ID"
+// @Success      200 {object} models.Job
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+func (h Handler) GetReconcileJob(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	job, err := h.services.Reconcile().GetByID(ctx, c.Param("id"))
+	if err != nil {
+		handleResponse(c, h.log, "reconcile job not found", http.StatusNotFound, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "reconcile job fetched", http.StatusOK, job)
+}
@@ -17,9 +17,12 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.RemovePagesRequest true "remove pages job"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
 // @Failure      401 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 func (h Handler) CreateRemovePagesJob(c *gin.Context) {
 	var req models.RemovePagesRequest
@@ -37,13 +40,14 @@ func (h Handler) CreateRemovePagesJob(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.RemovePage().Create(ctx, req, userID) // ❗️ pointer bo'lishi kerak
 	if err != nil {
-		handleResponse(c, h.log, "failed to create remove pages job", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "failed to create remove pages job", err)
 		return
 	}
 
-	handleResponse(c, h.log, "remove pages job created", http.StatusCreated, gin.H{"id": jobID})
+	handleResponse(c, h.log, "remove pages job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetRemovePagesJob godoc
@@ -53,7 +57,9 @@ func (h Handler) CreateRemovePagesJob(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "remove job ID"
-// @Success      200 {object} models.RemoveJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
 // @Failure      500 {object} models.Response
 func (h Handler) GetRemovePagesJob(c *gin.Context) {
@@ -72,5 +78,10 @@ func (h Handler) GetRemovePagesJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "remove job fetched", http.StatusOK, job)
 }
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"test/api/models"
+	"test/service"
+)
+
+// CleanupOldFiles godoc
+// @Router       /file/cleanup [GET]
+// @Security     ApiKeyAuth
+// @Summary      Run retention cleanup
+// @Description  Saqlash muddati bo‘yicha tozalashni darhol ishga tushiradi: muddati o‘tgan mehmon va foydalanuvchi fayllari, ulashish havolalari va ishchi papkalardagi yetim fayllar o‘chiriladi.
+// @Description  Biriktirilgan, amaldagi havolasi bor va bajarilayotgan job kiruvchi fayllari o‘chirilmaydi (faqat admin)
+// @Tags         file
+// @Produce      json
+// @Success      200 {object} models.CleanupRun
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      409 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) CleanupOldFiles(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	run, err := h.services.Retention().Run(ctx, models.CleanupTriggerManual)
+	if errors.Is(err, service.ErrCleanupRunning) {
+		handleResponse(c, h.log, "cleanup is already running", http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil && run == nil {
+		handleResponse(c, h.log, "failed to cleanup old files", http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err != nil {
+		handleResponse(c, h.log, "cleanup failed", http.StatusInternalServerError, run)
+		return
+	}
+
+	handleResponse(c, h.log, "old files cleaned up", http.StatusOK, run)
+}
+
+// GetCleanupRuns godoc
+// @Router       /admin/cleanup/runs [GET]
+// @Security     ApiKeyAuth
+// @Summary      Cleanup history
+// @Description  Avtomatik va qo‘lda ishga tushirilgan tozalashlar tarixi, yangisi birinchi (faqat admin)
+// @Tags         admin
+// @Produce      json
+// @Param        limit query int false "Nechta yozuv (standart 50, maksimal 500)"
+// @Success      200 {object} models.CleanupRunListResponse
+// @Failure      400 {object} models.Response
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) GetCleanupRuns(c *gin.Context) {
+	limit := 50
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 500 {
+			handleResponse(c, h.log, "invalid limit", http.StatusBadRequest, "limit must be between 1 and 500")
+			return
+		}
+		limit = n
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	runs, err := h.services.Retention().GetRuns(ctx, limit)
+	if err != nil {
+		handleResponse(c, h.log, "failed to get cleanup runs", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "cleanup runs fetched", http.StatusOK, models.CleanupRunListResponse{
+		Runs:  runs,
+		Count: len(runs),
+	})
+}
+
+// PinFile godoc
+// @Router       /file/{id}/pin [PUT]
+// @Security     ApiKeyAuth
+// @Summary      Pin file
+// @Description  Faylni biriktiradi - saqlash muddati o‘tsa ham avtomatik tozalashda o‘chirilmaydi. Faqat egasi yoki admin; mehmon fayllarini faqat admin biriktiradi.
+// @Tags         file
+// @Produce      json
+// @Param        id path string true "File ID"
+// @Success      200 {object} models.File
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) PinFile(c *gin.Context) {
+	h.setFilePinned(c, true)
+}
+
+// UnpinFile godoc
+// @Router       /file/{id}/pin [DELETE]
+// @Security     ApiKeyAuth
+// @Summary      Unpin file
+// @Description  Fayl biriktirilishini bekor qiladi - u yana saqlash muddati bo‘yicha o‘chiriladi
+// @Tags         file
+// @Produce      json
+// @Param        id path string true "File ID"
+// @Success      200 {object} models.File
+// @Failure      401 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) UnpinFile(c *gin.Context) {
+	h.setFilePinned(c, false)
+}
+
+func (h Handler) setFilePinned(c *gin.Context, pinned bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	file, err := h.services.File().Get(ctx, c.Param("id"))
+	if err != nil {
+		handleResponse(c, h.log, "file not found", http.StatusNotFound, err.Error())
+		return
+	}
+	if !canManageFile(c, file) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, "you can only pin your own files")
+		return
+	}
+
+	file, err = h.services.File().SetPinned(ctx, file.ID, pinned)
+	if err != nil {
+		handleResponse(c, h.log, "failed to update file", http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	handleResponse(c, h.log, "file updated", http.StatusOK, file)
+}
+
+// canManageFile - faylni faqat egasi yoki admin o'zgartiradi; mehmon fayllarini ID ni bilgan har kim
+// muddatsiz saqlab qo'ymasligi uchun ularni faqat admin o'zgartiradi
+func canManageFile(c *gin.Context, file models.File) bool {
+	if c.GetString("user_role") == "admin" {
+		return true
+	}
+	return file.UserID != nil && *file.UserID == c.GetString("user_id")
+}
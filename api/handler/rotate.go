@@ -17,8 +17,11 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.RotatePDFRequest true "Rotate request"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 // @Router       /api/pdf/rotate [post]
 func (h *Handler) CreateRotateJob(c *gin.Context) {
@@ -49,13 +52,14 @@ func (h *Handler) CreateRotateJob(c *gin.Context) {
 	defer cancel()
 
 	// Rotate job yaratish
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.Rotate().Create(ctx, req, userID)
 	if err != nil {
-		handleResponse(c, h.log, "rotate job failed", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "rotate job failed", err)
 		return
 	}
 
-	handleResponse(c, h.log, "rotate job created", http.StatusCreated, gin.H{"id": jobID})
+	handleResponse(c, h.log, "rotate job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetRotateJob godoc
@@ -64,7 +68,9 @@ func (h *Handler) CreateRotateJob(c *gin.Context) {
 // @Tags         PDF Edit
 // @Produce      json
 // @Param        id path string true "Job ID"
-// @Success      200 {object} models.RotateJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
 // @Router       /api/pdf/rotate/{id} [get]
 func (h *Handler) GetRotateJob(c *gin.Context) {
@@ -81,6 +87,11 @@ func (h *Handler) GetRotateJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	// Muvaffaqiyatli jobni qaytarish
 	handleResponse(c, h.log, "rotate job found", http.StatusOK, job)
 }
@@ -17,6 +17,7 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.CreateSharedLinkRequest true "File ID and expiration date"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
 // @Failure      500 {object} models.Response
@@ -17,8 +17,11 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.CreateSplitJobRequest true "split job"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 func (h Handler) CreateSplitJob(c *gin.Context) {
 	var req models.CreateSplitJobRequest
@@ -38,13 +41,14 @@ func (h Handler) CreateSplitJob(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.Split().Create(ctx, req, userID)
 	if err != nil {
-		handleResponse(c, h.log, "failed to create split job", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "failed to create split job", err)
 		return
 	}
 
-	handleResponse(c, h.log, "split job created", http.StatusCreated, gin.H{"id": jobID})
+	handleResponse(c, h.log, "split job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetSplitJob godoc
@@ -54,7 +58,9 @@ func (h Handler) CreateSplitJob(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "split job ID"
-// @Success      200 {object} models.SplitJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
 // @Failure      500 {object} models.Response
 func (h Handler) GetSplitJob(c *gin.Context) {
@@ -73,5 +79,10 @@ func (h Handler) GetSplitJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "split job fetched", http.StatusOK, job)
 }
@@ -17,6 +17,7 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.UnlockPDFRequest true "Unlock PDF request"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
 // @Failure      500 {object} models.Response
@@ -47,13 +48,14 @@ func (h *Handler) CreateUnlockJob(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.Unlock().Create(ctx, req, userID)
 	if err != nil {
-		handleResponse(c, h.log, "unlock job failed", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "unlock job failed", err)
 		return
 	}
 
-	handleResponse(c, h.log, "unlock job created", http.StatusCreated, gin.H{"id": jobID})
+	handleResponse(c, h.log, "unlock job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetUnlockJob godoc
@@ -62,8 +64,12 @@ func (h *Handler) CreateUnlockJob(c *gin.Context) {
 // @Tags         PDF Unlock
 // @Produce      json
 // @Param        id path string true "Job ID"
-// @Success      200 {object} models.UnlockPDFJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 // @Router       /api/pdf/unlock/{id} [get]
 func (h *Handler) GetUnlockJob(c *gin.Context) {
@@ -78,5 +84,10 @@ func (h *Handler) GetUnlockJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "unlock job found", http.StatusOK, job)
 }
@@ -0,0 +1,345 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"test/api/models"
+	"test/service"
+)
+
+const (
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,expiration,termination"
+	// tusChunkContentType - PATCH body turi (tus protokoli talabi)
+	tusChunkContentType = "application/offset+octet-stream"
+	// uploadRequestTimeout - katta bo'lakni qabul qilish va oxirida faylni yig'ish uchun vaqt
+	uploadRequestTimeout = 10 * time.Minute
+)
+
+// UploadOptions godoc
+// @Router       /file/uploads [OPTIONS]
+// @Summary      Resumable upload capabilities
+// @Description  tus protokoli imkoniyatlari: versiya, kengaytmalar va shu foydalanuvchi uchun maksimal hajm (Tus-Max-Size)
+// @Tags         file
+// @Success      204
+func (h Handler) UploadOptions(c *gin.Context) {
+	c.Header("Tus-Resumable", tusVersion)
+	c.Header("Tus-Version", tusVersion)
+	c.Header("Tus-Extension", tusExtensions)
+	c.Header("Tus-Max-Size", strconv.FormatInt(h.services.Upload().MaxSize(optionalUserID(c)), 10))
+	c.Status(http.StatusNoContent)
+}
+
+// CreateUpload godoc
+// @Router       /file/uploads [POST]
+// @Security     ApiKeyAuth
+// @Summary      Create resumable upload
+// @Description  Bo‘laklab yuklashni boshlaydi. Javobdagi Location ga PATCH so‘rovlari bilan bo‘laklar yuboriladi.
+// @Description  Upload-Metadata: "filename <base64>" (tus formati). Hajm chegarasi: mehmon va ro‘yxatdan o‘tgan foydalanuvchi uchun alohida; fayl foydalanuvchi kvotasiga ham sig‘ishi kerak (aks holda 403).
+// @Tags         file
+// @Produce      json
+// @Param        Upload-Length   header string true  "Faylning to‘liq hajmi (bayt)"
+// @Param        Upload-Metadata header string true  "Masalan: filename c2Nhbi5wZGY="
+// @Param        Tus-Resumable   header string false "1.0.0"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
+// @Success      201 {object} models.Upload
+// @Failure      400 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      412 {object} models.Response
+// @Failure      413 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) CreateUpload(c *gin.Context) {
+	if !h.checkTusVersion(c) {
+		return
+	}
+
+	length, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+	if err != nil || length <= 0 {
+		handleResponse(c, h.log, "invalid Upload-Length", http.StatusBadRequest, "Upload-Length must be a positive integer")
+		return
+	}
+
+	metadata := parseUploadMetadata(c.GetHeader("Upload-Metadata"))
+	fileName := metadata["filename"]
+	if fileName == "" {
+		fileName = metadata["name"]
+	}
+	fileName = filepath.Base(strings.TrimSpace(fileName))
+	if fileName == "" || fileName == "." || fileName == string(filepath.Separator) || len(fileName) > 255 {
+		handleResponse(c, h.log, "invalid file name", http.StatusBadRequest, "Upload-Metadata must contain a filename (up to 255 bytes)")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	upload, err := h.services.Upload().Create(ctx, models.CreateUploadRequest{
+		UserID:   optionalUserID(c),
+		FileName: fileName,
+		Length:   length,
+	})
+	if err != nil {
+		h.handleUploadError(c, "failed to create upload", err)
+		return
+	}
+
+	setUploadHeaders(c, upload)
+	c.Header("Location", "/file/uploads/"+upload.ID)
+	handleResponse(c, h.log, "upload created", http.StatusCreated, upload)
+}
+
+// HeadUpload godoc
+// @Router       /file/uploads/{id} [HEAD]
+// @Security     ApiKeyAuth
+// @Summary      Get upload offset
+// @Description  Uzilgan yuklashni davom ettirish uchun serverga yetib kelgan baytlar soni (Upload-Offset header)
+// @Tags         file
+// @Param        id path string true "Upload ID"
+// @Success      200
+// @Failure      403
+// @Failure      404
+// @Failure      410
+func (h Handler) HeadUpload(c *gin.Context) {
+	if _, ok := h.getUpload(c); ok {
+		c.Status(http.StatusOK)
+	}
+}
+
+// GetUpload godoc
+// @Router       /file/uploads/{id} [GET]
+// @Security     ApiKeyAuth
+// @Summary      Get upload
+// @Description  Yuklash holati; completed bo‘lganda file_id yig‘ilgan faylni ko‘rsatadi
+// @Tags         file
+// @Produce      json
+// @Param        id path string true "Upload ID"
+// @Success      200 {object} models.Upload
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      410 {object} models.Response
+func (h Handler) GetUpload(c *gin.Context) {
+	if upload, ok := h.getUpload(c); ok {
+		handleResponse(c, h.log, "upload fetched", http.StatusOK, upload)
+	}
+}
+
+// PatchUpload godoc
+// @Router       /file/uploads/{id} [PATCH]
+// @Security     ApiKeyAuth
+// @Summary      Upload chunk
+// @Description  Upload-Offset dan boshlab navbatdagi bo‘lakni yozadi. Oxirgi bo‘lakdan keyin fayl avtomatik yig‘iladi (file_id ni GET orqali olish mumkin).
+// @Tags         file
+// @Accept       application/offset+octet-stream
+// @Param        id            path   string true  "Upload ID"
+// @Param        Upload-Offset header string true  "Bo‘lak boshlanadigan bayt (HEAD dagi Upload-Offset bilan bir xil bo‘lishi kerak)"
+// @Param        Tus-Resumable header string false "1.0.0"
+// @Success      204
+// @Failure      400 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      409 {object} models.Response
+// @Failure      410 {object} models.Response
+// @Failure      411 {object} models.Response
+// @Failure      413 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
+// @Failure      500 {object} models.Response
+// @Failure      503 {object} models.Response
+func (h Handler) PatchUpload(c *gin.Context) {
+	if c.ContentType() != tusChunkContentType {
+		handleResponse(c, h.log, "invalid content type", http.StatusUnsupportedMediaType, "Content-Type must be "+tusChunkContentType)
+		return
+	}
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		handleResponse(c, h.log, "invalid Upload-Offset", http.StatusBadRequest, "Upload-Offset must be a non-negative integer")
+		return
+	}
+	if c.Request.ContentLength < 0 {
+		handleResponse(c, h.log, "missing Content-Length", http.StatusLengthRequired, "chunk size must be sent in Content-Length")
+		return
+	}
+
+	upload, ok := h.getUpload(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), uploadRequestTimeout)
+	defer cancel()
+
+	upload, err = h.services.Upload().WriteChunk(ctx, upload, offset, c.Request.Body, c.Request.ContentLength)
+	if err != nil {
+		h.handleUploadError(c, "failed to write chunk", err)
+		return
+	}
+
+	setUploadHeaders(c, upload)
+	c.Status(http.StatusNoContent)
+}
+
+// FinalizeUpload godoc
+// @Router       /file/uploads/{id}/finalize [POST]
+// @Security     ApiKeyAuth
+// @Summary      Finalize upload
+// @Description  To‘liq yuklangan bo‘laklarni faylga yig‘adi. Odatda oxirgi PATCH buni o‘zi bajaradi; bu endpoint yig‘ish xato bilan tugaganda qayta urinish uchun. Qayta chaqirish xavfsiz. Fayl mazmuni tekshiruvdan o‘tmasa (415/422), upload o‘chiriladi; skaner ishlamasa (503) qayta urinish mumkin. Zararli dastur topilgan fayl karantinda saqlanadi.
+// @Tags         file
+// @Produce      json
+// @Param        id path string true "Upload ID"
+// @Success      200 {object} models.Upload
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      409 {object} models.Response
+// @Failure      410 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
+// @Failure      500 {object} models.Response
+// @Failure      503 {object} models.Response
+func (h Handler) FinalizeUpload(c *gin.Context) {
+	upload, ok := h.getUpload(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), uploadRequestTimeout)
+	defer cancel()
+
+	upload, err := h.services.Upload().Finalize(ctx, upload)
+	if err != nil {
+		h.handleUploadError(c, "failed to finalize upload", err)
+		return
+	}
+
+	setUploadHeaders(c, upload)
+	handleResponse(c, h.log, "upload finalized", http.StatusOK, upload)
+}
+
+// DeleteUpload godoc
+// @Router       /file/uploads/{id} [DELETE]
+// @Security     ApiKeyAuth
+// @Summary      Terminate upload
+// @Description  Yuklashni bekor qiladi va yuklangan bo‘laklarni o‘chiradi
+// @Tags         file
+// @Param        id path string true "Upload ID"
+// @Success      204
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Failure      409 {object} models.Response
+// @Failure      500 {object} models.Response
+func (h Handler) DeleteUpload(c *gin.Context) {
+	upload, ok := h.getUpload(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := h.services.Upload().Terminate(ctx, upload); err != nil {
+		h.handleUploadError(c, "failed to terminate upload", err)
+		return
+	}
+
+	c.Header("Tus-Resumable", tusVersion)
+	c.Status(http.StatusNoContent)
+}
+
+// getUpload - tus versiyasini, uploadni va unga kirish huquqini tekshiradi; false bo'lsa javob yozilgan
+func (h Handler) getUpload(c *gin.Context) (*models.Upload, bool) {
+	if !h.checkTusVersion(c) {
+		return nil, false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	upload, err := h.services.Upload().Get(ctx, c.Param("id"))
+	if err != nil {
+		h.handleUploadError(c, "upload not available", err)
+		return nil, false
+	}
+
+	if !canAccessOwned(c, upload.UserID) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, "you can only access your own uploads")
+		return nil, false
+	}
+
+	setUploadHeaders(c, upload)
+	return upload, true
+}
+
+func (h Handler) checkTusVersion(c *gin.Context) bool {
+	c.Header("Tus-Resumable", tusVersion)
+	if v := c.GetHeader("Tus-Resumable"); v != "" && v != tusVersion {
+		c.Header("Tus-Version", tusVersion)
+		handleResponse(c, h.log, "unsupported tus version", http.StatusPreconditionFailed, "supported version: "+tusVersion)
+		return false
+	}
+	return true
+}
+
+func (h Handler) handleUploadError(c *gin.Context, msg string, err error) {
+	status := http.StatusInternalServerError
+	if rejected, ok := fileRejectionStatus(err); ok {
+		handleResponse(c, h.log, "file rejected", rejected, err.Error())
+		return
+	}
+	switch {
+	case errors.Is(err, service.ErrUploadNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrQuotaExceeded):
+		status = http.StatusForbidden
+	case errors.Is(err, service.ErrScanUnavailable):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, service.ErrUploadExpired):
+		status = http.StatusGone
+	case errors.Is(err, service.ErrUploadTooLarge):
+		status = http.StatusRequestEntityTooLarge
+	case errors.Is(err, service.ErrUploadTooManyChunks):
+		status = http.StatusBadRequest
+	case errors.Is(err, service.ErrUploadOffsetMismatch), errors.Is(err, service.ErrUploadIncomplete), errors.Is(err, service.ErrUploadFinalizing):
+		status = http.StatusConflict
+	}
+	handleResponse(c, h.log, msg, status, err.Error())
+}
+
+func setUploadHeaders(c *gin.Context, upload *models.Upload) {
+	c.Header("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	c.Header("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	c.Header("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	c.Header("Cache-Control", "no-store")
+}
+
+// parseUploadMetadata - tus Upload-Metadata: "kalit base64qiymat,kalit2 base64qiymat2"
+func parseUploadMetadata(header string) map[string]string {
+	metadata := map[string]string{}
+	for _, pair := range strings.Split(header, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			continue
+		}
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			continue
+		}
+		metadata[key] = string(value)
+	}
+	return metadata
+}
+
+// optionalUserID - OptionalAuthMiddleware dan keyin: token bo'lmasa nil (mehmon)
+func optionalUserID(c *gin.Context) *string {
+	if id := c.GetString("user_id"); id != "" {
+		return &id
+	}
+	return nil
+}
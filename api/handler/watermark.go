@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"test/api/models"
+	"test/service"
+)
+
+// CreateWatermarkJob godoc
+// @Summary      Add watermark to PDF
+// @Description  PDF ga matnli (type=text) yoki rasmli (type=image, image_file_id - o‘zingiz yoki mehmon sifatida yuklangan PNG/JPEG; boshqa foydalanuvchining fayli 403) suv belgisi qo‘shadi. mode=stamp - kontent ustida, mode=watermark - kontent ortida. tile=true - butun sahifa bo‘ylab takrorlanadi, diagonal=true - chap pastdan o‘ng yuqoriga.
+// @Tags         PDF Edit
+// @Accept       json
+// @Produce      json
+// @Param        request body models.AddWatermarkRequest true "Watermark request"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
+// @Success      201 {object} map[string]string
+// @Failure      400 {object} models.Response
+// @Failure      403 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
+// @Failure      500 {object} models.Response
+// @Router       /api/pdf/watermark [post]
+func (h *Handler) CreateWatermarkJob(c *gin.Context) {
+	var req models.AddWatermarkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handleResponse(c, h.log, "invalid request", http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var userID *string
+	if uid := c.GetString("user_id"); uid != "" {
+		userID = &uid
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ctx = jobContext(ctx, &req.JobCallback)
+	jobID, err := h.services.Watermark().Create(ctx, req, userID)
+	if errors.Is(err, service.ErrInvalidWatermark) {
+		handleResponse(c, h.log, "invalid watermark", http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.handleJobError(c, "watermark job failed", err)
+		return
+	}
+
+	handleResponse(c, h.log, "watermark job created", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
+}
+
+// GetWatermarkJob godoc
+// @Summary      Get Watermark Job info
+// @Description  Suv belgisi qo‘shish jarayonining natijasini olish
+// @Tags         PDF Edit
+// @Produce      json
+// @Param        id path string true "Job ID"
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
+// @Failure      404 {object} models.Response
+// @Router       /api/pdf/watermark/{id} [get]
+func (h *Handler) GetWatermarkJob(c *gin.Context) {
+	jobID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := h.services.Watermark().GetByID(ctx, jobID)
+	if err != nil {
+		handleResponse(c, h.log, "watermark job not found", http.StatusNotFound, err.Error())
+		return
+	}
+
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
+	handleResponse(c, h.log, "watermark job found", http.StatusOK, job)
+}
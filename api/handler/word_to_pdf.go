@@ -17,6 +17,7 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        request body models.WordToPDFRequest true "Word to PDF so‘rovi"
+// @Param        Idempotency-Key header string false "Noyob so‘rov kaliti: shu kalit bilan qayta yuborilgan so‘rov 24 soat davomida asl javobni qaytaradi"
 // @Success      201 {object} map[string]string
 // @Failure      400 {object} models.Response
 // @Failure      500 {object} models.Response
@@ -43,13 +44,14 @@ func (h *Handler) CreateWordToPDF(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
+	ctx = jobContext(ctx, &req.JobCallback)
 	jobID, err := h.services.WordToPDF().Create(ctx, req, userID)
 	if err != nil {
-		handleResponse(c, h.log, "conversion failed", http.StatusInternalServerError, err.Error())
+		h.handleJobError(c, "conversion failed", err)
 		return
 	}
 
-	handleResponse(c, h.log, "conversion started", http.StatusCreated, gin.H{"id": jobID})
+	handleResponse(c, h.log, "conversion started", http.StatusCreated, gin.H{"id": jobID, "access_token": h.jobAccessToken(c, jobID)})
 }
 
 // GetWordToPDFJob godoc
@@ -58,8 +60,12 @@ func (h *Handler) CreateWordToPDF(c *gin.Context) {
 // @Tags         Word to PDF
 // @Produce      json
 // @Param        id path string true "Job ID"
-// @Success      200 {object} models.WordToPDFJob
+// @Param        access_token query string false "Job yaratilganda qaytarilgan token (mehmon joblari uchun)"
+// @Success      200 {object} models.Job
+// @Failure      403 {object} models.Response
 // @Failure      404 {object} models.Response
+// @Failure      415 {object} models.Response
+// @Failure      422 {object} models.Response
 // @Failure      500 {object} models.Response
 // @Router       /api/pdf/word-to-pdf/{id} [get]
 func (h *Handler) GetWordToPDFJob(c *gin.Context) {
@@ -74,5 +80,10 @@ func (h *Handler) GetWordToPDFJob(c *gin.Context) {
 		return
 	}
 
+	if !h.canReadJob(c, job) {
+		handleResponse(c, h.log, "forbidden", http.StatusForbidden, jobReadForbidden)
+		return
+	}
+
 	handleResponse(c, h.log, "job found", http.StatusOK, job)
 }
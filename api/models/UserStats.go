@@ -16,7 +16,8 @@ type UserStats struct {
 	Unlocked         int `json:"unlocked"`           // PDF qulflar yechilgan soni
 	Protected        int `json:"protected"`          // Parol bilan himoyalanganlar
 	Watermarked      int `json:"watermarked"`        // Suv belgisi qo‘shilganlar
+	AddedBackgrounds int `json:"added_backgrounds"`  // Orqa fon qo‘shilganlar
 
 	TotalFiles    int `json:"total_files"`     // Umumiy yuklangan fayllar
-	UsedStorageMB int `json:"used_storage_mb"` // MB’da ishlatilgan xotira (hisoblash kerak)
+	UsedStorageMB int `json:"used_storage_mb"` // MB’da ishlatilgan xotira
 }
@@ -1,22 +1,16 @@
 package models
 
-import "time"
-
+// CreateAddBackgroundRequest – PDF sahifalari ortiga rasm yoki bir xil rang qo‘yish so‘rovi.
+// background_image_file_id (yuklangan PNG/JPEG) yoki color dan faqat bittasi beriladi.
 type CreateAddBackgroundRequest struct {
-	InputFileID           string  `json:"input_file_id" binding:"required"`
-	BackgroundImageFileID string  `json:"background_image_file_id" binding:"required"`
-	Opacity               float64 `json:"opacity,omitempty"`  // 0.0 - 1.0
-	Position              string  `json:"position,omitempty"` // center, top-left, bottom-right
-}
+	InputFileID           string   `json:"input_file_id" binding:"required"`
+	BackgroundImageFileID string   `json:"background_image_file_id,omitempty"`
+	Color                 string   `json:"color,omitempty" example:"#FFF8E1"`                            // "#RRGGBB" - butun sahifani to‘ldiradi
+	Opacity               *float64 `json:"opacity,omitempty" binding:"omitempty,gt=0,lte=1" example:"1"` // 0.0 - 1.0, standart 1
+	Position              string   `json:"position,omitempty" example:"center"`                          // center, top-left, bottom-right; standart center
+	Scale                 float64  `json:"scale,omitempty" binding:"omitempty,gt=0,lte=1" example:"0.5"` // sahifa kengligiga nisbatan o‘lcham, standart 1
+	ScaleToFit            bool     `json:"scale_to_fit,omitempty"`                                       // rasm sahifaga to‘liq sig‘adigan eng katta o‘lchamda; scale e'tiborga olinmaydi
+	PageRange             string   `json:"page_range,omitempty" example:"1-3"`                           // bo‘sh - barcha sahifalar
 
-type AddBackgroundJob struct {
-	ID                    string    `json:"id"`
-	UserID                *string    `json:"user_id"`
-	InputFileID           string    `json:"input_file_id"`
-	BackgroundImageFileID string    `json:"background_image_file_id"`
-	Opacity               float64   `json:"opacity"`
-	Position              string    `json:"position"`
-	OutputFileID          *string    `json:"output_file_id,omitempty"`
-	Status                string    `json:"status"`
-	CreatedAt             time.Time `json:"created_at"`
+	JobCallback
 }
@@ -0,0 +1,34 @@
+package models
+
+import "encoding/json"
+
+// CreateBatchRequest – bitta amalni ko‘p faylga qo‘llash.
+// Params - amalning odatiy so‘rov modeli (masalan, CompressRequest) kiruvchi fayl maydonisiz; har bir fayl uchun alohida job yaratiladi.
+type CreateBatchRequest struct {
+	Type         string          `json:"type" binding:"required" example:"compress"`
+	Params       json.RawMessage `json:"params" swaggertype:"object"`
+	InputFileIDs []string        `json:"input_file_ids" binding:"required,min=1,max=500"`
+	Zip          bool            `json:"zip"` // true bo‘lsa, barcha natijalar bitta ZIP ga yig‘iladi
+
+	JobCallback
+}
+
+// BatchItemResult – bitta kiruvchi fayl bo‘yicha natija
+type BatchItemResult struct {
+	InputFileID   string          `json:"input_file_id"`
+	JobID         string          `json:"job_id,omitempty"` // hali boshlanmagan bo‘lsa bo‘sh
+	Status        JobStatus       `json:"status"`
+	OutputFileIDs []string        `json:"output_file_ids"`
+	Result        json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	ErrorCode     *string         `json:"error_code,omitempty"`
+	Error         *string         `json:"error,omitempty"`
+}
+
+// BatchResult – batch jobning umumiy natijasi (job.Result)
+type BatchResult struct {
+	Total     int               `json:"total"`
+	Done      int               `json:"done"`
+	Failed    int               `json:"failed"`
+	Items     []BatchItemResult `json:"items"`
+	ZipFileID *string           `json:"zip_file_id,omitempty"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Tozalashni kim ishga tushirgani
+const (
+	CleanupTriggerScheduled = "scheduled"
+	CleanupTriggerManual    = "manual"
+)
+
+// Tozalash holatlari
+const (
+	CleanupRunning = "running"
+	CleanupDone    = "done"
+	CleanupFailed  = "failed"
+)
+
+// CleanupRun – saqlash muddati bo'yicha bitta tozalash natijasi
+type CleanupRun struct {
+	ID           string     `json:"id"`
+	Trigger      string     `json:"trigger"`
+	Status       string     `json:"status"`
+	DeletedFiles int        `json:"deleted_files"`
+	FreedBytes   int64      `json:"freed_bytes"`
+	DeletedLinks int        `json:"deleted_links"`
+	RemovedPaths int        `json:"removed_paths"` // storage/ va tmp/ dan o'chirilgan yetim fayl va papkalar
+	Error        *string    `json:"error,omitempty"`
+	StartedAt    time.Time  `json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+}
+
+// CleanupRunListResponse – tozalashlar tarixi
+type CleanupRunListResponse struct {
+	Runs  []CleanupRun `json:"runs"`
+	Count int          `json:"count"`
+}
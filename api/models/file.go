@@ -2,6 +2,13 @@ package models
 
 import "time"
 
+// Yuklashdagi zararli dastur tekshiruvi holati (files.scan_status)
+const (
+	ScanUnscanned   = "unscanned" // skaner o'chiq, tekshirib bo'lmagan yoki job natijasi
+	ScanClean       = "clean"
+	ScanQuarantined = "quarantined" // zararli dastur topilgan: fayl jobga kirish fayli bo'la olmaydi
+)
+
 type File struct {
 	ID         string    `db:"id"`
 	UserID     *string   `db:"user_id"` // NULL bo‘lishi mumkin
@@ -9,7 +16,22 @@ type File struct {
 	FilePath   string    `db:"file_path"`
 	FileType   string    `db:"file_type"`
 	FileSize   int64     `db:"file_size"`
+	SHA256     string    `db:"sha256"`     // mazmun xeshi; eski yozuvlarda bo‘sh
+	PageCount  *int      `db:"page_count"` // PDF sahifalari soni; PDF bo‘lmasa yoki shifrlangan bo‘lsa NULL
+	Encrypted  bool      `db:"encrypted"`  // PDF parol bilan himoyalangan
+	Pinned     bool      `db:"pinned"`     // true bo‘lsa saqlash muddati tugasa ham o‘chirilmaydi
+	FolderID   *string   `db:"folder_id"`  // NULL - ildizda
+	Tags       []string  `db:"tags"`
 	UploadedAt time.Time `db:"uploaded_at"`
+
+	ScanStatus    string     `db:"scan_status"`
+	ScanSignature *string    `db:"scan_signature"` // karantindagi faylda topilgan zararli dastur nomi
+	ScannedAt     *time.Time `db:"scanned_at"`
+
+	// Fayl job natijasi bo‘lsa: qaysi job, qaysi amal va qaysi fayllardan olingan
+	SourceJobID   *string  `db:"source_job_id"`
+	SourceJobType *string  `db:"source_job_type"`
+	SourceFileIDs []string `db:"source_file_ids"`
 }
 
 type FileUploadRequest struct {
@@ -20,4 +42,15 @@ type FileUploadRequest struct {
 type OldFile struct {
 	ID       string `json:"id"`
 	FilePath string `json:"file_path"`
+	SHA256   string `json:"sha256"`
+	FileSize int64  `json:"file_size"`
+}
+
+// FileBlob – kontent manzilli blob va unga havola qilayotgan files yozuvlari soni
+type FileBlob struct {
+	Key       string
+	SHA256    string
+	Size      int64
+	RefCount  int
+	CreatedAt time.Time
 }
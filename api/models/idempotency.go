@@ -0,0 +1,11 @@
+package models
+
+// IdempotencyRecord – Idempotency-Key bo‘yicha Redisda saqlanadigan yozuv.
+// Completed=false bo‘lsa, shu kalitli birinchi so‘rov hali bajarilmoqda.
+type IdempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"` // method + path + body xeshi; kalit boshqa so‘rovga ishlatilmasligi uchun
+	Completed   bool   `json:"completed"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// JobStatus – jobning holati
+type JobStatus string
+
+const (
+	Pending    JobStatus = "pending"
+	Processing JobStatus = "processing"
+	Done       JobStatus = "done"
+	Failed     JobStatus = "failed"
+	Dead       JobStatus = "dead" // vaqtinchalik xato, lekin urinishlar tugagan (dead-letter)
+	Cancelled  JobStatus = "cancelled"
+)
+
+// Job – barcha PDF amallari uchun umumiy job modeli (`jobs` jadvali)
+type Job struct {
+	ID             string          `json:"id"`
+	UserID         *string         `json:"user_id,omitempty"`   // guest uchun nil
+	ParentID       *string         `json:"parent_id,omitempty"` // pipeline qadami bo'lsa, pipeline job IDsi
+	Type           string          `json:"type"`                // merge, split, compress, ...
+	Params         json.RawMessage `json:"params,omitempty" swaggertype:"object"`
+	InputFileIDs   []string        `json:"input_file_ids"`
+	OutputFileIDs  []string        `json:"output_file_ids"`
+	Result         json.RawMessage `json:"result,omitempty" swaggertype:"object"` // amalga xos natija (masalan, inspect metadata)
+	Status         JobStatus       `json:"status"`
+	ErrorCode      *string         `json:"error_code,omitempty"` // masalan: timeout, upstream_unavailable, invalid_input
+	Error          *string         `json:"error,omitempty"`
+	Attempts       int             `json:"attempts"`
+	MaxAttempts    int             `json:"max_attempts"`
+	NextRetryAt    *time.Time      `json:"next_retry_at,omitempty"` // navbatdagi avtomatik urinish vaqti
+	CallbackURL    *string         `json:"callback_url,omitempty"`  // job tugagach webhook yuboriladigan manzil
+	CallbackSecret *string         `json:"-"`                       // HMAC imzo kaliti, hech qachon qaytarilmaydi
+	CacheHit       bool            `json:"cache_hit"`               // natija keshdan olingan (amal qayta bajarilmagan)
+	CreatedAt      time.Time       `json:"created_at"`
+	StartedAt      *time.Time      `json:"started_at,omitempty"`
+	FinishedAt     *time.Time      `json:"finished_at,omitempty"`
+}
+
+// JobFilter – GET /api/jobs uchun filtrlar
+type JobFilter struct {
+	UserID string
+	Type   string
+	Status string
+}
+
+type JobListResponse struct {
+	Jobs  []Job `json:"jobs"`
+	Count int   `json:"count"`
+}
+
+// Job hodisalari turlari
+const (
+	JobEventStatus   = "status"   // holat o'zgardi
+	JobEventProgress = "progress" // sahifa bo'yicha jarayon
+)
+
+// JobEvent – SSE/WebSocket orqali mijozga yuboriladigan hodisa
+type JobEvent struct {
+	JobID     string       `json:"job_id"`
+	Event     string       `json:"event"` // status yoki progress
+	Status    JobStatus    `json:"status,omitempty"`
+	Progress  *JobProgress `json:"progress,omitempty"`
+	ErrorCode *string      `json:"error_code,omitempty"`
+	Error     *string      `json:"error,omitempty"`
+	Time      time.Time    `json:"time"`
+}
+
+// JobProgress – masalan, 14/120 sahifa
+type JobProgress struct {
+	Current int `json:"current"`
+	Total   int `json:"total"`
+}
+
+// IsFinal - job yakuniy holatdami (boshqa hodisa kutilmaydi)
+func (s JobStatus) IsFinal() bool {
+	return s == Done || s == Failed || s == Dead || s == Cancelled
+}
+
+// StatusEvent - jobning joriy holatidan status hodisasini yasaydi
+func (j *Job) StatusEvent() JobEvent {
+	return JobEvent{
+		JobID:     j.ID,
+		Event:     JobEventStatus,
+		Status:    j.Status,
+		ErrorCode: j.ErrorCode,
+		Error:     j.Error,
+		Time:      time.Now(),
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CachedOutput – keshdagi natija fayli: blobga havola va manba jobdagi fayl IDsi
+// (result ichidagi IDlarni yangi fayllarga almashtirish uchun)
+type CachedOutput struct {
+	FileID   string `json:"file_id"`
+	FileName string `json:"file_name"`
+	FileType string `json:"file_type"`
+	FilePath string `json:"file_path"`
+	SHA256   string `json:"sha256"`
+	Size     int64  `json:"size"`
+}
+
+// JobCacheEntry – bir xil kiruvchi fayllar va params bilan bajarilgan amal natijasi
+type JobCacheEntry struct {
+	Key         string          `json:"key"`
+	JobType     string          `json:"job_type"`
+	SourceJobID string          `json:"source_job_id"`
+	Outputs     []CachedOutput  `json:"outputs"`
+	Result      json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	Size        int64           `json:"size"`
+	Hits        int             `json:"hits"`
+	CreatedAt   time.Time       `json:"created_at"`
+	LastHitAt   *time.Time      `json:"last_hit_at,omitempty"`
+	ExpiresAt   time.Time       `json:"expires_at"`
+}
+
+// JobCacheFilter – qaysi kesh yozuvlari o‘chiriladi (bo‘sh filtr - hammasi)
+type JobCacheFilter struct {
+	Key         string
+	JobType     string
+	ExpiredOnly bool
+}
+
+// JobCacheTypeStat – amal turi bo‘yicha kesh hajmi
+type JobCacheTypeStat struct {
+	JobType string `json:"job_type"`
+	Entries int    `json:"entries"`
+	Hits    int    `json:"hits"`
+	Size    int64  `json:"size"`
+}
+
+// JobCacheStats – adminlar uchun kesh holati
+type JobCacheStats struct {
+	Enabled bool               `json:"enabled"`
+	TTL     string             `json:"ttl"`
+	Entries int                `json:"entries"`
+	Hits    int                `json:"hits"`
+	Size    int64              `json:"size"` // keshdagi natija fayllari hajmi (bayt)
+	Types   []JobCacheTypeStat `json:"types"`
+}
+
+type JobCachePurgeResponse struct {
+	Purged int `json:"purged"`
+}
@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// Folder – foydalanuvchi fayllari uchun papka; ParentID nil - ildizda
+type Folder struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	ParentID  *string   `json:"parent_id,omitempty"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CreateFolderRequest struct {
+	Name     string  `json:"name" binding:"required" example:"Hisobotlar"`
+	ParentID *string `json:"parent_id,omitempty"`
+}
+
+// UpdateFolderRequest – nomini o'zgartirish va/yoki ko'chirish; parent_id "" - ildizga
+type UpdateFolderRequest struct {
+	Name     *string `json:"name,omitempty" example:"2025"`
+	ParentID *string `json:"parent_id,omitempty"`
+}
+
+type FolderListResponse struct {
+	Folders []Folder `json:"folders"`
+	Count   int      `json:"count"`
+}
+
+// UpdateFileRequest – faylni qayta nomlash, ko'chirish (folder_id "" - ildizga) va teglarini almashtirish
+type UpdateFileRequest struct {
+	FileName *string   `json:"file_name,omitempty" example:"hisobot.pdf"`
+	FolderID *string   `json:"folder_id,omitempty"`
+	Tags     *[]string `json:"tags,omitempty"`
+}
+
+// FileFilter – fayllar kutubxonasi bo'yicha qidiruv (bo'sh maydonlar hisobga olinmaydi)
+type FileFilter struct {
+	UserID       string
+	FolderID     string // "root" - faqat papkasiz fayllar
+	Tag          string
+	FileTypes    []string // MIME turi va eski yozuvlardagi kengaytma ko'rinishi
+	Query        string   // nom bo'yicha qidiruv
+	From         *time.Time
+	To           *time.Time
+	MinSize      *int64
+	MaxSize      *int64
+	SourceFileID string // shu fayldan olingan natijalar
+	JobType      string // shu amal natijalari
+	Sort         string // name, size, type, uploaded_at
+	Desc         bool
+	Page         int
+	Limit        int
+}
+
+type FileListResponse struct {
+	Files []File `json:"files"`
+	Total int    `json:"total"`
+	Page  int    `json:"page"`
+	Limit int    `json:"limit"`
+}
+
+// TagCount – foydalanuvchi teglari va ular qo'yilgan fayllar soni
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
@@ -1,19 +1,13 @@
 package models
 
-import "time"
-
-
-type OrganizeJob struct {
-	ID           string    `json:"id"`
-	UserID       string    `json:"user_id"`
-	InputFileID  string    `json:"input_file_id"`
-	NewOrder     []int     `json:"new_order"`      
-	OutputFileID string    `json:"output_file_id"`
-	Status       string    `json:"status"`         
-	CreatedAt    time.Time `json:"created_at"`    
-}
-
+// CreateOrganizeJobRequest – sahifalarni qayta tartiblash so'rovi.
+// new_order - natijaviy hujjatdagi sahifalar ketma-ketligi (raqamlar 1 dan boshlanadi): bir sahifa
+// bir necha marta yozilsa nusxalanadi, 0 - shu o'ringa bo'sh sahifa qo'yiladi, ro'yxatda yo'q sahifalar tashlab yuboriladi.
+// rotations - natijadagi o'rin (1 dan) -> burish burchagi (90 ga karrali, manfiy - soat miliga teskari).
 type CreateOrganizeJobRequest struct {
-	InputFileID string `json:"input_file_id" example:"f680eafd-4c56-435d-8a3b-60f0d15b7f4e"`
-	NewOrder    []int  `json:"new_order" example:"[3,1,2]"` 
+	InputFileID string      `json:"input_file_id" binding:"required" example:"f680eafd-4c56-435d-8a3b-60f0d15b7f4e"`
+	NewOrder    []int       `json:"new_order" binding:"required,min=1" example:"3,1,0,2,2"`
+	Rotations   map[int]int `json:"rotations,omitempty"`
+
+	JobCallback
 }
@@ -0,0 +1,35 @@
+package models
+
+import "encoding/json"
+
+// PipelineStep – pipeline ichidagi bitta amal.
+// Params - shu amalning odatiy so‘rov modeli (masalan, CompressRequest); ikkinchi qadamdan boshlab
+// kiruvchi fayl maydoni (input_file_id / input_file_ids / file_id) oldingi qadam natijasi bilan to‘ldiriladi.
+type PipelineStep struct {
+	Type   string          `json:"type" binding:"required" example:"compress"`
+	Params json.RawMessage `json:"params" swaggertype:"object"`
+}
+
+type CreatePipelineRequest struct {
+	Steps []PipelineStep `json:"steps" binding:"required,min=1,max=10,dive"`
+
+	JobCallback
+}
+
+// PipelineStepResult – qadam holati (pipeline job.Result ichida saqlanadi)
+type PipelineStepResult struct {
+	Index         int             `json:"index"`
+	Type          string          `json:"type"`
+	JobID         string          `json:"job_id,omitempty"` // qadam hali boshlanmagan bo‘lsa bo‘sh
+	Status        JobStatus       `json:"status"`
+	OutputFileIDs []string        `json:"output_file_ids"`
+	Result        json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	ErrorCode     *string         `json:"error_code,omitempty"`
+	Error         *string         `json:"error,omitempty"`
+}
+
+// PipelineResult – pipeline jobning natijasi: har bir qadam holati va yakuniy fayllar
+type PipelineResult struct {
+	Steps         []PipelineStepResult `json:"steps"`
+	OutputFileIDs []string             `json:"output_file_ids"`
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Job turlari – navbatdagi vazifani qaysi service bajarishini aniqlaydi
+const (
+	JobTypeMerge           = "merge"
+	JobTypeSplit           = "split"
+	JobTypeRemovePages     = "remove_pages"
+	JobTypeExtractPages    = "extract_pages"
+	JobTypeCompress        = "compress"
+	JobTypeJPGToPDF        = "jpg_to_pdf"
+	JobTypePDFToJPG        = "pdf_to_jpg"
+	JobTypeRotate          = "rotate"
+	JobTypeOrganize        = "organize"
+	JobTypeWatermark       = "watermark"
+	JobTypeAddBackground   = "add_background"
+	JobTypeCrop            = "crop"
+	JobTypeUnlock          = "unlock"
+	JobTypeProtect         = "protect"
+	JobTypeAddPageNumbers  = "add_page_numbers"
+	JobTypeInspect         = "inspect"
+	JobTypeHeaderFooter    = "header_footer"
+	JobTypeDetectBlank     = "detect_blank"
+	JobTypeQRCode          = "qr_code"
+	JobTypePDFToWord       = "pdf_to_word"
+	JobTypeWordToPDF       = "word_to_pdf"
+	JobTypeExcelToPDF      = "excel_to_pdf"
+	JobTypePowerPointToPDF = "powerpoint_to_pdf"
+	JobTypeHTMLToPDF       = "html_to_pdf"
+	JobTypePipeline        = "pipeline"
+	JobTypeBatch           = "batch"
+	JobTypeReconcile       = "reconcile"
+)
+
+// QueueTask – Redis navbatiga qo‘yiladigan vazifa
+type QueueTask struct {
+	JobID      string          `json:"job_id"`
+	JobType    string          `json:"job_type"`
+	UserID     *string         `json:"user_id,omitempty"`
+	ParentID   string          `json:"parent_id,omitempty"` // pipeline/batch qadami - navbatga tushmaydi, parent workerida bajariladi
+	Payload    json.RawMessage `json:"payload,omitempty"`   // bazada saqlanmaydigan parametrlar (masalan, parol)
+	EnqueuedAt time.Time       `json:"enqueued_at"`
+}
+
+// Navbat yo‘laklari: ro‘yxatdan o‘tgan foydalanuvchilar joblari mehmonlarnikidan oldin olinadi
+const (
+	QueueLanePriority = "priority"
+	QueueLaneGuest    = "guest"
+)
+
+// JobTypeLimit – amal turi bo‘yicha bir vaqtda bajariladigan joblar chegarasi (barcha instansiyalar uchun umumiy)
+type JobTypeLimit struct {
+	JobType    string    `json:"job_type"`
+	MaxWorkers int       `json:"max_workers"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type SetJobTypeLimitRequest struct {
+	MaxWorkers int `json:"max_workers" binding:"required,min=1" example:"2"`
+}
+
+// JobTypeStat – amal turi bo‘yicha navbat holati
+type JobTypeStat struct {
+	JobType    string `json:"job_type"`
+	Pending    int    `json:"pending"`
+	Processing int    `json:"processing"`
+	MaxWorkers int    `json:"max_workers,omitempty"` // 0 - cheklanmagan
+}
+
+// QueueStats – adminlar uchun navbat chuqurligi
+type QueueStats struct {
+	Lanes           map[string]int64 `json:"lanes"`   // yo‘lak bo‘yicha Redisda kutayotgan vazifalar
+	Delayed         int64            `json:"delayed"` // qayta urinish yoki slot kutayotgan vazifalar
+	Types           []JobTypeStat    `json:"types"`
+	UserConcurrency int              `json:"user_concurrency"` // bitta foydalanuvchi uchun bir vaqtda bajariladigan joblar (0 - cheklanmagan)
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// QuotaPlan – tarif bo'yicha standart chegaralar (0 - cheklanmagan)
+type QuotaPlan struct {
+	Name      string `json:"name"`
+	MaxBytes  int64  `json:"max_bytes"`
+	MaxFiles  int64  `json:"max_files"`
+	IsDefault bool   `json:"is_default"`
+}
+
+// UserQuota – foydalanuvchiga amalda qo'llanadigan chegaralar: tarif va admin override
+type UserQuota struct {
+	Plan       string `json:"plan"`
+	MaxBytes   int64  `json:"max_bytes"` // 0 - cheklanmagan
+	MaxFiles   int64  `json:"max_files"` // 0 - cheklanmagan
+	Overridden bool   `json:"overridden"`
+}
+
+// StorageUsage – foydalanuvchi fayllari egallagan joy (bir xil mazmunli fayllar ham alohida hisoblanadi)
+type StorageUsage struct {
+	UsedBytes int64 `json:"used_bytes"`
+	FileCount int64 `json:"file_count"`
+}
+
+// UserUsage – GET /me/usage va admin kvota endpointlari javobi
+type UserUsage struct {
+	UserID string `json:"user_id"`
+	StorageUsage
+	Quota          UserQuota `json:"quota"`
+	RemainingBytes *int64    `json:"remaining_bytes"` // null - cheklanmagan
+	RemainingFiles *int64    `json:"remaining_files"` // null - cheklanmagan
+}
+
+// UserQuotaOverride – user_quotas yozuvi; nil maydon tarifdagi qiymatni oladi
+type UserQuotaOverride struct {
+	UserID    string
+	Plan      *string
+	MaxBytes  *int64
+	MaxFiles  *int64
+	UpdatedAt time.Time
+}
+
+// SetUserQuotaRequest – PUT /admin/quotas/{user_id}; berilmagan maydon tarifdagi qiymatni oladi
+type SetUserQuotaRequest struct {
+	Plan     string `json:"plan"`
+	MaxBytes *int64 `json:"max_bytes"`
+	MaxFiles *int64 `json:"max_files"`
+}
+
+// QuotaPlanListResponse – GET /admin/quotas/plans
+type QuotaPlanListResponse struct {
+	Plans []QuotaPlan `json:"plans"`
+	Count int         `json:"count"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+type ReconcileRequest struct {
+	// DryRun - true (standart) bo'lsa faqat hisobot tuziladi, hech narsa o'chirilmaydi
+	DryRun *bool `json:"dry_run,omitempty" example:"true"`
+	// MinAgeMinutes - diskdagi fayl shundan eski bo'lsagina yetim hisoblanadi (standart 60), shunda
+	// hali bajarilayotgan job yozayotgan fayllarga tegilmaydi
+	MinAgeMinutes int `json:"min_age_minutes,omitempty" example:"60"`
+
+	JobCallback
+}
+
+// ReconcileMissingBlob – files yozuvi bor, lekin mazmuni blob storageda yo'q
+type ReconcileMissingBlob struct {
+	FileID     string    `json:"file_id"`
+	FilePath   string    `json:"file_path"`
+	UserID     *string   `json:"user_id,omitempty"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+// ReconcileOrphanedPath – diskdagi fayl, unga hech qaysi files yoki file_blobs yozuvi havola qilmaydi
+type ReconcileOrphanedPath struct {
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// ReconcileReport – reconcile jobi natijasi (Job.Result ichida saqlanadi). Ro'yxatlar uzun bo'lsa
+// qisqartiriladi (Truncated), hisoblagichlar esa barcha topilganlarni o'z ichiga oladi.
+type ReconcileReport struct {
+	DryRun        bool                    `json:"dry_run"`
+	ScannedFiles  int                     `json:"scanned_files"`
+	ScannedPaths  int                     `json:"scanned_paths"`
+	MissingCount  int                     `json:"missing_count"`
+	OrphanedCount int                     `json:"orphaned_count"`
+	OrphanedBytes int64                   `json:"orphaned_bytes"`
+	MissingBlobs  []ReconcileMissingBlob  `json:"missing_blobs"`
+	OrphanedPaths []ReconcileOrphanedPath `json:"orphaned_paths"`
+	DeletedRows   int                     `json:"deleted_rows"`
+	RemovedPaths  int                     `json:"removed_paths"`
+	Truncated     bool                    `json:"truncated"`
+	Errors        []string                `json:"errors,omitempty"`
+}
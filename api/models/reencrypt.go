@@ -0,0 +1,15 @@
+package models
+
+// ReencryptReport – saqlangan fayllarni joriy shifrlash kalitiga o'tkazish natijasi (cmd/reencrypt)
+type ReencryptReport struct {
+	Scanned     int `json:"scanned"`
+	Reencrypted int `json:"reencrypted"`
+	// Current - allaqachon joriy kalit bilan shifrlangan
+	Current int `json:"current"`
+	// Skipped - havolasi qolmagan (o'chirilayotgan) bloblar
+	Skipped int `json:"skipped"`
+	// Missing - yozuvi bor, lekin blob storageda yo'q (reconcile jobi ularni tozalaydi)
+	Missing int      `json:"missing"`
+	Failed  int      `json:"failed"`
+	Errors  []string `json:"errors,omitempty"`
+}
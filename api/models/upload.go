@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+type UploadStatus string
+
+// Bo'laklab yuklash holatlari
+const (
+	UploadUploading  UploadStatus = "uploading"
+	UploadFinalizing UploadStatus = "finalizing"
+	UploadCompleted  UploadStatus = "completed"
+)
+
+// Upload – tus uslubidagi davom ettiriladigan yuklash. Offset - serverga yetib kelgan baytlar soni.
+type Upload struct {
+	ID        string       `json:"id"`
+	UserID    *string      `json:"user_id,omitempty"`
+	FileName  string       `json:"file_name"`
+	FileType  string       `json:"file_type"`
+	Length    int64        `json:"length"`
+	Offset    int64        `json:"offset"`
+	ChunkKeys []string     `json:"-"` // har bir PATCH bo'lagining blob kaliti, tartib bilan
+	Status    UploadStatus `json:"status"`
+	FileID    *string      `json:"file_id,omitempty"` // completed bo'lganda yig'ilgan fayl
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	ExpiresAt time.Time    `json:"expires_at"`
+}
+
+type CreateUploadRequest struct {
+	UserID   *string
+	FileName string
+	Length   int64
+}
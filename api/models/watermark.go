@@ -0,0 +1,24 @@
+package models
+
+// AddWatermarkRequest – PDF ga matnli yoki rasmli suv belgisi qo‘shish so‘rovi.
+// Bo‘sh qoldirilgan ixtiyoriy maydonlar standart qiymat oladi: mode=stamp, opacity=0.5, position=center,
+// scale=0.5 (tile da 0.25), font_name=Helvetica, color=#808080, page_range - barcha sahifalar.
+type AddWatermarkRequest struct {
+	InputFileID string   `json:"input_file_id" binding:"required"`
+	Type        string   `json:"type" binding:"required" example:"text"`                         // "text" yoki "image"
+	Text        string   `json:"text,omitempty" binding:"max=500" example:"CONFIDENTIAL"`        // type=text uchun
+	ImageFileID string   `json:"image_file_id,omitempty"`                                        // type=image uchun: yuklangan PNG/JPEG fayl IDsi
+	Mode        string   `json:"mode,omitempty" example:"stamp"`                                 // "stamp" - kontent ustida, "watermark" - kontent ortida
+	Opacity     *float64 `json:"opacity,omitempty" binding:"omitempty,gt=0,lte=1" example:"0.5"` // 0 dan katta, 1 gacha
+	Rotation    int      `json:"rotation,omitempty" binding:"min=-180,max=180" example:"45"`     // gradus, soat miliga teskari
+	Diagonal    bool     `json:"diagonal,omitempty"`                                             // chap pastdan o‘ng yuqoriga; rotation e'tiborga olinmaydi
+	Tile        bool     `json:"tile,omitempty"`                                                 // butun sahifa bo‘ylab takrorlash; position e'tiborga olinmaydi
+	Position    string   `json:"position,omitempty" example:"center"`                            // top-left, top-center, ..., center, ..., bottom-right
+	Scale       float64  `json:"scale,omitempty" binding:"omitempty,gt=0,lte=1" example:"0.5"`   // sahifa kengligiga nisbatan o‘lcham
+	FontName    string   `json:"font_name,omitempty" example:"Helvetica"`                        // pdfcpu asosiy shriftlari: Helvetica, Times-Roman, Courier, ...
+	FontSize    int      `json:"font_size,omitempty" binding:"min=0,max=400" example:"48"`       // 0 - o‘lcham scale bo‘yicha
+	Color       string   `json:"color,omitempty" example:"#808080"`                              // "#RRGGBB"
+	PageRange   string   `json:"page_range,omitempty" example:"1-3,5"`                           // bo‘sh - barcha sahifalar
+
+	JobCallback
+}
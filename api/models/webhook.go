@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// JobCallback – job yaratish so‘rovlariga qo‘shiladigan ixtiyoriy webhook sozlamalari
+type JobCallback struct {
+	CallbackURL    string `json:"callback_url,omitempty" binding:"omitempty,http_url" example:"https://example.com/hooks/pdfninja"`
+	CallbackSecret string `json:"callback_secret,omitempty" example:"s3cr3t"` // berilsa, payload HMAC-SHA256 bilan imzolanadi
+}
+
+// WebhookPayload – job done/failed bo‘lganda callback_url ga POST qilinadigan JSON
+type WebhookPayload struct {
+	JobID         string     `json:"job_id"`
+	Type          string     `json:"type"`
+	Status        JobStatus  `json:"status"`
+	OutputFileIDs []string   `json:"output_file_ids"`
+	ErrorCode     *string    `json:"error_code,omitempty"`
+	Error         *string    `json:"error,omitempty"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty"`
+}
+
+// WebhookDelivery – bitta yuborish urinishining logi (`job_webhook_deliveries` jadvali)
+type WebhookDelivery struct {
+	ID         string    `json:"id"`
+	JobID      string    `json:"job_id"`
+	URL        string    `json:"url"`
+	Attempt    int       `json:"attempt"`
+	StatusCode *int      `json:"status_code,omitempty"` // javob kelmagan bo‘lsa nil
+	Error      *string   `json:"error,omitempty"`
+	Success    bool      `json:"success"`
+	DurationMS int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	Count      int               `json:"count"`
+}
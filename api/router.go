@@ -34,12 +34,29 @@ func New(services service.IServiceManager, log logger.ILogger) *gin.Engine {
 	r.POST("/signup", h.SignUp)
 	r.POST("/login", h.Login)
 	r.GET("/me", h.AuthorizerMiddleware, h.GetMyProfile)
+	r.GET("/me/usage", h.AuthorizerMiddleware, h.GetMyUsage)
 
 	// === Logs (adminlar uchun) ===
 	admin := r.Group("/admin")
 	admin.Use(h.AuthorizerMiddleware, h.AdminMiddleware)
 	{
 		admin.GET("/logs/:id", h.GetLogsByJobID)
+
+		admin.GET("/queue", h.GetQueueStats)
+		admin.PUT("/queue/limits/:type", h.SetJobTypeLimit)
+		admin.DELETE("/queue/limits/:type", h.DeleteJobTypeLimit)
+
+		admin.GET("/cache", h.GetJobCacheStats)
+		admin.DELETE("/cache", h.PurgeJobCache)
+
+		admin.GET("/cleanup/runs", h.GetCleanupRuns)
+		admin.POST("/reconcile", h.CreateReconcileJob)
+		admin.GET("/reconcile/:id", h.GetReconcileJob)
+
+		admin.GET("/quotas/plans", h.GetQuotaPlans)
+		admin.GET("/quotas/:user_id", h.GetUserQuota)
+		admin.PUT("/quotas/:user_id", h.SetUserQuota)
+		admin.DELETE("/quotas/:user_id", h.ResetUserQuota)
 	}
 
 	// === Role (adminlar uchun) ===
@@ -65,7 +82,22 @@ func New(services service.IServiceManager, log logger.ILogger) *gin.Engine {
 	}
 
 	// === Fayllar (token kerak, chunki user_id kerak) ===
-	r.POST("/file/upload", h.UploadFile)
+	r.POST("/file/upload", h.OptionalAuthMiddleware, h.IdempotencyMiddleware, h.UploadFile)
+	r.GET("/file/:id/download", h.OptionalAuthMiddleware, h.DownloadFile)
+	r.HEAD("/file/:id/download", h.OptionalAuthMiddleware, h.DownloadFile)
+
+	// === Bo'laklab (tus uslubida) davom ettiriladigan yuklash ===
+	uploads := r.Group("/file/uploads")
+	uploads.Use(h.OptionalAuthMiddleware)
+	{
+		uploads.OPTIONS("", h.UploadOptions)
+		uploads.POST("", h.IdempotencyMiddleware, h.CreateUpload)
+		uploads.HEAD("/:id", h.HeadUpload)
+		uploads.GET("/:id", h.GetUpload)
+		uploads.PATCH("/:id", h.PatchUpload)
+		uploads.POST("/:id/finalize", h.FinalizeUpload)
+		uploads.DELETE("/:id", h.DeleteUpload)
+	}
 
 	file := r.Group("/file")
 
@@ -73,13 +105,63 @@ func New(services service.IServiceManager, log logger.ILogger) *gin.Engine {
 
 	{
 		file.GET("/:id", h.GetFile)
+		file.PATCH("/:id", h.UpdateFile)
 		file.DELETE("/:id", h.DeleteFile)
+		file.PUT("/:id/pin", h.PinFile)
+		file.DELETE("/:id/pin", h.UnpinFile)
 		file.GET("/list", h.ListUserFiles)
+		file.GET("/tags", h.ListFileTags)
+
+		file.POST("/folders", h.CreateFolder)
+		file.GET("/folders", h.ListFolders)
+		file.PATCH("/folders/:id", h.UpdateFolder)
+		file.DELETE("/folders/:id", h.DeleteFolder)
+
+		file.HEAD("/by-hash/:sha256", h.GetFileByHash)
+		file.GET("/by-hash/:sha256", h.GetFileByHash)
 		file.GET("/cleanup", h.AdminMiddleware, h.CleanupOldFiles)
 	}
 
+	// === Joblar (barcha amallar uchun umumiy) ===
+	// Holat va hodisalarni egasi, admin yoki job yaratilganda qaytarilgan access_token bilan mehmon ham o'qiydi
+	jobReads := r.Group("/api/jobs")
+	jobReads.Use(h.OptionalAuthMiddleware)
+	{
+		jobReads.GET("/:id", h.GetJob)
+		jobReads.GET("/:id/events", h.GetJobEvents)
+		jobReads.GET("/:id/ws", h.GetJobEventsWS)
+	}
+
+	jobs := r.Group("/api/jobs")
+	jobs.Use(h.AuthorizerMiddleware)
+	{
+		jobs.GET("", h.GetJobList)
+		jobs.DELETE("/:id", h.DeleteJob)
+		jobs.POST("/:id/retry", h.RetryJob)
+		jobs.POST("/:id/cancel", h.CancelJob)
+		jobs.GET("/:id/webhooks", h.GetJobWebhooks)
+	}
+
+	// === Pipeline (bir nechta amal ketma-ket) ===
+	pipelines := r.Group("/api/pipelines")
+	pipelines.Use(h.OptionalAuthMiddleware, h.IdempotencyMiddleware)
+	{
+		pipelines.POST("", h.CreatePipeline)
+		pipelines.GET("/:id", h.GetPipeline)
+	}
+
+	// === Batch (bitta amal ko'p faylga) ===
+	batches := r.Group("/api/batches")
+	batches.Use(h.OptionalAuthMiddleware, h.IdempotencyMiddleware)
+	{
+		batches.POST("", h.CreateBatch)
+		batches.GET("/:id", h.GetBatch)
+	}
+
 	// === PDF xizmatlari (token shart emas — optional auth) ===
 	pdf := r.Group("/api/pdf")
+	// Token ixtiyoriy: bo'lsa job foydalanuvchiga bog'lanadi; POST so'rovlarda Idempotency-Key qo'llab-quvvatlanadi
+	pdf.Use(h.OptionalAuthMiddleware, h.IdempotencyMiddleware)
 
 	{
 		pdf.POST("/merge", h.CreateMergeJob)
@@ -107,6 +189,15 @@ func New(services service.IServiceManager, log logger.ILogger) *gin.Engine {
 		pdf.POST("/rotate", h.CreateRotateJob)
 		pdf.GET("/rotate/:id", h.GetRotateJob)
 
+		pdf.POST("/organize", h.CreateOrganizeJob)
+		pdf.GET("/organize/:id", h.GetOrganizeJob)
+
+		pdf.POST("/watermark", h.CreateWatermarkJob)
+		pdf.GET("/watermark/:id", h.GetWatermarkJob)
+
+		pdf.POST("/add-background", h.CreateAddBackgroundJob)
+		pdf.GET("/add-background/:id", h.GetAddBackgroundJob)
+
 		pdf.POST("/crop", h.CreateCropJob)
 		pdf.GET("/crop/:id", h.GetCropJob)
 
@@ -2,17 +2,27 @@ package main
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"test/api"
 	"test/config"
+	"test/pkg/blobstore"
 	"test/pkg/gotenberg" // gotenberg package'ni import qilish
 	"test/pkg/logger"
 	"test/pkg/mailer"
+	"test/pkg/scanner"
 	"test/service"
 	"test/storage/postgres"
 	"test/storage/redis"
 )
 
+const shutdownTimeout = 15 * time.Second
+
 func main() {
 	// 1. Load config
 	cfg := config.Load()
@@ -20,8 +30,30 @@ func main() {
 	// 2. Logger yaratish
 	log := logger.New(cfg.ServiceName)
 
-	// 3. Postgresga ulanish
-	pgStore, err := postgres.New(context.Background(), cfg, log, nil)
+	// 3. Fayllar saqlanadigan joy (lokal disk yoki S3, kalit berilgan bo'lsa shifrlangan)
+	blob, err := blobstore.New(blobstore.Config{
+		Driver:    cfg.BlobDriver,
+		LocalRoot: cfg.BlobLocalRoot,
+		S3: blobstore.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PathStyle: cfg.S3PathStyle,
+		},
+		Encryption: blobstore.EncryptionConfig{
+			Keys:        cfg.BlobEncryptionKeys,
+			ActiveKeyID: cfg.BlobEncryptionKeyID,
+		},
+	})
+	if err != nil {
+		log.Error("error while creating blob store", logger.Error(err))
+		return
+	}
+
+	// Postgresga ulanish
+	pgStore, err := postgres.New(context.Background(), cfg, log, nil, blob)
 	if err != nil {
 		log.Error("error while connecting to db", logger.Error(err))
 		return
@@ -43,14 +75,68 @@ func main() {
 	// 6. Gotenberg client yaratish
 	gotClient := gotenberg.New(cfg.GotenbergURL) // gotenberg clientini yaratish
 
+	// Yuklangan fayllarni zararli dasturga tekshiruvchi skaner (SCANNER_DRIVER bo'sh bo'lsa nil)
+	fileScanner, err := scanner.New(scanner.Config{
+		Driver:  cfg.ScannerDriver,
+		Address: cfg.ScannerAddress,
+		Timeout: cfg.ScannerTimeout,
+	})
+	if err != nil {
+		log.Error("error while creating file scanner", logger.Error(err))
+		return
+	}
+
 	// 7. Servicelarni ulash
-	services := service.New(pgStore, log, mailService, redis, gotClient) // gotClient ni uzatish
+	services := service.New(cfg, pgStore, log, mailService, redis, gotClient, fileScanner) // gotClient ni uzatish
+
+	// 8. Fon workerlarini ishga tushurish
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// 8. API serverni ishga tushurish
-	server := api.New(services, log)
+	workersDone := make(chan struct{})
+	go func() {
+		defer close(workersDone)
+		services.Queue().Run(ctx)
+	}()
+
+	// callback_url ga webhooklarni yuborish va qayta urinish
+	go services.Webhook().RunDeliveries(ctx)
+
+	// Tashlab ketilgan bo'laklab yuklashlarni va muddati o'tgan kesh yozuvlarini tozalash
+	go services.Upload().RunCleanup(ctx)
+	go services.JobCache().RunCleanup(ctx)
+
+	// Saqlash muddati o'tgan fayllar va ishchi papkalarni davriy tozalash
+	go services.Retention().RunScheduler(ctx)
+
+	// 9. API serverni ishga tushurish
+	server := &http.Server{
+		Addr:    "localhost:8080",
+		Handler: api.New(services, log),
+	}
+
+	go func() {
+		log.Info("Service is running on", logger.Int("port", 8080))
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("server stopped", logger.Error(err))
+			stop()
+		}
+	}()
+
+	// 10. Signal kelganda: yangi so'rovlarni qabul qilmaslik, bajarilayotgan joblarni to'xtatib navbatga qaytarish
+	<-ctx.Done()
+	log.Info("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Error("server shutdown failed", logger.Error(err))
+	}
 
-	log.Info("Service is running on", logger.Int("port", 8080))
-	if err = server.Run("localhost:8080"); err != nil {
-		panic(err)
+	select {
+	case <-workersDone:
+	case <-shutdownCtx.Done():
+		log.Error("job workers did not stop in time")
 	}
 }
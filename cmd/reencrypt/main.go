@@ -0,0 +1,79 @@
+// reencrypt - saqlangan fayllarni BLOB_ENCRYPTION_KEY_ID kaliti bilan qayta shifrlaydi.
+// Shifrlash birinchi marta yoqilgandan keyin (eski fayllar ochiq turgan bo'ladi) yoki kalit
+// almashtirilgandan keyin ishga tushiriladi. Kalit almashtirish tartibi:
+//
+//  1. yangi kalitni BLOB_ENCRYPTION_KEYS ga qo'shish va BLOB_ENCRYPTION_KEY_ID ni unga o'zgartirish
+//  2. servisni qayta ishga tushirish - yangi fayllar yangi kalit bilan yoziladi
+//  3. make reencrypt - eski fayllar yangi kalitga o'tadi
+//  4. xatosiz tugagandan keyin eski kalitni BLOB_ENCRYPTION_KEYS dan olib tashlash
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"test/config"
+	"test/pkg/blobstore"
+	"test/pkg/logger"
+	"test/service"
+	"test/storage/postgres"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run - chiqish kodi: 0 - hammasi joriy kalitda, 1 - xato yoki o'tkazilmagan fayllar bor
+func run() int {
+	cfg := config.Load()
+	log := logger.New(cfg.ServiceName)
+
+	blob, err := blobstore.New(blobstore.Config{
+		Driver:    cfg.BlobDriver,
+		LocalRoot: cfg.BlobLocalRoot,
+		S3: blobstore.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PathStyle: cfg.S3PathStyle,
+		},
+		Encryption: blobstore.EncryptionConfig{
+			Keys:        cfg.BlobEncryptionKeys,
+			ActiveKeyID: cfg.BlobEncryptionKeyID,
+		},
+	})
+	if err != nil {
+		log.Error("error while creating blob store", logger.Error(err))
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	pgStore, err := postgres.New(ctx, cfg, log, nil, blob)
+	if err != nil {
+		log.Error("error while connecting to db", logger.Error(err))
+		return 1
+	}
+	defer pgStore.Close()
+
+	report, err := service.NewReencryptService(pgStore, log).Run(ctx)
+	if report != nil {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(report)
+	}
+	if err != nil {
+		log.Error("re-encryption stopped", logger.Error(err))
+		return 1
+	}
+	if report.Failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -32,6 +32,60 @@ type Config struct {
 	JWTSecretKey   string // ✅ YANGI QO‘SHILDI
 
 	GotenbergURL string
+
+	BlobDriver    string // fayllar qayerda saqlanadi: local yoki s3
+	BlobLocalRoot string // local drayver uchun ildiz papka
+
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3PathStyle bool // MinIO kabi xizmatlar uchun <endpoint>/<bucket>/<key> ko'rinishi
+
+	BlobEncryptionKeys  string // master kalitlar "id:base64,..." (32 bayt); bo'sh bo'lsa fayllar shifrlanmaydi
+	BlobEncryptionKeyID string // yangi fayllar shifrlanadigan kalit id si; eski kalitlar o'qish uchun qoladi
+
+	InstanceID  string        // shu instansiya nomi (workerlarning processing ro'yxatlari uchun); standart - hostname
+	WorkerCount int           // fon workerlari soni
+	JobTimeout  time.Duration // bitta job uchun maksimal vaqt
+
+	JobMaxAttempts  int           // vaqtinchalik xatoda jobni necha marta urinish
+	JobRetryBackoff time.Duration // birinchi qayta urinishgacha kutish (keyin 2 baravar oshadi)
+
+	JobUserConcurrency int // bitta foydalanuvchining bir vaqtda bajariladigan joblari (0 - cheklanmagan)
+	JobPriorityWeight  int // mehmonlar navbatiga navbat berishdan oldin ro'yxatdan o'tganlar navbatidan necha marta olinadi
+
+	JobCacheEnabled bool          // bir xil fayl va params bilan takroriy joblar natijasi keshdan olinadi
+	JobCacheTTL     time.Duration // kesh yozuvi qancha vaqt saqlanadi
+
+	WebhookMaxAttempts  int           // callback_url ga necha marta yuborishga urinish
+	WebhookTimeout      time.Duration // bitta yuborish uchun HTTP timeout
+	WebhookRetryBackoff time.Duration // muvaffaqiyatsiz yuborishdan keyin kutish (keyin 2 baravar oshadi)
+
+	WSAllowedOrigins string // job WebSocket iga ulanishi mumkin bo'lgan boshqa saytlar: "https://a.uz,https://b.uz"
+	JobTokenSecret   string // job access_token (holat, SSE, WS uchun) imzo kaliti; bo'sh bo'lsa JWT_SECRET_KEY ishlatiladi
+
+	BatchConcurrency int // bitta batch ichida bir vaqtda bajariladigan fayllar soni (JOB_USER_CONCURRENCY va amal turi chegarasi ham qo'llanadi)
+
+	IdempotencyTTL time.Duration // Idempotency-Key javobi qancha vaqt saqlanadi
+
+	UploadGuestMaxSize int64         // mehmon uchun maksimal fayl hajmi (bayt): oddiy va bo'laklab yuklashda
+	UploadUserMaxSize  int64         // ro'yxatdan o'tgan foydalanuvchi uchun maksimal fayl hajmi (bayt)
+	UploadExpiry       time.Duration // oxirgi bo'lakdan keyin tugallanmagan upload qancha saqlanadi
+	UploadPDFMaxPages  int           // yuklanadigan PDF dagi maksimal sahifalar soni (0 - cheklanmagan)
+
+	ScannerDriver   string        // yuklangan fayllarni tekshirish: "" (o'chiq) yoki clamav
+	ScannerAddress  string        // clamd manzili: host:port yoki unix:///path/clamd.sock
+	ScannerTimeout  time.Duration // bitta fayl tekshiruvi uchun maksimal vaqt
+	ScannerFailOpen bool          // skaner ishlamasa fayl tekshirilmasdan qabul qilinadi (aks holda 503)
+
+	RetentionEnabled      bool          // saqlash muddati o'tgan fayllar avtomatik o'chiriladi
+	RetentionInterval     time.Duration // avtomatik tozalash qanchalik tez-tez ishga tushadi
+	RetentionGuestHours   int           // mehmon fayllari necha soatdan keyin o'chiriladi
+	RetentionUserDays     int           // foydalanuvchi fayllari necha kundan keyin o'chiriladi (0 - o'chirilmaydi)
+	RetentionWorkdirGrace time.Duration // storage/ va tmp/ dagi yetim fayllar shu vaqtdan eski bo'lsa o'chiriladi
+	RetentionBatchSize    int           // bitta tozalashda o'chiriladigan maksimal fayllar soni
 }
 
 func Load() Config {
@@ -65,6 +119,56 @@ func Load() Config {
 	cfg.SMTPSenderName = cast.ToString(getOrReturnDefault("SMTP_SENDER_NAME", "pdfninja"))
 	cfg.GotenbergURL = cast.ToString(getOrReturnDefault("GOTENBERG_URL", "http://localhost:3000"))
 
+	cfg.BlobDriver = cast.ToString(getOrReturnDefault("BLOB_DRIVER", "local"))
+	cfg.BlobLocalRoot = cast.ToString(getOrReturnDefault("BLOB_LOCAL_ROOT", "."))
+	cfg.S3Endpoint = cast.ToString(getOrReturnDefault("S3_ENDPOINT", "http://localhost:9000"))
+	cfg.S3Region = cast.ToString(getOrReturnDefault("S3_REGION", "us-east-1"))
+	cfg.S3Bucket = cast.ToString(getOrReturnDefault("S3_BUCKET", "pdfninja"))
+	cfg.S3AccessKey = cast.ToString(getOrReturnDefault("S3_ACCESS_KEY", ""))
+	cfg.S3SecretKey = cast.ToString(getOrReturnDefault("S3_SECRET_KEY", ""))
+	cfg.S3PathStyle = cast.ToBool(getOrReturnDefault("S3_PATH_STYLE", true))
+	cfg.BlobEncryptionKeys = cast.ToString(getOrReturnDefault("BLOB_ENCRYPTION_KEYS", ""))
+	cfg.BlobEncryptionKeyID = cast.ToString(getOrReturnDefault("BLOB_ENCRYPTION_KEY_ID", ""))
+
+	hostname, _ := os.Hostname()
+	cfg.InstanceID = cast.ToString(getOrReturnDefault("INSTANCE_ID", hostname))
+	cfg.WorkerCount = cast.ToInt(getOrReturnDefault("WORKER_COUNT", 4))
+	cfg.JobTimeout = cast.ToDuration(getOrReturnDefault("JOB_TIMEOUT", "10m"))
+	cfg.JobMaxAttempts = cast.ToInt(getOrReturnDefault("JOB_MAX_ATTEMPTS", 3))
+	cfg.JobRetryBackoff = cast.ToDuration(getOrReturnDefault("JOB_RETRY_BACKOFF", "10s"))
+	cfg.JobUserConcurrency = cast.ToInt(getOrReturnDefault("JOB_USER_CONCURRENCY", 3))
+	cfg.JobPriorityWeight = cast.ToInt(getOrReturnDefault("JOB_PRIORITY_WEIGHT", 4))
+	cfg.JobCacheEnabled = cast.ToBool(getOrReturnDefault("JOB_CACHE_ENABLED", true))
+	cfg.JobCacheTTL = cast.ToDuration(getOrReturnDefault("JOB_CACHE_TTL", "168h"))
+
+	cfg.WebhookMaxAttempts = cast.ToInt(getOrReturnDefault("WEBHOOK_MAX_ATTEMPTS", 5))
+	cfg.WebhookTimeout = cast.ToDuration(getOrReturnDefault("WEBHOOK_TIMEOUT", "10s"))
+	cfg.WebhookRetryBackoff = cast.ToDuration(getOrReturnDefault("WEBHOOK_RETRY_BACKOFF", "5s"))
+
+	cfg.WSAllowedOrigins = cast.ToString(getOrReturnDefault("WS_ALLOWED_ORIGINS", ""))
+	cfg.JobTokenSecret = cast.ToString(getOrReturnDefault("JOB_TOKEN_SECRET", ""))
+
+	cfg.BatchConcurrency = cast.ToInt(getOrReturnDefault("BATCH_CONCURRENCY", 4))
+
+	cfg.IdempotencyTTL = cast.ToDuration(getOrReturnDefault("IDEMPOTENCY_TTL", "24h"))
+
+	cfg.UploadGuestMaxSize = cast.ToInt64(getOrReturnDefault("UPLOAD_GUEST_MAX_SIZE", 100<<20))
+	cfg.UploadUserMaxSize = cast.ToInt64(getOrReturnDefault("UPLOAD_USER_MAX_SIZE", 500<<20))
+	cfg.UploadExpiry = cast.ToDuration(getOrReturnDefault("UPLOAD_EXPIRY", "24h"))
+	cfg.UploadPDFMaxPages = cast.ToInt(getOrReturnDefault("UPLOAD_PDF_MAX_PAGES", 2000))
+
+	cfg.ScannerDriver = cast.ToString(getOrReturnDefault("SCANNER_DRIVER", ""))
+	cfg.ScannerAddress = cast.ToString(getOrReturnDefault("SCANNER_ADDRESS", "localhost:3310"))
+	cfg.ScannerTimeout = cast.ToDuration(getOrReturnDefault("SCANNER_TIMEOUT", "60s"))
+	cfg.ScannerFailOpen = cast.ToBool(getOrReturnDefault("SCANNER_FAIL_OPEN", false))
+
+	cfg.RetentionEnabled = cast.ToBool(getOrReturnDefault("RETENTION_ENABLED", true))
+	cfg.RetentionInterval = cast.ToDuration(getOrReturnDefault("RETENTION_INTERVAL", "1h"))
+	cfg.RetentionGuestHours = cast.ToInt(getOrReturnDefault("RETENTION_GUEST_HOURS", 24))
+	cfg.RetentionUserDays = cast.ToInt(getOrReturnDefault("RETENTION_USER_DAYS", 30))
+	cfg.RetentionWorkdirGrace = cast.ToDuration(getOrReturnDefault("RETENTION_WORKDIR_GRACE", "24h"))
+	cfg.RetentionBatchSize = cast.ToInt(getOrReturnDefault("RETENTION_BATCH_SIZE", 1000))
+
 	return cfg
 }
 
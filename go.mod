@@ -22,6 +22,7 @@ require (
 	github.com/swaggo/swag v1.16.5
 	go.uber.org/zap v1.27.0
 	golang.org/x/crypto v0.40.0
+	golang.org/x/net v0.41.0
 	golang.org/x/time v0.12.0
 	rsc.io/pdf v0.1.1
 )
@@ -74,7 +75,6 @@ require (
 	golang.org/x/arch v0.8.0 // indirect
 	golang.org/x/image v0.27.0 // indirect
 	golang.org/x/mod v0.25.0 // indirect
-	golang.org/x/net v0.41.0 // indirect
 	golang.org/x/sync v0.16.0 // indirect
 	golang.org/x/sys v0.34.0 // indirect
 	golang.org/x/text v0.27.0 // indirect
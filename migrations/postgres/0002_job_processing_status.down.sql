@@ -0,0 +1,5 @@
+UPDATE pdf_inspect_jobs SET status = 'pending' WHERE status = 'processing';
+ALTER TABLE pdf_inspect_jobs DROP CONSTRAINT IF EXISTS pdf_inspect_jobs_status_check;
+ALTER TABLE pdf_inspect_jobs
+    ADD CONSTRAINT pdf_inspect_jobs_status_check
+    CHECK (status IN ('pending', 'done', 'failed'));
@@ -0,0 +1,5 @@
+-- Navbat workerlari jobni 'processing' holatiga o'tkazadi
+ALTER TABLE pdf_inspect_jobs DROP CONSTRAINT IF EXISTS pdf_inspect_jobs_status_check;
+ALTER TABLE pdf_inspect_jobs
+    ADD CONSTRAINT pdf_inspect_jobs_status_check
+    CHECK (status IN ('pending', 'processing', 'done', 'failed'));
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_jobs_type_status;
+DROP INDEX IF EXISTS idx_jobs_user_created;
+DROP TABLE IF EXISTS jobs;
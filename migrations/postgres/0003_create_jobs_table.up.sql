@@ -0,0 +1,191 @@
+-- Barcha PDF amallari uchun umumiy jobs jadvali
+CREATE TABLE IF NOT EXISTS jobs (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE, -- guest uchun NULL
+    type VARCHAR(50) NOT NULL,
+    params JSONB NOT NULL DEFAULT '{}'::jsonb,
+    input_file_ids UUID[] NOT NULL DEFAULT ARRAY[]::UUID[],
+    output_file_ids UUID[] NOT NULL DEFAULT ARRAY[]::UUID[],
+    result JSONB,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'done', 'failed')),
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_user_created ON jobs (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_jobs_type_status ON jobs (type, status);
+
+-- Eski jadvallardagi ma'lumotlarni ko'chirish (eski jadvallar o'chirilmaydi)
+INSERT INTO jobs (id, user_id, type, input_file_ids, output_file_ids, status, created_at)
+SELECT m.id, m.user_id, 'merge',
+       ARRAY(SELECT f.file_id FROM merge_job_input_files f WHERE f.job_id = m.id),
+       CASE WHEN m.output_file_id IS NULL THEN ARRAY[]::UUID[] ELSE ARRAY[m.output_file_id] END,
+       CASE WHEN m.status IN ('pending', 'processing', 'done') THEN m.status ELSE 'failed' END,
+       COALESCE(m.created_at, NOW())
+FROM merge_jobs m
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, params, input_file_ids, output_file_ids, status, created_at)
+SELECT id, user_id, 'split',
+       jsonb_build_object('input_file_id', input_file_id, 'split_ranges', split_ranges),
+       ARRAY[input_file_id], COALESCE(output_file_ids, ARRAY[]::UUID[]),
+       CASE WHEN status IN ('pending', 'processing', 'done') THEN status ELSE 'failed' END,
+       COALESCE(created_at, NOW())
+FROM split_jobs
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, params, input_file_ids, output_file_ids, status, created_at)
+SELECT id, user_id, 'remove_pages',
+       jsonb_build_object('input_file_id', input_file_id, 'pages_to_remove', pages_to_remove),
+       ARRAY[input_file_id],
+       CASE WHEN output_file_id IS NULL THEN ARRAY[]::UUID[] ELSE ARRAY[output_file_id] END,
+       CASE WHEN status IN ('pending', 'processing', 'done') THEN status ELSE 'failed' END,
+       COALESCE(created_at, NOW())
+FROM remove_pages_jobs
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, params, input_file_ids, output_file_ids, status, created_at)
+SELECT id, user_id, 'extract_pages',
+       jsonb_build_object('input_file_id', input_file_id, 'page_ranges', pages_to_extract),
+       ARRAY[input_file_id],
+       CASE WHEN output_file_id IS NULL THEN ARRAY[]::UUID[] ELSE ARRAY[output_file_id] END,
+       CASE WHEN status IN ('pending', 'processing', 'done') THEN status ELSE 'failed' END,
+       COALESCE(created_at, NOW())
+FROM extract_pages_jobs
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, params, input_file_ids, output_file_ids, status, created_at)
+SELECT id, user_id, 'compress',
+       jsonb_build_object('input_file_id', input_file_id, 'compression', compression),
+       ARRAY[input_file_id],
+       CASE WHEN output_file_id IS NULL THEN ARRAY[]::UUID[] ELSE ARRAY[output_file_id] END,
+       CASE WHEN status IN ('pending', 'processing', 'done') THEN status ELSE 'failed' END,
+       COALESCE(created_at, NOW())
+FROM compress_jobs
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, input_file_ids, output_file_ids, status, created_at)
+SELECT id, user_id, 'jpg_to_pdf', input_file_ids,
+       CASE WHEN output_file_id IS NULL THEN ARRAY[]::UUID[] ELSE ARRAY[output_file_id] END,
+       status, COALESCE(created_at, NOW())
+FROM jpg_to_pdf_jobs
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, params, input_file_ids, output_file_ids, result, status, created_at)
+SELECT id, user_id, 'pdf_to_jpg',
+       jsonb_build_object('input_file_id', input_file_id),
+       ARRAY[input_file_id], COALESCE(output_file_ids, ARRAY[]::UUID[]),
+       CASE WHEN zip_file_id IS NULL THEN NULL ELSE jsonb_build_object('zip_file_id', zip_file_id) END,
+       status, COALESCE(created_at, NOW())
+FROM pdf_to_jpg_jobs
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, params, input_file_ids, output_file_ids, status, created_at)
+SELECT id, user_id, 'rotate',
+       jsonb_build_object('input_file_id', input_file_id, 'angle', rotation_angle, 'pages', pages),
+       ARRAY[input_file_id],
+       CASE WHEN output_file_id IS NULL THEN ARRAY[]::UUID[] ELSE ARRAY[output_file_id] END,
+       CASE WHEN status IN ('pending', 'processing', 'done') THEN status ELSE 'failed' END,
+       COALESCE(created_at, NOW())
+FROM rotate_jobs
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, params, input_file_ids, output_file_ids, status, created_at)
+SELECT id, user_id, 'add_page_numbers',
+       jsonb_build_object('input_file_id', input_file_id, 'first_number', first_number, 'page_range', page_range,
+                          'position', position, 'color', color, 'font_size', font_size),
+       ARRAY[input_file_id],
+       CASE WHEN output_file_id IS NULL THEN ARRAY[]::UUID[] ELSE ARRAY[output_file_id] END,
+       CASE WHEN status IN ('pending', 'processing', 'done') THEN status ELSE 'failed' END,
+       COALESCE(created_at, NOW())
+FROM add_page_number_jobs
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, params, input_file_ids, output_file_ids, status, created_at)
+SELECT id, user_id, 'crop',
+       jsonb_build_object('input_file_id', input_file_id, 'top', top, 'bottom', bottom, 'left', "left", 'right', "right"),
+       ARRAY[input_file_id],
+       CASE WHEN output_file_id IS NULL THEN ARRAY[]::UUID[] ELSE ARRAY[output_file_id] END,
+       CASE WHEN status IN ('pending', 'processing', 'done') THEN status ELSE 'failed' END,
+       COALESCE(created_at, NOW())
+FROM crop_pdf_jobs
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, params, input_file_ids, output_file_ids, status, created_at)
+SELECT id, user_id, 'unlock',
+       jsonb_build_object('input_file_id', input_file_id),
+       ARRAY[input_file_id],
+       CASE WHEN output_file_id IS NULL THEN ARRAY[]::UUID[] ELSE ARRAY[output_file_id] END,
+       CASE WHEN status IN ('pending', 'processing', 'done') THEN status ELSE 'failed' END,
+       COALESCE(created_at, NOW())
+FROM unlock_jobs
+ON CONFLICT (id) DO NOTHING;
+
+-- Parollar yangi jadvalga ko'chirilmaydi
+INSERT INTO jobs (id, user_id, type, params, input_file_ids, output_file_ids, status, created_at)
+SELECT id, user_id, 'protect',
+       jsonb_build_object('input_file_id', input_file_id),
+       ARRAY[input_file_id],
+       CASE WHEN output_file_id IS NULL THEN ARRAY[]::UUID[] ELSE ARRAY[output_file_id] END,
+       CASE WHEN status IN ('pending', 'processing', 'done') THEN status ELSE 'failed' END,
+       COALESCE(created_at, NOW())
+FROM protect_jobs
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, params, input_file_ids, result, status, created_at)
+SELECT id, user_id, 'inspect',
+       jsonb_build_object('file_id', file_id),
+       ARRAY[file_id],
+       jsonb_build_object('page_count', page_count, 'title', title, 'author', author,
+                          'subject', subject, 'keywords', keywords),
+       status, COALESCE(created_at, NOW())
+FROM pdf_inspect_jobs
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, params, input_file_ids, output_file_ids, status, created_at)
+SELECT id, user_id, 'pdf_to_word',
+       jsonb_build_object('input_file_id', input_file_id),
+       ARRAY[input_file_id],
+       CASE WHEN output_file_id IS NULL THEN ARRAY[]::UUID[] ELSE ARRAY[output_file_id] END,
+       CASE WHEN status IN ('pending', 'processing', 'done') THEN status ELSE 'failed' END,
+       COALESCE(created_at, NOW())
+FROM pdf_to_word_jobs
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, params, input_file_ids, output_file_ids, status, created_at)
+SELECT id, user_id, 'word_to_pdf',
+       jsonb_build_object('input_file_id', input_file_id),
+       ARRAY[input_file_id],
+       CASE WHEN output_file_id IS NULL THEN ARRAY[]::UUID[] ELSE ARRAY[output_file_id] END,
+       status, COALESCE(created_at, NOW())
+FROM word_to_pdf_jobs
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, params, input_file_ids, output_file_ids, status, created_at)
+SELECT id, user_id, 'excel_to_pdf',
+       jsonb_build_object('input_file_id', input_file_id),
+       ARRAY[input_file_id],
+       CASE WHEN output_file_id IS NULL THEN ARRAY[]::UUID[] ELSE ARRAY[output_file_id] END,
+       status, COALESCE(created_at, NOW())
+FROM excel_to_pdf_jobs
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, params, input_file_ids, output_file_ids, status, created_at)
+SELECT id, user_id, 'powerpoint_to_pdf',
+       jsonb_build_object('input_file_id', input_file_id),
+       ARRAY[input_file_id],
+       CASE WHEN output_file_id IS NULL THEN ARRAY[]::UUID[] ELSE ARRAY[output_file_id] END,
+       status, COALESCE(created_at, NOW())
+FROM powerpoint_to_pdf_jobs
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO jobs (id, user_id, type, params, output_file_ids, status, created_at)
+SELECT id, user_id, 'html_to_pdf',
+       jsonb_build_object('html_content', html_content),
+       CASE WHEN output_file_id IS NULL THEN ARRAY[]::UUID[] ELSE ARRAY[output_file_id] END,
+       status, COALESCE(created_at, NOW())
+FROM html_to_pdf_jobs
+WHERE user_id IS NULL OR user_id IN (SELECT id FROM users)
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,11 @@
+UPDATE jobs SET status = 'failed' WHERE status = 'dead';
+
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_status_check
+    CHECK (status IN ('pending', 'processing', 'done', 'failed'));
+
+ALTER TABLE jobs
+    DROP COLUMN IF EXISTS next_retry_at,
+    DROP COLUMN IF EXISTS max_attempts,
+    DROP COLUMN IF EXISTS attempts,
+    DROP COLUMN IF EXISTS error_code;
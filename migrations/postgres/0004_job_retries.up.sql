@@ -0,0 +1,10 @@
+-- Job xatolik kodi, urinishlar soni va dead-letter holati
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS error_code VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS max_attempts INT NOT NULL DEFAULT 3,
+    ADD COLUMN IF NOT EXISTS next_retry_at TIMESTAMP;
+
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_status_check
+    CHECK (status IN ('pending', 'processing', 'done', 'failed', 'dead'));
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS job_webhook_deliveries;
+
+ALTER TABLE jobs
+    DROP COLUMN IF EXISTS callback_secret,
+    DROP COLUMN IF EXISTS callback_url;
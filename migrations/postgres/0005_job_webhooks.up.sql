@@ -0,0 +1,18 @@
+-- Job tugaganda chaqiriladigan webhook va yuborishlar logi
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS callback_url TEXT,
+    ADD COLUMN IF NOT EXISTS callback_secret TEXT;
+
+CREATE TABLE IF NOT EXISTS job_webhook_deliveries (
+    id UUID PRIMARY KEY,
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    attempt INT NOT NULL,
+    status_code INT,
+    error TEXT,
+    success BOOLEAN NOT NULL DEFAULT FALSE,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_webhook_deliveries_job ON job_webhook_deliveries (job_id, created_at);
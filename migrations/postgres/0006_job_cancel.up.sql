@@ -0,0 +1,4 @@
+-- Foydalanuvchi bekor qilgan joblar uchun "cancelled" holati
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_status_check
+    CHECK (status IN ('pending', 'processing', 'done', 'failed', 'dead', 'cancelled'));
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_jobs_parent;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,5 @@
+-- Pipeline qadamlari alohida joblar sifatida saqlanadi va pipeline jobga bog'lanadi
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES jobs(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_parent ON jobs (parent_id) WHERE parent_id IS NOT NULL;
@@ -0,0 +1,6 @@
+-- Har bir amal turi uchun bir vaqtda bajariladigan joblar soni (admin sozlaydi)
+CREATE TABLE IF NOT EXISTS job_type_limits (
+    job_type VARCHAR(50) PRIMARY KEY,
+    max_workers INT NOT NULL CHECK (max_workers > 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,4 @@
+ALTER TABLE files ALTER COLUMN file_size TYPE INTEGER;
+
+DROP INDEX IF EXISTS idx_uploads_expires_at;
+DROP TABLE IF EXISTS uploads;
@@ -0,0 +1,21 @@
+-- Bo'laklab (tus uslubida) yuklanayotgan fayllar. Har bir PATCH bo'lagi alohida blob sifatida saqlanadi,
+-- oxirgi bo'lak kelganda ular bitta files yozuviga yig'iladi.
+CREATE TABLE IF NOT EXISTS uploads (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    file_type VARCHAR(20) NOT NULL,
+    upload_length BIGINT NOT NULL CHECK (upload_length > 0),
+    upload_offset BIGINT NOT NULL DEFAULT 0,
+    chunk_keys TEXT[] NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'uploading', -- uploading, finalizing, completed
+    file_id UUID REFERENCES files(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_uploads_expires_at ON uploads (expires_at);
+
+-- Katta fayllar uchun 2 GB dan oshadigan hajm
+ALTER TABLE files ALTER COLUMN file_size TYPE BIGINT;
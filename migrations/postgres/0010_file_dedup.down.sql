@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS file_blobs;
+
+DROP INDEX IF EXISTS idx_files_user_sha256;
+ALTER TABLE files DROP COLUMN IF EXISTS sha256;
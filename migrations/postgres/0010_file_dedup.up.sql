@@ -0,0 +1,14 @@
+-- Fayl mazmunining SHA-256 xeshi; bir xil mazmunli fayllar bitta blobni ishlatadi
+ALTER TABLE files ADD COLUMN IF NOT EXISTS sha256 CHAR(64);
+
+CREATE INDEX IF NOT EXISTS idx_files_user_sha256 ON files (user_id, sha256);
+
+-- Kontent manzilli bloblar: nechta files yozuvi shu blobga havola qilishi (ref_count).
+-- Oxirgi havola o'chirilganda blob ham o'chiriladi.
+CREATE TABLE IF NOT EXISTS file_blobs (
+    blob_key TEXT PRIMARY KEY,
+    sha256 CHAR(64) NOT NULL,
+    size BIGINT NOT NULL,
+    ref_count INT NOT NULL CHECK (ref_count >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
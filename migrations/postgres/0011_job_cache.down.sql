@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_job_cache_expires_at;
+DROP INDEX IF EXISTS idx_job_cache_type;
+DROP TABLE IF EXISTS job_cache;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS cache_hit;
@@ -0,0 +1,20 @@
+-- Natija keshdan olingan bo'lsa true (handler qayta ishlamagan)
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS cache_hit BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Amal natijalari keshi: kalit = amal turi + kanonik params + kiruvchi fayllar xeshlari.
+-- outputs dagi har bir blob uchun kesh file_blobs da o'z havolasini ushlab turadi.
+CREATE TABLE IF NOT EXISTS job_cache (
+    cache_key CHAR(64) PRIMARY KEY,
+    job_type VARCHAR(50) NOT NULL,
+    source_job_id UUID NOT NULL,
+    outputs JSONB NOT NULL DEFAULT '[]'::jsonb,
+    result JSONB,
+    size BIGINT NOT NULL DEFAULT 0,
+    hits INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_hit_at TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_cache_type ON job_cache (job_type);
+CREATE INDEX IF NOT EXISTS idx_job_cache_expires_at ON job_cache (expires_at);
@@ -0,0 +1,2 @@
+ALTER TABLE files DROP COLUMN IF EXISTS encrypted;
+ALTER TABLE files DROP COLUMN IF EXISTS page_count;
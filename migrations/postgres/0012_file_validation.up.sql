@@ -0,0 +1,7 @@
+-- file_type endi fayl mazmunidan aniqlangan MIME turi (masalan, docx turi 71 belgi)
+ALTER TABLE files ALTER COLUMN file_type TYPE VARCHAR(127);
+
+-- Yuklashdagi PDF tekshiruvi natijasi: sahifalar soni (shifrlangan yoki PDF bo'lmagan faylda NULL)
+-- va fayl parol bilan shifrlanganmi
+ALTER TABLE files ADD COLUMN IF NOT EXISTS page_count INT;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS encrypted BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,25 @@
+-- Tariflar bo'yicha standart xotira chegaralari (0 - cheklanmagan). is_default tarif
+-- user_quotas da yozuvi yo'q foydalanuvchilarga qo'llanadi.
+CREATE TABLE IF NOT EXISTS quota_plans (
+    name VARCHAR(50) PRIMARY KEY,
+    max_bytes BIGINT NOT NULL DEFAULT 0 CHECK (max_bytes >= 0),
+    max_files INT NOT NULL DEFAULT 0 CHECK (max_files >= 0),
+    is_default BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_quota_plans_default ON quota_plans (is_default) WHERE is_default;
+
+INSERT INTO quota_plans (name, max_bytes, max_files, is_default) VALUES
+    ('free', 1073741824, 1000, TRUE),
+    ('pro', 21474836480, 20000, FALSE),
+    ('unlimited', 0, 0, FALSE)
+ON CONFLICT (name) DO NOTHING;
+
+-- Admin belgilagan foydalanuvchi tarifi va chegaralari; NULL ustun tarifdagi qiymatni oladi
+CREATE TABLE IF NOT EXISTS user_quotas (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    plan VARCHAR(50) REFERENCES quota_plans(name),
+    max_bytes BIGINT CHECK (max_bytes >= 0),
+    max_files INT CHECK (max_files >= 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS cleanup_runs;
+
+DROP INDEX IF EXISTS idx_files_retention;
+ALTER TABLE files DROP COLUMN IF EXISTS pinned;
@@ -0,0 +1,20 @@
+-- Biriktirilgan (pinned) fayllar saqlash muddati tugasa ham o'chirilmaydi
+ALTER TABLE files ADD COLUMN IF NOT EXISTS pinned BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_files_retention ON files (uploaded_at) WHERE NOT pinned;
+
+-- Avtomatik (scheduled) yoki admin ishga tushirgan (manual) tozalashlar tarixi
+CREATE TABLE IF NOT EXISTS cleanup_runs (
+    id UUID PRIMARY KEY,
+    trigger VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running', -- running, done, failed
+    deleted_files INT NOT NULL DEFAULT 0,
+    freed_bytes BIGINT NOT NULL DEFAULT 0,
+    deleted_links INT NOT NULL DEFAULT 0,
+    removed_paths INT NOT NULL DEFAULT 0,
+    error TEXT,
+    started_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_cleanup_runs_started_at ON cleanup_runs (started_at DESC);
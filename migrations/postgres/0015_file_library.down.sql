@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_files_source_job_id;
+DROP INDEX IF EXISTS idx_files_tags;
+DROP INDEX IF EXISTS idx_files_folder_id;
+DROP INDEX IF EXISTS idx_files_user_uploaded_at;
+
+ALTER TABLE files DROP COLUMN IF EXISTS source_job_id;
+ALTER TABLE files DROP COLUMN IF EXISTS tags;
+ALTER TABLE files DROP COLUMN IF EXISTS folder_id;
+
+DROP TABLE IF EXISTS folders;
@@ -0,0 +1,24 @@
+-- Foydalanuvchi fayllari uchun papkalar (ichma-ich bo'lishi mumkin)
+CREATE TABLE IF NOT EXISTS folders (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES folders(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Bitta papka ichida nomlar takrorlanmaydi (ildiz uchun parent_id NULL)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_folders_unique_name
+    ON folders (user_id, COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::uuid), name);
+
+-- Papka o'chirilsa, ichidagi fayllar ildizga o'tadi
+ALTER TABLE files ADD COLUMN IF NOT EXISTS folder_id UUID REFERENCES folders(id) ON DELETE SET NULL;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+-- Fayl qaysi job natijasi (yuklangan fayllarda NULL)
+ALTER TABLE files ADD COLUMN IF NOT EXISTS source_job_id UUID REFERENCES jobs(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_files_user_uploaded_at ON files (user_id, uploaded_at DESC);
+CREATE INDEX IF NOT EXISTS idx_files_folder_id ON files (folder_id);
+CREATE INDEX IF NOT EXISTS idx_files_tags ON files USING GIN (tags);
+CREATE INDEX IF NOT EXISTS idx_files_source_job_id ON files (source_job_id);
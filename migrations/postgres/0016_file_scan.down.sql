@@ -0,0 +1,3 @@
+ALTER TABLE files DROP COLUMN IF EXISTS scanned_at;
+ALTER TABLE files DROP COLUMN IF EXISTS scan_signature;
+ALTER TABLE files DROP COLUMN IF EXISTS scan_status;
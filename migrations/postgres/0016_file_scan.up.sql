@@ -0,0 +1,5 @@
+-- Yuklashdagi zararli dastur tekshiruvi: unscanned (skaner o'chiq, tekshirib bo'lmagan yoki job natijasi),
+-- clean yoki quarantined. Karantindagi fayl saqlanadi, lekin hech qaysi jobga kirish fayli bo'la olmaydi.
+ALTER TABLE files ADD COLUMN IF NOT EXISTS scan_status VARCHAR(20) NOT NULL DEFAULT 'unscanned';
+ALTER TABLE files ADD COLUMN IF NOT EXISTS scan_signature TEXT;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS scanned_at TIMESTAMP;
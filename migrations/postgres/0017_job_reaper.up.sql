@@ -0,0 +1,2 @@
+-- Osilib qolgan processing joblarni (worker o'chib qolgan) tez topish uchun
+CREATE INDEX IF NOT EXISTS idx_jobs_processing_started ON jobs (started_at) WHERE status = 'processing';
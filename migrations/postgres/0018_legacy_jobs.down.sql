@@ -0,0 +1 @@
+-- Ma'lumot migratsiyasi: failed qilingan eski joblar qayta pending qilinmaydi
@@ -0,0 +1,35 @@
+-- Eski jadvallardan (0003) ko'chirilgan, lekin tugallanmagan joblarni hech kim navbatga qo'ymaydi:
+-- ularning payloadi yo'q va started_at NULL bo'lgani uchun reaper ham ko'rmaydi. Ular failed qilinadi -
+-- foydalanuvchi /api/jobs/{id}/retry bilan qayta ishga tushiradi yoki qaytadan yuboradi.
+-- Faqat eski jadvallarda IDsi bor va hech bir workerga tushmagan joblar o'zgaradi, shuning uchun qayta
+-- ishga tushirish yangi joblarga tegmaydi.
+UPDATE jobs
+SET status = 'failed',
+    error_code = 'abandoned',
+    error = 'job was not finished before the move to the unified jobs table, retry or submit it again',
+    started_at = NULL,
+    next_retry_at = NULL,
+    finished_at = NOW()
+WHERE status IN ('pending', 'processing')
+  AND attempts = 0
+  AND started_at IS NULL
+  AND id IN (
+      SELECT id FROM add_page_number_jobs
+      UNION ALL SELECT id FROM compress_jobs
+      UNION ALL SELECT id FROM crop_pdf_jobs
+      UNION ALL SELECT id FROM excel_to_pdf_jobs
+      UNION ALL SELECT id FROM extract_pages_jobs
+      UNION ALL SELECT id FROM html_to_pdf_jobs
+      UNION ALL SELECT id FROM jpg_to_pdf_jobs
+      UNION ALL SELECT id FROM merge_jobs
+      UNION ALL SELECT id FROM pdf_inspect_jobs
+      UNION ALL SELECT id FROM pdf_to_jpg_jobs
+      UNION ALL SELECT id FROM pdf_to_word_jobs
+      UNION ALL SELECT id FROM powerpoint_to_pdf_jobs
+      UNION ALL SELECT id FROM protect_jobs
+      UNION ALL SELECT id FROM remove_pages_jobs
+      UNION ALL SELECT id FROM rotate_jobs
+      UNION ALL SELECT id FROM split_jobs
+      UNION ALL SELECT id FROM unlock_jobs
+      UNION ALL SELECT id FROM word_to_pdf_jobs
+  );
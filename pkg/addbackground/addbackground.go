@@ -1,41 +1,98 @@
 package addbackground
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // rasm o'lchamini aniqlash uchun
+	"image/png"
+	"math"
 	"os"
+	"strconv"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
-	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+
+	"test/pkg/watermark"
 )
 
-// AddBackgroundParams holds input/output paths and background image options.
+// colorImageWidth - rangli fon uchun yaratiladigan bir xil rangli rasm kengligi (balandligi sahifa nisbatida).
+// Nisbatdagi yaxlitlash xatosi sahifa chetida bo'sh chiziq qoldirmasligi uchun yetarlicha katta.
+const colorImageWidth = 1000
+
+// AddBackgroundParams holds input/output paths and background options.
 type AddBackgroundParams struct {
 	InputPath           string
 	OutputPath          string
-	BackgroundImagePath string
-	Opacity             float64
-	Position            string // 'center', 'top-left', 'bottom-right', etc.
-	PageRange           string // "all", "1-3" va hokazo
+	BackgroundImagePath string  // PNG/JPEG rasm; bo'sh bo'lsa Color ishlatiladi
+	Color               string  // "#RRGGBB" - butun sahifani to'ldiradi
+	Opacity             float64 // 0.0 - 1.0
+	Position            string  // 'center', 'top-left', 'bottom-right', etc.
+	Scale               float64 // sahifa kengligiga nisbatan o'lcham (ScaleToFit bo'lmasa)
+	ScaleToFit          bool    // rasm sahifaga to'liq sig'adigan eng katta o'lchamda
+	PageRange           string  // "all", "1-3" va hokazo
 }
 
-// AddBackgroundImage adds a background image to the PDF file.
+// AddBackgroundImage adds a background image or solid color behind the content of the PDF file.
+// Sahifa o'lchamiga bog'liq parametrlar (scale-to-fit, rang) uchun sahifalar o'lchami bo'yicha
+// guruhlanadi; har bir guruhga bitta rasm obyekti qo'shiladi.
 func AddBackgroundImage(params AddBackgroundParams) error {
-	conf := model.NewDefaultConfiguration()
-
-	// Opacity va position ni watermark sifatida belgilash
-	wmConf := fmt.Sprintf("opacity:%f, pos:%s", params.Opacity, params.Position)
+	var (
+		data        []byte
+		imageWidth  int
+		imageHeight int
+	)
+	if params.BackgroundImagePath != "" {
+		var err error
+		if data, err = os.ReadFile(params.BackgroundImagePath); err != nil {
+			return fmt.Errorf("failed to read background image: %w", err)
+		}
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("failed to decode background image: %w", err)
+		}
+		imageWidth, imageHeight = cfg.Width, cfg.Height
+	} else if params.Color == "" {
+		return errors.New("background image or color is required")
+	}
 
-	// Background uchun watermark yaratish:
-	wm, err := pdfcpu.ParseImageWatermarkDetails(params.BackgroundImagePath, wmConf, true, model.NewDefaultConfiguration().Unit)
-	if err != nil {
-		return fmt.Errorf("failed to parse background watermark: %w", err)
+	var selected []string
+	if params.PageRange != "" && params.PageRange != "all" {
+		var err error
+		if selected, err = api.ParsePageSelection(params.PageRange); err != nil {
+			return fmt.Errorf("invalid page range: %w", err)
+		}
 	}
 
-	// Orqa fon rasmi qo'shish
-	err = api.AddWatermarksFile(params.InputPath, params.OutputPath, []string{params.PageRange}, wm, conf)
+	unit := model.NewDefaultConfiguration().Unit
+	err := watermark.ApplyByPageSize(params.InputPath, params.OutputPath, selected, func(dim types.Dim) ([]*model.Watermark, error) {
+		position, scale := params.Position, params.Scale
+		img := data
+		if params.BackgroundImagePath == "" {
+			// Rangli fon: sahifa nisbatidagi rasm sahifa kengligiga cho'ziladi va uni to'liq qoplaydi
+			var err error
+			if img, err = colorImage(params.Color, dim); err != nil {
+				return nil, err
+			}
+			position, scale = "center", 1
+		} else if params.ScaleToFit {
+			// rel o'lcham sahifa kengligiga nisbatan: balandlik ham sig'ishi uchun kichraytiriladi
+			scale = math.Min(1, dim.Height*float64(imageWidth)/(dim.Width*float64(imageHeight)))
+		}
+
+		// Orqa fon kontent ortida (onTop=false) joylashadi
+		wmConf := fmt.Sprintf("opacity:%.2f, pos:%s, scalefactor:%.4f rel, rot:0", params.Opacity, position, scale)
+		wm, err := api.ImageWatermarkForReader(bytes.NewReader(img), wmConf, false, false, unit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse background watermark: %w", err)
+		}
+		return []*model.Watermark{wm}, nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to add background image: %w", err)
+		return err
 	}
 
 	// Natija fayli yaratilganligini tekshirish
@@ -45,3 +102,25 @@ func AddBackgroundImage(params AddBackgroundParams) error {
 
 	return nil
 }
+
+// colorImage - "#RRGGBB" rangidagi, sahifa nisbatidagi PNG
+func colorImage(hex string, dim types.Dim) ([]byte, error) {
+	if len(hex) != 7 || hex[0] != '#' {
+		return nil, fmt.Errorf("invalid color %q", hex)
+	}
+	rgb, err := strconv.ParseUint(hex[1:], 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color %q", hex)
+	}
+	fill := color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 255}
+
+	height := max(1, int(math.Round(colorImageWidth*dim.Height/dim.Width)))
+	// Bitta rangli palitra: barcha piksellar 0-indeks, qo'shimcha to'ldirish kerak emas
+	img := image.NewPaletted(image.Rect(0, 0, colorImageWidth, height), color.Palette{fill})
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
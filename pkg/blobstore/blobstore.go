@@ -0,0 +1,68 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var (
+	// ErrNotFound - bunday kalitli obyekt yo'q
+	ErrNotFound = errors.New("blob not found")
+	// ErrPresignNotSupported - drayver vaqtinchalik URL bera olmaydi (masalan, lokal disk)
+	ErrPresignNotSupported = errors.New("presigned urls are not supported by this blob store")
+)
+
+// BlobStore – fayllar saqlanadigan joy. Kalit - "/" bilan ajratilgan nisbiy yo'l (masalan, "uploads/<id>.pdf"),
+// u files.file_path ustunida saqlanadi.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+	Presign(ctx context.Context, key string, expires time.Duration) (string, error)
+}
+
+// LocalStore - obyektlari lokal diskda turgan drayver; tashqi dasturlar faylni nusxalamasdan shu yo'ldan o'qiydi
+type LocalStore interface {
+	LocalPath(key string) (string, error)
+}
+
+type ObjectInfo struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ETag        string
+	ModTime     time.Time
+}
+
+// Config – qaysi drayver ishlatilishi va uning sozlamalari
+type Config struct {
+	Driver    string // "local" yoki "s3"
+	LocalRoot string
+
+	S3 S3Config
+
+	Encryption EncryptionConfig
+}
+
+func New(cfg Config) (BlobStore, error) {
+	var (
+		store BlobStore
+		err   error
+	)
+	switch cfg.Driver {
+	case "", "local":
+		store = NewLocal(cfg.LocalRoot)
+	case "s3":
+		store, err = NewS3(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown blob driver %q", cfg.Driver)
+	}
+	if err != nil || cfg.Encryption.Keys == "" {
+		return store, err
+	}
+	return NewEncrypted(store, cfg.Encryption)
+}
@@ -0,0 +1,517 @@
+package blobstore
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Shifrlangan obyekt ko'rinishi:
+//
+//	magic (8) | kalit id uzunligi (1) | kalit id | o'ralgan ma'lumot kaliti (12 nonce + 32 + 16 tag) | segmentlar
+//
+// Har bir obyekt o'zining tasodifiy ma'lumot kaliti (DEK) bilan shifrlanadi, DEK esa master kalit bilan
+// o'raladi. Mazmun 64 KiB lik segmentlarga bo'linib AES-GCM bilan shifrlanadi: nonce - segment raqami va
+// "oxirgi segment" belgisi, shuning uchun segmentlarni almashtirish yoki faylni kesish aniqlanadi va
+// Range so'rovlari uchun faqat kerakli segmentlar o'qiladi. Segmentlar sarlavhaga bog'lanmagan -
+// master kalit almashganda faqat sarlavha qayta yoziladi.
+const (
+	encMagic       = "PNXENC\x00\x01"
+	encSegmentSize = 64 << 10
+	encKeySize     = 32
+	encMaxKeyID    = 64
+	encNonceSize   = 12
+	encTagSize     = 16
+	encWrappedSize = encNonceSize + encKeySize + encTagSize
+	encMaxHeader   = len(encMagic) + 1 + encMaxKeyID + encWrappedSize
+)
+
+var (
+	// ErrUnknownKey - obyekt konfiguratsiyada yo'q master kalit bilan shifrlangan
+	ErrUnknownKey = errors.New("blob is encrypted with an unknown key")
+	// ErrCorrupted - shifrlangan obyekt buzilgan yoki kesilgan
+	ErrCorrupted = errors.New("encrypted blob is corrupted")
+)
+
+// EncryptionConfig – master kalitlar. Keys bo'sh bo'lsa shifrlash o'chiq.
+type EncryptionConfig struct {
+	Keys        string // "id:base64,id2:base64" - har biri 32 bayt; eski kalitlar o'qish uchun qoldiriladi
+	ActiveKeyID string // yangi obyektlar shu kalit bilan shifrlanadi
+}
+
+// Reencrypter - obyektni joriy master kalitga o'tkaza oladigan drayver. Shifrlanmagan obyekt shifrlanadi,
+// boshqa kalitdagi obyektning faqat sarlavhasi qayta yoziladi. false - obyekt allaqachon joriy kalitda.
+type Reencrypter interface {
+	Reencrypt(ctx context.Context, key string) (bool, error)
+}
+
+type encryptedStore struct {
+	inner  BlobStore
+	keys   map[string]cipher.AEAD
+	active string
+}
+
+// NewEncrypted - inner ustidan shaffof shifrlovchi qatlam. Shifrlashdan oldin yozilgan obyektlar
+// o'zgarishsiz o'qiladi, ularni Reencrypt bilan shifrlash mumkin.
+func NewEncrypted(inner BlobStore, cfg EncryptionConfig) (BlobStore, error) {
+	keys, err := parseKeys(cfg.Keys)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := keys[cfg.ActiveKeyID]; !ok {
+		return nil, fmt.Errorf("active encryption key %q is not configured", cfg.ActiveKeyID)
+	}
+	return &encryptedStore{inner: inner, keys: keys, active: cfg.ActiveKeyID}, nil
+}
+
+func parseKeys(s string) (map[string]cipher.AEAD, error) {
+	keys := make(map[string]cipher.AEAD)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(item, ":")
+		if !ok || id == "" || len(id) > encMaxKeyID {
+			return nil, fmt.Errorf("invalid encryption key entry %q: expected <id>:<base64>", id)
+		}
+		if _, ok := keys[id]; ok {
+			return nil, fmt.Errorf("duplicate encryption key id %q", id)
+		}
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(raw) != encKeySize {
+			return nil, fmt.Errorf("encryption key %q must be %d base64-encoded bytes", id, encKeySize)
+		}
+		aead, err := newAEAD(raw)
+		if err != nil {
+			return nil, err
+		}
+		keys[id] = aead
+	}
+	return keys, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *encryptedStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	dek := make([]byte, encKeySize)
+	if _, err := rand.Read(dek); err != nil {
+		return fmt.Errorf("failed to generate data key: %w", err)
+	}
+	header, err := s.header(dek)
+	if err != nil {
+		return err
+	}
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return err
+	}
+
+	body := io.MultiReader(bytes.NewReader(header), newEncryptReader(r, aead))
+	return s.inner.Put(ctx, key, body, encryptedSize(len(header), size), contentType)
+}
+
+func (s *encryptedStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	body, err := s.inner.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReader(body)
+	header, err := s.readHeader(br)
+	if err != nil {
+		body.Close()
+		return nil, fmt.Errorf("%s: %w", key, err)
+	}
+	if header == nil {
+		return readCloser{Reader: br, Closer: body}, nil
+	}
+	return readCloser{Reader: newDecryptReader(br, header.aead, 0), Closer: body}, nil
+}
+
+// Stat - Size shifrlanmagan mazmun hajmi (Content-Length va Range lar uchun)
+func (s *encryptedStore) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	info, header, err := s.stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return info, nil
+	}
+
+	size, err := plaintextSize(info.Size - int64(header.size))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", key, err)
+	}
+	plain := *info
+	plain.Size = size
+	return &plain, nil
+}
+
+func (s *encryptedStore) Delete(ctx context.Context, key string) error {
+	return s.inner.Delete(ctx, key)
+}
+
+// Presign - to'g'ridan-to'g'ri havola shifrlangan baytlarni berib yuborardi
+func (s *encryptedStore) Presign(ctx context.Context, key string, expires time.Duration) (string, error) {
+	return "", ErrPresignNotSupported
+}
+
+// GetRange - shifrlanmagan mazmundagi offset/length; kerakli segmentdan boshlab o'qiladi
+func (s *encryptedStore) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	if offset < 0 || length <= 0 {
+		return nil, fmt.Errorf("invalid range %d+%d", offset, length)
+	}
+
+	info, header, err := s.stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return getRange(ctx, s.inner, key, offset, length)
+	}
+
+	segment := offset / encSegmentSize
+	start := int64(header.size) + segment*(encSegmentSize+encTagSize)
+	if start >= info.Size {
+		return nil, fmt.Errorf("range %d+%d is out of bounds of %s", offset, length, key)
+	}
+	body, err := getRange(ctx, s.inner, key, start, info.Size-start)
+	if err != nil {
+		return nil, err
+	}
+
+	r := newDecryptReader(bufio.NewReader(body), header.aead, uint64(segment))
+	if _, err := io.CopyN(io.Discard, r, offset-segment*encSegmentSize); err != nil {
+		body.Close()
+		return nil, err
+	}
+	return readCloser{Reader: io.LimitReader(r, length), Closer: body}, nil
+}
+
+// Reencrypt - o'qish va yozish bir xil kalitda: lokal drayver yangi faylni rename qiladi, S3 esa
+// o'qilayotgan versiyani o'zgartirmaydi, shuning uchun eski mazmun oxirigacha o'qiladi.
+func (s *encryptedStore) Reencrypt(ctx context.Context, key string) (bool, error) {
+	info, header, err := s.stat(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	if header != nil && header.keyID == s.active {
+		return false, nil
+	}
+
+	if header == nil {
+		body, err := s.inner.Get(ctx, key)
+		if err != nil {
+			return false, err
+		}
+		defer body.Close()
+		return true, s.Put(ctx, key, body, info.Size, info.ContentType)
+	}
+
+	newHeader, err := s.header(header.dek)
+	if err != nil {
+		return false, err
+	}
+	bodySize := info.Size - int64(header.size)
+	body, err := getRange(ctx, s.inner, key, int64(header.size), bodySize)
+	if err != nil {
+		return false, err
+	}
+	defer body.Close()
+
+	r := io.MultiReader(bytes.NewReader(newHeader), body)
+	return true, s.inner.Put(ctx, key, r, int64(len(newHeader))+bodySize, info.ContentType)
+}
+
+// stat - inner dagi (shifrlangan) hajm va sarlavha; shifrlanmagan obyektda sarlavha nil
+func (s *encryptedStore) stat(ctx context.Context, key string) (*ObjectInfo, *encHeader, error) {
+	info, err := s.inner.Stat(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if info.Size < int64(len(encMagic)) {
+		return info, nil, nil
+	}
+
+	body, err := getRange(ctx, s.inner, key, 0, min(info.Size, int64(encMaxHeader)))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer body.Close()
+
+	header, err := s.readHeader(bufio.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", key, err)
+	}
+	return info, header, nil
+}
+
+type encHeader struct {
+	keyID string
+	dek   []byte
+	aead  cipher.AEAD // dek bo'yicha segment shifri
+	size  int         // sarlavhaning baytlardagi uzunligi
+}
+
+// header - dek ni joriy master kalit bilan o'raydi; kalit id si o'rashga qo'shimcha ma'lumot sifatida bog'lanadi
+func (s *encryptedStore) header(dek []byte) ([]byte, error) {
+	prefix := append([]byte(encMagic), byte(len(s.active)))
+	prefix = append(prefix, s.active...)
+
+	nonce := make([]byte, encNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	wrapped := s.keys[s.active].Seal(nil, nonce, dek, prefix)
+	return append(append(prefix, nonce...), wrapped...), nil
+}
+
+// readHeader - r obyekt boshida turibdi; magic bo'lmasa obyekt shifrlanmagan (nil, nil) va r dan hech narsa o'qilmaydi
+func (s *encryptedStore) readHeader(r *bufio.Reader) (*encHeader, error) {
+	if magic, _ := r.Peek(len(encMagic)); string(magic) != encMagic {
+		return nil, nil
+	}
+
+	prefix := make([]byte, len(encMagic)+1)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, ErrCorrupted
+	}
+	id := make([]byte, int(prefix[len(prefix)-1]))
+	if _, err := io.ReadFull(r, id); err != nil {
+		return nil, ErrCorrupted
+	}
+	wrapped := make([]byte, encWrappedSize)
+	if _, err := io.ReadFull(r, wrapped); err != nil {
+		return nil, ErrCorrupted
+	}
+
+	master, ok := s.keys[string(id)]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, id)
+	}
+	dek, err := master.Open(nil, wrapped[:encNonceSize], wrapped[encNonceSize:], append(prefix, id...))
+	if err != nil {
+		return nil, ErrCorrupted
+	}
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encHeader{
+		keyID: string(id),
+		dek:   dek,
+		aead:  aead,
+		size:  len(prefix) + len(id) + encWrappedSize,
+	}, nil
+}
+
+// encryptedSize - size baytli mazmunning shifrlangan hajmi; bo'sh mazmun ham bitta (bo'sh) segment oladi
+func encryptedSize(headerSize int, size int64) int64 {
+	if size < 0 {
+		return -1
+	}
+	segments := max((size+encSegmentSize-1)/encSegmentSize, 1)
+	return int64(headerSize) + size + segments*encTagSize
+}
+
+// plaintextSize - sarlavhasiz shifrlangan hajmdan asl hajm
+func plaintextSize(size int64) (int64, error) {
+	segments := (size + encSegmentSize + encTagSize - 1) / (encSegmentSize + encTagSize)
+	plain := size - segments*encTagSize
+	if segments == 0 || plain < (segments-1)*encSegmentSize {
+		return 0, ErrCorrupted
+	}
+	return plain, nil
+}
+
+// segmentNonce - segment raqami va oxirgi segment belgisi; har bir obyektning kaliti alohida,
+// shuning uchun nonce obyektlar orasida takrorlanishi xavfsiz
+func segmentNonce(counter uint64, last bool) []byte {
+	nonce := make([]byte, encNonceSize)
+	binary.BigEndian.PutUint64(nonce, counter)
+	if last {
+		nonce[encNonceSize-1] = 1
+	}
+	return nonce
+}
+
+// encryptReader - mazmunni segmentlab shifrlaydi; oxirgi segmentni bilish uchun bir bayt oldinga qaraladi
+type encryptReader struct {
+	src     *bufio.Reader
+	aead    cipher.AEAD
+	counter uint64
+	plain   []byte
+	sealed  []byte
+	out     []byte
+	done    bool
+}
+
+func newEncryptReader(r io.Reader, aead cipher.AEAD) *encryptReader {
+	return &encryptReader{
+		src:    bufio.NewReader(r),
+		aead:   aead,
+		plain:  make([]byte, encSegmentSize),
+		sealed: make([]byte, 0, encSegmentSize+encTagSize),
+	}
+}
+
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+func (r *encryptReader) next() error {
+	n, err := io.ReadFull(r.src, r.plain)
+	last, err := isLastSegment(r.src, err)
+	if err != nil {
+		return err
+	}
+
+	r.out = r.aead.Seal(r.sealed[:0], segmentNonce(r.counter, last), r.plain[:n], nil)
+	r.counter++
+	r.done = last
+	return nil
+}
+
+// decryptReader - counter-segmentdan boshlab o'qiydi; kesilgan yoki almashtirilgan segment ErrCorrupted beradi
+type decryptReader struct {
+	src     *bufio.Reader
+	aead    cipher.AEAD
+	counter uint64
+	sealed  []byte
+	plain   []byte
+	out     []byte
+	done    bool
+}
+
+func newDecryptReader(r *bufio.Reader, aead cipher.AEAD, counter uint64) *decryptReader {
+	return &decryptReader{
+		src:     r,
+		aead:    aead,
+		counter: counter,
+		sealed:  make([]byte, encSegmentSize+encTagSize),
+		plain:   make([]byte, 0, encSegmentSize),
+	}
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+func (r *decryptReader) next() error {
+	n, err := io.ReadFull(r.src, r.sealed)
+	if err == io.EOF {
+		// Oxirgi segment belgilangan segment hali kelmagan - obyekt kesilgan
+		return ErrCorrupted
+	}
+	last, err := isLastSegment(r.src, err)
+	if err != nil {
+		return err
+	}
+
+	plain, err := r.aead.Open(r.plain[:0], segmentNonce(r.counter, last), r.sealed[:n], nil)
+	if err != nil {
+		return fmt.Errorf("%w: segment %d", ErrCorrupted, r.counter)
+	}
+	r.out = plain
+	r.counter++
+	r.done = last
+	return nil
+}
+
+// isLastSegment - io.ReadFull natijasi bo'yicha: to'lmagan segment oxirgisi, to'lganda esa keyin bayt qolmaganmi
+func isLastSegment(src *bufio.Reader, err error) (bool, error) {
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return true, nil
+	case err != nil:
+		return false, err
+	}
+
+	if _, err := src.Peek(1); errors.Is(err, io.EOF) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// getRange - drayver Range ni qo'llamasa, lokal faylda seek qilinadi, aks holda boshi o'tkazib yuboriladi
+func getRange(ctx context.Context, store BlobStore, key string, offset, length int64) (io.ReadCloser, error) {
+	if rg, ok := store.(RangeGetter); ok {
+		return rg.GetRange(ctx, key, offset, length)
+	}
+
+	if local, ok := store.(LocalStore); ok {
+		p, err := local.LocalPath(key)
+		if err != nil {
+			return nil, err
+		}
+		f, err := os.Open(p)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			f.Close()
+			return nil, err
+		}
+		return readCloser{Reader: io.LimitReader(f, length), Closer: f}, nil
+	}
+
+	body, err := store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.CopyN(io.Discard, body, offset); err != nil {
+		body.Close()
+		return nil, err
+	}
+	return readCloser{Reader: io.LimitReader(body, length), Closer: body}, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,318 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, encKeySize))
+}
+
+// newTestEncrypted - lokal papka ustidagi shifrlovchi qatlam; inner ga shifrlangan baytlarni tekshirish uchun kerak
+func newTestEncrypted(t *testing.T, root, keys, active string) *encryptedStore {
+	t.Helper()
+	store, err := NewEncrypted(NewLocal(root), EncryptionConfig{Keys: keys, ActiveKeyID: active})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store.(*encryptedStore)
+}
+
+func testContent(size int) []byte {
+	data := make([]byte, size)
+	rand.New(rand.NewSource(int64(size))).Read(data)
+	return data
+}
+
+func readAll(r io.ReadCloser, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// rawObject - diskdagi (shifrlangan) obyekt
+func rawObject(t *testing.T, s *encryptedStore, key string) (string, []byte) {
+	t.Helper()
+	p, err := s.inner.(LocalStore).LocalPath(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p, data
+}
+
+func TestEncryptedRoundTrip(t *testing.T) {
+	s := newTestEncrypted(t, t.TempDir(), "k1:"+testKey(1), "k1")
+	ctx := context.Background()
+
+	sizes := []int{0, 1, 100, encSegmentSize - 1, encSegmentSize, encSegmentSize + 1, 3*encSegmentSize + 123}
+	for _, size := range sizes {
+		content := testContent(size)
+		key := "objects/" + strings.Repeat("x", size%7+1)
+
+		if err := s.Put(ctx, key, bytes.NewReader(content), int64(size), "application/pdf"); err != nil {
+			t.Fatalf("size %d: Put: %v", size, err)
+		}
+
+		got, err := readAll(s.Get(ctx, key))
+		if err != nil {
+			t.Fatalf("size %d: Get: %v", size, err)
+		}
+		if !bytes.Equal(got, content) {
+			t.Errorf("size %d: content mismatch (%d bytes read)", size, len(got))
+		}
+
+		info, err := s.Stat(ctx, key)
+		if err != nil {
+			t.Fatalf("size %d: Stat: %v", size, err)
+		}
+		if info.Size != int64(size) {
+			t.Errorf("size %d: Stat size = %d", size, info.Size)
+		}
+
+		_, raw := rawObject(t, s, key)
+		header, _ := s.header(make([]byte, encKeySize))
+		if want := encryptedSize(len(header), int64(size)); int64(len(raw)) != want {
+			t.Errorf("size %d: encrypted size = %d, want %d", size, len(raw), want)
+		}
+		if size >= 16 && bytes.Contains(raw, content[:16]) {
+			t.Errorf("size %d: plaintext found in stored object", size)
+		}
+	}
+}
+
+func TestEncryptedGetRange(t *testing.T) {
+	s := newTestEncrypted(t, t.TempDir(), "k1:"+testKey(1), "k1")
+	ctx := context.Background()
+
+	content := testContent(3*encSegmentSize + 123)
+	if err := s.Put(ctx, "a.pdf", bytes.NewReader(content), int64(len(content)), ""); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		offset, length int64
+	}{
+		{"start", 0, 10},
+		{"inside first segment", 1000, 500},
+		{"ends at segment boundary", encSegmentSize - 10, 10},
+		{"starts at segment boundary", encSegmentSize, 10},
+		{"crosses one boundary", encSegmentSize - 5, 10},
+		{"crosses two boundaries", encSegmentSize - 1, encSegmentSize + 2},
+		{"whole second segment", encSegmentSize, encSegmentSize},
+		{"last byte", int64(len(content)) - 1, 1},
+		{"past the end", int64(len(content)) - 10, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readAll(s.GetRange(ctx, "a.pdf", tt.offset, tt.length))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := content[tt.offset:min(tt.offset+tt.length, int64(len(content)))]
+			if !bytes.Equal(got, want) {
+				t.Errorf("GetRange(%d, %d) returned %d bytes, want %d matching bytes", tt.offset, tt.length, len(got), len(want))
+			}
+		})
+	}
+
+	if _, err := s.GetRange(ctx, "a.pdf", int64(len(content))+encSegmentSize, 1); err == nil {
+		t.Error("GetRange beyond the object succeeded")
+	}
+}
+
+func TestEncryptedTamperedSegment(t *testing.T) {
+	ctx := context.Background()
+	content := testContent(3*encSegmentSize + 123)
+
+	tests := []struct {
+		name   string
+		modify func(raw []byte, headerSize int) []byte
+	}{
+		{"flipped byte in second segment", func(raw []byte, h int) []byte {
+			raw[h+encSegmentSize+encTagSize+100] ^= 1
+			return raw
+		}},
+		{"flipped tag of last segment", func(raw []byte, _ int) []byte {
+			raw[len(raw)-1] ^= 1
+			return raw
+		}},
+		{"swapped segments", func(raw []byte, h int) []byte {
+			seg := encSegmentSize + encTagSize
+			first := append([]byte(nil), raw[h:h+seg]...)
+			copy(raw[h:h+seg], raw[h+seg:h+2*seg])
+			copy(raw[h+seg:h+2*seg], first)
+			return raw
+		}},
+		{"flipped wrapped key", func(raw []byte, h int) []byte {
+			raw[h-1] ^= 1
+			return raw
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestEncrypted(t, t.TempDir(), "k1:"+testKey(1), "k1")
+			if err := s.Put(ctx, "a.pdf", bytes.NewReader(content), int64(len(content)), ""); err != nil {
+				t.Fatal(err)
+			}
+			_, header, err := s.stat(ctx, "a.pdf")
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, raw := rawObject(t, s, "a.pdf")
+			if err := os.WriteFile(p, tt.modify(raw, header.size), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := readAll(s.Get(ctx, "a.pdf")); !errors.Is(err, ErrCorrupted) {
+				t.Errorf("Get: err = %v, want ErrCorrupted", err)
+			}
+		})
+	}
+}
+
+func TestEncryptedTamperedSegmentRange(t *testing.T) {
+	s := newTestEncrypted(t, t.TempDir(), "k1:"+testKey(1), "k1")
+	ctx := context.Background()
+	content := testContent(3 * encSegmentSize)
+	if err := s.Put(ctx, "a.pdf", bytes.NewReader(content), int64(len(content)), ""); err != nil {
+		t.Fatal(err)
+	}
+	_, header, _ := s.stat(ctx, "a.pdf")
+	p, raw := rawObject(t, s, "a.pdf")
+	raw[header.size+2*(encSegmentSize+encTagSize)+1] ^= 1
+	if err := os.WriteFile(p, raw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Buzilmagan segmentdagi Range o'qiladi, buzilgan segmentga yetgan Range esa xato beradi
+	if got, err := readAll(s.GetRange(ctx, "a.pdf", 10, encSegmentSize)); err != nil || !bytes.Equal(got, content[10:10+encSegmentSize]) {
+		t.Errorf("range over intact segments: err = %v", err)
+	}
+	if _, err := readAll(s.GetRange(ctx, "a.pdf", 2*encSegmentSize-1, 2)); !errors.Is(err, ErrCorrupted) {
+		t.Errorf("range into tampered segment: err = %v, want ErrCorrupted", err)
+	}
+}
+
+func TestEncryptedTruncated(t *testing.T) {
+	ctx := context.Background()
+	content := testContent(3*encSegmentSize + 123)
+	seg := encSegmentSize + encTagSize
+
+	tests := []struct {
+		name string
+		cut  func(size, headerSize int) int // qoldiriladigan baytlar soni
+	}{
+		{"last segment removed", func(size, h int) int { return h + 3*seg }},
+		{"cut at first segment boundary", func(size, h int) int { return h + seg }},
+		{"cut inside a segment", func(size, h int) int { return h + seg + 100 }},
+		{"last byte removed", func(size, h int) int { return size - 1 }},
+		{"only header left", func(size, h int) int { return h }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestEncrypted(t, t.TempDir(), "k1:"+testKey(1), "k1")
+			if err := s.Put(ctx, "a.pdf", bytes.NewReader(content), int64(len(content)), ""); err != nil {
+				t.Fatal(err)
+			}
+			_, header, _ := s.stat(ctx, "a.pdf")
+			p, raw := rawObject(t, s, "a.pdf")
+			if err := os.WriteFile(p, raw[:tt.cut(len(raw), header.size)], 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := readAll(s.Get(ctx, "a.pdf")); !errors.Is(err, ErrCorrupted) {
+				t.Errorf("Get: err = %v, want ErrCorrupted", err)
+			}
+		})
+	}
+}
+
+func TestEncryptedRotateThenRead(t *testing.T) {
+	root := t.TempDir()
+	ctx := context.Background()
+	content := testContent(2*encSegmentSize + 7)
+
+	old := newTestEncrypted(t, root, "k1:"+testKey(1), "k1")
+	if err := old.Put(ctx, "a.pdf", bytes.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+		t.Fatal(err)
+	}
+	// Shifrlash yoqilishidan oldin yozilgan obyekt
+	if err := old.inner.Put(ctx, "plain.pdf", bytes.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated := newTestEncrypted(t, root, "k1:"+testKey(1)+",k2:"+testKey(2), "k2")
+	for _, key := range []string{"a.pdf", "plain.pdf"} {
+		if got, err := readAll(rotated.Get(ctx, key)); err != nil || !bytes.Equal(got, content) {
+			t.Fatalf("%s before reencrypt: err = %v", key, err)
+		}
+
+		changed, err := rotated.Reencrypt(ctx, key)
+		if err != nil || !changed {
+			t.Fatalf("%s: Reencrypt = %v, %v", key, changed, err)
+		}
+		if changed, err := rotated.Reencrypt(ctx, key); err != nil || changed {
+			t.Errorf("%s: second Reencrypt = %v, %v, want no-op", key, changed, err)
+		}
+
+		_, header, err := rotated.stat(ctx, key)
+		if err != nil || header == nil || header.keyID != "k2" {
+			t.Fatalf("%s: header after reencrypt = %+v, %v", key, header, err)
+		}
+		if got, err := readAll(rotated.GetRange(ctx, key, encSegmentSize-3, 6)); err != nil || !bytes.Equal(got, content[encSegmentSize-3:encSegmentSize+3]) {
+			t.Errorf("%s: range after reencrypt: err = %v", key, err)
+		}
+	}
+
+	// Eski kalit olib tashlangandan keyin ham o'qiladi, faqat eski kalit bilan esa yo'q
+	onlyNew := newTestEncrypted(t, root, "k2:"+testKey(2), "k2")
+	if got, err := readAll(onlyNew.Get(ctx, "a.pdf")); err != nil || !bytes.Equal(got, content) {
+		t.Errorf("read with only the new key: err = %v", err)
+	}
+	onlyOld := newTestEncrypted(t, root, "k1:"+testKey(1), "k1")
+	if _, err := readAll(onlyOld.Get(ctx, "a.pdf")); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("read with only the old key: err = %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		keys    string
+		want    int
+		wantErr bool
+	}{
+		{"k1:" + testKey(1), 1, false},
+		{" k1:" + testKey(1) + " , k2:" + testKey(2) + ",", 2, false},
+		{"", 0, false},
+		{"k1", 0, true},
+		{":" + testKey(1), 0, true},
+		{"k1:not-base64", 0, true},
+		{"k1:" + base64.StdEncoding.EncodeToString([]byte("short")), 0, true},
+		{"k1:" + testKey(1) + ",k1:" + testKey(2), 0, true},
+		{strings.Repeat("k", encMaxKeyID+1) + ":" + testKey(1), 0, true},
+	}
+	for _, tt := range tests {
+		keys, err := parseKeys(tt.keys)
+		if (err != nil) != tt.wantErr || len(keys) != tt.want {
+			t.Errorf("parseKeys(%q) = %d keys, %v", tt.keys, len(keys), err)
+		}
+	}
+}
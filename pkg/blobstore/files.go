@@ -0,0 +1,83 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// PutFile - diskdagi faylni key ostida saqlaydi. Lokal drayverda fayl allaqachon
+// shu joyda bo'lsa, nusxalanmaydi.
+func PutFile(ctx context.Context, store BlobStore, key, filePath, contentType string) error {
+	if local, ok := store.(LocalStore); ok {
+		if dst, err := local.LocalPath(key); err == nil && samePath(dst, filePath) {
+			return nil
+		}
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	return store.Put(ctx, key, f, info.Size(), contentType)
+}
+
+// FetchFile - obyektni tashqi dasturlar o'qiy oladigan lokal faylga olib keladi.
+// Lokal drayverda faylning o'z yo'li qaytadi; boshqalarida tmpDir ga yuklab olinadi va cleanup uni o'chiradi.
+func FetchFile(ctx context.Context, store BlobStore, key, tmpDir string) (string, func(), error) {
+	if local, ok := store.(LocalStore); ok {
+		p, err := local.LocalPath(key)
+		if err != nil {
+			return "", nil, err
+		}
+		if _, err := store.Stat(ctx, key); err != nil {
+			return "", nil, err
+		}
+		return p, func() {}, nil
+	}
+
+	r, err := store.Get(ctx, key)
+	if err != nil {
+		return "", nil, err
+	}
+	defer r.Close()
+
+	if err := os.MkdirAll(tmpDir, os.ModePerm); err != nil {
+		return "", nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	// Kengaytma saqlanadi - ba'zi konvertorlar fayl turini shundan aniqlaydi
+	f, err := os.CreateTemp(tmpDir, "blob-*"+path.Ext(key))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	cleanup := func() { _ = os.Remove(f.Name()) }
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("failed to download blob %s: %w", key, err)
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	return f.Name(), cleanup, nil
+}
+
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
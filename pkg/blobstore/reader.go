@@ -0,0 +1,153 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// RangeGetter - obyektning bir qismini (offset dan length bayt) o'qiy oladigan drayver
+type RangeGetter interface {
+	GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+}
+
+// Open - obyektni Range so'rovlari uchun seek qilinadigan ko'rinishda ochadi.
+// Lokal drayverda fayl to'g'ridan-to'g'ri ochiladi; RangeGetter drayverida har bir seek dan
+// keyingi o'qish faqat kerakli qismni so'raydi, ya'ni fayl butunlay yuklab olinmaydi.
+func Open(ctx context.Context, store BlobStore, key string) (io.ReadSeekCloser, *ObjectInfo, error) {
+	info, err := store.Stat(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if local, ok := store.(LocalStore); ok {
+		p, err := local.LocalPath(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		f, err := os.Open(p)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return f, info, nil
+	}
+
+	rg, ok := store.(RangeGetter)
+	if !ok {
+		return nil, nil, fmt.Errorf("blob store %T does not support range reads", store)
+	}
+	return &rangeReader{ctx: ctx, store: rg, key: key, size: info.Size}, info, nil
+}
+
+// rangeReader - Seek faqat pozitsiyani eslab qoladi, keyingi Read shu joydan boshlab yangi so'rov ochadi
+type rangeReader struct {
+	ctx   context.Context
+	store RangeGetter
+	key   string
+	size  int64
+	pos   int64
+	body  io.ReadCloser
+}
+
+func (r *rangeReader) Read(p []byte) (int, error) {
+	if r.pos >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		body, err := r.store.GetRange(r.ctx, r.key, r.pos, r.size-r.pos)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+	}
+
+	n, err := r.body.Read(p)
+	r.pos += int64(n)
+	return n, err
+}
+
+func (r *rangeReader) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = r.pos + offset
+	case io.SeekEnd:
+		abs = r.size + offset
+	default:
+		return 0, errors.New("blobstore: invalid whence")
+	}
+	if abs < 0 {
+		return 0, errors.New("blobstore: negative position")
+	}
+
+	if abs != r.pos && r.body != nil {
+		r.body.Close()
+		r.body = nil
+	}
+	r.pos = abs
+	return abs, nil
+}
+
+func (r *rangeReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
+
+// Concat - bir nechta obyektni ketma-ket bitta oqim sifatida o'qiydi. Keyingi obyekt faqat
+// oldingisi tugaganda ochiladi, shuning uchun bir vaqtda bittadan ortiq ulanish ochilmaydi.
+func Concat(ctx context.Context, store BlobStore, keys []string) io.ReadCloser {
+	return &concatReader{ctx: ctx, store: store, keys: keys}
+}
+
+type concatReader struct {
+	ctx   context.Context
+	store BlobStore
+	keys  []string
+	cur   io.ReadCloser
+}
+
+func (r *concatReader) Read(p []byte) (int, error) {
+	for {
+		if r.cur == nil {
+			if len(r.keys) == 0 {
+				return 0, io.EOF
+			}
+			cur, err := r.store.Get(r.ctx, r.keys[0])
+			if err != nil {
+				return 0, err
+			}
+			r.cur, r.keys = cur, r.keys[1:]
+		}
+
+		n, err := r.cur.Read(p)
+		if err == io.EOF {
+			r.cur.Close()
+			r.cur = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (r *concatReader) Close() error {
+	if r.cur == nil {
+		return nil
+	}
+	err := r.cur.Close()
+	r.cur = nil
+	return err
+}
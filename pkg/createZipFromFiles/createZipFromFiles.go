@@ -7,38 +7,58 @@ import (
 	"path/filepath"
 )
 
+// Entry - arxivdagi fayl: Path - diskdagi joyi, Name - ZIP ichidagi nomi (papka bilan bo'lishi mumkin)
+type Entry struct {
+	Path string
+	Name string
+}
+
 func CreateZipFromFiles(zipFile *os.File, files []string) error {
+	entries := make([]Entry, 0, len(files))
+	for _, filePath := range files {
+		entries = append(entries, Entry{Path: filePath, Name: filepath.Base(filePath)})
+	}
+	return CreateZipFromEntries(zipFile, entries)
+}
+
+// CreateZipFromEntries - fayllarni berilgan nomlar bilan arxivlaydi (nomlar bir xil bo'lib qolmasligi uchun)
+func CreateZipFromEntries(zipFile *os.File, entries []Entry) error {
 	zipWriter := zip.NewWriter(zipFile)
 	defer zipWriter.Close()
 
-	for _, filePath := range files {
-		fileToZip, err := os.Open(filePath)
-		if err != nil {
+	for _, entry := range entries {
+		if err := addFile(zipWriter, entry); err != nil {
 			return err
 		}
-		defer fileToZip.Close()
+	}
 
-		info, err := fileToZip.Stat()
-		if err != nil {
-			return err
-		}
+	return nil
+}
 
-		header, err := zip.FileInfoHeader(info)
-		if err != nil {
-			return err
-		}
-		header.Name = filepath.Base(filePath)
-		header.Method = zip.Deflate
+func addFile(zipWriter *zip.Writer, entry Entry) error {
+	fileToZip, err := os.Open(entry.Path)
+	if err != nil {
+		return err
+	}
+	defer fileToZip.Close()
 
-		writer, err := zipWriter.CreateHeader(header)
-		if err != nil {
-			return err
-		}
+	info, err := fileToZip.Stat()
+	if err != nil {
+		return err
+	}
 
-		if _, err := io.Copy(writer, fileToZip); err != nil {
-			return err
-		}
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
 	}
+	header.Name = entry.Name
+	header.Method = zip.Deflate
 
-	return nil
+	writer, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(writer, fileToZip)
+	return err
 }
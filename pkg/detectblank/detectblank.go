@@ -2,22 +2,34 @@ package detectblank
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
 )
 
+// ProgressFunc har bir sahifa tekshirilgandan keyin chaqiriladi (page - joriy sahifa, total - jami)
+type ProgressFunc func(page, total int)
+
 // DetectBlankPages pdftotext yordamida sahifalardagi matnlarni olib, bo‘sh sahifalarni aniqlaydi
 func DetectBlankPages(pdfPath string, pageCount int) ([]int, error) {
+	return DetectBlankPagesContext(context.Background(), pdfPath, pageCount, nil)
+}
+
+// DetectBlankPagesContext DetectBlankPages bilan bir xil, lekin ctx bekor qilinsa to‘xtaydi va har sahifadan keyin onPage ni chaqiradi
+func DetectBlankPagesContext(ctx context.Context, pdfPath string, pageCount int, onPage ProgressFunc) ([]int, error) {
 	var blankPages []int
 
 	for i := 1; i <= pageCount; i++ {
 		// pdftotext -f <page> -l <page> <file> - | text
-		cmd := exec.Command("pdftotext", "-f", fmt.Sprint(i), "-l", fmt.Sprint(i), pdfPath, "-")
+		cmd := exec.CommandContext(ctx, "pdftotext", "-f", fmt.Sprint(i), "-l", fmt.Sprint(i), pdfPath, "-")
 		var out bytes.Buffer
 		cmd.Stdout = &out
 		err := cmd.Run()
 		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
 			return nil, fmt.Errorf("failed to extract page %d text: %w", i, err)
 		}
 
@@ -25,6 +37,10 @@ func DetectBlankPages(pdfPath string, pageCount int) ([]int, error) {
 		if text == "" {
 			blankPages = append(blankPages, i)
 		}
+
+		if onPage != nil {
+			onPage(i, pageCount)
+		}
 	}
 
 	return blankPages, nil
@@ -19,6 +19,21 @@ type Client interface {
 	HTMLToPDF(ctx context.Context, htmlPath string) ([]byte, error)
 }
 
+// StatusError - Gotenberg 200 dan boshqa status qaytarganda
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("conversion failed (status %d): %s", e.StatusCode, e.Body)
+}
+
+// Temporary - 5xx xatolar vaqtinchalik hisoblanadi va qayta urinish mumkin
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode >= http.StatusInternalServerError
+}
+
 type gotenbergClient struct {
 	baseURL string
 }
@@ -63,7 +78,7 @@ func (g *gotenbergClient) PDFToWord(ctx context.Context, pdfPath string) ([]byte
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("conversion failed: %s", string(bodyBytes))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
 	}
 
 	return io.ReadAll(resp.Body)
@@ -105,7 +120,7 @@ func (g *gotenbergClient) WordToPDF(ctx context.Context, wordPath string) ([]byt
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("conversion failed: %s", string(bodyBytes))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
 	}
 
 	return io.ReadAll(resp.Body)
@@ -147,7 +162,7 @@ func (g *gotenbergClient) ExcelToPDF(ctx context.Context, excelPath string) ([]b
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("conversion failed: %s", string(bodyBytes))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
 	}
 
 	return io.ReadAll(resp.Body)
@@ -189,7 +204,7 @@ func (g *gotenbergClient) PowerPointToPDF(ctx context.Context, pptPath string) (
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("conversion failed: %s", string(bodyBytes))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
 	}
 
 	return io.ReadAll(resp.Body)
@@ -232,7 +247,7 @@ func (g *gotenbergClient) HTMLToPDF(ctx context.Context, htmlPath string) ([]byt
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("gotenberg error: %s", string(bodyBytes))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
 	}
 
 	return io.ReadAll(resp.Body)
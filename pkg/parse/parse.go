@@ -35,11 +35,23 @@ func ParseToken(tokenString string) (map[string]interface{}, error) {
 	return nil, errors.New("invalid token or claims")
 }
 
-func ParsePageOrder(order []int) ([]int, error) {
+// ParsePageOrder - yangi sahifa tartibini tekshiradi: 0 - bo'sh sahifa, qolganlari 1..pageCount
+// oralig'ida bo'lishi va kamida bitta haqiqiy sahifa bo'lishi kerak. pageCount <= 0 - sahifa soni noma'lum.
+func ParsePageOrder(order []int, pageCount int) ([]int, error) {
+	hasPage := false
 	for _, page := range order {
-		if page <= 0 {
+		if page < 0 {
 			return nil, fmt.Errorf("invalid page number: %d", page)
 		}
+		if pageCount > 0 && page > pageCount {
+			return nil, fmt.Errorf("page %d does not exist (document has %d pages)", page, pageCount)
+		}
+		if page > 0 {
+			hasPage = true
+		}
+	}
+	if !hasPage {
+		return nil, errors.New("order must contain at least one existing page")
 	}
 	return order, nil
 }
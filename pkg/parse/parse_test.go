@@ -0,0 +1,35 @@
+package jwt
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParsePageOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		order     []int
+		pageCount int
+		wantErr   bool
+	}{
+		{"reverse", []int{3, 2, 1}, 3, false},
+		{"duplicates", []int{1, 1, 2}, 2, false},
+		{"blank pages", []int{0, 1, 0}, 1, false},
+		{"unknown page count", []int{100}, 0, false},
+		{"empty", nil, 3, true},
+		{"only blank pages", []int{0, 0}, 3, true},
+		{"negative", []int{1, -1}, 3, true},
+		{"out of range", []int{1, 4}, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePageOrder(tt.order, tt.pageCount)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePageOrder(%v, %d) err = %v, wantErr %v", tt.order, tt.pageCount, err, tt.wantErr)
+			}
+			if !tt.wantErr && !slices.Equal(got, tt.order) {
+				t.Errorf("ParsePageOrder(%v, %d) = %v", tt.order, tt.pageCount, got)
+			}
+		})
+	}
+}
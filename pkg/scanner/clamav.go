@@ -0,0 +1,126 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const clamdChunkSize = 64 << 10
+
+type clamAV struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamAV - clamd bilan INSTREAM buyrug'i orqali ishlaydi: mazmun bo'laklab TCP (yoki unix socket)
+// orqali yuboriladi, fayl clamd ga ko'rinishi shart emas. timeout - bitta tekshiruv uchun
+func NewClamAV(address string, timeout time.Duration) Scanner {
+	network := "tcp"
+	switch {
+	case strings.HasPrefix(address, "unix://"):
+		network, address = "unix", strings.TrimPrefix(address, "unix://")
+	case strings.HasPrefix(address, "tcp://"):
+		address = strings.TrimPrefix(address, "tcp://")
+	}
+	return &clamAV{network: network, address: address, timeout: timeout}
+}
+
+func (c *clamAV) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return Result{}, fmt.Errorf("clamd: %w", err)
+	}
+	defer conn.Close()
+
+	// ctx bekor bo'lsa yoki vaqti tugasa, to'xtab qolgan o'qish/yozish darhol xato bilan qaytadi
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Unix(1, 0)) })
+	defer stop()
+
+	reply := bufio.NewReader(conn)
+	if readErr, err := c.stream(conn, r); readErr != nil {
+		return Result{}, readErr
+	} else if err != nil {
+		if ctx.Err() != nil {
+			return Result{}, fmt.Errorf("clamd: %w", ctx.Err())
+		}
+		// clamd hajm chegarasidan oshganda javob yozib ulanishni yopadi - sababi shu javobda
+		if line, readErr := readReply(reply); readErr == nil {
+			return parseReply(line)
+		}
+		return Result{}, fmt.Errorf("clamd: failed to send data: %w", err)
+	}
+
+	line, err := readReply(reply)
+	if err != nil {
+		if ctx.Err() != nil {
+			return Result{}, fmt.Errorf("clamd: %w", ctx.Err())
+		}
+		return Result{}, fmt.Errorf("clamd: failed to read reply: %w", err)
+	}
+	return parseReply(line)
+}
+
+// stream - "zINSTREAM\0", keyin har bir bo'lak oldidan 4 baytli uzunlik, oxirida 0 uzunlik.
+// readErr - mazmunni o'qib bo'lmadi, err - clamd ga yuborib bo'lmadi
+func (c *clamAV) stream(w io.Writer, r io.Reader) (readErr, err error) {
+	if _, err := io.WriteString(w, "zINSTREAM\x00"); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 4+clamdChunkSize)
+	for {
+		n, err := r.Read(buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, err := w.Write(buf[:4+n]); err != nil {
+				return nil, err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read content: %w", err), nil
+		}
+	}
+
+	_, err = w.Write([]byte{0, 0, 0, 0})
+	return nil, err
+}
+
+// readReply - "z" buyruqlarining javobi \0 bilan tugaydi
+func readReply(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString(0)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimSuffix(line, "\x00")), nil
+}
+
+// parseReply - "stream: OK", "stream: <nomi> FOUND" yoki "<sabab> ERROR"
+func parseReply(line string) (Result, error) {
+	body := strings.TrimSpace(strings.TrimPrefix(line, "stream:"))
+	switch {
+	case body == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(body, " FOUND"):
+		return Result{Infected: true, Signature: strings.TrimSuffix(body, " FOUND")}, nil
+	default:
+		return Result{}, fmt.Errorf("clamd: %s", strings.TrimSuffix(body, " ERROR"))
+	}
+}
@@ -0,0 +1,229 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClamd - INSTREAM ni clamd kabi qabul qiladi: kelgan bo'laklar hajmi va mazmuni yoziladi,
+// javobni reply(data) beradi. limit > 0 bo'lsa, shundan oshganda clamd kabi darhol ERROR qaytaradi.
+type fakeClamd struct {
+	ln     net.Listener
+	limit  int
+	reply  func(data []byte) string
+	chunks chan []int
+	data   chan []byte
+}
+
+func newFakeClamd(t *testing.T, limit int, reply func(data []byte) string) *fakeClamd {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &fakeClamd{ln: ln, limit: limit, reply: reply, chunks: make(chan []int, 1), data: make(chan []byte, 1)}
+	t.Cleanup(func() { ln.Close() })
+	go d.serve()
+	return d
+}
+
+func (d *fakeClamd) serve() {
+	conn, err := d.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	cmd, err := r.ReadString(0)
+	if err != nil || cmd != "zINSTREAM\x00" {
+		io.WriteString(conn, "UNKNOWN COMMAND\x00")
+		return
+	}
+
+	var (
+		sizes []int
+		data  []byte
+	)
+	defer func() { d.chunks <- sizes; d.data <- data }()
+
+	for {
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return
+		}
+		if size == 0 {
+			break
+		}
+		chunk := make([]byte, size)
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return
+		}
+		sizes = append(sizes, int(size))
+		data = append(data, chunk...)
+
+		if d.limit > 0 && len(data) > d.limit {
+			io.WriteString(conn, "INSTREAM size limit exceeded. ERROR\x00")
+			// Qolgan mazmun o'qib tashlanadi, aks holda yopilgan ulanish RST bilan javobni yo'qotishi mumkin
+			_ = conn.(*net.TCPConn).CloseWrite()
+			_, _ = io.Copy(io.Discard, r)
+			return
+		}
+	}
+
+	io.WriteString(conn, "stream: "+d.reply(data)+"\x00")
+}
+
+func TestClamAVScan(t *testing.T) {
+	eicar := []byte(`X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`)
+	reply := func(data []byte) string {
+		if bytes.Contains(data, []byte("EICAR-STANDARD-ANTIVIRUS-TEST-FILE")) {
+			return "Eicar-Test-Signature FOUND"
+		}
+		return "OK"
+	}
+
+	tests := []struct {
+		name       string
+		content    []byte
+		limit      int
+		wantChunks []int
+		want       Result
+		wantErr    string
+	}{
+		{
+			name:       "clean file in several chunks",
+			content:    bytes.Repeat([]byte("a"), 2*clamdChunkSize+100),
+			wantChunks: []int{clamdChunkSize, clamdChunkSize, 100},
+			want:       Result{},
+		},
+		{
+			name:       "empty file",
+			content:    nil,
+			wantChunks: nil,
+			want:       Result{},
+		},
+		{
+			name:       "infected",
+			content:    eicar,
+			wantChunks: []int{len(eicar)},
+			want:       Result{Infected: true, Signature: "Eicar-Test-Signature"},
+		},
+		{
+			name:    "size limit",
+			content: bytes.Repeat([]byte("a"), 4*clamdChunkSize),
+			limit:   clamdChunkSize + 1,
+			wantErr: "clamd: INSTREAM size limit exceeded.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clamd := newFakeClamd(t, tt.limit, reply)
+			scanner := NewClamAV("tcp://"+clamd.ln.Addr().String(), 5*time.Second)
+
+			got, err := scanner.Scan(context.Background(), bytes.NewReader(tt.content))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("result = %+v, want %+v", got, tt.want)
+			}
+
+			sizes, data := <-clamd.chunks, <-clamd.data
+			if !slices.Equal(sizes, tt.wantChunks) {
+				t.Errorf("chunk sizes = %v, want %v", sizes, tt.wantChunks)
+			}
+			if !bytes.Equal(data, tt.content) {
+				t.Errorf("clamd received %d bytes, want %d", len(data), len(tt.content))
+			}
+		})
+	}
+}
+
+func TestClamAVScanTimeout(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	// Javob bermaydigan clamd
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = io.Copy(io.Discard, conn)
+	}()
+
+	_, err = NewClamAV(ln.Addr().String(), 200*time.Millisecond).Scan(context.Background(), strings.NewReader("data"))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+}
+
+func TestClamAVScanReadError(t *testing.T) {
+	clamd := newFakeClamd(t, 0, func([]byte) string { return "OK" })
+	readErr := errors.New("disk failure")
+
+	_, err := NewClamAV(clamd.ln.Addr().String(), 5*time.Second).Scan(context.Background(), io.MultiReader(strings.NewReader("data"), errReader{readErr}))
+	if !errors.Is(err, readErr) {
+		t.Fatalf("err = %v, want %v", err, readErr)
+	}
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestNewClamAVAddress(t *testing.T) {
+	tests := []struct {
+		address, network, want string
+	}{
+		{"127.0.0.1:3310", "tcp", "127.0.0.1:3310"},
+		{"tcp://clamd:3310", "tcp", "clamd:3310"},
+		{"unix:///run/clamd.sock", "unix", "/run/clamd.sock"},
+	}
+	for _, tt := range tests {
+		c := NewClamAV(tt.address, 0).(*clamAV)
+		if c.network != tt.network || c.address != tt.want {
+			t.Errorf("NewClamAV(%q) = %s %s, want %s %s", tt.address, c.network, c.address, tt.network, tt.want)
+		}
+	}
+}
+
+func TestParseReply(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    Result
+		wantErr bool
+	}{
+		{"stream: OK", Result{}, false},
+		{"stream: Win.Test.EICAR_HDB-1 FOUND", Result{Infected: true, Signature: "Win.Test.EICAR_HDB-1"}, false},
+		{"INSTREAM size limit exceeded. ERROR", Result{}, true},
+		{"stream: Can't allocate memory ERROR", Result{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseReply(tt.line)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseReply(%q) = %+v, %v", tt.line, got, err)
+		}
+	}
+}
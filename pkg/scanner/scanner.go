@@ -0,0 +1,39 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Result – tekshiruv natijasi
+type Result struct {
+	Infected  bool
+	Signature string // topilgan zararli dastur nomi (faqat Infected bo'lsa)
+}
+
+// Scanner – fayl mazmunini zararli dasturlarga tekshiradi. Xato - tekshirib bo'lmadi
+// (skaner ishlamayapti, vaqt tugadi va h.k.), fayl haqida hech narsa aytmaydi.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// Config – qaysi skaner ishlatilishi
+type Config struct {
+	Driver  string // "" yoki "none" - tekshiruv o'chiq, "clamav" - clamd
+	Address string // clamd manzili: "host:port", "tcp://host:port" yoki "unix:///path/clamd.sock"
+	Timeout time.Duration
+}
+
+// New - Driver bo'sh yoki "none" bo'lsa nil qaytadi (yuklashlar tekshirilmaydi)
+func New(cfg Config) (Scanner, error) {
+	switch cfg.Driver {
+	case "", "none":
+		return nil, nil
+	case "clamav":
+		return NewClamAV(cfg.Address, cfg.Timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown scanner driver %q", cfg.Driver)
+	}
+}
@@ -0,0 +1,212 @@
+package watermark
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Tile rejimida sahifa shu ustun va qatorlarga bo'linadi; toq qatorlar yarim katakka suriladi
+const (
+	tileColumns = 3
+	tileRows    = 4
+)
+
+// Positions - foydalanuvchi pozitsiyasi -> pdfcpu anchor
+var Positions = map[string]string{
+	"top-left":      "tl",
+	"top-center":    "tc",
+	"top-right":     "tr",
+	"left":          "l",
+	"center":        "c",
+	"right":         "r",
+	"bottom-left":   "bl",
+	"bottom-center": "bc",
+	"bottom-right":  "br",
+}
+
+// Params holds input/output paths and watermark options.
+type Params struct {
+	InputPath  string
+	OutputPath string
+	Text       string // matnli suv belgisi; bo'sh bo'lsa ImagePath ishlatiladi
+	ImagePath  string // PNG/JPEG rasm (kengaytmasi bilan)
+	FontName   string
+	FontSize   int     // 0 - o'lcham Scale bo'yicha
+	Color      string  // "#RRGGBB"
+	Opacity    float64 // 0..1
+	Rotation   int
+	Diagonal   bool    // chap pastdan o'ng yuqoriga; Rotation e'tiborga olinmaydi
+	Tile       bool    // butun sahifa bo'ylab takrorlash; Position e'tiborga olinmaydi
+	Position   string  // Positions kalitlaridan biri
+	Scale      float64 // sahifa kengligiga nisbatan o'lcham (0..1]
+	PageRange  string  // "" - barcha sahifalar, "1-3,5" va hokazo
+	OnTop      bool    // true - stamp (kontent ustida), false - watermark (kontent ortida)
+}
+
+// AddWatermark adds a text or image watermark to the PDF file.
+func AddWatermark(params Params) error {
+	conf := model.NewDefaultConfiguration()
+
+	var selected []string
+	if params.PageRange != "" {
+		var err error
+		if selected, err = api.ParsePageSelection(params.PageRange); err != nil {
+			return fmt.Errorf("invalid page range: %w", err)
+		}
+	}
+
+	if !params.Tile {
+		wm, err := parse(params, Positions[params.Position], 0, 0)
+		if err != nil {
+			return err
+		}
+		if err := api.AddWatermarksFile(params.InputPath, params.OutputPath, selected, wm, conf); err != nil {
+			return fmt.Errorf("failed to add watermark: %w", err)
+		}
+		return nil
+	}
+
+	// Tile: har bir katak alohida suv belgisi sifatida shu o'lchamdagi barcha sahifalarga qo'yiladi
+	// (rasm har bir katak uchun bir marta saqlanadi, har sahifa uchun emas)
+	return ApplyByPageSize(params.InputPath, params.OutputPath, selected, func(dim types.Dim) ([]*model.Watermark, error) {
+		offsets := tileOffsets(dim)
+		wms := make([]*model.Watermark, 0, len(offsets))
+		for _, offset := range offsets {
+			wm, err := parse(params, "c", offset[0], offset[1])
+			if err != nil {
+				return nil, err
+			}
+			wms = append(wms, wm)
+		}
+		return wms, nil
+	})
+}
+
+// ApplyByPageSize - hujjatni bir marta o'qiydi, tanlangan sahifalarni (selected bo'sh bo'lsa barchasini)
+// o'lchami bo'yicha guruhlaydi va har bir guruhga build qaytargan suv belgilarini qo'yadi. Sahifa o'lchamiga
+// bog'liq suv belgilari (tile, scale-to-fit, rangli fon) uchun; natija outputPath ga yoziladi.
+func ApplyByPageSize(inputPath, outputPath string, selected []string, build func(dim types.Dim) ([]*model.Watermark, error)) error {
+	f, err := os.Open(inputPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	conf := model.NewDefaultConfiguration()
+	conf.Cmd = model.ADDWATERMARKS
+	conf.OptimizeDuplicateContentStreams = false
+	ctx, err := api.ReadValidateAndOptimize(f, conf)
+	if err != nil {
+		return fmt.Errorf("failed to read pdf: %w", err)
+	}
+	pages, err := api.PagesForPageSelection(ctx.PageCount, selected, true, true)
+	if err != nil {
+		return fmt.Errorf("invalid page range: %w", err)
+	}
+	dims, err := ctx.PageDims()
+	if err != nil {
+		return fmt.Errorf("failed to read page sizes: %w", err)
+	}
+
+	groups := make(map[types.Dim]types.IntSet)
+	for page, ok := range pages {
+		if !ok || page < 1 || page > len(dims) {
+			continue
+		}
+		dim := dims[page-1]
+		if groups[dim] == nil {
+			groups[dim] = types.IntSet{}
+		}
+		groups[dim][page] = true
+	}
+	if len(groups) == 0 {
+		return errors.New("no pages selected")
+	}
+
+	for dim, pageSet := range groups {
+		wms, err := build(dim)
+		if err != nil {
+			return err
+		}
+		for _, wm := range wms {
+			if err := api.WatermarkContext(ctx, pageSet, wm); err != nil {
+				return fmt.Errorf("failed to add watermark: %w", err)
+			}
+		}
+	}
+
+	out, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	if err := api.Write(ctx, out, conf); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to write pdf: %w", err)
+	}
+	return out.Close()
+}
+
+// tileOffsets - sahifa markaziga nisbatan katak markazlari (dx, dy)
+func tileOffsets(dim types.Dim) [][2]float64 {
+	stepX := dim.Width / tileColumns
+	stepY := dim.Height / tileRows
+
+	offsets := make([][2]float64, 0, tileColumns*tileRows)
+	for row := 0; row < tileRows; row++ {
+		dy := (float64(row) - float64(tileRows-1)/2) * stepY
+		shift := -stepX / 4
+		if row%2 == 1 {
+			shift = stepX / 4
+		}
+		for col := 0; col < tileColumns; col++ {
+			dx := (float64(col)-float64(tileColumns-1)/2)*stepX + shift
+			offsets = append(offsets, [2]float64{dx, dy})
+		}
+	}
+	return offsets
+}
+
+// parse - pdfcpu tavsif qatorini tuzadi va suv belgisini yaratadi
+func parse(params Params, pos string, dx, dy float64) (*model.Watermark, error) {
+	desc := []string{
+		"pos:" + pos,
+		fmt.Sprintf("offset:%.2f %.2f", dx, dy),
+		fmt.Sprintf("opacity:%.2f", params.Opacity),
+	}
+	if params.Diagonal {
+		desc = append(desc, "diagonal:1")
+	} else {
+		desc = append(desc, fmt.Sprintf("rotation:%d", params.Rotation))
+	}
+
+	unit := model.NewDefaultConfiguration().Unit
+
+	if params.Text == "" {
+		desc = append(desc, fmt.Sprintf("scalefactor:%.2f rel", params.Scale))
+		wm, err := pdfcpu.ParseImageWatermarkDetails(params.ImagePath, strings.Join(desc, ", "), params.OnTop, unit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse image watermark: %w", err)
+		}
+		return wm, nil
+	}
+
+	if params.FontSize > 0 {
+		desc = append(desc, "scalefactor:1 abs", fmt.Sprintf("points:%d", params.FontSize))
+	} else {
+		desc = append(desc, fmt.Sprintf("scalefactor:%.2f rel", params.Scale))
+	}
+	desc = append(desc, "fontname:"+params.FontName, "fillcolor:"+params.Color)
+
+	wm, err := pdfcpu.ParseTextWatermarkDetails(params.Text, strings.Join(desc, ", "), params.OnTop, unit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse text watermark: %w", err)
+	}
+	return wm, nil
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+
+	"test/api/models"
+	"test/pkg/addbackground"
+	"test/pkg/logger"
+	"test/pkg/watermark"
+	"test/storage"
+)
+
+// ErrInvalidBackground - orqa fon parametrlari noto'g'ri (mijoz xatosi)
+var ErrInvalidBackground = errors.New("invalid background request")
+
+// AddBackgroundService – PDF sahifalari ortiga yuklangan rasm yoki bir xil rang qo'yadi.
+// Fon kontent ortida joylashadi, shuning uchun to'liq rasmli (skaner) sahifalarda ko'rinmasligi mumkin.
+type AddBackgroundService interface {
+	Create(ctx context.Context, req models.CreateAddBackgroundRequest, userID *string) (string, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
+}
+
+type addBackgroundService struct {
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
+}
+
+func NewAddBackgroundService(stg storage.IStorage, log logger.ILogger, queue QueueService) AddBackgroundService {
+	return &addBackgroundService{
+		stg:   stg,
+		log:   log,
+		queue: queue,
+	}
+}
+
+func (s *addBackgroundService) Create(ctx context.Context, req models.CreateAddBackgroundRequest, userID *string) (string, error) {
+	s.log.Info("AddBackgroundService.Create called")
+
+	// 1. Parametrlarni va fayllarni tekshirish
+	if _, err := backgroundParams(req); err != nil {
+		return "", err
+	}
+
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
+		s.log.Error("input file not found", logger.Error(err))
+		return "", fmt.Errorf("input file not found: %w", err)
+	}
+
+	// Rasm ham kiruvchi fayl: egasi va turi Submit da tekshiriladi, retention uni o'chirmaydi
+	inputIDs := []string{req.InputFileID}
+	if req.BackgroundImageFileID != "" {
+		if _, err := imageAsset(ctx, s.stg, userID, req.BackgroundImageFileID, ErrInvalidBackground); err != nil {
+			return "", err
+		}
+		inputIDs = append(inputIDs, req.BackgroundImageFileID)
+	}
+
+	// 2. Job yaratish va navbatga qo'yish
+	job, err := newJob(models.JobTypeAddBackground, userID, inputIDs, req)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("failed to submit add background job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan add background jobni bajaradi
+func (s *addBackgroundService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.CreateAddBackgroundRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid add background params: %w", err)
+	}
+
+	outputFileID, err := s.addBackground(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputFileID}
+
+	s.log.Info("add background job completed", logger.String("jobID", job.ID))
+	return nil
+}
+
+func (s *addBackgroundService) addBackground(ctx context.Context, job *models.Job, req models.CreateAddBackgroundRequest) (string, error) {
+	// Pipeline/batch qadamlari Create dan o'tmaydi, shuning uchun parametrlar shu yerda ham tekshiriladi
+	params, err := backgroundParams(req)
+	if err != nil {
+		return "", &JobError{Code: ErrCodeInvalidInput, Err: err}
+	}
+
+	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
+	if err != nil {
+		return "", fmt.Errorf("input file not found: %w", err)
+	}
+
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+	params.InputPath = inputPath
+
+	if req.BackgroundImageFileID != "" {
+		image, err := imageAsset(ctx, s.stg, job.UserID, req.BackgroundImageFileID, ErrInvalidBackground)
+		if err != nil {
+			if errors.Is(err, ErrInvalidBackground) || errors.Is(err, ErrUnsupportedFileType) || errors.Is(err, ErrFileQuarantined) || errors.Is(err, ErrFileAccessDenied) {
+				return "", &JobError{Code: ErrCodeInvalidInput, Err: err}
+			}
+			return "", err
+		}
+
+		imagePath, cleanupImage, err := fetchInput(ctx, s.stg, image)
+		if err != nil {
+			return "", err
+		}
+		defer cleanupImage()
+		params.BackgroundImagePath = imagePath
+	}
+
+	outputFileID := uuid.NewString()
+	params.OutputPath = filepath.Join("storage/add_background", outputFileID+".pdf")
+
+	if err := os.MkdirAll(filepath.Dir(params.OutputPath), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	if err := addbackground.AddBackgroundImage(params); err != nil {
+		s.log.Error("failed to add background", logger.Error(err))
+		return "", err
+	}
+
+	if err := saveOutputFile(ctx, s.stg, outputFileID, job.UserID, params.OutputPath, "application/pdf"); err != nil {
+		return "", err
+	}
+
+	return outputFileID, nil
+}
+
+func (s *addBackgroundService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	return getJobByType(ctx, s.stg, id, models.JobTypeAddBackground)
+}
+
+// backgroundParams - so'rovni tekshiradi va standart qiymatlar bilan pkg/addbackground parametrlariga aylantiradi
+func backgroundParams(req models.CreateAddBackgroundRequest) (addbackground.AddBackgroundParams, error) {
+	params := addbackground.AddBackgroundParams{
+		Color:      req.Color,
+		Opacity:    1,
+		Position:   "center",
+		Scale:      1,
+		ScaleToFit: req.ScaleToFit,
+		PageRange:  strings.TrimSpace(req.PageRange),
+	}
+
+	switch {
+	case req.BackgroundImageFileID != "" && req.Color != "":
+		return params, fmt.Errorf("%w: background_image_file_id and color are mutually exclusive", ErrInvalidBackground)
+	case req.BackgroundImageFileID == "" && req.Color == "":
+		return params, fmt.Errorf("%w: background_image_file_id or color is required", ErrInvalidBackground)
+	case req.Color != "" && !hexColorRe.MatchString(req.Color):
+		return params, fmt.Errorf("%w: color must be #RRGGBB", ErrInvalidBackground)
+	}
+
+	if req.Position != "" {
+		if _, ok := watermark.Positions[req.Position]; !ok {
+			return params, fmt.Errorf("%w: unknown position %q", ErrInvalidBackground, req.Position)
+		}
+		params.Position = req.Position
+	}
+
+	if req.Opacity != nil {
+		params.Opacity = *req.Opacity
+	}
+	if req.Scale > 0 {
+		params.Scale = req.Scale
+	}
+
+	if params.PageRange != "" {
+		if _, err := api.ParsePageSelection(params.PageRange); err != nil {
+			return params, fmt.Errorf("%w: invalid page_range %q", ErrInvalidBackground, params.PageRange)
+		}
+	}
+
+	return params, nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"test/api/models"
+	"test/pkg/addbackground"
+)
+
+func TestBackgroundParams(t *testing.T) {
+	opacity := 0.3
+
+	tests := []struct {
+		name    string
+		req     models.CreateAddBackgroundRequest
+		want    addbackground.AddBackgroundParams
+		wantErr bool
+	}{
+		{
+			name: "color defaults",
+			req:  models.CreateAddBackgroundRequest{Color: "#FFF8E1"},
+			want: addbackground.AddBackgroundParams{Color: "#FFF8E1", Opacity: 1, Position: "center", Scale: 1},
+		},
+		{
+			name: "image with options",
+			req: models.CreateAddBackgroundRequest{
+				BackgroundImageFileID: "img", Opacity: &opacity, Position: "bottom-right", Scale: 0.5, PageRange: " 2-4 ",
+			},
+			want: addbackground.AddBackgroundParams{Opacity: 0.3, Position: "bottom-right", Scale: 0.5, PageRange: "2-4"},
+		},
+		{
+			name: "scale to fit",
+			req:  models.CreateAddBackgroundRequest{BackgroundImageFileID: "img", ScaleToFit: true},
+			want: addbackground.AddBackgroundParams{Opacity: 1, Position: "center", Scale: 1, ScaleToFit: true},
+		},
+		{name: "image and color", req: models.CreateAddBackgroundRequest{BackgroundImageFileID: "img", Color: "#FFFFFF"}, wantErr: true},
+		{name: "neither image nor color", req: models.CreateAddBackgroundRequest{}, wantErr: true},
+		{name: "invalid color", req: models.CreateAddBackgroundRequest{Color: "white"}, wantErr: true},
+		{name: "unknown position", req: models.CreateAddBackgroundRequest{Color: "#FFFFFF", Position: "middle"}, wantErr: true},
+		{name: "invalid page range", req: models.CreateAddBackgroundRequest{Color: "#FFFFFF", PageRange: "a-b"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := backgroundParams(tt.req)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidBackground) {
+					t.Fatalf("err = %v, want ErrInvalidBackground", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("backgroundParams = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
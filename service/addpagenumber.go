@@ -2,11 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -17,56 +16,81 @@ import (
 
 type AddPageNumberService interface {
 	Create(ctx context.Context, req models.AddPageNumbersRequest, userID *string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.AddPageNumberJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type addPageNumberService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewAddPageNumberService(stg storage.IStorage, log logger.ILogger) AddPageNumberService {
-	return &addPageNumberService{stg: stg, log: log}
+func NewAddPageNumberService(stg storage.IStorage, log logger.ILogger, queue QueueService) AddPageNumberService {
+	return &addPageNumberService{stg: stg, log: log, queue: queue}
 }
 
 func (s *addPageNumberService) Create(ctx context.Context, req models.AddPageNumbersRequest, userID *string) (string, error) {
 	s.log.Info("AddPageNumberService.Create called")
 
 	// 1. Input faylni tekshirish
-	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
-	if err != nil {
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
 		s.log.Error("input file not found", logger.Error(err))
-		return "", fmt.Errorf("input file not found: %v", err)
+		return "", fmt.Errorf("input file not found: %w", err)
 	}
 
-	// 2. Job ID va output path yaratish
-	jobID := uuid.NewString()
-	outputFileID := uuid.NewString()
-	outputPath := filepath.Join("storage/add_page_numbers", outputFileID+".pdf")
+	// 2. Job yaratish va navbatga qo'yish
+	job, err := newJob(models.JobTypeAddPageNumbers, userID, []string{req.InputFileID}, req)
+	if err != nil {
+		return "", err
+	}
 
-	if err := os.MkdirAll(filepath.Dir(outputPath), os.ModePerm); err != nil {
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
 		return "", err
 	}
 
-	// 3. Job ma'lumotlarini saqlash
-	job := &models.AddPageNumberJob{
-		ID:          jobID,
-		UserID:      userID,
-		InputFileID: req.InputFileID,
-		Status:      "pending",
-		FirstNumber: req.FirstNumber,
-		PageRange:   req.PageRange,
-		Position:    req.Position,
-		Color:       req.Color,
-		FontSize:    req.FontSize,
-		CreatedAt:   time.Now(),
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan sahifa raqamlash jobini bajaradi
+func (s *addPageNumberService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.AddPageNumbersRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid add page numbers params: %w", err)
+	}
+
+	outputFileID, err := s.addPageNumbers(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputFileID}
+
+	s.log.Info("Add page number job completed", logger.String("jobID", job.ID))
+	return nil
+}
+
+func (s *addPageNumberService) addPageNumbers(ctx context.Context, job *models.Job, req models.AddPageNumbersRequest) (string, error) {
+	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
+	if err != nil {
+		s.log.Error("input file not found", logger.Error(err))
+		return "", fmt.Errorf("input file not found: %w", err)
+	}
+
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return "", err
 	}
+	defer cleanup()
 
-	if err := s.stg.AddPageNumber().Create(ctx, job); err != nil {
+	outputFileID := uuid.NewString()
+	outputPath := filepath.Join("storage/add_page_numbers", outputFileID+".pdf")
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), os.ModePerm); err != nil {
 		return "", err
 	}
 
-	// 4. CLI buyrug‘ini to‘g‘ri formatda tuzish
+	// CLI buyrug‘ini to‘g‘ri formatda tuzish
 	args := []string{
 		"stamp", "add",
 		"-mode", "text",
@@ -79,52 +103,24 @@ func (s *addPageNumberService) Create(ctx context.Context, req models.AddPageNum
 			req.Color,
 			req.FontSize,
 		),
-		file.FilePath,
+		inputPath,
 		outputPath,
 	}
 
-	// 5. Komandani ishga tushirish
-	cmd := exec.Command("pdfcpu", args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
+	// Komandani ishga tushirish
+	if err := runTool(ctx, outputPath, "pdfcpu", args...); err != nil {
 		s.log.Error("pdfcpu failed", logger.Error(err))
 		return "", err
 	}
 
-	// 6. Natijaviy faylni tekshirish va DBga saqlash
-	fi, err := os.Stat(outputPath)
-	if err != nil {
-		return "", err
-	}
-
-	newFile := models.File{
-		ID:         outputFileID,
-		UserID:     userID,
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/pdf",
-		FileSize:   fi.Size(),
-		UploadedAt: time.Now(),
-	}
-
-	if _, err := s.stg.File().Save(ctx, newFile); err != nil {
-		return "", err
-	}
-
-	// 7. Job holatini yangilash
-	job.OutputFileID = &outputFileID
-	job.Status = "done"
-
-	if err := s.stg.AddPageNumber().Update(ctx, job); err != nil {
+	// Natijaviy faylni DBga saqlash
+	if err := saveOutputFile(ctx, s.stg, outputFileID, job.UserID, outputPath, "application/pdf"); err != nil {
 		return "", err
 	}
 
-	s.log.Info("Add page number job completed", logger.String("jobID", jobID))
-	return jobID, nil
+	return outputFileID, nil
 }
 
-func (s *addPageNumberService) GetByID(ctx context.Context, id string) (*models.AddPageNumberJob, error) {
-	return s.stg.AddPageNumber().GetByID(ctx, id)
+func (s *addPageNumberService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	return getJobByType(ctx, s.stg, id, models.JobTypeAddPageNumbers)
 }
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"test/api/models"
+	"test/config"
+	createzipfromfiles "test/pkg/createZipFromFiles"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+// ErrInvalidBatch - batch so'rovi noto'g'ri (mijoz xatosi)
+var ErrInvalidBatch = errors.New("invalid batch")
+
+type BatchService interface {
+	Create(ctx context.Context, req models.CreateBatchRequest, userID *string) (string, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
+}
+
+type batchService struct {
+	stg   storage.IStorage
+	log   logger.ILogger
+	cfg   config.Config
+	queue QueueService
+}
+
+func NewBatchService(stg storage.IStorage, log logger.ILogger, cfg config.Config, queue QueueService) BatchService {
+	return &batchService{
+		stg:   stg,
+		log:   log,
+		cfg:   cfg,
+		queue: queue,
+	}
+}
+
+// Create - amal va fayllarni tekshiradi va batch jobni navbatga qo'yadi
+func (s *batchService) Create(ctx context.Context, req models.CreateBatchRequest, userID *string) (string, error) {
+	s.log.Info("BatchService.Create called", logger.String("type", req.Type), logger.Int("files", len(req.InputFileIDs)))
+
+	spec, err := validateStepParams(req.Type, req.Params, []string{placeholderFileID})
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidBatch, err)
+	}
+	if spec.inputField == "" || spec.multiInput {
+		return "", fmt.Errorf("%w: %s does not take a single input file", ErrInvalidBatch, req.Type)
+	}
+
+	for _, id := range req.InputFileIDs {
+		if _, err := s.stg.File().GetByID(ctx, id); err != nil {
+			return "", fmt.Errorf("%w: input file %s not found", ErrInvalidBatch, id)
+		}
+	}
+	if err := checkInputFiles(ctx, s.stg, req.Type, userID, req.InputFileIDs); err != nil {
+		return "", err
+	}
+
+	params := models.CreateBatchRequest{
+		Type:         req.Type,
+		Params:       req.Params,
+		InputFileIDs: req.InputFileIDs,
+		Zip:          req.Zip,
+	}
+
+	// Parol bazaga tushmasligi uchun params navbat payloadiga ko'chiriladi
+	var payload interface{}
+	if spec.secret {
+		payload = req.Params
+		params.Params = nil
+	}
+
+	// Rasm (suv belgisi) ham batch ning kiruvchi fayli: u batch tugaguncha o'chirilmasligi kerak
+	inputIDs := slices.Concat(req.InputFileIDs, jobAssetIDs(req.Type, req.Params))
+	job, err := newJob(models.JobTypeBatch, userID, inputIDs, params)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Submit(ctx, job, payload); err != nil {
+		s.log.Error("failed to submit batch job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+func (s *batchService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	return getJobByType(ctx, s.stg, id, models.JobTypeBatch)
+}
+
+// Process - har bir fayl uchun bola job yaratib, ularni cheklangan parallellik bilan bajaradi. Har bir bola job
+// foydalanuvchi va amal turi slotlarini egallaydi va o'z JobTimeout i bilan cheklanadi (runChildJob).
+func (s *batchService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.CreateBatchRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid batch params: %w", err)
+	}
+
+	spec, ok := jobSteps[req.Type]
+	if !ok {
+		return &JobError{Code: ErrCodeInvalidInput, Err: fmt.Errorf("unsupported batch type %q", req.Type)}
+	}
+
+	params := req.Params
+	if spec.secret {
+		if len(task.Payload) == 0 {
+			return &JobError{Code: ErrCodeInvalidInput, Err: fmt.Errorf("batch %s parameters are missing", req.Type)}
+		}
+		params = task.Payload
+	}
+
+	total := len(req.InputFileIDs)
+	result := models.BatchResult{
+		Total: total,
+		Items: make([]models.BatchItemResult, total),
+	}
+	for i, fileID := range req.InputFileIDs {
+		result.Items[i] = models.BatchItemResult{
+			InputFileID:   fileID,
+			Status:        models.Pending,
+			OutputFileIDs: []string{},
+		}
+	}
+
+	concurrency := s.cfg.BatchConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		completed int
+		sem       = make(chan struct{}, concurrency)
+	)
+
+loop:
+	for i, fileID := range req.InputFileIDs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break loop
+		}
+
+		wg.Add(1)
+		go func(i int, fileID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			item := s.runItem(ctx, job, req.Type, spec, params, fileID)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			result.Items[i] = item
+			switch item.Status {
+			case models.Done:
+				result.Done++
+			case models.Failed, models.Dead, models.Cancelled:
+				result.Failed++
+			}
+			completed++
+			s.saveProgress(ctx, job, result)
+			s.queue.Progress(ctx, job.ID, completed, total)
+		}(i, fileID)
+	}
+	wg.Wait()
+
+	// Tugagan fayllar natijalari jobga yoziladi: batch to'xtatilsa yoki qayta urinilsa, Execute ularni o'chiradi
+	var outputIDs []string
+	for _, item := range result.Items {
+		outputIDs = append(outputIDs, item.OutputFileIDs...)
+	}
+	job.OutputFileIDs = outputIDs
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if result.Done == 0 {
+		if err := setJobResult(job, result); err != nil {
+			return err
+		}
+		return batchError(result)
+	}
+
+	if req.Zip && len(outputIDs) > 0 {
+		// Batch ning umumiy vaqti cheklanmagan, ZIP yig'ish esa bitta job kabi cheklanadi
+		zipCtx, cancel := context.WithTimeout(ctx, s.cfg.JobTimeout)
+		zipID, err := s.zipOutputs(zipCtx, job, result)
+		cancel()
+		if err != nil {
+			return err
+		}
+		result.ZipFileID = &zipID
+	}
+
+	if err := setJobResult(job, result); err != nil {
+		return err
+	}
+
+	s.log.Info("batch completed", logger.String("jobID", job.ID), logger.Int("done", result.Done), logger.Int("failed", result.Failed))
+	return nil
+}
+
+func (s *batchService) runItem(ctx context.Context, job *models.Job, jobType string, spec jobStep, params json.RawMessage, fileID string) models.BatchItemResult {
+	item := models.BatchItemResult{
+		InputFileID:   fileID,
+		Status:        models.Failed,
+		OutputFileIDs: []string{},
+	}
+
+	itemParams, err := setStepInputs(params, spec, []string{fileID})
+	if err == nil {
+		var child *models.Job
+		if child, err = runChildJob(ctx, s.stg, s.queue, job, jobType, spec.secret, itemParams, []string{fileID}); err == nil {
+			item.JobID = child.ID
+			item.Status = child.Status
+			item.Result = child.Result
+			item.ErrorCode = child.ErrorCode
+			item.Error = child.Error
+			if child.Status == models.Done {
+				item.OutputFileIDs = child.OutputFileIDs
+			}
+			return item
+		}
+	}
+
+	msg := err.Error()
+	item.Error = &msg
+	return item
+}
+
+func (s *batchService) saveProgress(ctx context.Context, job *models.Job, result models.BatchResult) {
+	if err := setJobResult(job, result); err != nil {
+		s.log.Error("failed to encode batch progress", logger.String("jobID", job.ID), logger.Error(err))
+		return
+	}
+	if err := s.stg.Job().Update(ctx, job); err != nil {
+		s.log.Error("failed to save batch progress", logger.String("jobID", job.ID), logger.Error(err))
+	}
+}
+
+// zipOutputs - barcha natijalarni bitta ZIP ga yig'adi; fayllar kiruvchi fayl nomi bilan nomlanadi
+func (s *batchService) zipOutputs(ctx context.Context, job *models.Job, result models.BatchResult) (string, error) {
+	var entries []createzipfromfiles.Entry
+	used := make(map[string]bool)
+
+	// Natijalar blob storageda bo'lishi mumkin - ZIP yozilguncha lokal nusxalar saqlanadi
+	var cleanups []func()
+	defer func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+	}()
+
+	for i, item := range result.Items {
+		if len(item.OutputFileIDs) == 0 {
+			continue
+		}
+
+		stem := item.InputFileID
+		if input, err := s.stg.File().GetByID(ctx, item.InputFileID); err == nil {
+			stem = strings.TrimSuffix(input.FileName, filepath.Ext(input.FileName))
+		}
+
+		for _, outputID := range item.OutputFileIDs {
+			output, err := s.stg.File().GetByID(ctx, outputID)
+			if err != nil {
+				return "", fmt.Errorf("failed to get batch output %s: %w", outputID, err)
+			}
+
+			name := zipEntryName(used, i, stem, output.FilePath, len(item.OutputFileIDs) > 1)
+
+			localPath, cleanup, err := fetchInput(ctx, s.stg, output)
+			if err != nil {
+				return "", err
+			}
+			cleanups = append(cleanups, cleanup)
+
+			entries = append(entries, createzipfromfiles.Entry{Path: localPath, Name: name})
+		}
+	}
+
+	if err := os.MkdirAll("storage/batch", os.ModePerm); err != nil {
+		return "", fmt.Errorf("failed to create batch directory: %w", err)
+	}
+
+	zipID := uuid.NewString()
+	zipPath := filepath.Join("storage/batch", job.ID+".zip")
+
+	zipFile, err := os.Create(zipPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create zip file: %w", err)
+	}
+	err = createzipfromfiles.CreateZipFromEntries(zipFile, entries)
+	if closeErr := zipFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(zipPath)
+		return "", fmt.Errorf("failed to write batch zip: %w", err)
+	}
+
+	if err := saveOutputFile(ctx, s.stg, zipID, job.UserID, zipPath, "application/zip"); err != nil {
+		return "", err
+	}
+	return zipID, nil
+}
+
+// zipEntryName - bitta natija "<asl nom>.<kengaytma>", bir nechta natija "<asl nom>/<fayl>".
+// Nom band bo'lsa (bir xil nomli kiruvchi fayllar) oldiga fayl tartib raqami qo'shiladi.
+func zipEntryName(used map[string]bool, index int, stem, outputPath string, multiple bool) string {
+	name := stem + filepath.Ext(outputPath)
+	if multiple {
+		name = stem + "/" + filepath.Base(outputPath)
+	}
+	if used[name] {
+		name = fmt.Sprintf("%d_%s", index+1, name)
+	}
+	used[name] = true
+	return name
+}
+
+// batchError - hamma fayl muvaffaqiyatsiz bo'lsa; barchasi vaqtinchalik xato bo'lsa, batch qayta urinadi
+func batchError(result models.BatchResult) *JobError {
+	transient := true
+	code := ErrCodeProcessingFailed
+	for _, item := range result.Items {
+		if item.Status != models.Dead {
+			transient = false
+		}
+		if item.ErrorCode != nil {
+			code = *item.ErrorCode
+		}
+	}
+
+	return &JobError{
+		Code:      code,
+		Transient: transient,
+		Err:       fmt.Errorf("all %d files failed", result.Total),
+	}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"test/api/models"
+	"test/config"
+)
+
+func newTestBatch(t *testing.T, stg *fakeStorage, cfg config.Config) (*batchService, *queueService) {
+	t.Helper()
+	q, _ := newTestQueue(t, stg, cfg)
+	return NewBatchService(stg, nopLogger{}, cfg, q).(*batchService), q
+}
+
+func batchJob(t *testing.T, req models.CreateBatchRequest) *models.Job {
+	t.Helper()
+	params, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &models.Job{ID: "batch-1", Type: models.JobTypeBatch, Params: params, Status: models.Processing}
+}
+
+func TestBatchStoppedKeepsFinishedOutputs(t *testing.T) {
+	stg := newFakeStorage()
+	s, q := newTestBatch(t, stg, config.Config{BatchConcurrency: 1, JobTimeout: time.Minute})
+	stg.files.put(models.File{ID: "in-a", FileType: mimePDF})
+	stg.files.put(models.File{ID: "in-b", FileType: mimePDF})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Birinchi fayl tugaydi, ikkinchisida batch to'xtatiladi
+	q.Register(models.JobTypeCompress, func(ctx context.Context, job *models.Job, _ models.QueueTask) error {
+		if job.InputFileIDs[0] == "in-a" {
+			job.OutputFileIDs = []string{"out-a"}
+			return nil
+		}
+		cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	job := batchJob(t, models.CreateBatchRequest{Type: models.JobTypeCompress, Params: json.RawMessage(`{}`), InputFileIDs: []string{"in-a", "in-b"}})
+	if err := s.Process(ctx, job, models.QueueTask{JobID: job.ID}); err == nil {
+		t.Fatal("Process succeeded after the batch was stopped")
+	}
+
+	// Execute to'xtatilgan batch ning natijalarini o'chira olishi uchun ular jobda bo'lishi kerak
+	if !slices.Equal(job.OutputFileIDs, []string{"out-a"}) {
+		t.Errorf("batch outputs = %v, want [out-a]", job.OutputFileIDs)
+	}
+}
+
+func TestBatchPartialFailure(t *testing.T) {
+	stg := newFakeStorage()
+	s, q := newTestBatch(t, stg, config.Config{BatchConcurrency: 2, JobTimeout: time.Minute})
+	stg.files.put(models.File{ID: "in-a", FileType: mimePDF})
+	stg.files.put(models.File{ID: "in-b", FileType: mimePDF})
+
+	q.Register(models.JobTypeCompress, func(_ context.Context, job *models.Job, _ models.QueueTask) error {
+		if job.InputFileIDs[0] == "in-b" {
+			return &JobError{Code: ErrCodeInvalidInput, Err: errors.New("broken pdf")}
+		}
+		job.OutputFileIDs = []string{"out-a"}
+		return nil
+	})
+
+	job := batchJob(t, models.CreateBatchRequest{Type: models.JobTypeCompress, Params: json.RawMessage(`{"compression":"high"}`), InputFileIDs: []string{"in-a", "in-b"}})
+	if err := s.Process(context.Background(), job, models.QueueTask{JobID: job.ID}); err != nil {
+		t.Fatalf("Process: %v (one failed file must not fail the batch)", err)
+	}
+
+	var result models.BatchResult
+	if err := json.Unmarshal(job.Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 2 || result.Done != 1 || result.Failed != 1 {
+		t.Errorf("result = total %d, done %d, failed %d; want 2, 1, 1", result.Total, result.Done, result.Failed)
+	}
+	if item := result.Items[1]; item.Status != models.Failed || item.ErrorCode == nil || *item.ErrorCode != ErrCodeInvalidInput {
+		t.Errorf("failed item = %+v", item)
+	}
+	if !slices.Equal(job.OutputFileIDs, []string{"out-a"}) {
+		t.Errorf("batch outputs = %v, want [out-a]", job.OutputFileIDs)
+	}
+}
+
+func TestBatchAllFailed(t *testing.T) {
+	tests := []struct {
+		name          string
+		errs          map[string]error
+		wantTransient bool
+	}{
+		{
+			name: "all transient",
+			errs: map[string]error{
+				"in-a": &JobError{Code: ErrCodeUpstreamUnavailable, Transient: true, Err: errors.New("gotenberg down")},
+				"in-b": &JobError{Code: ErrCodeUpstreamUnavailable, Transient: true, Err: errors.New("gotenberg down")},
+			},
+			wantTransient: true,
+		},
+		{
+			name: "one permanent",
+			errs: map[string]error{
+				"in-a": &JobError{Code: ErrCodeUpstreamUnavailable, Transient: true, Err: errors.New("gotenberg down")},
+				"in-b": &JobError{Code: ErrCodeInvalidInput, Err: errors.New("broken pdf")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stg := newFakeStorage()
+			s, q := newTestBatch(t, stg, config.Config{BatchConcurrency: 1, JobTimeout: time.Minute})
+			stg.files.put(models.File{ID: "in-a", FileType: mimePDF})
+			stg.files.put(models.File{ID: "in-b", FileType: mimePDF})
+
+			q.Register(models.JobTypeCompress, func(_ context.Context, job *models.Job, _ models.QueueTask) error {
+				return tt.errs[job.InputFileIDs[0]]
+			})
+
+			job := batchJob(t, models.CreateBatchRequest{Type: models.JobTypeCompress, Params: json.RawMessage(`{"compression":"high"}`), InputFileIDs: []string{"in-a", "in-b"}})
+			err := s.Process(context.Background(), job, models.QueueTask{JobID: job.ID})
+
+			// Fayllar bittadan urinadi (dead); hammasi vaqtinchalik bo'lsagina batch butunlay qayta urinadi
+			var jobErr *JobError
+			if !errors.As(err, &jobErr) {
+				t.Fatalf("err = %v, want *JobError", err)
+			}
+			if jobErr.Transient != tt.wantTransient {
+				t.Errorf("transient = %v, want %v", jobErr.Transient, tt.wantTransient)
+			}
+			if len(job.Result) == 0 {
+				t.Error("per-file results must be kept when every file failed")
+			}
+		})
+	}
+}
+
+func TestZipEntryName(t *testing.T) {
+	used := map[string]bool{}
+	tests := []struct {
+		index      int
+		stem, path string
+		multiple   bool
+		want       string
+	}{
+		{0, "report", "blobs/ab/abc123.pdf", false, "report.pdf"},
+		{1, "scan", "blobs/cd/page_1.jpg", true, "scan/page_1.jpg"},
+		{1, "scan", "blobs/cd/page_2.jpg", true, "scan/page_2.jpg"},
+		// Bir xil nomli ikkinchi kiruvchi fayl
+		{2, "report", "blobs/ef/def456.pdf", false, "3_report.pdf"},
+	}
+	for _, tt := range tests {
+		if got := zipEntryName(used, tt.index, tt.stem, tt.path, tt.multiple); got != tt.want {
+			t.Errorf("zipEntryName(%d, %q, %q) = %q, want %q", tt.index, tt.stem, tt.path, got, tt.want)
+		}
+	}
+}
@@ -2,10 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/pdfcpu/pdfcpu/pkg/api"
@@ -18,18 +18,21 @@ import (
 
 type CompressService interface {
 	Create(ctx context.Context, req models.CompressRequest, userID *string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.CompressJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type compressService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewCompressService(stg storage.IStorage, log logger.ILogger) CompressService {
+func NewCompressService(stg storage.IStorage, log logger.ILogger, queue QueueService) CompressService {
 	return &compressService{
-		stg: stg,
-		log: log,
+		stg:   stg,
+		log:   log,
+		queue: queue,
 	}
 }
 func (s *compressService) Create(ctx context.Context, req models.CompressRequest, userID *string) (string, error) {
@@ -43,30 +46,58 @@ func (s *compressService) Create(ctx context.Context, req models.CompressRequest
 	}
 
 	// Ensure the file exists before proceeding
-	if _, err := os.Stat(file.FilePath); os.IsNotExist(err) {
-		s.log.Error("Input file does not exist", logger.String("filePath", file.FilePath))
+	if _, err := s.stg.Blob().Stat(ctx, file.FilePath); err != nil {
+		s.log.Error("Input file does not exist", logger.String("filePath", file.FilePath), logger.Error(err))
 		return "", fmt.Errorf("input file does not exist: %s", file.FilePath)
 	}
 
-	// 2. Job yaratish
-	job := &models.CompressJob{
-		ID:               uuid.NewString(),
-		UserID:           userID, // Correctly pass the userID (nil for guest users)
-		InputFileID:      req.InputFileID,
-		CompressionLevel: req.Compression, // Correctly pass compression level
-		Status:           "pending",
-		CreatedAt:        time.Now(),
+	// 2. Job yaratish va navbatga qo'yish
+	job, err := newJob(models.JobTypeCompress, userID, []string{req.InputFileID}, req)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("Failed to submit compress job", logger.Error(err))
+		return "", err
+	}
+
+	s.log.Info("Compress job queued", logger.String("jobID", job.ID))
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan compress jobni bajaradi
+func (s *compressService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.CompressRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid compress params: %w", err)
 	}
 
-	// Handle output_file_id as nil initially
-	job.OutputFileID = nil
+	outputID, err := s.compress(ctx, job, req)
+	if err != nil {
+		return err
+	}
 
-	if err := s.stg.Compress().Create(ctx, job); err != nil {
-		s.log.Error("Failed to create compress job", logger.Error(err))
+	job.OutputFileIDs = []string{outputID}
+
+	s.log.Info("Compress job completed", logger.String("jobID", job.ID))
+	return nil
+}
+
+func (s *compressService) compress(ctx context.Context, job *models.Job, req models.CompressRequest) (string, error) {
+	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
+	if err != nil {
+		s.log.Error("Input file not found", logger.String("fileID", req.InputFileID), logger.Error(err))
+		return "", err
+	}
+
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
 		return "", err
 	}
+	defer cleanup()
 
-	// 3. Output fayl yo‘lini tayyorlash
+	// 1. Output fayl yo‘lini tayyorlash
 	outputID := uuid.NewString()
 	outputDir := "storage/compress"
 	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
@@ -75,53 +106,27 @@ func (s *compressService) Create(ctx context.Context, req models.CompressRequest
 	}
 	outputPath := filepath.Join(outputDir, outputID+".pdf")
 
-	// 4. Pdfcpu konfiguratsiyasi
+	// 2. Pdfcpu konfiguratsiyasi
 	conf := model.NewDefaultConfiguration()
 	conf.Cmd = model.OPTIMIZE
 
-	// 5. Faylni siqish (strukturaviy optimallashtirish)
-	if err := api.OptimizeFile(file.FilePath, outputPath, conf); err != nil {
+	// 3. Faylni siqish (strukturaviy optimallashtirish)
+	if err := api.OptimizeFile(inputPath, outputPath, conf); err != nil {
 		s.log.Error("pdfcpu optimize failed", logger.Error(err))
 		return "", err
 	}
 
-	// 6. Natijaviy faylni sistemaga saqlash
-	fi, err := os.Stat(outputPath)
-	if err != nil {
-		s.log.Error("Cannot stat output file", logger.Error(err))
-		return "", err
-	}
-
-	newFile := models.File{
-		ID:         outputID,
-		UserID:     userID,
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/pdf",
-		FileSize:   fi.Size(),
-		UploadedAt: time.Now(),
-	}
-
-	if _, err := s.stg.File().Save(ctx, newFile); err != nil {
+	// 4. Natijaviy faylni sistemaga saqlash
+	if err := saveOutputFile(ctx, s.stg, outputID, job.UserID, outputPath, "application/pdf"); err != nil {
 		s.log.Error("Failed to save output file", logger.Error(err))
 		return "", err
 	}
 
-	// 7. Jobni update qilish
-	job.OutputFileID = &outputID
-	job.Status = "done"
-
-	if err := s.stg.Compress().Update(ctx, job); err != nil {
-		s.log.Error("Failed to update compress job", logger.Error(err))
-		return "", err
-	}
-
-	s.log.Info("Compress job completed", logger.String("jobID", job.ID))
-	return job.ID, nil
+	return outputID, nil
 }
 
-func (s *compressService) GetByID(ctx context.Context, id string) (*models.CompressJob, error) {
-	job, err := s.stg.Compress().GetByID(ctx, id)
+func (s *compressService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	job, err := getJobByType(ctx, s.stg, id, models.JobTypeCompress)
 	if err != nil {
 		s.log.Error("Failed to get compress job", logger.Error(err))
 		return nil, err
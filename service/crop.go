@@ -2,11 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -17,103 +16,101 @@ import (
 
 type CropPDFService interface {
 	Create(ctx context.Context, req models.CropPDFRequest, userID *string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.CropPDFJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type cropPDFService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewCropPDFService(stg storage.IStorage, log logger.ILogger) CropPDFService {
-	return &cropPDFService{stg: stg, log: log}
+func NewCropPDFService(stg storage.IStorage, log logger.ILogger, queue QueueService) CropPDFService {
+	return &cropPDFService{stg: stg, log: log, queue: queue}
 }
 
 func (s *cropPDFService) Create(ctx context.Context, req models.CropPDFRequest, userID *string) (string, error) {
 	s.log.Info("CropPDFService.Create called")
 
-	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
-	if err != nil {
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
 		s.log.Error("Input file not found", logger.Error(err))
-		return "", fmt.Errorf("input file not found: %v", err)
+		return "", fmt.Errorf("input file not found: %w", err)
 	}
 
-	jobID := uuid.NewString()
-	outputFileID := uuid.NewString()
-	outputPath := filepath.Join("storage/crop_pdfs", outputFileID+".pdf")
-
-	if err := os.MkdirAll(filepath.Dir(outputPath), os.ModePerm); err != nil {
+	job, err := newJob(models.JobTypeCrop, userID, []string{req.InputFileID}, req)
+	if err != nil {
 		return "", err
 	}
 
-	job := &models.CropPDFJob{
-		ID:          jobID,
-		UserID:      userID,
-		InputFileID: req.InputFileID,
-		Top:         req.Top,
-		Bottom:      req.Bottom,
-		Left:        req.Left,
-		Right:       req.Right,
-		Box:         req.Box,
-		Pages:       req.Pages,
-		Status:      "pending",
-		CreatedAt:   time.Now(),
-	}
-
-	if err := s.stg.Crop().Create(ctx, job); err != nil {
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
 		return "", err
 	}
 
-	// ✅ To‘g‘rilangan crop description
-	cropDesc := fmt.Sprintf("%d %d %d %d", req.Top, req.Right, req.Bottom, req.Left)
+	return job.ID, nil
+}
 
-	args := []string{"crop"}
+// Process - navbatdan olingan crop jobni bajaradi
+func (s *cropPDFService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.CropPDFRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid crop params: %w", err)
+	}
 
-	if req.Pages != "" {
-		args = append(args, "-pages", req.Pages)
+	outputFileID, err := s.crop(ctx, job, req)
+	if err != nil {
+		return err
 	}
 
-	args = append(args, "--", cropDesc, file.FilePath, outputPath)
+	job.OutputFileIDs = []string{outputFileID}
 
-	cmd := exec.Command("pdfcpu", args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	s.log.Info("Crop job completed", logger.String("jobID", job.ID))
+	return nil
+}
 
-	if err := cmd.Run(); err != nil {
-		s.log.Error("pdfcpu crop failed", logger.Error(err))
-		return "", err
+func (s *cropPDFService) crop(ctx context.Context, job *models.Job, req models.CropPDFRequest) (string, error) {
+	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
+	if err != nil {
+		s.log.Error("Input file not found", logger.Error(err))
+		return "", fmt.Errorf("input file not found: %w", err)
 	}
 
-	fi, err := os.Stat(outputPath)
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
 	if err != nil {
 		return "", err
 	}
+	defer cleanup()
 
-	newFile := models.File{
-		ID:         outputFileID,
-		UserID:     userID,
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/pdf",
-		FileSize:   fi.Size(),
-		UploadedAt: time.Now(),
-	}
+	outputFileID := uuid.NewString()
+	outputPath := filepath.Join("storage/crop_pdfs", outputFileID+".pdf")
 
-	if _, err := s.stg.File().Save(ctx, newFile); err != nil {
+	if err := os.MkdirAll(filepath.Dir(outputPath), os.ModePerm); err != nil {
 		return "", err
 	}
 
-	job.OutputFileID = &outputFileID
-	job.Status = "done"
+	// ✅ To‘g‘rilangan crop description
+	cropDesc := fmt.Sprintf("%d %d %d %d", req.Top, req.Right, req.Bottom, req.Left)
+
+	args := []string{"crop"}
+
+	if req.Pages != "" {
+		args = append(args, "-pages", req.Pages)
+	}
+
+	args = append(args, "--", cropDesc, inputPath, outputPath)
+
+	if err := runTool(ctx, outputPath, "pdfcpu", args...); err != nil {
+		s.log.Error("pdfcpu crop failed", logger.Error(err))
+		return "", err
+	}
 
-	if err := s.stg.Crop().Update(ctx, job); err != nil {
+	if err := saveOutputFile(ctx, s.stg, outputFileID, job.UserID, outputPath, "application/pdf"); err != nil {
 		return "", err
 	}
 
-	s.log.Info("Crop job completed", logger.String("jobID", jobID))
-	return jobID, nil
+	return outputFileID, nil
 }
 
-func (s *cropPDFService) GetByID(ctx context.Context, id string) (*models.CropPDFJob, error) {
-	return s.stg.Crop().GetByID(ctx, id)
+func (s *cropPDFService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	return getJobByType(ctx, s.stg, id, models.JobTypeCrop)
 }
@@ -3,9 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
-	"time"
 
-	"github.com/google/uuid"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
 
 	"test/api/models"
 	"test/pkg/detectblank"
@@ -15,58 +14,78 @@ import (
 
 type DetectBlankService interface {
 	Create(ctx context.Context, inputFileID string, userID string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.DetectBlankPagesJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type detectBlankService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewDetectBlankService(stg storage.IStorage, log logger.ILogger) DetectBlankService {
-	return &detectBlankService{stg: stg, log: log}
+func NewDetectBlankService(stg storage.IStorage, log logger.ILogger, queue QueueService) DetectBlankService {
+	return &detectBlankService{stg: stg, log: log, queue: queue}
 }
 
 func (s *detectBlankService) Create(ctx context.Context, inputFileID string, userID string) (string, error) {
 	s.log.Info("DetectBlankService.Create called")
 
-	file, err := s.stg.File().GetByID(ctx, inputFileID)
-	if err != nil {
+	if _, err := s.stg.File().GetByID(ctx, inputFileID); err != nil {
 		return "", fmt.Errorf("input file not found")
 	}
 
-	jobID := uuid.New().String()
-	job := &models.DetectBlankPagesJob{
-		ID:          jobID,
-		UserID:      userID,
-		InputFileID: inputFileID,
-		Status:      "pending",
-		CreatedAt:   time.Now(),
+	var owner *string
+	if userID != "" {
+		owner = &userID
 	}
 
-	if err := s.stg.DetectBlankPages().Create(ctx, job); err != nil {
+	job, err := newJob(models.JobTypeDetectBlank, owner, []string{inputFileID}, models.DetectBlankPagesRequest{InputFileID: inputFileID})
+	if err != nil {
 		return "", err
 	}
 
-	blankPages, err := detectblank.DetectBlankPages(file.FilePath, 10) // masalan, 10 — minimal matn uzunligi
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		return "", err
+	}
 
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan bo'sh sahifalarni aniqlash jobini bajaradi
+func (s *detectBlankService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	if len(job.InputFileIDs) == 0 {
+		return fmt.Errorf("detect blank job has no input file")
+	}
+
+	file, err := s.stg.File().GetByID(ctx, job.InputFileIDs[0])
 	if err != nil {
-		s.log.Error("failed to detect blank pages", logger.Error(err))
-		job.Status = "failed"
-		_ = s.stg.DetectBlankPages().Update(ctx, job)
-		return "", err
+		return fmt.Errorf("input file not found: %w", err)
 	}
 
-	job.BlankPages = blankPages
-	job.Status = "done"
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
 
-	if err := s.stg.DetectBlankPages().Update(ctx, job); err != nil {
-		return "", err
+	pageCount, err := api.PageCountFile(inputPath)
+	if err != nil {
+		s.log.Error("failed to read PDF page count", logger.Error(err))
+		return fmt.Errorf("failed to read page count: %w", err)
+	}
+
+	blankPages, err := detectblank.DetectBlankPagesContext(ctx, inputPath, pageCount, func(page, total int) {
+		s.queue.Progress(ctx, job.ID, page, total)
+	})
+	if err != nil {
+		s.log.Error("failed to detect blank pages", logger.Error(err))
+		return err
 	}
 
-	return jobID, nil
+	return setJobResult(job, models.DetectBlankPagesResult{BlankPages: blankPages})
 }
 
-func (s *detectBlankService) GetByID(ctx context.Context, id string) (*models.DetectBlankPagesJob, error) {
-	return s.stg.DetectBlankPages().GetByID(ctx, id)
+func (s *detectBlankService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	return getJobByType(ctx, s.stg, id, models.JobTypeDetectBlank)
 }
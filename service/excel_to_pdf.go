@@ -2,9 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -16,40 +17,83 @@ import (
 
 type ExcelToPDFService interface {
 	Create(ctx context.Context, req models.ExcelToPDFRequest, userID *string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.ExcelToPDFJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type excelToPDFService struct {
 	stg       storage.IStorage
 	log       logger.ILogger
 	gotClient gotenberg.Client
+	queue     QueueService
 }
 
-func NewExcelToPDFService(stg storage.IStorage, log logger.ILogger, gotClient gotenberg.Client) ExcelToPDFService {
+func NewExcelToPDFService(stg storage.IStorage, log logger.ILogger, gotClient gotenberg.Client, queue QueueService) ExcelToPDFService {
 	return &excelToPDFService{
 		stg:       stg,
 		log:       log,
 		gotClient: gotClient,
+		queue:     queue,
 	}
 }
 
 func (s *excelToPDFService) Create(ctx context.Context, req models.ExcelToPDFRequest, userID *string) (string, error) {
 	s.log.Info("ExcelToPDFService.Create called")
 
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
+		s.log.Error("input file not found", logger.Error(err))
+		return "", err
+	}
+
+	job, err := newJob(models.JobTypeExcelToPDF, userID, []string{req.InputFileID}, req)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("failed to submit excel to pdf job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan konvertatsiya jobini bajaradi
+func (s *excelToPDFService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.ExcelToPDFRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid excel to pdf params: %w", err)
+	}
+
+	outputFileID, err := s.convert(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputFileID}
+	return nil
+}
+
+func (s *excelToPDFService) convert(ctx context.Context, job *models.Job, req models.ExcelToPDFRequest) (string, error) {
 	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
 	if err != nil {
 		s.log.Error("input file not found", logger.Error(err))
 		return "", err
 	}
 
-	// 📥 Excelni PDFga aylantirish
-	resultBytes, err := s.gotClient.WordToPDF(ctx, file.FilePath)
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	// 🔥 Gotenbergdan PDF faylni olish
+	resultBytes, err := s.gotClient.WordToPDF(ctx, inputPath)
 	if err != nil {
 		s.log.Error("Gotenberg conversion failed", logger.Error(err))
 		return "", err
 	}
 
-	jobID := uuid.NewString()
 	outputFileID := uuid.NewString()
 	outputPath := filepath.Join("storage/excel_to_pdf", outputFileID+".pdf")
 
@@ -60,40 +104,16 @@ func (s *excelToPDFService) Create(ctx context.Context, req models.ExcelToPDFReq
 		return "", err
 	}
 
-	fi, _ := os.Stat(outputPath)
-
-	newFile := models.File{
-		ID:         outputFileID,
-		UserID:     userID,
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/pdf",
-		FileSize:   fi.Size(),
-		UploadedAt: time.Now(),
-	}
-	if _, err := s.stg.File().Save(ctx, newFile); err != nil {
+	if err := saveOutputFile(ctx, s.stg, outputFileID, job.UserID, outputPath, "application/pdf"); err != nil {
 		s.log.Error("failed to save output file", logger.Error(err))
 		return "", err
 	}
 
-	job := &models.ExcelToPDFJob{
-		ID:           jobID,
-		UserID:       userID,
-		InputFileID:  req.InputFileID,
-		OutputFileID: &outputFileID,
-		Status:       "done",
-		CreatedAt:    time.Now(),
-	}
-	if err := s.stg.ExcelToPDF().Create(ctx, job); err != nil {
-		s.log.Error("failed to save job", logger.Error(err))
-		return "", err
-	}
-
-	return jobID, nil
+	return outputFileID, nil
 }
 
-func (s *excelToPDFService) GetByID(ctx context.Context, id string) (*models.ExcelToPDFJob, error) {
-	job, err := s.stg.ExcelToPDF().GetByID(ctx, id)
+func (s *excelToPDFService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	job, err := getJobByType(ctx, s.stg, id, models.JobTypeExcelToPDF)
 	if err != nil {
 		s.log.Error("excelToPDF job not found", logger.Error(err))
 		return nil, err
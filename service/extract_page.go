@@ -2,12 +2,12 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/pdfcpu/pdfcpu/pkg/api"
@@ -20,63 +20,99 @@ import (
 
 type ExtractPageService interface {
 	Create(ctx context.Context, req models.ExtractPagesRequest, userID *string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.ExtractJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type extractPageService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewExtractService(stg storage.IStorage, log logger.ILogger) ExtractPageService {
+func NewExtractService(stg storage.IStorage, log logger.ILogger, queue QueueService) ExtractPageService {
 	return &extractPageService{
-		stg: stg,
-		log: log,
+		stg:   stg,
+		log:   log,
+		queue: queue,
 	}
 }
 func (s *extractPageService) Create(ctx context.Context, req models.ExtractPagesRequest, userID *string) (string, error) {
 	s.log.Info("ExtractService.Create called")
 
+	// 1. Faylni tekshirish
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
+		s.log.Error("input file not found", logger.Error(err))
+		return "", err
+	}
+
+	// 2. Job yaratish va navbatga qo'yish
+	job, err := newJob(models.JobTypeExtractPages, userID, []string{req.InputFileID}, req)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("failed to submit extract job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan extract jobni bajaradi
+func (s *extractPageService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.ExtractPagesRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid extract params: %w", err)
+	}
+
+	outputIDs, err := s.extract(ctx, job, req)
+	if err != nil {
+		return err
+	}
+	if len(outputIDs) == 0 {
+		return fmt.Errorf("no pages were extracted")
+	}
+
+	job.OutputFileIDs = outputIDs
+
+	s.log.Info("extract job finished", logger.String("jobID", job.ID), logger.Int("files", len(outputIDs)))
+	return nil
+}
+
+func (s *extractPageService) extract(ctx context.Context, job *models.Job, req models.ExtractPagesRequest) ([]string, error) {
 	// 1. Faylni olish
 	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
 	if err != nil {
 		s.log.Error("input file not found", logger.Error(err))
-		return "", err
+		return nil, err
+	}
+
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return nil, err
 	}
-	s.log.Info("input file path", logger.String("filePath", file.FilePath))
+	defer cleanup()
+	s.log.Info("input file path", logger.String("filePath", inputPath))
 
 	// 2. PDF sahifa sonini olish
-	pdfCtx, err := api.ReadContextFile(file.FilePath)
+	pdfCtx, err := api.ReadContextFile(inputPath)
 	if err != nil {
 		s.log.Error("failed to read PDF context", logger.Error(err))
-		return "", err
+		return nil, err
 	}
 	totalPages := pdfCtx.PageCount
 	s.log.Info("PDF page count", logger.Int("pageCount", totalPages))
 
-	// 3. Job yaratish
-	jobID := uuid.NewString()
-	job := &models.ExtractJob{
-		ID:             jobID,
-		UserID:         userID,
-		InputFileID:    req.InputFileID,
-		PagesToExtract: req.PageRanges,
-		Status:         "pending",
-		CreatedAt:      time.Now(),
-	}
-	if err := s.stg.ExtractPage().Create(ctx, job); err != nil {
-		s.log.Error("failed to create extract job", logger.Error(err))
-		return "", err
-	}
-
-	// 4. Output papkani yaratish
+	// 3. Output papkani yaratish
 	outputDir := filepath.Join("storage/extract", job.ID)
 	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
 		s.log.Error("failed to create output dir", logger.Error(err))
-		return "", err
+		return nil, err
 	}
 
-	// 5. Sahifa raqamlarini ajratish
+	// 4. Sahifa raqamlarini ajratish
 	var pages []string
 	for _, r := range strings.Split(req.PageRanges, ",") {
 		r = strings.TrimSpace(r)
@@ -101,81 +137,47 @@ func (s *extractPageService) Create(ctx context.Context, req models.ExtractPages
 		}
 	}
 
-	// 6. Agar sahifa topilmagan bo‘lsa
+	// 5. Agar sahifa topilmagan bo‘lsa
 	if len(pages) == 0 {
-		job.Status = "failed"
-		_ = s.stg.ExtractPage().Update(ctx, job)
 		s.log.Error("no valid pages were extracted", logger.String("jobID", job.ID))
-		return job.ID, nil
+		return nil, fmt.Errorf("no valid pages to extract: %s", req.PageRanges)
 	}
 
-	// 7. Sahifalarni chiqarish
-	err = api.ExtractPagesFile(file.FilePath, outputDir, pages, model.NewDefaultConfiguration())
+	// 6. Sahifalarni chiqarish
+	err = api.ExtractPagesFile(inputPath, outputDir, pages, model.NewDefaultConfiguration())
 	if err != nil {
 		s.log.Error("pdfcpu extract failed", logger.Error(err))
-		job.Status = "failed"
-		_ = s.stg.ExtractPage().Update(ctx, job)
-		return job.ID, nil
+		return nil, err
 	}
 
-	// 8. Fayllarni saqlash
+	// 7. Fayllarni saqlash
 	files, err := os.ReadDir(outputDir)
 	if err != nil {
 		s.log.Error("cannot read output dir", logger.Error(err))
-		job.Status = "failed"
-		_ = s.stg.ExtractPage().Update(ctx, job)
-		return job.ID, nil
+		return nil, err
 	}
 
-	var firstOutputFileID *string
+	var outputIDs []string
 	for _, f := range files {
 		if f.IsDir() || !strings.HasSuffix(f.Name(), ".pdf") {
 			continue
 		}
 
 		fullPath := filepath.Join(outputDir, f.Name())
-		fi, err := os.Stat(fullPath)
-		if err != nil {
-			s.log.Error("cannot stat extracted file", logger.Error(err))
-			continue
-		}
-
-		newFile := models.File{
-			ID:         uuid.NewString(),
-			UserID:     userID,
-			FileName:   f.Name(),
-			FilePath:   fullPath,
-			FileType:   "application/pdf",
-			FileSize:   fi.Size(),
-			UploadedAt: time.Now(),
-		}
-
-		fileID, err := s.stg.File().Save(ctx, newFile)
-		if err != nil {
+		fileID := uuid.NewString()
+		if err := saveOutputFile(ctx, s.stg, fileID, job.UserID, fullPath, "application/pdf"); err != nil {
 			s.log.Error("failed to save extracted file", logger.Error(err))
 			continue
 		}
 
-		if firstOutputFileID == nil {
-			firstOutputFileID = &fileID
-		}
+		outputIDs = append(outputIDs, fileID)
 	}
 
-	// 9. Job holatini yangilash
-	if firstOutputFileID != nil {
-		job.Status = "done"
-		job.OutputFileID = firstOutputFileID
-	} else {
-		job.Status = "failed"
-	}
-	_ = s.stg.ExtractPage().Update(ctx, job)
-
-	s.log.Info("extract job finished", logger.String("jobID", job.ID), logger.String("status", job.Status))
-	return job.ID, nil
+	return outputIDs, nil
 }
 
-func (s *extractPageService) GetByID(ctx context.Context, id string) (*models.ExtractJob, error) {
-	job, err := s.stg.ExtractPage().GetByID(ctx, id)
+func (s *extractPageService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	job, err := getJobByType(ctx, s.stg, id, models.JobTypeExtractPages)
 	if err != nil {
 		s.log.Error("failed to get extract job", logger.Error(err))
 		return nil, err
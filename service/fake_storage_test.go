@@ -0,0 +1,851 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"test/api/models"
+	"test/pkg/blobstore"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+// Testlar uchun xotiradagi storage. Faqat testlarda ishlatiladigan metodlar yozilgan;
+// qolganlari ichki interfeys (nil) orqali chaqirilsa panic beradi.
+
+type nopLogger struct{}
+
+func (nopLogger) Info(string, ...logger.Field)    {}
+func (nopLogger) Error(string, ...logger.Field)   {}
+func (nopLogger) Warning(string, ...logger.Field) {}
+
+type fakeStorage struct {
+	storage.IStorage
+	redis      *fakeRedis
+	jobs       *fakeJobs
+	files      *fakeFiles
+	deliveries *fakeDeliveries
+	blobs      *fakeBlobs
+	uploads    *fakeUploads
+	fileBlobs  *fakeFileBlobs
+	quotas     *fakeQuotas
+	folders    *fakeFolders
+}
+
+func newFakeStorage() *fakeStorage {
+	jobs := &fakeJobs{jobs: map[string]*models.Job{}}
+	return &fakeStorage{
+		redis:      newFakeRedis(),
+		jobs:       jobs,
+		files:      &fakeFiles{files: map[string]models.File{}, links: map[string][]*time.Time{}, jobs: jobs},
+		deliveries: &fakeDeliveries{},
+		blobs:      &fakeBlobs{data: map[string][]byte{}},
+		uploads:    &fakeUploads{uploads: map[string]*models.Upload{}},
+		fileBlobs:  &fakeFileBlobs{refs: map[string]int{}},
+		quotas:     &fakeQuotas{quotas: map[string]models.UserQuota{}, usage: map[string]models.StorageUsage{}},
+		folders:    &fakeFolders{folders: map[string]models.Folder{}},
+	}
+}
+
+func (s *fakeStorage) Redis() storage.IRedisStorage                     { return s.redis }
+func (s *fakeStorage) Job() storage.IJobStorage                         { return s.jobs }
+func (s *fakeStorage) File() storage.IFileStorage                       { return s.files }
+func (s *fakeStorage) WebhookDelivery() storage.IWebhookDeliveryStorage { return s.deliveries }
+func (s *fakeStorage) Blob() blobstore.BlobStore                        { return s.blobs }
+func (s *fakeStorage) Upload() storage.IUploadStorage                   { return s.uploads }
+func (s *fakeStorage) FileBlob() storage.IFileBlobStorage               { return s.fileBlobs }
+func (s *fakeStorage) Quota() storage.IQuotaStorage                     { return s.quotas }
+func (s *fakeStorage) Folder() storage.IFolderStorage                   { return s.folders }
+
+type fakeRedis struct {
+	storage.IRedisStorage
+
+	mu    sync.Mutex
+	kv    map[string]string
+	lists map[string][]string
+	zsets map[string]map[string]time.Time
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{
+		kv:    map[string]string{},
+		lists: map[string][]string{},
+		zsets: map[string]map[string]time.Time{},
+	}
+}
+
+func (r *fakeRedis) SetX(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.kv[key] = toString(value)
+	return nil
+}
+
+func (r *fakeRedis) Get(_ context.Context, key string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := r.kv[key]
+	if !ok {
+		return "", errors.New("redis: nil")
+	}
+	return v, nil
+}
+
+func (r *fakeRedis) Del(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.kv, key)
+	delete(r.lists, key)
+	return nil
+}
+
+func (r *fakeRedis) Push(_ context.Context, key string, value interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lists[key] = append([]string{toString(value)}, r.lists[key]...)
+	return nil
+}
+
+func (r *fakeRedis) Move(_ context.Context, _ time.Duration, dest string, keys ...string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, key := range keys {
+		list := r.lists[key]
+		if len(list) == 0 {
+			continue
+		}
+		v := list[len(list)-1]
+		r.lists[key] = list[:len(list)-1]
+		r.lists[dest] = append([]string{v}, r.lists[dest]...)
+		return v, nil
+	}
+	return "", nil
+}
+
+func (r *fakeRedis) Remove(_ context.Context, key string, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, v := range r.lists[key] {
+		if v == value {
+			r.lists[key] = append(r.lists[key][:i:i], r.lists[key][i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (r *fakeRedis) Range(_ context.Context, key string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.lists[key]...), nil
+}
+
+func (r *fakeRedis) Keys(_ context.Context, pattern string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	prefix := strings.TrimSuffix(pattern, "*")
+	var keys []string
+	for key, list := range r.lists {
+		if strings.HasPrefix(key, prefix) && len(list) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// list - ro'yxat nusxasi (boshidan oxirigacha)
+func (r *fakeRedis) list(key string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.lists[key]...)
+}
+
+func (r *fakeRedis) Len(_ context.Context, key string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return int64(len(r.lists[key])), nil
+}
+
+func (r *fakeRedis) AddDelayed(_ context.Context, key string, value string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.zsets[key] == nil {
+		r.zsets[key] = map[string]time.Time{}
+	}
+	r.zsets[key][value] = at
+	return nil
+}
+
+func (r *fakeRedis) PopDue(_ context.Context, key string, until time.Time, limit int64) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []string
+	for member, at := range r.zsets[key] {
+		if !at.After(until) {
+			due = append(due, member)
+		}
+	}
+	sort.Strings(due)
+	if int64(len(due)) > limit {
+		due = due[:limit]
+	}
+	for _, member := range due {
+		delete(r.zsets[key], member)
+	}
+	return due, nil
+}
+
+func (r *fakeRedis) RemoveDelayed(_ context.Context, key string, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.zsets[key], value)
+	return nil
+}
+
+func (r *fakeRedis) DelayedLen(_ context.Context, key string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return int64(len(r.zsets[key])), nil
+}
+
+func (r *fakeRedis) AcquireLease(_ context.Context, key, member string, limit int64, until time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.zsets[key] == nil {
+		r.zsets[key] = map[string]time.Time{}
+	}
+	now := time.Now()
+	for m, at := range r.zsets[key] {
+		if !at.After(now) {
+			delete(r.zsets[key], m)
+		}
+	}
+	if _, held := r.zsets[key][member]; !held && int64(len(r.zsets[key])) >= limit {
+		return false, nil
+	}
+	r.zsets[key][member] = until
+	return true, nil
+}
+
+func (r *fakeRedis) ReleaseLease(_ context.Context, key, member string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.zsets[key], member)
+	return nil
+}
+
+// delayed - kalitdagi barcha kechiktirilgan qiymatlar va ularning vaqti
+func (r *fakeRedis) delayed(key string) map[string]time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make(map[string]time.Time, len(r.zsets[key]))
+	for k, v := range r.zsets[key] {
+		out[k] = v
+	}
+	return out
+}
+
+func toString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		panic("fakeRedis: unsupported value type")
+	}
+}
+
+type fakeJobs struct {
+	storage.IJobStorage
+
+	mu    sync.Mutex
+	jobs  map[string]*models.Job
+	stale []models.Job // ReapStale qaytaradigan joblar
+}
+
+// activeInputs - pending/processing joblarning kiruvchi fayllari
+func (r *fakeJobs) activeInputs() map[string]bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	busy := map[string]bool{}
+	for _, job := range r.jobs {
+		if job.Status == models.Pending || job.Status == models.Processing {
+			for _, id := range job.InputFileIDs {
+				busy[id] = true
+			}
+		}
+	}
+	return busy
+}
+
+func (r *fakeJobs) ReapStale(context.Context, time.Duration) ([]models.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stale := r.stale
+	r.stale = nil
+	return stale, nil
+}
+
+func (r *fakeJobs) put(job *models.Job) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *job
+	r.jobs[job.ID] = &cp
+}
+
+func (r *fakeJobs) Create(_ context.Context, job *models.Job) error {
+	r.put(job)
+	return nil
+}
+
+// Update - bazadagi kabi bekor qilingan job qayta yozilmaydi
+func (r *fakeJobs) Update(_ context.Context, job *models.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.jobs[job.ID]; ok && old.Status == models.Cancelled {
+		return nil
+	}
+	cp := *job
+	r.jobs[job.ID] = &cp
+	return nil
+}
+
+func (r *fakeJobs) Claim(_ context.Context, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok || job.Status != models.Pending {
+		return false, nil
+	}
+	now := time.Now()
+	job.Status = models.Processing
+	job.StartedAt = &now
+	job.Attempts++
+	job.NextRetryAt = nil
+	return true, nil
+}
+
+func (r *fakeJobs) Cancel(_ context.Context, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok || (job.Status != models.Pending && job.Status != models.Processing) {
+		return false, nil
+	}
+	now := time.Now()
+	job.Status = models.Cancelled
+	job.FinishedAt = &now
+	return true, nil
+}
+
+// children - parentID ning bola joblari (yaratilish tartibida)
+func (r *fakeJobs) children(parentID string) []models.Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []models.Job
+	for _, job := range r.jobs {
+		if job.ParentID != nil && *job.ParentID == parentID {
+			out = append(out, *job)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out
+}
+
+func (r *fakeJobs) GetByID(_ context.Context, id string) (*models.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	cp := *job
+	return &cp, nil
+}
+
+type fakeFiles struct {
+	storage.IFileStorage
+
+	mu    sync.Mutex
+	files map[string]models.File
+	links map[string][]*time.Time // shared_links: file_id -> expires_at (nil - muddatsiz)
+	jobs  *fakeJobs               // GetExpired bajarilayotgan joblarning kiruvchi fayllarini o'tkazib yuboradi
+}
+
+func (r *fakeFiles) put(file models.File) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.files[file.ID] = file
+}
+
+func (r *fakeFiles) GetByID(_ context.Context, id string) (models.File, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	file, ok := r.files[id]
+	if !ok {
+		return models.File{}, pgx.ErrNoRows
+	}
+	return file, nil
+}
+
+func (r *fakeFiles) SetSourceJob(_ context.Context, jobID string, fileIDs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range fileIDs {
+		if file, ok := r.files[id]; ok {
+			file.SourceJobID = &jobID
+			r.files[id] = file
+		}
+	}
+	return nil
+}
+
+func (r *fakeFiles) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.files, id)
+	return nil
+}
+
+func (r *fakeFiles) DeleteByID(ctx context.Context, id string) error {
+	return r.Delete(ctx, id)
+}
+
+func (r *fakeFiles) ExistsByPath(_ context.Context, path string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, file := range r.files {
+		if file.FilePath == path {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ListAfter - ID bo'yicha tartiblangan sahifa (afterID "" - boshidan)
+func (r *fakeFiles) ListAfter(_ context.Context, afterID string, limit int) ([]models.File, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []models.File
+	for _, file := range r.files {
+		if file.ID > afterID {
+			out = append(out, file)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (r *fakeFiles) Update(_ context.Context, file models.File) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.files[file.ID]
+	if !ok {
+		return pgx.ErrNoRows
+	}
+	stored.FileName, stored.FolderID, stored.Tags = file.FileName, file.FolderID, file.Tags
+	r.files[file.ID] = stored
+	return nil
+}
+
+func (r *fakeFiles) SetPinned(_ context.Context, id string, pinned bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	file, ok := r.files[id]
+	if !ok {
+		return pgx.ErrNoRows
+	}
+	file.Pinned = pinned
+	r.files[id] = file
+	return nil
+}
+
+// ListTags - SQL dagidek: ko'p ishlatilgan teg oldin, tengida alifbo bo'yicha
+func (r *fakeFiles) ListTags(_ context.Context, userID string) ([]models.TagCount, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := map[string]int{}
+	for _, file := range r.files {
+		if file.UserID != nil && *file.UserID == userID {
+			for _, tag := range file.Tags {
+				counts[tag]++
+			}
+		}
+	}
+	out := []models.TagCount{}
+	for tag, count := range counts {
+		out = append(out, models.TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Tag < out[j].Tag
+	})
+	return out, nil
+}
+
+// GetExpired - SQL dagidek: mehmon fayllari guestBefore dan, foydalanuvchi fayllari userBefore dan oldin
+// yuklangan bo'lsa; biriktirilgan, amaldagi ulashish havolasi bor va bajarilayotgan job kiruvchi fayllari qaytmaydi
+func (r *fakeFiles) GetExpired(_ context.Context, guestBefore time.Time, userBefore *time.Time, limit int) ([]models.OldFile, error) {
+	busy := r.jobs.activeInputs()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	var expired []models.File
+	for _, file := range r.files {
+		before := &guestBefore
+		if file.UserID != nil {
+			before = userBefore
+		}
+		if before == nil || !file.UploadedAt.Before(*before) || file.Pinned || busy[file.ID] {
+			continue
+		}
+		shared := slices.ContainsFunc(r.links[file.ID], func(expiresAt *time.Time) bool {
+			return expiresAt == nil || expiresAt.After(now)
+		})
+		if !shared {
+			expired = append(expired, file)
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool {
+		if !expired[i].UploadedAt.Equal(expired[j].UploadedAt) {
+			return expired[i].UploadedAt.Before(expired[j].UploadedAt)
+		}
+		return expired[i].ID < expired[j].ID
+	})
+
+	var out []models.OldFile
+	for _, file := range expired {
+		out = append(out, models.OldFile{ID: file.ID, FilePath: file.FilePath, SHA256: file.SHA256, FileSize: file.FileSize})
+	}
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// fakeFolders - folders jadvali: bir papkada nomlar takrorlanmaydi (23505), o'chirish ichki papkalarga ham tarqaladi
+type fakeFolders struct {
+	storage.IFolderStorage
+
+	mu      sync.Mutex
+	folders map[string]models.Folder
+}
+
+func (r *fakeFolders) checkUnique(folder *models.Folder) error {
+	for _, other := range r.folders {
+		if other.ID != folder.ID && other.UserID == folder.UserID && other.Name == folder.Name &&
+			(other.ParentID == nil) == (folder.ParentID == nil) &&
+			(other.ParentID == nil || *other.ParentID == *folder.ParentID) {
+			return &pgconn.PgError{Code: "23505"}
+		}
+	}
+	return nil
+}
+
+func (r *fakeFolders) Create(_ context.Context, folder *models.Folder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.checkUnique(folder); err != nil {
+		return err
+	}
+	r.folders[folder.ID] = *folder
+	return nil
+}
+
+func (r *fakeFolders) GetByID(_ context.Context, id string) (*models.Folder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	folder, ok := r.folders[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return &folder, nil
+}
+
+func (r *fakeFolders) GetList(_ context.Context, userID string, parentID *string) ([]models.Folder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := []models.Folder{}
+	for _, folder := range r.folders {
+		if folder.UserID == userID && (folder.ParentID == nil) == (parentID == nil) &&
+			(parentID == nil || *folder.ParentID == *parentID) {
+			out = append(out, folder)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+func (r *fakeFolders) Update(_ context.Context, folder *models.Folder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.checkUnique(folder); err != nil {
+		return err
+	}
+	r.folders[folder.ID] = *folder
+	return nil
+}
+
+func (r *fakeFolders) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.folders, id)
+	for changed := true; changed; {
+		changed = false
+		for childID, folder := range r.folders {
+			if folder.ParentID != nil {
+				if _, ok := r.folders[*folder.ParentID]; !ok {
+					delete(r.folders, childID)
+					changed = true
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// IsDescendant - folderID ota papkalar zanjiri bo'ylab ancestorID ga yetadimi (o'zi ham hisoblanadi)
+func (r *fakeFolders) IsDescendant(_ context.Context, folderID, ancestorID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id := &folderID; id != nil; {
+		if *id == ancestorID {
+			return true, nil
+		}
+		folder, ok := r.folders[*id]
+		if !ok {
+			return false, nil
+		}
+		id = folder.ParentID
+	}
+	return false, nil
+}
+
+// fakeQuotas - foydalanuvchining amaldagi kvotasi va egallagan joyi (yo'q bo'lsa - cheklanmagan, bo'sh)
+type fakeQuotas struct {
+	storage.IQuotaStorage
+
+	quotas map[string]models.UserQuota
+	usage  map[string]models.StorageUsage
+}
+
+func (r *fakeQuotas) GetEffective(_ context.Context, userID string) (models.UserQuota, error) {
+	return r.quotas[userID], nil
+}
+
+func (r *fakeQuotas) GetUsage(_ context.Context, userID string) (models.StorageUsage, error) {
+	return r.usage[userID], nil
+}
+
+// fakeFileBlobs - file_blobs havola hisoblagichi
+type fakeFileBlobs struct {
+	storage.IFileBlobStorage
+
+	mu   sync.Mutex
+	refs map[string]int
+}
+
+func (r *fakeFileBlobs) Acquire(ctx context.Context, blob models.FileBlob, put func(ctx context.Context) error) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.refs[blob.Key]; ok {
+		r.refs[blob.Key]++
+		return false, nil
+	}
+	if err := put(ctx); err != nil {
+		return false, err
+	}
+	r.refs[blob.Key] = 1
+	return true, nil
+}
+
+func (r *fakeFileBlobs) Exists(_ context.Context, key string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.refs[key]
+	return ok, nil
+}
+
+func (r *fakeFileBlobs) Release(ctx context.Context, key string, remove func(ctx context.Context) error) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.refs[key] > 1 {
+		r.refs[key]--
+		return false, nil
+	}
+	if err := remove(ctx); err != nil {
+		return false, err
+	}
+	delete(r.refs, key)
+	return true, nil
+}
+
+type fakeBlobs struct {
+	blobstore.BlobStore
+
+	mu      sync.Mutex
+	data    map[string][]byte
+	puts    int
+	deleted []string
+}
+
+func (b *fakeBlobs) Put(_ context.Context, key string, r io.Reader, size int64, _ string) error {
+	data, err := io.ReadAll(io.LimitReader(r, size))
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.data[key] = data
+	b.puts++
+	return nil
+}
+
+func (b *fakeBlobs) Stat(_ context.Context, key string) (*blobstore.ObjectInfo, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	data, ok := b.data[key]
+	if !ok {
+		return nil, blobstore.ErrNotFound
+	}
+	return &blobstore.ObjectInfo{Key: key, Size: int64(len(data))}, nil
+}
+
+func (b *fakeBlobs) Delete(_ context.Context, key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.data, key)
+	b.deleted = append(b.deleted, key)
+	return nil
+}
+
+func (b *fakeBlobs) keys() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var keys []string
+	for key := range b.data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fakeUploads - uploads jadvali; AppendChunk SQL dagi shartlarni takrorlaydi
+type fakeUploads struct {
+	storage.IUploadStorage
+
+	mu      sync.Mutex
+	uploads map[string]*models.Upload
+}
+
+func (r *fakeUploads) Create(_ context.Context, upload *models.Upload) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *upload
+	r.uploads[upload.ID] = &cp
+	return nil
+}
+
+func (r *fakeUploads) GetByID(_ context.Context, id string) (*models.Upload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	upload, ok := r.uploads[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	cp := *upload
+	cp.ChunkKeys = append([]string(nil), upload.ChunkKeys...)
+	return &cp, nil
+}
+
+func (r *fakeUploads) AppendChunk(_ context.Context, id string, offset, size int64, key string, expiresAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	upload, ok := r.uploads[id]
+	if !ok || upload.Status != models.UploadUploading || upload.Offset != offset ||
+		upload.Offset+size > upload.Length || !upload.ExpiresAt.After(time.Now()) {
+		return false, nil
+	}
+	upload.Offset += size
+	upload.ChunkKeys = append(upload.ChunkKeys, key)
+	upload.ExpiresAt = expiresAt
+	return true, nil
+}
+
+type fakeDeliveries struct {
+	mu   sync.Mutex
+	list []models.WebhookDelivery
+}
+
+func (r *fakeDeliveries) Create(_ context.Context, d *models.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.list = append(r.list, *d)
+	return nil
+}
+
+func (r *fakeDeliveries) GetByJobID(_ context.Context, jobID string) ([]models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []models.WebhookDelivery
+	for _, d := range r.list {
+		if d.JobID == jobID {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+type fakeEvents struct {
+	mu     sync.Mutex
+	events []models.JobEvent
+}
+
+func (e *fakeEvents) Publish(_ context.Context, event models.JobEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events = append(e.events, event)
+}
+
+func (e *fakeEvents) Subscribe(context.Context, string) (<-chan models.JobEvent, func(), error) {
+	return nil, func() {}, nil
+}
+
+func (e *fakeEvents) AllowedOrigin(string) bool { return false }
+
+// fakeCache - natijalar keshlanmaydi
+type fakeCache struct {
+	JobCacheService
+}
+
+func (fakeCache) Key(context.Context, *models.Job, models.QueueTask) (string, bool) { return "", false }
+
+type fakeWebhooks struct {
+	WebhookService
+
+	mu       sync.Mutex
+	notified []string
+}
+
+func (w *fakeWebhooks) Notify(job *models.Job) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.notified = append(w.notified, job.ID)
+}
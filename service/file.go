@@ -2,49 +2,161 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 
 	"test/api/models"
+	"test/config"
+	"test/pkg/blobstore"
 	"test/pkg/logger"
+	"test/pkg/scanner"
 	"test/storage"
 )
 
+var (
+	// ErrFileNotFound - bunday fayl yo'q (yoki foydalanuvchiga tegishli emas)
+	ErrFileNotFound = errors.New("file not found")
+	// ErrInvalidFileFilter - kutubxona filtri noto'g'ri (masalan, source_file_id UUID emas)
+	ErrInvalidFileFilter = errors.New("invalid file filter")
+)
+
+const (
+	defaultFileListLimit = 50
+	maxFileListLimit     = 200
+)
+
 type FileService interface {
-	Upload(ctx context.Context, req models.File) (string, error)
+	Upload(ctx context.Context, req models.File, content io.Reader) (models.File, error)
 	Get(ctx context.Context, id string) (models.File, error)
+	FindByHash(ctx context.Context, userID, sha256 string) (models.File, error)
+	Open(ctx context.Context, file models.File) (*FileContent, error)
 	Delete(ctx context.Context, id string) error
-	List(ctx context.Context, userID string) ([]models.File, error)
-	CleanupOldFiles(ctx context.Context, olderThanDays int) (int, error)
+	List(ctx context.Context, filter models.FileFilter) (*models.FileListResponse, error)
+	Update(ctx context.Context, file models.File, req models.UpdateFileRequest) (models.File, error)
+	ListTags(ctx context.Context, userID string) ([]models.TagCount, error)
+	SetPinned(ctx context.Context, id string, pinned bool) (models.File, error)
 }
 
 type fileService struct {
-	stg storage.IFileStorage
-	log logger.ILogger
+	stg     storage.IFileStorage
+	blob    blobstore.BlobStore
+	store   storage.IStorage
+	log     logger.ILogger
+	cfg     config.Config
+	scanner scanner.Scanner // nil - yuklashlar tekshirilmaydi
+}
+
+// FileContent - yuklab olish uchun ochilgan fayl; Content ni chaqiruvchi yopishi kerak
+type FileContent struct {
+	Content     io.ReadSeekCloser
+	ContentType string
+	ETag        string
+	ModTime     time.Time
 }
 
-func NewFileService(stg storage.IStorage, log logger.ILogger) FileService {
+func NewFileService(stg storage.IStorage, log logger.ILogger, cfg config.Config, fileScanner scanner.Scanner) FileService {
 	return &fileService{
-		stg: stg.File(), // storageManager.File()
-		log: log,
+		stg:     stg.File(), // storageManager.File()
+		blob:    stg.Blob(),
+		store:   stg,
+		log:     log,
+		cfg:     cfg,
+		scanner: fileScanner,
 	}
 }
 
-// Upload - faylni DBga yozish (upload jarayoni)
-func (s *fileService) Upload(ctx context.Context, req models.File) (string, error) {
+// Upload - faylni blob storagega yuklash va DBga yozish (upload jarayoni).
+// Mazmun avval vaqtinchalik faylga yozilib tekshiriladi: turi sarlavha baytlaridan aniqlanadi
+// (file_type ga canonical MIME yoziladi), PDF esa pdfcpu bilan tekshiriladi, so'ng fayl zararli dasturga
+// tekshiriladi (topilsa karantin holatida saqlanadi). Keyin fayl xeshlanadi;
+// shu mazmunli blob bo'lsa, qayta saqlanmaydi. Foydalanuvchi fayli uning kvotasiga sig'ishi kerak.
+func (s *fileService) Upload(ctx context.Context, req models.File, content io.Reader) (models.File, error) {
 	s.log.Info("FileService.Upload called", logger.String("file_name", req.FileName))
 
+	if err := checkQuota(ctx, s.store, req.UserID, req.FileSize); err != nil {
+		return models.File{}, err
+	}
+
+	ext := filepath.Ext(req.FileName)
+	tmpPath, err := spoolToTemp(content, ext)
+	if err != nil {
+		s.log.Error("failed to receive file", logger.Error(err))
+		return models.File{}, err
+	}
+	defer os.Remove(tmpPath)
+
+	fileType, keyExt, err := detectUploadType(tmpPath, ext)
+	if err != nil {
+		s.log.Error("file rejected", logger.String("file_name", req.FileName), logger.Error(err))
+		return models.File{}, err
+	}
+	req.FileType = fileType
+
+	if fileType == mimePDF {
+		info, err := inspectPDF(tmpPath, s.cfg.UploadPDFMaxPages)
+		if err != nil {
+			s.log.Error("pdf rejected", logger.String("file_name", req.FileName), logger.Error(err))
+			return models.File{}, err
+		}
+		req.Encrypted = info.Encrypted
+		if info.PageCount > 0 {
+			req.PageCount = &info.PageCount
+		}
+	}
+
+	if err := s.scanUpload(ctx, tmpPath, &req); err != nil {
+		return models.File{}, err
+	}
+
+	key, sum, size, err := storeFile(ctx, s.store, tmpPath, keyExt, fileType)
+	if err != nil {
+		s.log.Error("failed to store file", logger.Error(err))
+		return models.File{}, err
+	}
+
 	req.ID = uuid.NewString()
+	req.FilePath = key
+	req.FileSize = size
+	req.SHA256 = sum
 	req.UploadedAt = time.Now()
 
-	id, err := s.stg.Save(ctx, req)
-	if err != nil {
+	if err := saveFileRecord(ctx, s.store, req); err != nil {
 		s.log.Error("failed to save file", logger.Error(err))
-		return "", err
+		_ = releaseBlob(s.store, key, sum)
+		return models.File{}, err
 	}
-	return id, nil
+	return req, nil
+}
+
+// spoolToTemp - kiruvchi oqimni xeshlash va blob storagega yuklash uchun vaqtinchalik faylga yozadi
+func spoolToTemp(content io.Reader, ext string) (string, error) {
+	if err := os.MkdirAll(blobTmpDir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(blobTmpDir, "upload-*"+ext)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	_, err = io.Copy(f, content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("failed to receive file: %w", err)
+	}
+	return f.Name(), nil
 }
 
 // Get - faylni ID orqali olish
@@ -52,36 +164,178 @@ func (s *fileService) Get(ctx context.Context, id string) (models.File, error) {
 	return s.stg.GetByID(ctx, id)
 }
 
-// Delete - faylni o‘chirish
-func (s *fileService) Delete(ctx context.Context, id string) error {
-	return s.stg.Delete(ctx, id)
+// FindByHash - foydalanuvchi shu mazmunli faylni avval yuklaganmi (qayta yuklamaslik uchun)
+func (s *fileService) FindByHash(ctx context.Context, userID, sha256 string) (models.File, error) {
+	file, err := s.stg.GetByHash(ctx, userID, strings.ToLower(sha256))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.File{}, ErrFileNotFound
+	}
+	return file, err
+}
+
+// Open - fayl mazmunini Range so'rovlari uchun seek qilinadigan ko'rinishda ochadi
+func (s *fileService) Open(ctx context.Context, file models.File) (*FileContent, error) {
+	content, info, err := blobstore.Open(ctx, s.blob, file.FilePath)
+	if err != nil {
+		s.log.Error("failed to open file", logger.String("id", file.ID), logger.Error(err))
+		return nil, err
+	}
+
+	return &FileContent{
+		Content:     content,
+		ContentType: fileContentType(file, info.ContentType),
+		ETag:        fileETag(file, info),
+		ModTime:     info.ModTime,
+	}, nil
 }
 
-// List - user fayllari ro‘yxati
-func (s *fileService) List(ctx context.Context, userID string) ([]models.File, error) {
-	return s.stg.ListByUser(ctx, userID)
+// fileETag - xeshi ma'lum faylda ETag mazmunga bog'liq bo'ladi, aks holda drayver bergan ETag
+func fileETag(file models.File, info *blobstore.ObjectInfo) string {
+	if file.SHA256 != "" {
+		return `"` + file.SHA256 + `"`
+	}
+	return info.ETag
 }
-func (s *fileService) CleanupOldFiles(ctx context.Context, olderThanDays int) (int, error) {
-	s.log.Info("Cleaning up old files...")
 
-	oldFiles, err := s.stg.GetOldFiles(ctx, olderThanDays)
+// fileContentType - file_type yuklangan fayllarda kengaytma (".pdf"), natija fayllarda MIME turi saqlanadi
+func fileContentType(file models.File, stored string) string {
+	if strings.Contains(file.FileType, "/") {
+		return file.FileType
+	}
+	for _, ext := range []string{file.FileType, filepath.Ext(file.FileName)} {
+		if t := mime.TypeByExtension(ext); ext != "" && t != "" {
+			return t
+		}
+	}
+	if stored != "" {
+		return stored
+	}
+	return "application/octet-stream"
+}
+
+// Delete - faylni o‘chirish; blob faqat unga oxirgi havola o‘chirilganda o‘chadi
+func (s *fileService) Delete(ctx context.Context, id string) error {
+	file, err := s.stg.GetByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
 	if err != nil {
-		s.log.Error("failed to get old files", logger.Error(err))
-		return 0, err
+		return err
 	}
 
-	count := 0
-	for _, file := range oldFiles {
-		if err := os.Remove(file.FilePath); err != nil {
-			s.log.Error("failed to delete file from disk", logger.Error(err), logger.String("path", file.FilePath))
-			continue
+	if err := s.stg.Delete(ctx, id); err != nil {
+		return err
+	}
+	if err := releaseBlob(s.store, file.FilePath, file.SHA256); err != nil {
+		s.log.Error("failed to release file blob", logger.Error(err), logger.String("path", file.FilePath))
+	}
+	return nil
+}
+
+// List - foydalanuvchi fayllari kutubxonasi: filtr, saralash va sahifalash bilan
+func (s *fileService) List(ctx context.Context, filter models.FileFilter) (*models.FileListResponse, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit < 1 {
+		filter.Limit = defaultFileListLimit
+	}
+	filter.Limit = min(filter.Limit, maxFileListLimit)
+
+	if filter.SourceFileID != "" && uuid.Validate(filter.SourceFileID) != nil {
+		return nil, fmt.Errorf("%w: source_file_id must be a UUID", ErrInvalidFileFilter)
+	}
+	filter.FileTypes = expandFileTypes(filter.FileTypes)
+
+	if filter.FolderID != "" && filter.FolderID != "root" {
+		if _, err := getUserFolder(ctx, s.store, filter.UserID, filter.FolderID); err != nil {
+			return nil, err
+		}
+	}
+
+	files, total, err := s.stg.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &models.FileListResponse{
+		Files: files,
+		Total: total,
+		Page:  filter.Page,
+		Limit: filter.Limit,
+	}, nil
+}
+
+// Update - faylni qayta nomlaydi, boshqa papkaga ko‘chiradi (folder_id "" - ildizga) va teglarini almashtiradi.
+// Papka fayl egasiniki bo‘lishi kerak; mehmon fayllarini papkaga qo‘yib bo‘lmaydi.
+func (s *fileService) Update(ctx context.Context, file models.File, req models.UpdateFileRequest) (models.File, error) {
+	var err error
+	if req.FileName != nil {
+		if file.FileName, err = normalizeName(*req.FileName); err != nil {
+			return models.File{}, err
 		}
-		if err := s.stg.DeleteByID(ctx, file.ID); err != nil {
-			s.log.Error("failed to delete file from db", logger.Error(err), logger.String("id", file.ID))
+	}
+	if req.FolderID != nil {
+		file.FolderID = emptyToNil(req.FolderID)
+		if file.FolderID != nil {
+			if file.UserID == nil {
+				return models.File{}, ErrFolderNotFound
+			}
+			if _, err := getUserFolder(ctx, s.store, *file.UserID, *file.FolderID); err != nil {
+				return models.File{}, err
+			}
+		}
+	}
+	if req.Tags != nil {
+		if file.Tags, err = normalizeTags(*req.Tags); err != nil {
+			return models.File{}, err
+		}
+	}
+
+	if err := s.stg.Update(ctx, file); err != nil {
+		return models.File{}, err
+	}
+	return s.stg.GetByID(ctx, file.ID)
+}
+
+// expandFileTypes - "pdf", ".pdf" yoki "application/pdf" ni canonical MIME turi va eski yozuvlardagi
+// kengaytma ko‘rinishlariga aylantiradi, shunda filtr yangi va eski fayllarni birdek topadi
+func expandFileTypes(types []string) []string {
+	var result []string
+	for _, t := range types {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
 			continue
 		}
-		count++
+
+		mimeType := t
+		if !strings.Contains(t, "/") {
+			ext := "." + strings.TrimPrefix(t, ".")
+			var ok bool
+			if mimeType, ok = extFileTypes[ext]; !ok {
+				result = append(result, ext)
+				continue
+			}
+		}
+
+		result = append(result, mimeType)
+		for ext, extType := range extFileTypes {
+			if extType == mimeType {
+				result = append(result, ext)
+			}
+		}
 	}
+	return result
+}
+
+// ListTags - foydalanuvchi teglari va ular qo‘yilgan fayllar soni
+func (s *fileService) ListTags(ctx context.Context, userID string) ([]models.TagCount, error) {
+	return s.stg.ListTags(ctx, userID)
+}
 
-	return count, nil
+// SetPinned - biriktirilgan fayl saqlash muddati o'tsa ham avtomatik tozalashda o'chirilmaydi
+func (s *fileService) SetPinned(ctx context.Context, id string, pinned bool) (models.File, error) {
+	if err := s.stg.SetPinned(ctx, id, pinned); err != nil {
+		return models.File{}, err
+	}
+	return s.stg.GetByID(ctx, id)
 }
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"test/api/models"
+	"test/pkg/blobstore"
+	"test/storage"
+)
+
+// contentKey - kontent manzilli blob kaliti. Kengaytma ham kalitga kiradi, chunki tashqi
+// konvertorlar (LibreOffice, Gotenberg) fayl turini shundan aniqlaydi.
+func contentKey(sum, ext string) string {
+	return fmt.Sprintf("blobs/%s/%s%s", sum[:2], sum, strings.ToLower(ext))
+}
+
+// hashFile - faylning SHA-256 xeshi (hex) va hajmi
+func hashFile(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to hash file: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// storeFile - diskdagi faylni kontent manzili bo'yicha saqlaydi. Shu mazmunli blob allaqachon
+// bo'lsa, qayta yuklanmaydi - faqat havolalar soni oshadi. Har bir muvaffaqiyatli chaqiruvga
+// (files yozuvi o'chirilganda) bitta releaseBlob to'g'ri kelishi kerak.
+func storeFile(ctx context.Context, stg storage.IStorage, path, ext, contentType string) (key, sum string, size int64, err error) {
+	sum, size, err = hashFile(path)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	key = contentKey(sum, ext)
+	blob := models.FileBlob{Key: key, SHA256: sum, Size: size}
+	_, err = stg.FileBlob().Acquire(ctx, blob, func(ctx context.Context) error {
+		return blobstore.PutFile(ctx, stg.Blob(), key, path, contentType)
+	})
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to store file: %w", err)
+	}
+	return key, sum, size, nil
+}
+
+// releaseBlob - files yozuvi o'chirilgandan keyin chaqiriladi: oxirgi havola ketganda blob o'chiriladi.
+// sha256 siz (dedup dan oldingi) yozuvlarning bloblari umumiy emas, ular to'g'ridan-to'g'ri o'chiriladi.
+func releaseBlob(stg storage.IStorage, key, sum string) error {
+	if sum == "" {
+		return deleteBlob(stg, key)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := stg.FileBlob().Release(ctx, key, func(ctx context.Context) error {
+		return stg.Blob().Delete(ctx, key)
+	})
+	return err
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"test/api/models"
+	"test/config"
+)
+
+// storeTestFile - mazmunni vaqtinchalik faylga yozib, storeFile orqali saqlaydi va files yozuvini qo'shadi
+func storeTestFile(t *testing.T, stg *fakeStorage, id, content string, userID *string, uploadedAt time.Time) models.File {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), id+".pdf")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	key, sum, size, err := storeFile(context.Background(), stg, path, ".pdf", mimePDF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := models.File{ID: id, UserID: userID, FilePath: key, SHA256: sum, FileSize: size, FileType: mimePDF, UploadedAt: uploadedAt}
+	stg.files.put(file)
+	return file
+}
+
+func TestDedupRefcountOnDelete(t *testing.T) {
+	stg := newFakeStorage()
+	files := NewFileService(stg, nopLogger{}, config.Config{}, nil)
+	ctx := context.Background()
+
+	a := storeTestFile(t, stg, "a", "%PDF-1.7 same", nil, time.Now())
+	b := storeTestFile(t, stg, "b", "%PDF-1.7 same", nil, time.Now())
+
+	// Bir xil mazmun bitta blobga yoziladi, havolalar soni 2
+	if a.FilePath != b.FilePath || stg.blobs.puts != 1 || stg.fileBlobs.refs[a.FilePath] != 2 {
+		t.Fatalf("keys %s/%s, puts %d, refs %d; want one shared blob with 2 refs", a.FilePath, b.FilePath, stg.blobs.puts, stg.fileBlobs.refs[a.FilePath])
+	}
+
+	if err := files.Delete(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if len(stg.blobs.deleted) != 0 || stg.fileBlobs.refs[a.FilePath] != 1 {
+		t.Errorf("after first delete: deleted %v, refs %d; blob must stay for b", stg.blobs.deleted, stg.fileBlobs.refs[a.FilePath])
+	}
+
+	if err := files.Delete(ctx, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(stg.blobs.deleted, []string{a.FilePath}) {
+		t.Errorf("after last delete: deleted %v, want [%s]", stg.blobs.deleted, a.FilePath)
+	}
+	if _, ok := stg.fileBlobs.refs[a.FilePath]; ok {
+		t.Error("blob refcount must be dropped with the last reference")
+	}
+}
+
+func TestDedupLegacyFileDelete(t *testing.T) {
+	stg := newFakeStorage()
+	files := NewFileService(stg, nopLogger{}, config.Config{}, nil)
+
+	// Dedup dan oldingi yozuv (sha256 yo'q): blob umumiy emas, havola hisobisiz o'chiriladi
+	stg.files.put(models.File{ID: "old", FilePath: "storage/merge/old.pdf"})
+	if err := files.Delete(context.Background(), "old"); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(stg.blobs.deleted, []string{"storage/merge/old.pdf"}) {
+		t.Errorf("deleted = %v, want the legacy path", stg.blobs.deleted)
+	}
+}
+
+func TestDedupRefcountOnRetention(t *testing.T) {
+	stg := newFakeStorage()
+	s := NewRetentionService(stg, nopLogger{}, config.Config{RetentionGuestHours: 24, RetentionBatchSize: 100}).(*retentionService)
+
+	uid := "u1"
+	old := time.Now().Add(-48 * time.Hour)
+	guest := storeTestFile(t, stg, "guest", "%PDF-1.7 shared", nil, old)
+	storeTestFile(t, stg, "user", "%PDF-1.7 shared", &uid, old) // RETENTION_USER_DAYS=0 - o'chirilmaydi
+	lone := storeTestFile(t, stg, "lone", "%PDF-1.7 lone", nil, old)
+
+	run := &models.CleanupRun{}
+	if err := s.deleteExpiredFiles(context.Background(), run); err != nil {
+		t.Fatal(err)
+	}
+
+	if run.DeletedFiles != 2 || run.FreedBytes != guest.FileSize+lone.FileSize {
+		t.Errorf("run = %d files, %d bytes; want 2, %d", run.DeletedFiles, run.FreedBytes, guest.FileSize+lone.FileSize)
+	}
+	// Umumiy blob foydalanuvchi fayli uchun qoladi, yolg'iz blob o'chiriladi
+	if stg.fileBlobs.refs[guest.FilePath] != 1 {
+		t.Errorf("shared blob refs = %d, want 1", stg.fileBlobs.refs[guest.FilePath])
+	}
+	if !slices.Equal(stg.blobs.deleted, []string{lone.FilePath}) {
+		t.Errorf("deleted blobs = %v, want [%s]", stg.blobs.deleted, lone.FilePath)
+	}
+}
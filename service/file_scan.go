@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"test/api/models"
+	"test/pkg/logger"
+)
+
+var (
+	// ErrFileQuarantined - fayl karantinda (yuklashda zararli dastur topilgan), uni jobga berib bo'lmaydi
+	ErrFileQuarantined = errors.New("file is quarantined")
+	// ErrScanUnavailable - skaner javob bermadi va SCANNER_FAIL_OPEN o'chiq, shuning uchun fayl qabul qilinmadi
+	ErrScanUnavailable = errors.New("malware scanner is unavailable")
+)
+
+// scanUpload - yuklangan faylni skanerdan o'tkazadi va natijani file ga yozadi. Zararli fayl rad
+// etilmaydi - karantin holatida saqlanadi. Skaner o'chiq bo'lsa fayl "unscanned" bo'lib qoladi.
+func (s *fileService) scanUpload(ctx context.Context, path string, file *models.File) error {
+	file.ScanStatus = models.ScanUnscanned
+	if s.scanner == nil {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	result, err := s.scanner.Scan(ctx, f)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		s.log.Error("failed to scan file", logger.String("file_name", file.FileName), logger.Error(err))
+		if s.cfg.ScannerFailOpen {
+			return nil
+		}
+		return fmt.Errorf("%w: %v", ErrScanUnavailable, err)
+	}
+
+	now := time.Now()
+	file.ScannedAt = &now
+	if !result.Infected {
+		file.ScanStatus = models.ScanClean
+		return nil
+	}
+
+	s.log.Warning("malware found, file quarantined",
+		logger.String("file_name", file.FileName), logger.String("signature", result.Signature))
+	file.ScanStatus = models.ScanQuarantined
+	file.ScanSignature = &result.Signature
+	return nil
+}
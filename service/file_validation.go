@@ -0,0 +1,341 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+
+	"test/api/models"
+	"test/storage"
+)
+
+// Fayl mazmuni bo'yicha aniqlangan (canonical) MIME turlari
+const (
+	mimePDF  = "application/pdf"
+	mimeJPEG = "image/jpeg"
+	mimePNG  = "image/png"
+	mimeGIF  = "image/gif"
+	mimeDOC  = "application/msword"
+	mimeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	mimeXLS  = "application/vnd.ms-excel"
+	mimeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	mimePPT  = "application/vnd.ms-powerpoint"
+	mimePPTX = "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+)
+
+var (
+	// ErrUnsupportedFileType - fayl turi yuklash yoki shu amal uchun ruxsat etilmagan
+	ErrUnsupportedFileType = errors.New("unsupported file type")
+	// ErrFileTypeMismatch - fayl kengaytmasi uning mazmuniga mos emas (masalan, ".pdf" aslida rasm)
+	ErrFileTypeMismatch = errors.New("file extension does not match its content")
+	// ErrInvalidPDF - PDF buzilgan yoki pdfcpu tekshiruvidan o'tmadi
+	ErrInvalidPDF = errors.New("invalid or corrupt PDF")
+	// ErrPDFTooManyPages - PDF sahifalari soni ruxsat etilganidan ko'p
+	ErrPDFTooManyPages = errors.New("PDF has too many pages")
+	// ErrFileAccessDenied - kiruvchi fayl boshqa foydalanuvchiga tegishli
+	ErrFileAccessDenied = errors.New("file belongs to another user")
+)
+
+// fileTypeExts - yuklash mumkin bo'lgan turlar va ularning asosiy kengaytmasi (blob kaliti shundan olinadi,
+// chunki LibreOffice/Gotenberg fayl turini kengaytmadan aniqlaydi)
+var fileTypeExts = map[string]string{
+	mimePDF:  ".pdf",
+	mimeJPEG: ".jpg",
+	mimePNG:  ".png",
+	mimeGIF:  ".gif",
+	mimeDOC:  ".doc",
+	mimeDOCX: ".docx",
+	mimeXLS:  ".xls",
+	mimeXLSX: ".xlsx",
+	mimePPT:  ".ppt",
+	mimePPTX: ".pptx",
+}
+
+// extFileTypes - kengaytma qaysi turni da'vo qiladi; mazmun boshqa tur bo'lsa, fayl rad etiladi
+var extFileTypes = map[string]string{
+	".pdf":  mimePDF,
+	".jpg":  mimeJPEG,
+	".jpeg": mimeJPEG,
+	".png":  mimePNG,
+	".gif":  mimeGIF,
+	".doc":  mimeDOC,
+	".docx": mimeDOCX,
+	".xls":  mimeXLS,
+	".xlsx": mimeXLSX,
+	".ppt":  mimePPT,
+	".pptx": mimePPTX,
+}
+
+// jobInputTypes - amal turi qabul qiladigan kiruvchi fayl turlari. Ro'yxatda yo'q amallar
+// (html_to_pdf, pipeline, batch) kiruvchi faylni o'zi tekshirmaydi yoki bola joblarga topshiradi.
+var jobInputTypes = map[string][]string{
+	models.JobTypeMerge:           {mimePDF},
+	models.JobTypeSplit:           {mimePDF},
+	models.JobTypeRemovePages:     {mimePDF},
+	models.JobTypeExtractPages:    {mimePDF},
+	models.JobTypeCompress:        {mimePDF},
+	models.JobTypeJPGToPDF:        {mimeJPEG, mimePNG, mimeGIF},
+	models.JobTypePDFToJPG:        {mimePDF},
+	models.JobTypeRotate:          {mimePDF},
+	models.JobTypeOrganize:        {mimePDF},
+	models.JobTypeWatermark:       {mimePDF},
+	models.JobTypeAddBackground:   {mimePDF},
+	models.JobTypeCrop:            {mimePDF},
+	models.JobTypeUnlock:          {mimePDF},
+	models.JobTypeProtect:         {mimePDF},
+	models.JobTypeAddPageNumbers:  {mimePDF},
+	models.JobTypeInspect:         {mimePDF},
+	models.JobTypeHeaderFooter:    {mimePDF},
+	models.JobTypeDetectBlank:     {mimePDF},
+	models.JobTypeQRCode:          {mimePDF},
+	models.JobTypePDFToWord:       {mimePDF},
+	models.JobTypeWordToPDF:       {mimeDOC, mimeDOCX},
+	models.JobTypeExcelToPDF:      {mimeXLS, mimeXLSX},
+	models.JobTypePowerPointToPDF: {mimePPT, mimePPTX},
+}
+
+// jobAssetTypes - kiruvchi fayldan keyin InputFileIDs ga qo'shiladigan qo'shimcha rasm (suv belgisi,
+// orqa fon) turlari. Rasm ham kiruvchi fayl: retention uni o'chirmaydi va u kesh kalitiga kiradi.
+var jobAssetTypes = map[string][]string{
+	models.JobTypeWatermark:     {mimePNG, mimeJPEG},
+	models.JobTypeAddBackground: {mimePNG, mimeJPEG},
+}
+
+// oleMagic - eski Office (doc/xls/ppt) fayllarining OLE2 sarlavhasi
+var oleMagic = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// PDFInfo - yuklash paytidagi pdfcpu tekshiruvi natijasi
+type PDFInfo struct {
+	PageCount int
+	Encrypted bool
+}
+
+// sniffFileType - faylning MIME turini sarlavha baytlari bo'yicha aniqlaydi. ext faqat mazmundan
+// ajratib bo'lmaydigan turlarni (OLE2 ichidagi doc/xls/ppt) farqlash uchun ishlatiladi.
+func sniffFileType(path, ext string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read file header: %w", err)
+	}
+	head = head[:n]
+
+	if bytes.HasPrefix(head, oleMagic) {
+		switch t := extFileTypes[strings.ToLower(ext)]; t {
+		case mimeDOC, mimeXLS, mimePPT:
+			return t, nil
+		}
+		return "application/x-ole-storage", nil
+	}
+
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if detected == "application/zip" {
+		return sniffOOXML(f)
+	}
+	return detected, nil
+}
+
+// sniffOOXML - docx/xlsx/pptx oddiy ZIP arxiv; turi arxiv ichidagi asosiy papkadan aniqlanadi
+func sniffOOXML(f *os.File) (string, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	zr, err := zip.NewReader(f, info.Size())
+	if err != nil {
+		return "application/zip", nil
+	}
+
+	for _, entry := range zr.File {
+		switch {
+		case strings.HasPrefix(entry.Name, "word/"):
+			return mimeDOCX, nil
+		case strings.HasPrefix(entry.Name, "xl/"):
+			return mimeXLSX, nil
+		case strings.HasPrefix(entry.Name, "ppt/"):
+			return mimePPTX, nil
+		}
+	}
+	return "application/zip", nil
+}
+
+// detectUploadType - yuklangan fayl turini tekshiradi va canonical MIME turini hamda blob kaliti
+// uchun kengaytmani qaytaradi
+func detectUploadType(path, ext string) (string, string, error) {
+	detected, err := sniffFileType(path, ext)
+	if err != nil {
+		return "", "", err
+	}
+
+	canonicalExt, ok := fileTypeExts[detected]
+	if !ok {
+		return "", "", fmt.Errorf("%w: %s", ErrUnsupportedFileType, detected)
+	}
+	if claimed, known := extFileTypes[strings.ToLower(ext)]; known && claimed != detected {
+		return "", "", fmt.Errorf("%w: %s file has %s content", ErrFileTypeMismatch, ext, detected)
+	}
+	return detected, canonicalExt, nil
+}
+
+// inspectPDF - PDF ni pdfcpu bilan o'qib tekshiradi. Ochish paroli bilan shifrlangan faylning
+// ichini tekshirib bo'lmaydi - u faqat Encrypted deb belgilanadi (unlock uchun kerak bo'ladi).
+func inspectPDF(path string, maxPages int) (*PDFInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	conf := model.NewDefaultConfiguration()
+	conf.ValidationMode = model.ValidationRelaxed
+
+	pdfCtx, err := api.ReadContext(f, conf)
+	if errors.Is(err, pdfcpu.ErrWrongPassword) {
+		return &PDFInfo{Encrypted: true}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPDF, err)
+	}
+	if err := api.ValidateContext(pdfCtx); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPDF, err)
+	}
+	if err := pdfCtx.EnsurePageCount(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPDF, err)
+	}
+
+	info := &PDFInfo{PageCount: pdfCtx.PageCount, Encrypted: pdfCtx.Encrypt != nil}
+	if maxPages > 0 && info.PageCount > maxPages {
+		return nil, fmt.Errorf("%w: %d pages (limit %d)", ErrPDFTooManyPages, info.PageCount, maxPages)
+	}
+	return info, nil
+}
+
+// isRejectedContent - xato fayl mazmuni tekshiruvidan o'tmaganini bildiradimi (qayta urinish foyda bermaydi)
+func isRejectedContent(err error) bool {
+	return errors.Is(err, ErrUnsupportedFileType) || errors.Is(err, ErrFileTypeMismatch) ||
+		errors.Is(err, ErrInvalidPDF) || errors.Is(err, ErrPDFTooManyPages)
+}
+
+// fileMIMEType - files.file_type dan MIME turi; eski yuklangan fayllarda u kengaytma (".pdf") bo'ladi
+func fileMIMEType(file models.File) string {
+	if strings.Contains(file.FileType, "/") {
+		return file.FileType
+	}
+	if t, ok := extFileTypes[strings.ToLower(file.FileType)]; ok {
+		return t
+	}
+	return fileContentType(file, "")
+}
+
+// checkInputFiles - kiruvchi fayllar job egasiniki yoki mehmonnikimi (aks holda ErrFileAccessDenied),
+// karantinda emasmi (aks holda ErrFileQuarantined) va shu amal qabul qiladigan turdami (aks holda
+// ErrUnsupportedFileType). jobAssetTypes dagi amallarda birinchisidan keyingi fayllar - rasm.
+func checkInputFiles(ctx context.Context, stg storage.IStorage, jobType string, userID *string, inputIDs []string) error {
+	for i, id := range inputIDs {
+		allowed, typed := jobInputTypes[jobType]
+		if assets, ok := jobAssetTypes[jobType]; ok && i > 0 {
+			allowed = assets
+		}
+
+		file, err := stg.File().GetByID(ctx, id)
+		if err != nil {
+			// Turi cheklanmagan amallarda topilmagan faylni amalning o'zi aniqlaydi va o'z xatosini qaytaradi
+			if !typed && (errors.Is(err, pgx.ErrNoRows) || uuid.Validate(id) != nil) {
+				continue
+			}
+			return fmt.Errorf("failed to get input file %s: %w", id, err)
+		}
+
+		if !canUseFile(file, userID) {
+			return fmt.Errorf("%w: file %s", ErrFileAccessDenied, id)
+		}
+		if file.ScanStatus == models.ScanQuarantined {
+			return fmt.Errorf("%w: file %s", ErrFileQuarantined, id)
+		}
+		if !typed {
+			continue
+		}
+
+		fileType := fileMIMEType(file)
+		if !slices.Contains(allowed, fileType) {
+			return fmt.Errorf("%w: %s accepts %s, file %s is %s",
+				ErrUnsupportedFileType, jobType, strings.Join(allowed, ", "), id, fileType)
+		}
+	}
+	return nil
+}
+
+// canUseFile - egasi bor faylni faqat egasining joblari ishlatadi; mehmon fayllari ID orqali ochiq
+// (yuklab olishdagi kabi)
+func canUseFile(file models.File, userID *string) bool {
+	return file.UserID == nil || (userID != nil && *file.UserID == *userID)
+}
+
+// jobAssetIDs - amal params idagi qo'shimcha rasm IDlari. Batch va pipeline ularni o'z InputFileIDs iga
+// qo'shadi, shunda rasm job tugaguncha retention tomonidan o'chirilmaydi.
+func jobAssetIDs(jobType string, params json.RawMessage) []string {
+	switch jobType {
+	case models.JobTypeWatermark:
+		var req models.AddWatermarkRequest
+		if json.Unmarshal(params, &req) == nil && req.Type == watermarkTypeImage && req.ImageFileID != "" {
+			return []string{req.ImageFileID}
+		}
+	case models.JobTypeAddBackground:
+		var req models.CreateAddBackgroundRequest
+		if json.Unmarshal(params, &req) == nil && req.BackgroundImageFileID != "" {
+			return []string{req.BackgroundImageFileID}
+		}
+	}
+	return nil
+}
+
+// imageAsset - amalning qo'shimcha rasmini (suv belgisi, orqa fon) oladi. Pipeline/batch qadamlarida rasm
+// bola jobning InputFileIDs ida yo'q, shuning uchun egasi, karantin va turi shu yerda ham tekshiriladi;
+// topilmasa - errInvalid.
+func imageAsset(ctx context.Context, stg storage.IStorage, userID *string, id string, errInvalid error) (models.File, error) {
+	if uuid.Validate(id) != nil {
+		return models.File{}, fmt.Errorf("%w: image file id %q is not valid", errInvalid, id)
+	}
+
+	file, err := stg.File().GetByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.File{}, fmt.Errorf("%w: image file %s not found", errInvalid, id)
+	}
+	if err != nil {
+		return models.File{}, err
+	}
+
+	if !canUseFile(file, userID) {
+		return models.File{}, fmt.Errorf("%w: file %s", ErrFileAccessDenied, id)
+	}
+	if file.ScanStatus == models.ScanQuarantined {
+		return models.File{}, fmt.Errorf("%w: file %s", ErrFileQuarantined, id)
+	}
+	if fileType := fileMIMEType(file); fileType != mimePNG && fileType != mimeJPEG {
+		return models.File{}, fmt.Errorf("%w: image must be %s or %s, file %s is %s",
+			ErrUnsupportedFileType, mimePNG, mimeJPEG, id, fileType)
+	}
+	return file, nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"test/api/models"
+)
+
+func TestCheckInputFiles(t *testing.T) {
+	owner, other := "user-1", "user-2"
+
+	stg := newFakeStorage()
+	stg.files.put(models.File{ID: "pdf-own", UserID: &owner, FileType: mimePDF})
+	stg.files.put(models.File{ID: "pdf-guest", FileType: mimePDF})
+	stg.files.put(models.File{ID: "pdf-other", UserID: &other, FileType: mimePDF})
+	stg.files.put(models.File{ID: "pdf-bad", UserID: &owner, FileType: mimePDF, ScanStatus: models.ScanQuarantined})
+	stg.files.put(models.File{ID: "png-own", UserID: &owner, FileType: mimePNG})
+	stg.files.put(models.File{ID: "png-other", UserID: &other, FileType: mimePNG})
+
+	tests := []struct {
+		name    string
+		jobType string
+		userID  *string
+		ids     []string
+		wantErr error
+	}{
+		{"own file", models.JobTypeCompress, &owner, []string{"pdf-own"}, nil},
+		{"guest file used by user", models.JobTypeCompress, &owner, []string{"pdf-guest"}, nil},
+		{"guest file used by guest", models.JobTypeCompress, nil, []string{"pdf-guest"}, nil},
+		{"another user's file", models.JobTypeCompress, &owner, []string{"pdf-other"}, ErrFileAccessDenied},
+		{"user file used by guest", models.JobTypeCompress, nil, []string{"pdf-own"}, ErrFileAccessDenied},
+		{"quarantined", models.JobTypeCompress, &owner, []string{"pdf-bad"}, ErrFileQuarantined},
+		{"wrong type", models.JobTypeCompress, &owner, []string{"png-own"}, ErrUnsupportedFileType},
+		{"untyped job checks owner", models.JobTypePipeline, &owner, []string{"pdf-other"}, ErrFileAccessDenied},
+		{"watermark with image", models.JobTypeWatermark, &owner, []string{"pdf-own", "png-own"}, nil},
+		{"watermark with pdf as image", models.JobTypeWatermark, &owner, []string{"pdf-own", "pdf-guest"}, ErrUnsupportedFileType},
+		{"watermark with image as input", models.JobTypeWatermark, &owner, []string{"png-own"}, ErrUnsupportedFileType},
+		{"watermark with another user's image", models.JobTypeWatermark, &owner, []string{"pdf-own", "png-other"}, ErrFileAccessDenied},
+		{"background with image", models.JobTypeAddBackground, &owner, []string{"pdf-own", "png-own"}, nil},
+		{"background with pdf as image", models.JobTypeAddBackground, &owner, []string{"pdf-own", "pdf-guest"}, ErrUnsupportedFileType},
+		{"background with another user's image", models.JobTypeAddBackground, &owner, []string{"pdf-own", "png-other"}, ErrFileAccessDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkInputFiles(context.Background(), stg, tt.jobType, tt.userID, tt.ids)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJobAssetIDs(t *testing.T) {
+	params := func(v interface{}) json.RawMessage {
+		data, _ := json.Marshal(v)
+		return data
+	}
+
+	tests := []struct {
+		name    string
+		jobType string
+		params  json.RawMessage
+		want    []string
+	}{
+		{"image watermark", models.JobTypeWatermark, params(models.AddWatermarkRequest{Type: watermarkTypeImage, ImageFileID: "img"}), []string{"img"}},
+		{"text watermark", models.JobTypeWatermark, params(models.AddWatermarkRequest{Type: "text", Text: "DRAFT", ImageFileID: "img"}), nil},
+		{"image watermark without id", models.JobTypeWatermark, params(models.AddWatermarkRequest{Type: watermarkTypeImage}), nil},
+		{"background image", models.JobTypeAddBackground, params(models.CreateAddBackgroundRequest{BackgroundImageFileID: "bg"}), []string{"bg"}},
+		{"background color", models.JobTypeAddBackground, params(models.CreateAddBackgroundRequest{Color: "#FFFFFF"}), nil},
+		{"other type", models.JobTypeCompress, params(map[string]string{"image_file_id": "img"}), nil},
+		{"invalid params", models.JobTypeWatermark, json.RawMessage(`[`), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jobAssetIDs(tt.jobType, tt.params); !slices.Equal(got, tt.want) {
+				t.Errorf("jobAssetIDs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
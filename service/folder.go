@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+const (
+	maxNameLength = 255
+	maxFileTags   = 20
+	maxTagLength  = 50
+)
+
+var (
+	// ErrFolderNotFound - papka yo'q yoki boshqa foydalanuvchiniki
+	ErrFolderNotFound = errors.New("folder not found")
+	// ErrFolderExists - shu papka ichida bunday nomli papka bor
+	ErrFolderExists = errors.New("folder with this name already exists")
+	// ErrInvalidFolderMove - papkani o'zining ichiga ko'chirib bo'lmaydi
+	ErrInvalidFolderMove = errors.New("folder cannot be moved into itself")
+	// ErrInvalidName - fayl yoki papka nomi bo'sh, juda uzun yoki "/" bor
+	ErrInvalidName = errors.New("invalid name")
+	// ErrInvalidTag - teg bo'sh, juda uzun yoki teglar juda ko'p
+	ErrInvalidTag = errors.New("invalid tag")
+)
+
+// FolderService – foydalanuvchi fayllari kutubxonasidagi papkalar. Papka faqat egasiga ko'rinadi.
+type FolderService interface {
+	Create(ctx context.Context, userID string, req models.CreateFolderRequest) (*models.Folder, error)
+	GetList(ctx context.Context, userID string, parentID *string) ([]models.Folder, error)
+	Update(ctx context.Context, userID, id string, req models.UpdateFolderRequest) (*models.Folder, error)
+	Delete(ctx context.Context, userID, id string) error
+}
+
+type folderService struct {
+	stg storage.IStorage
+	log logger.ILogger
+}
+
+func NewFolderService(stg storage.IStorage, log logger.ILogger) FolderService {
+	return &folderService{
+		stg: stg,
+		log: log,
+	}
+}
+
+func (s *folderService) Create(ctx context.Context, userID string, req models.CreateFolderRequest) (*models.Folder, error) {
+	name, err := normalizeName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	parentID := emptyToNil(req.ParentID)
+	if parentID != nil {
+		if _, err := getUserFolder(ctx, s.stg, userID, *parentID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	folder := &models.Folder{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		ParentID:  parentID,
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.stg.Folder().Create(ctx, folder); err != nil {
+		return nil, folderError(err)
+	}
+	return folder, nil
+}
+
+func (s *folderService) GetList(ctx context.Context, userID string, parentID *string) ([]models.Folder, error) {
+	parentID = emptyToNil(parentID)
+	if parentID != nil {
+		if _, err := getUserFolder(ctx, s.stg, userID, *parentID); err != nil {
+			return nil, err
+		}
+	}
+	return s.stg.Folder().GetList(ctx, userID, parentID)
+}
+
+// Update - papkani qayta nomlaydi va/yoki ko'chiradi (parent_id "" - ildizga)
+func (s *folderService) Update(ctx context.Context, userID, id string, req models.UpdateFolderRequest) (*models.Folder, error) {
+	folder, err := getUserFolder(ctx, s.stg, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		if folder.Name, err = normalizeName(*req.Name); err != nil {
+			return nil, err
+		}
+	}
+	if req.ParentID != nil {
+		folder.ParentID = emptyToNil(req.ParentID)
+		if folder.ParentID != nil {
+			if _, err := getUserFolder(ctx, s.stg, userID, *folder.ParentID); err != nil {
+				return nil, err
+			}
+			cycle, err := s.stg.Folder().IsDescendant(ctx, *folder.ParentID, folder.ID)
+			if err != nil {
+				return nil, err
+			}
+			if cycle {
+				return nil, ErrInvalidFolderMove
+			}
+		}
+	}
+
+	folder.UpdatedAt = time.Now()
+	if err := s.stg.Folder().Update(ctx, folder); err != nil {
+		return nil, folderError(err)
+	}
+	return folder, nil
+}
+
+// Delete - papkani ichki papkalari bilan o'chiradi; fayllar o'chirilmaydi, ildizga o'tadi
+func (s *folderService) Delete(ctx context.Context, userID, id string) error {
+	if _, err := getUserFolder(ctx, s.stg, userID, id); err != nil {
+		return err
+	}
+	return s.stg.Folder().Delete(ctx, id)
+}
+
+// getUserFolder - papka shu foydalanuvchiniki bo'lsagina qaytadi, aks holda ErrFolderNotFound
+func getUserFolder(ctx context.Context, stg storage.IStorage, userID, id string) (*models.Folder, error) {
+	if uuid.Validate(id) != nil {
+		return nil, ErrFolderNotFound
+	}
+
+	folder, err := stg.Folder().GetByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrFolderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if folder.UserID != userID {
+		return nil, ErrFolderNotFound
+	}
+	return folder, nil
+}
+
+// folderError - unique_violation (23505) bir papkada bir xil nom degani
+func folderError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrFolderExists
+	}
+	return err
+}
+
+// normalizeName - fayl yoki papka nomini tekshiradi; bo'sh joylar olib tashlanadi
+func normalizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return "", fmt.Errorf("%w: must be 1-%d characters", ErrInvalidName, maxNameLength)
+	}
+	if strings.ContainsAny(name, `/\`) || strings.ContainsFunc(name, unicode.IsControl) {
+		return "", fmt.Errorf("%w: must not contain slashes or control characters", ErrInvalidName)
+	}
+	return name, nil
+}
+
+// normalizeTags - teglar kichik harfga o'tkaziladi, takrorlari olib tashlanadi (tartib saqlanadi)
+func normalizeTags(tags []string) ([]string, error) {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || utf8.RuneCountInString(tag) > maxTagLength || strings.ContainsFunc(tag, unicode.IsControl) {
+			return nil, fmt.Errorf("%w: %q must be 1-%d characters", ErrInvalidTag, tag, maxTagLength)
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			result = append(result, tag)
+		}
+	}
+	if len(result) > maxFileTags {
+		return nil, fmt.Errorf("%w: at most %d tags per file", ErrInvalidTag, maxFileTags)
+	}
+	return result, nil
+}
+
+// emptyToNil - "" (ildiz) ni nil ga aylantiradi
+func emptyToNil(id *string) *string {
+	if id == nil || *id == "" {
+		return nil
+	}
+	return id
+}
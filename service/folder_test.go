@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"test/api/models"
+	"test/config"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestFolderTree(t *testing.T) {
+	stg := newFakeStorage()
+	s := NewFolderService(stg, nopLogger{})
+	ctx := context.Background()
+
+	docs, err := s.Create(ctx, "u1", models.CreateFolderRequest{Name: "  Hujjatlar "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if docs.Name != "Hujjatlar" {
+		t.Errorf("name = %q, want trimmed", docs.Name)
+	}
+	year, err := s.Create(ctx, "u1", models.CreateFolderRequest{Name: "2025", ParentID: &docs.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Bir papkada bir xil nom bo'lmaydi, boshqa papkada esa bo'ladi
+	if _, err := s.Create(ctx, "u1", models.CreateFolderRequest{Name: "Hujjatlar"}); !errors.Is(err, ErrFolderExists) {
+		t.Errorf("duplicate name: err = %v, want ErrFolderExists", err)
+	}
+	if _, err := s.Create(ctx, "u1", models.CreateFolderRequest{Name: "Hujjatlar", ParentID: &docs.ID}); err != nil {
+		t.Errorf("same name in another folder: %v", err)
+	}
+
+	// Papkani o'ziga yoki o'z ichidagi papkaga ko'chirib bo'lmaydi
+	if _, err := s.Update(ctx, "u1", docs.ID, models.UpdateFolderRequest{ParentID: &docs.ID}); !errors.Is(err, ErrInvalidFolderMove) {
+		t.Errorf("move into itself: err = %v, want ErrInvalidFolderMove", err)
+	}
+	if _, err := s.Update(ctx, "u1", docs.ID, models.UpdateFolderRequest{ParentID: &year.ID}); !errors.Is(err, ErrInvalidFolderMove) {
+		t.Errorf("move into a child: err = %v, want ErrInvalidFolderMove", err)
+	}
+	moved, err := s.Update(ctx, "u1", year.ID, models.UpdateFolderRequest{ParentID: ptr("")})
+	if err != nil || moved.ParentID != nil {
+		t.Fatalf("move to root: %v, parent %v", err, moved)
+	}
+
+	// Boshqa foydalanuvchi papkasi "topilmadi" bo'lib ko'rinadi
+	if _, err := s.Create(ctx, "u2", models.CreateFolderRequest{Name: "x", ParentID: &docs.ID}); !errors.Is(err, ErrFolderNotFound) {
+		t.Errorf("foreign parent: err = %v, want ErrFolderNotFound", err)
+	}
+	if err := s.Delete(ctx, "u2", docs.ID); !errors.Is(err, ErrFolderNotFound) {
+		t.Errorf("foreign delete: err = %v, want ErrFolderNotFound", err)
+	}
+	if _, err := s.GetList(ctx, "u1", ptr("not-a-uuid")); !errors.Is(err, ErrFolderNotFound) {
+		t.Errorf("invalid id: err = %v, want ErrFolderNotFound", err)
+	}
+
+	root, err := s.GetList(ctx, "u1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, folder := range root {
+		names = append(names, folder.Name)
+	}
+	if !slices.Equal(names, []string{"2025", "Hujjatlar"}) {
+		t.Errorf("root folders = %v", names)
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	for _, name := range []string{"", "   ", "a/b", `a\b`, "a\x00b", strings.Repeat("я", maxNameLength+1)} {
+		if _, err := normalizeName(name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("normalizeName(%q) err = %v, want ErrInvalidName", name, err)
+		}
+	}
+	if got, err := normalizeName(" " + strings.Repeat("я", maxNameLength) + " "); err != nil || len([]rune(got)) != maxNameLength {
+		t.Errorf("name at the length limit: %v", err)
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	got, err := normalizeTags([]string{" Hisobot", "moliya", "HISOBOT", "2025"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, []string{"hisobot", "moliya", "2025"}) {
+		t.Errorf("tags = %v, want lowercased, deduplicated, order kept", got)
+	}
+
+	for _, tags := range [][]string{{""}, {strings.Repeat("a", maxTagLength+1)}, {"a\nb"}} {
+		if _, err := normalizeTags(tags); !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("normalizeTags(%q) err = %v, want ErrInvalidTag", tags, err)
+		}
+	}
+
+	many := make([]string, maxFileTags+1)
+	for i := range many {
+		many[i] = strings.Repeat("t", i+1)
+	}
+	if _, err := normalizeTags(many[:maxFileTags]); err != nil {
+		t.Errorf("%d tags: %v", maxFileTags, err)
+	}
+	if _, err := normalizeTags(many); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("%d tags: err = %v, want ErrInvalidTag", len(many), err)
+	}
+}
+
+func TestFileLibraryUpdate(t *testing.T) {
+	stg := newFakeStorage()
+	files := NewFileService(stg, nopLogger{}, config.Config{}, nil)
+	folders := NewFolderService(stg, nopLogger{})
+	ctx := context.Background()
+
+	uid, other := "u1", "u2"
+	docs, err := folders.Create(ctx, uid, models.CreateFolderRequest{Name: "Hujjatlar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreign, err := folders.Create(ctx, other, models.CreateFolderRequest{Name: "Boshqa"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stg.files.put(models.File{ID: "a", UserID: &uid, FileName: "a.pdf", Tags: []string{"moliya"}, UploadedAt: time.Now()})
+	stg.files.put(models.File{ID: "b", UserID: &uid, FileName: "b.pdf", UploadedAt: time.Now()})
+	stg.files.put(models.File{ID: "g", FileName: "g.pdf", UploadedAt: time.Now()})
+
+	file, _ := stg.files.GetByID(ctx, "b")
+	updated, err := files.Update(ctx, file, models.UpdateFileRequest{
+		FileName: ptr(" hisobot.pdf "),
+		FolderID: &docs.ID,
+		Tags:     &[]string{"Moliya", "2025"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.FileName != "hisobot.pdf" || updated.FolderID == nil || *updated.FolderID != docs.ID || !slices.Equal(updated.Tags, []string{"moliya", "2025"}) {
+		t.Errorf("updated = %+v", updated)
+	}
+
+	// Boshqa foydalanuvchi papkasiga va mehmon faylini papkaga ko'chirib bo'lmaydi
+	if _, err := files.Update(ctx, updated, models.UpdateFileRequest{FolderID: &foreign.ID}); !errors.Is(err, ErrFolderNotFound) {
+		t.Errorf("move to a foreign folder: err = %v, want ErrFolderNotFound", err)
+	}
+	guest, _ := stg.files.GetByID(ctx, "g")
+	if _, err := files.Update(ctx, guest, models.UpdateFileRequest{FolderID: &docs.ID}); !errors.Is(err, ErrFolderNotFound) {
+		t.Errorf("guest file into a folder: err = %v, want ErrFolderNotFound", err)
+	}
+	// Rad etilgan o'zgarish yozuvga tushmaydi
+	if stored, _ := stg.files.GetByID(ctx, "b"); stored.FolderID == nil || *stored.FolderID != docs.ID {
+		t.Errorf("rejected move changed the folder: %v", stored.FolderID)
+	}
+
+	// folder_id "" - ildizga qaytaradi, tags [] - hammasini olib tashlaydi
+	rooted, err := files.Update(ctx, updated, models.UpdateFileRequest{FolderID: ptr(""), Tags: &[]string{}})
+	if err != nil || rooted.FolderID != nil || len(rooted.Tags) != 0 {
+		t.Errorf("move to root: %v, %+v", err, rooted)
+	}
+
+	a, _ := stg.files.GetByID(ctx, "a")
+	if _, err := files.Update(ctx, a, models.UpdateFileRequest{Tags: &[]string{"moliya", "soliq"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := files.Update(ctx, rooted, models.UpdateFileRequest{Tags: &[]string{"soliq"}}); err != nil {
+		t.Fatal(err)
+	}
+	tags, err := files.ListTags(ctx, uid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []models.TagCount{{Tag: "soliq", Count: 2}, {Tag: "moliya", Count: 1}}; !slices.Equal(tags, want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+
+	pinned, err := files.SetPinned(ctx, "a", true)
+	if err != nil || !pinned.Pinned {
+		t.Fatalf("pin: %v, pinned %v", err, pinned.Pinned)
+	}
+	unpinned, err := files.SetPinned(ctx, "a", false)
+	if err != nil || unpinned.Pinned {
+		t.Errorf("unpin: %v, pinned %v", err, unpinned.Pinned)
+	}
+}
@@ -2,10 +2,9 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -17,91 +16,99 @@ import (
 
 type AddHeaderFooterService interface {
 	Create(ctx context.Context, req models.CreateAddHeaderFooterRequest, userID string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.AddHeaderFooterJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type addHeaderFooterService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewAddHeaderFooterService(stg storage.IStorage, log logger.ILogger) AddHeaderFooterService {
+func NewAddHeaderFooterService(stg storage.IStorage, log logger.ILogger, queue QueueService) AddHeaderFooterService {
 	return &addHeaderFooterService{
-		stg: stg,
-		log: log,
+		stg:   stg,
+		log:   log,
+		queue: queue,
 	}
 }
 
 func (s *addHeaderFooterService) Create(ctx context.Context, req models.CreateAddHeaderFooterRequest, userID string) (string, error) {
 	s.log.Info("AddHeaderFooterService.Create called")
 
-	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
-	if err != nil {
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
 		return "", fmt.Errorf("input file not found")
 	}
 
-	jobID := uuid.New().String()
-	job := &models.AddHeaderFooterJob{
-		ID:          jobID,
-		UserID:      userID,
-		InputFileID: req.InputFileID,
-		HeaderText:  req.HeaderText,
-		FooterText:  req.FooterText,
-		FontSize:    req.FontSize,
-		FontColor:   req.FontColor,
-		Position:    req.Position,
-		Status:      "pending",
-		CreatedAt:   time.Now(),
+	var owner *string
+	if userID != "" {
+		owner = &userID
+	}
+
+	job, err := newJob(models.JobTypeHeaderFooter, owner, []string{req.InputFileID}, req)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan header/footer jobni bajaradi
+func (s *addHeaderFooterService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.CreateAddHeaderFooterRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid header/footer params: %w", err)
+	}
+
+	outputID, err := s.addHeaderFooter(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputID}
+	return nil
+}
+
+func (s *addHeaderFooterService) addHeaderFooter(ctx context.Context, job *models.Job, req models.CreateAddHeaderFooterRequest) (string, error) {
+	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
+	if err != nil {
+		return "", fmt.Errorf("input file not found: %w", err)
 	}
 
-	err = s.stg.AddHeaderFooter().Create(ctx, job)
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
 	if err != nil {
 		return "", err
 	}
+	defer cleanup()
 
 	outputID := uuid.New().String()
 	outputPath := filepath.Join("storage/header_footer", outputID+".pdf")
 
 	params := addheaderfooter.AddHeaderFooterParams{
-		InputPath:  file.FilePath,
+		InputPath:  inputPath,
 		OutputPath: outputPath,
 		HeaderText: req.HeaderText,
 		FooterText: req.FooterText,
 		PageRange:  "all", // kerak bo‘lsa o‘zgartiring
 	}
 
-	err = addheaderfooter.AddHeaderFooterPDF(params)
-	if err != nil {
+	if err := addheaderfooter.AddHeaderFooterPDF(params); err != nil {
 		s.log.Error("failed to add header/footer", logger.Error(err))
 		return "", err
 	}
 
-	fi, _ := os.Stat(outputPath)
-	newFile := models.File{
-		ID:         outputID,
-		UserID:     &userID,
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/pdf",
-		FileSize:   fi.Size(),
-		UploadedAt: time.Now(),
-	}
-
-	_, err = s.stg.File().Save(ctx, newFile)
-	if err != nil {
-		return "", err
-	}
-
-	job.OutputFileID = outputID
-	job.Status = "done"
-	err = s.stg.AddHeaderFooter().Update(ctx, job)
-	if err != nil {
+	if err := saveOutputFile(ctx, s.stg, outputID, job.UserID, outputPath, "application/pdf"); err != nil {
 		return "", err
 	}
 
-	return jobID, nil
+	return outputID, nil
 }
 
-func (s *addHeaderFooterService) GetByID(ctx context.Context, id string) (*models.AddHeaderFooterJob, error) {
-	return s.stg.AddHeaderFooter().GetByID(ctx, id)
+func (s *addHeaderFooterService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	return getJobByType(ctx, s.stg, id, models.JobTypeHeaderFooter)
 }
@@ -2,9 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -16,29 +17,62 @@ import (
 
 type HTMLToPDFService interface {
 	Create(ctx context.Context, req models.CreateHTMLToPDFRequest, userID *string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.HTMLToPDFJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type htmlToPDFService struct {
 	stg       storage.IStorage
 	log       logger.ILogger
 	gotClient gotenberg.Client
+	queue     QueueService
 }
 
-func NewHTMLToPDFService(stg storage.IStorage, log logger.ILogger, gotClient gotenberg.Client) HTMLToPDFService {
+func NewHTMLToPDFService(stg storage.IStorage, log logger.ILogger, gotClient gotenberg.Client, queue QueueService) HTMLToPDFService {
 	return &htmlToPDFService{
 		stg:       stg,
 		log:       log,
 		gotClient: gotClient,
+		queue:     queue,
 	}
 }
 
 func (s *htmlToPDFService) Create(ctx context.Context, req models.CreateHTMLToPDFRequest, userID *string) (string, error) {
 	s.log.Info("HTMLToPDFService.Create called")
 
+	// HTML kontent job params ichida saqlanadi, worker uni bazadan o'qiydi
+	job, err := newJob(models.JobTypeHTMLToPDF, userID, nil, req)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("failed to submit html-to-pdf job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan HTML -> PDF jobni bajaradi
+func (s *htmlToPDFService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.CreateHTMLToPDFRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid html-to-pdf params: %w", err)
+	}
+
+	outputFileID, err := s.convert(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputFileID}
+	return nil
+}
+
+func (s *htmlToPDFService) convert(ctx context.Context, job *models.Job, req models.CreateHTMLToPDFRequest) (string, error) {
 	// 1. HTML faylni vaqtinchalik yaratish
-	tempID := uuid.NewString()
-	htmlFilePath := filepath.Join("tmp", tempID+".html")
+	htmlFilePath := filepath.Join("tmp", job.ID+".html")
 
 	if err := os.MkdirAll("tmp", os.ModePerm); err != nil {
 		s.log.Error("failed to create tmp dir", logger.Error(err))
@@ -49,6 +83,8 @@ func (s *htmlToPDFService) Create(ctx context.Context, req models.CreateHTMLToPD
 		s.log.Error("failed to write html file", logger.Error(err))
 		return "", err
 	}
+	// Vaqtinchalik HTML faylni o‘chirish
+	defer os.Remove(htmlFilePath)
 
 	// 2. Gotenberg orqali PDFga aylantirish
 	resultBytes, err := s.gotClient.HTMLToPDF(ctx, htmlFilePath)
@@ -57,8 +93,7 @@ func (s *htmlToPDFService) Create(ctx context.Context, req models.CreateHTMLToPD
 		return "", err
 	}
 
-	// 3. Yangi ID va chiqish faylini saqlash
-	jobID := uuid.NewString()
+	// 3. Chiqish faylini saqlash
 	outputFileID := uuid.NewString()
 	outputPath := filepath.Join("storage/html_to_pdf", outputFileID+".pdf")
 
@@ -69,42 +104,16 @@ func (s *htmlToPDFService) Create(ctx context.Context, req models.CreateHTMLToPD
 		return "", err
 	}
 
-	fi, _ := os.Stat(outputPath)
-	newFile := models.File{
-		ID:         outputFileID,
-		UserID:     userID,
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/pdf",
-		FileSize:   fi.Size(),
-		UploadedAt: time.Now(),
-	}
-	if _, err := s.stg.File().Save(ctx, newFile); err != nil {
+	if err := saveOutputFile(ctx, s.stg, outputFileID, job.UserID, outputPath, "application/pdf"); err != nil {
 		s.log.Error("failed to save output file", logger.Error(err))
 		return "", err
 	}
 
-	// 4. Jobni saqlash
-	job := &models.HTMLToPDFJob{
-		ID:           jobID,
-		UserID:       userID,
-		OutputFileID: &outputFileID,
-		Status:       "done",
-		CreatedAt:    time.Now(),
-	}
-	if err := s.stg.HTMLToPDF().Create(ctx, job); err != nil {
-		s.log.Error("failed to save html-to-pdf job", logger.Error(err))
-		return "", err
-	}
-
-	// 5. Vaqtinchalik HTML faylni o‘chirish
-	_ = os.Remove(htmlFilePath)
-
-	return jobID, nil
+	return outputFileID, nil
 }
 
-func (s *htmlToPDFService) GetByID(ctx context.Context, id string) (*models.HTMLToPDFJob, error) {
-	job, err := s.stg.HTMLToPDF().GetByID(ctx, id)
+func (s *htmlToPDFService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	job, err := getJobByType(ctx, s.stg, id, models.JobTypeHTMLToPDF)
 	if err != nil {
 		s.log.Error("HTMLToPDF job not found", logger.Error(err))
 		return nil, err
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+const (
+	idempotencyKeyPrefix = "pdfninja:idempotency:"
+
+	// Birinchi so'rov shu vaqt ichida tugamasa (masalan, instansiya o'chib qolsa), kalit yana bo'shaydi
+	idempotencyLockTTL = 5 * time.Minute
+)
+
+var (
+	// ErrIdempotencyInProgress - shu kalitli so'rov hali bajarilmoqda
+	ErrIdempotencyInProgress = errors.New("request with this idempotency key is still in progress")
+	// ErrIdempotencyKeyReused - kalit boshqa parametrli so'rov uchun ishlatilgan
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+)
+
+// IdempotencyService – Idempotency-Key → javob moslamasini Redisda saqlaydi,
+// shunda qayta yuborilgan so'rov yangi job yaratmasdan asl javobni oladi
+type IdempotencyService interface {
+	Begin(ctx context.Context, scope, key, fingerprint string) (*models.IdempotencyRecord, error)
+	Complete(ctx context.Context, scope, key string, record models.IdempotencyRecord) error
+	Release(ctx context.Context, scope, key string)
+}
+
+type idempotencyService struct {
+	redis storage.IRedisStorage
+	log   logger.ILogger
+	ttl   time.Duration
+}
+
+func NewIdempotencyService(redis storage.IRedisStorage, log logger.ILogger, cfg config.Config) IdempotencyService {
+	return &idempotencyService{
+		redis: redis,
+		log:   log,
+		ttl:   cfg.IdempotencyTTL,
+	}
+}
+
+// Begin - kalitni band qiladi. nil, nil qaytsa so'rov bajarilishi kerak;
+// yozuv qaytsa, bu avval saqlangan javob.
+func (s *idempotencyService) Begin(ctx context.Context, scope, key, fingerprint string) (*models.IdempotencyRecord, error) {
+	redisKey := idempotencyKey(scope, key)
+
+	lock, err := json.Marshal(models.IdempotencyRecord{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, err
+	}
+
+	acquired, err := s.redis.SetNX(ctx, redisKey, lock, idempotencyLockTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if acquired {
+		return nil, nil
+	}
+
+	data, err := s.redis.Get(ctx, redisKey)
+	if err != nil || data == "" {
+		// Kalit SetNX va Get orasida muddati tugab o'chgan - birinchi so'rov hali tugamagan deb hisoblaymiz
+		return nil, ErrIdempotencyInProgress
+	}
+
+	var record models.IdempotencyRecord
+	if err := json.Unmarshal([]byte(data), &record); err != nil {
+		return nil, fmt.Errorf("invalid idempotency record: %w", err)
+	}
+
+	if record.Fingerprint != fingerprint {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if !record.Completed {
+		return nil, ErrIdempotencyInProgress
+	}
+	return &record, nil
+}
+
+// Complete - muvaffaqiyatli javobni TTL davomida saqlaydi
+func (s *idempotencyService) Complete(ctx context.Context, scope, key string, record models.IdempotencyRecord) error {
+	record.Completed = true
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if err := s.redis.SetX(ctx, idempotencyKey(scope, key), data, s.ttl); err != nil {
+		s.log.Error("failed to save idempotent response", logger.String("key", key), logger.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Release - muvaffaqiyatsiz so'rovdan keyin kalitni bo'shatadi, mijoz shu kalit bilan qayta urinishi mumkin
+func (s *idempotencyService) Release(ctx context.Context, scope, key string) {
+	if err := s.redis.Del(ctx, idempotencyKey(scope, key)); err != nil {
+		s.log.Error("failed to release idempotency key", logger.String("key", key), logger.Error(err))
+	}
+}
+
+func idempotencyKey(scope, key string) string {
+	return idempotencyKeyPrefix + scope + ":" + key
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+
+	"test/api/models"
+	"test/config"
+	"test/pkg/blobstore"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+// blobTmpDir - lokal bo'lmagan blob drayverida kiruvchi fayllar vaqtincha shu yerga yuklab olinadi
+const blobTmpDir = "tmp/blobs"
+
+type JobService interface {
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	GetList(ctx context.Context, filter models.JobFilter) ([]models.Job, error)
+	Delete(ctx context.Context, id string) error
+	Retry(ctx context.Context, id string) (*models.Job, error)
+	Cancel(ctx context.Context, id string) (*models.Job, error)
+
+	// AccessToken - job yaratilganda mijozga beriladigan o'qish tokeni (mehmon ham shu orqali holat va oqimni oladi)
+	AccessToken(id string) string
+	// CheckAccessToken - token shu job uchun berilganmi
+	CheckAccessToken(id, token string) bool
+}
+
+type jobService struct {
+	stg    storage.IJobStorage
+	log    logger.ILogger
+	queue  QueueService
+	secret []byte
+}
+
+func NewJobService(stg storage.IStorage, log logger.ILogger, cfg config.Config, queue QueueService) JobService {
+	secret := cfg.JobTokenSecret
+	if secret == "" {
+		secret = cfg.JWTSecretKey
+	}
+	return &jobService{
+		stg:    stg.Job(),
+		log:    log,
+		queue:  queue,
+		secret: []byte(secret),
+	}
+}
+
+// AccessToken - job ID ning HMAC imzosi. Bazada saqlanmaydi: token faqat shu jobni o'qishga ruxsat beradi
+// va kalitni bilmasdan boshqa job uchun yasab bo'lmaydi
+func (s *jobService) AccessToken(id string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("job:" + id))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *jobService) CheckAccessToken(id, token string) bool {
+	if token == "" {
+		return false
+	}
+	return hmac.Equal([]byte(token), []byte(s.AccessToken(id)))
+}
+
+func (s *jobService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	job, err := s.stg.GetByID(ctx, id)
+	if err != nil {
+		s.log.Error("failed to get job", logger.String("jobID", id), logger.Error(err))
+		return nil, err
+	}
+	return job, nil
+}
+
+func (s *jobService) GetList(ctx context.Context, filter models.JobFilter) ([]models.Job, error) {
+	jobs, err := s.stg.GetList(ctx, filter)
+	if err != nil {
+		s.log.Error("failed to list jobs", logger.Error(err))
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (s *jobService) Delete(ctx context.Context, id string) error {
+	if err := s.stg.Delete(ctx, id); err != nil {
+		s.log.Error("failed to delete job", logger.String("jobID", id), logger.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Retry - failed yoki dead jobni qayta navbatga qo'yadi
+func (s *jobService) Retry(ctx context.Context, id string) (*models.Job, error) {
+	job, err := s.stg.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.queue.Retry(ctx, job); err != nil {
+		s.log.Error("failed to retry job", logger.String("jobID", id), logger.Error(err))
+		return nil, err
+	}
+
+	return s.stg.GetByID(ctx, id)
+}
+
+// Cancel - pending yoki processing jobni bekor qiladi
+func (s *jobService) Cancel(ctx context.Context, id string) (*models.Job, error) {
+	job, err := s.stg.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.queue.Cancel(ctx, job); err != nil {
+		s.log.Error("failed to cancel job", logger.String("jobID", id), logger.Error(err))
+		return nil, err
+	}
+
+	return s.stg.GetByID(ctx, id)
+}
+
+// newJob - yangi pending job tayyorlaydi, params JSON ko'rinishida saqlanadi
+func newJob(jobType string, userID *string, inputFileIDs []string, params interface{}) (*models.Job, error) {
+	job := &models.Job{
+		ID:           uuid.NewString(),
+		UserID:       userID,
+		Type:         jobType,
+		InputFileIDs: inputFileIDs,
+		Status:       models.Pending,
+		CreatedAt:    time.Now(),
+	}
+
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal job params: %w", err)
+		}
+		job.Params = data
+	}
+
+	return job, nil
+}
+
+// getJobByType - jobni oladi va uning turi kutilgan turga mosligini tekshiradi
+func getJobByType(ctx context.Context, stg storage.IStorage, id, jobType string) (*models.Job, error) {
+	job, err := stg.Job().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Type != jobType {
+		return nil, fmt.Errorf("job %s is not a %s job", id, jobType)
+	}
+	return job, nil
+}
+
+// setJobResult - amalga xos natijani job.Result ga yozadi
+func setJobResult(job *models.Job, result interface{}) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job result: %w", err)
+	}
+	job.Result = data
+	return nil
+}
+
+// runChildJob - parent (pipeline yoki batch) ichida bola job yaratib, navbatni kutmasdan shu workerda bajaradi.
+// Bola job qayta urinilmaydi (max_attempts = 1); secret bo'lsa params bazaga emas, payloadga yoziladi.
+// Slot va vaqt chegaralari oddiy joblar kabi qo'llanadi (QueueService.ExecuteChild).
+func runChildJob(ctx context.Context, stg storage.IStorage, queue QueueService, parent *models.Job, jobType string, secret bool, params json.RawMessage, inputIDs []string) (*models.Job, error) {
+	child := &models.Job{
+		ID:           uuid.NewString(),
+		UserID:       parent.UserID,
+		ParentID:     &parent.ID,
+		Type:         jobType,
+		InputFileIDs: inputIDs,
+		Status:       models.Pending,
+		MaxAttempts:  1,
+		CreatedAt:    time.Now(),
+	}
+
+	task := models.QueueTask{
+		JobID:      child.ID,
+		JobType:    jobType,
+		UserID:     parent.UserID,
+		ParentID:   parent.ID,
+		EnqueuedAt: time.Now(),
+	}
+	if secret {
+		task.Payload = params
+	} else {
+		child.Params = params
+	}
+
+	if err := stg.Job().Create(ctx, child); err != nil {
+		return nil, fmt.Errorf("failed to create child job: %w", err)
+	}
+
+	// Bola job xatosi uning statusida saqlanadi, shuning uchun bu yerda faqat ctx xatosi muhim
+	if err := queue.ExecuteChild(ctx, task); err != nil && ctx.Err() != nil {
+		return nil, err
+	}
+
+	return stg.Job().GetByID(ctx, child.ID)
+}
+
+// runTool - tashqi dasturni job ctx bilan ishga tushiradi: job bekor qilinsa yoki vaqti tugasa jarayon o'ldiriladi.
+// Xato bo'lsa, qisman yozilgan outputPath o'chiriladi.
+func runTool(ctx context.Context, outputPath, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	if err == nil {
+		return nil
+	}
+
+	if outputPath != "" {
+		_ = os.Remove(outputPath)
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("%s interrupted: %w", name, ctx.Err())
+	}
+	return fmt.Errorf("%s failed: %w", name, err)
+}
+
+// fetchInput - kiruvchi faylni tashqi dasturlar o'qiy oladigan lokal yo'lga olib keladi.
+// cleanup chaqirilgandan keyin yo'l ishlatilmasligi kerak.
+func fetchInput(ctx context.Context, stg storage.IStorage, file models.File) (string, func(), error) {
+	path, cleanup, err := blobstore.FetchFile(ctx, stg.Blob(), file.FilePath, blobTmpDir)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to fetch input file %s: %w", file.ID, err)
+	}
+	return path, cleanup, nil
+}
+
+// saveOutputFile - diskdagi natija faylini kontent manzili bo'yicha blob storagega yuklaydi va
+// files jadvaliga yozadi. Shu mazmunli blob bo'lsa qayta yuklanmaydi; diskdagi nusxa o'chiriladi.
+// Natija ham egasining xotira kvotasiga kiradi - sig'masa ErrQuotaExceeded.
+func saveOutputFile(ctx context.Context, stg storage.IStorage, fileID string, userID *string, path, fileType string) error {
+	key, sum, size, err := storeFile(ctx, stg, path, filepath.Ext(path), fileType)
+	if err != nil {
+		return fmt.Errorf("failed to store output file: %w", err)
+	}
+	_ = os.Remove(path)
+
+	err = saveFileRecord(ctx, stg, models.File{
+		ID:         fileID,
+		UserID:     userID,
+		FileName:   filepath.Base(path),
+		FilePath:   key,
+		FileType:   fileType,
+		FileSize:   size,
+		SHA256:     sum,
+		UploadedAt: time.Now(),
+	})
+	if err != nil {
+		_ = releaseBlob(stg, key, sum)
+		return fmt.Errorf("failed to save output file: %w", err)
+	}
+	return nil
+}
+
+// deleteBlob - faylni blob storagedan o'chiradi; job ctx si tugagan bo'lishi mumkinligi uchun alohida ctx ishlatiladi
+func deleteBlob(stg storage.IStorage, key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return stg.Blob().Delete(ctx, key)
+}
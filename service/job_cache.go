@@ -0,0 +1,351 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"test/api/models"
+	"test/config"
+	"test/pkg/blobstore"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+const (
+	// jobCacheVersion - amallar natijasi o'zgaradigan yangilanishda oshiriladi, eski yozuvlar ishlatilmaydi
+	jobCacheVersion       = "v1"
+	jobCacheCleanupPeriod = 30 * time.Minute
+)
+
+// cacheableJobTypes - natijasi faqat kiruvchi fayllar va params ga bog'liq amallar.
+// Secret params (parol) payloadda keladi va keshlanmaydi; html_to_pdf tashqi resurslarni yuklashi mumkin.
+var cacheableJobTypes = map[string]bool{
+	models.JobTypeMerge:           true,
+	models.JobTypeSplit:           true,
+	models.JobTypeRemovePages:     true,
+	models.JobTypeExtractPages:    true,
+	models.JobTypeCompress:        true,
+	models.JobTypeJPGToPDF:        true,
+	models.JobTypePDFToJPG:        true,
+	models.JobTypeRotate:          true,
+	models.JobTypeOrganize:        true,
+	models.JobTypeWatermark:       true,
+	models.JobTypeAddBackground:   true,
+	models.JobTypeCrop:            true,
+	models.JobTypeAddPageNumbers:  true,
+	models.JobTypeInspect:         true,
+	models.JobTypeHeaderFooter:    true,
+	models.JobTypeDetectBlank:     true,
+	models.JobTypeQRCode:          true,
+	models.JobTypePDFToWord:       true,
+	models.JobTypeWordToPDF:       true,
+	models.JobTypeExcelToPDF:      true,
+	models.JobTypePowerPointToPDF: true,
+}
+
+// JobCacheService – bir xil kiruvchi fayllar (SHA-256 bo'yicha) va params bilan takrorlangan job
+// natijasini qayta hisoblamasdan beradi. Kesh natija bloblariga o'z havolasini ushlab turadi,
+// shuning uchun manba jobning fayllari o'chirilsa ham yozuv ishlaydi.
+type JobCacheService interface {
+	Key(ctx context.Context, job *models.Job, task models.QueueTask) (string, bool)
+	Load(ctx context.Context, key string, job *models.Job) (bool, error)
+	Store(ctx context.Context, key string, job *models.Job) error
+	Stats(ctx context.Context) (*models.JobCacheStats, error)
+	Purge(ctx context.Context, filter models.JobCacheFilter) (int, error)
+	RunCleanup(ctx context.Context)
+}
+
+type jobCacheService struct {
+	stg storage.IStorage
+	log logger.ILogger
+	cfg config.Config
+}
+
+func NewJobCacheService(stg storage.IStorage, log logger.ILogger, cfg config.Config) JobCacheService {
+	return &jobCacheService{
+		stg: stg,
+		log: log,
+		cfg: cfg,
+	}
+}
+
+// Key - kesh kaliti: amal turi, kanonik params (fayl IDlari xeshlarga almashtirilgan) va kiruvchi
+// fayllar xeshlari. false - job keshlanmaydi (turi mos emas, secret params yoki xeshsiz fayl).
+func (s *jobCacheService) Key(ctx context.Context, job *models.Job, task models.QueueTask) (string, bool) {
+	if !s.cfg.JobCacheEnabled || !cacheableJobTypes[job.Type] || len(task.Payload) > 0 || len(job.InputFileIDs) == 0 {
+		return "", false
+	}
+
+	hashes := make([]string, 0, len(job.InputFileIDs))
+	replace := make(map[string]string, len(job.InputFileIDs))
+	for _, id := range job.InputFileIDs {
+		file, err := s.stg.File().GetByID(ctx, id)
+		if err != nil || file.SHA256 == "" {
+			return "", false
+		}
+		hashes = append(hashes, file.SHA256)
+		replace[id] = "sha256:" + file.SHA256
+	}
+
+	params, err := remapJSON(job.Params, replace)
+	if err != nil {
+		return "", false
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n%s", jobCacheVersion, job.Type, params, strings.Join(hashes, ","))
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+// Load - keshda natija bo'lsa, job egasi uchun yangi files yozuvlarini (o'sha bloblarga) yaratadi
+// va result dagi fayl IDlarini almashtiradi. Buzilgan yozuv o'chiriladi va false qaytadi.
+func (s *jobCacheService) Load(ctx context.Context, key string, job *models.Job) (bool, error) {
+	entry, err := s.stg.JobCache().Get(ctx, key)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	ids := make(map[string]string, len(entry.Outputs))
+	outputIDs := make([]string, 0, len(entry.Outputs))
+	for _, out := range entry.Outputs {
+		file, err := s.copyOutput(ctx, out, job.UserID)
+		if err != nil {
+			s.dropFiles(outputIDs)
+			if errors.Is(err, blobstore.ErrNotFound) {
+				s.purgeQuietly(models.JobCacheFilter{Key: key})
+				return false, nil
+			}
+			return false, err
+		}
+		ids[out.FileID] = file.ID
+		outputIDs = append(outputIDs, file.ID)
+	}
+
+	result, err := remapJSON(entry.Result, ids)
+	if err != nil {
+		s.dropFiles(outputIDs)
+		return false, err
+	}
+
+	job.OutputFileIDs = outputIDs
+	job.Result = result
+	job.CacheHit = true
+	_ = s.stg.JobCache().Hit(ctx, key)
+	return true, nil
+}
+
+// copyOutput - keshdagi blobga yangi havola va job egasiga tegishli files yozuvi
+func (s *jobCacheService) copyOutput(ctx context.Context, out models.CachedOutput, userID *string) (models.File, error) {
+	blob := models.FileBlob{Key: out.FilePath, SHA256: out.SHA256, Size: out.Size}
+	_, err := s.stg.FileBlob().Acquire(ctx, blob, func(ctx context.Context) error {
+		return fmt.Errorf("%w: %s", blobstore.ErrNotFound, out.FilePath)
+	})
+	if err != nil {
+		return models.File{}, err
+	}
+
+	id := uuid.NewString()
+	name := out.FileName
+	if strings.HasPrefix(name, out.FileID) {
+		name = id + strings.TrimPrefix(name, out.FileID)
+	}
+
+	file := models.File{
+		ID:         id,
+		UserID:     userID,
+		FileName:   name,
+		FilePath:   out.FilePath,
+		FileType:   out.FileType,
+		FileSize:   out.Size,
+		SHA256:     out.SHA256,
+		UploadedAt: time.Now(),
+	}
+	if err := saveFileRecord(ctx, s.stg, file); err != nil {
+		_ = releaseBlob(s.stg, out.FilePath, out.SHA256)
+		return models.File{}, err
+	}
+	return file, nil
+}
+
+// Store - muvaffaqiyatli job natijasini keshga yozadi; xeshsiz natija fayli bo'lsa keshlanmaydi
+func (s *jobCacheService) Store(ctx context.Context, key string, job *models.Job) error {
+	now := time.Now()
+	entry := &models.JobCacheEntry{
+		Key:         key,
+		JobType:     job.Type,
+		SourceJobID: job.ID,
+		Outputs:     []models.CachedOutput{},
+		Result:      job.Result,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.cfg.JobCacheTTL),
+	}
+
+	for _, id := range job.OutputFileIDs {
+		file, err := s.stg.File().GetByID(ctx, id)
+		if err != nil {
+			s.releaseOutputs(entry.Outputs)
+			return err
+		}
+		if file.SHA256 == "" {
+			s.releaseOutputs(entry.Outputs)
+			return nil
+		}
+
+		blob := models.FileBlob{Key: file.FilePath, SHA256: file.SHA256, Size: file.FileSize}
+		_, err = s.stg.FileBlob().Acquire(ctx, blob, func(ctx context.Context) error {
+			return fmt.Errorf("%w: %s", blobstore.ErrNotFound, file.FilePath)
+		})
+		if err != nil {
+			s.releaseOutputs(entry.Outputs)
+			return err
+		}
+
+		entry.Outputs = append(entry.Outputs, models.CachedOutput{
+			FileID:   file.ID,
+			FileName: file.FileName,
+			FileType: file.FileType,
+			FilePath: file.FilePath,
+			SHA256:   file.SHA256,
+			Size:     file.FileSize,
+		})
+		entry.Size += file.FileSize
+	}
+
+	created, err := s.stg.JobCache().Create(ctx, entry)
+	if err != nil || !created {
+		s.releaseOutputs(entry.Outputs)
+	}
+	return err
+}
+
+func (s *jobCacheService) Stats(ctx context.Context) (*models.JobCacheStats, error) {
+	types, err := s.stg.JobCache().Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.JobCacheStats{
+		Enabled: s.cfg.JobCacheEnabled,
+		TTL:     s.cfg.JobCacheTTL.String(),
+		Types:   types,
+	}
+	for _, t := range types {
+		stats.Entries += t.Entries
+		stats.Hits += t.Hits
+		stats.Size += t.Size
+	}
+	return stats, nil
+}
+
+// Purge - yozuvlarni o'chiradi va ular ushlab turgan blob havolalarini bo'shatadi
+func (s *jobCacheService) Purge(ctx context.Context, filter models.JobCacheFilter) (int, error) {
+	entries, err := s.stg.JobCache().Delete(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, entry := range entries {
+		s.releaseOutputs(entry.Outputs)
+	}
+	if len(entries) > 0 {
+		s.log.Info("job cache purged", logger.Int("entries", len(entries)), logger.String("type", filter.JobType))
+	}
+	return len(entries), nil
+}
+
+// RunCleanup - muddati o'tgan yozuvlarni davriy ravishda o'chiradi
+func (s *jobCacheService) RunCleanup(ctx context.Context) {
+	ticker := time.NewTicker(jobCacheCleanupPeriod)
+	defer ticker.Stop()
+
+	for {
+		s.purgeQuietly(models.JobCacheFilter{ExpiredOnly: true})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *jobCacheService) purgeQuietly(filter models.JobCacheFilter) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if _, err := s.Purge(ctx, filter); err != nil {
+		s.log.Error("failed to purge job cache", logger.Error(err))
+	}
+}
+
+func (s *jobCacheService) releaseOutputs(outputs []models.CachedOutput) {
+	for _, out := range outputs {
+		if err := releaseBlob(s.stg, out.FilePath, out.SHA256); err != nil {
+			s.log.Error("failed to release cached output", logger.String("path", out.FilePath), logger.Error(err))
+		}
+	}
+}
+
+// dropFiles - qisman yaratilgan nusxa fayllarini o'chiradi
+func (s *jobCacheService) dropFiles(ids []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for _, id := range ids {
+		file, err := s.stg.File().GetByID(ctx, id)
+		if err != nil {
+			continue
+		}
+		if err := s.stg.File().Delete(ctx, id); err != nil {
+			continue
+		}
+		_ = releaseBlob(s.stg, file.FilePath, file.SHA256)
+	}
+}
+
+// remapJSON - JSON ichidagi replace kalitlariga teng satrlarni almashtiradi. Natija kanonik:
+// obyekt kalitlari saralangan holda qayta kodlanadi, raqamlar o'zgarmaydi.
+func remapJSON(data json.RawMessage, replace map[string]string) (json.RawMessage, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(replaceStrings(v, replace))
+}
+
+func replaceStrings(v interface{}, replace map[string]string) interface{} {
+	switch t := v.(type) {
+	case string:
+		if r, ok := replace[t]; ok {
+			return r
+		}
+	case []interface{}:
+		for i := range t {
+			t[i] = replaceStrings(t[i], replace)
+		}
+	case map[string]interface{}:
+		for k, x := range t {
+			t[k] = replaceStrings(x, replace)
+		}
+	}
+	return v
+}
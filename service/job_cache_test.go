@@ -0,0 +1,38 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRemapJSON(t *testing.T) {
+	replace := map[string]string{"file-a": "copy-a", "file-b": "copy-b"}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{"empty", "", "", false},
+		{"string", `"file-a"`, `"copy-a"`, false},
+		{"array", `["file-a","file-b","file-c"]`, `["copy-a","copy-b","file-c"]`, false},
+		{"nested", `{"steps":[{"input":"file-a"}],"image":"file-b"}`, `{"image":"copy-b","steps":[{"input":"copy-a"}]}`, false},
+		{"keys are not replaced", `{"file-a":"x"}`, `{"file-a":"x"}`, false},
+		{"substring is not replaced", `{"id":"file-a1"}`, `{"id":"file-a1"}`, false},
+		{"numbers kept exact", `{"n":12345678901234567890,"f":0.10}`, `{"f":0.10,"n":12345678901234567890}`, false},
+		{"canonical key order", `{ "b": 1, "a": [true, null] }`, `{"a":[true,null],"b":1}`, false},
+		{"invalid", `{"a":`, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := remapJSON(json.RawMessage(tt.data), replace)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("remapJSON(%s) = %s, want %s", tt.data, got, tt.want)
+			}
+		})
+	}
+}
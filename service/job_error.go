@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"test/pkg/blobstore"
+	"test/pkg/gotenberg"
+)
+
+// Job xatolik kodlari (jobs.error_code)
+const (
+	ErrCodeInvalidInput        = "invalid_input"
+	ErrCodeInputNotFound       = "input_not_found"
+	ErrCodeTimeout             = "timeout"
+	ErrCodeUpstreamUnavailable = "upstream_unavailable"
+	ErrCodeConversionFailed    = "conversion_failed"
+	ErrCodeDatabase            = "database_error"
+	ErrCodeProcessingFailed    = "processing_failed"
+	ErrCodePanic               = "panic"
+	ErrCodeQuotaExceeded       = "quota_exceeded"
+	ErrCodeAbandoned           = "abandoned" // eski jadvallardan ko'chirilgan va hech qachon bajarilmagan job (0018 migratsiya)
+)
+
+var (
+	// ErrJobNotRetryable - faqat failed, dead yoki cancelled (parolsiz) jobni qayta ishga tushirish mumkin
+	ErrJobNotRetryable = errors.New("job cannot be retried")
+	// ErrJobNotCancellable - faqat pending yoki processing jobni bekor qilish mumkin
+	ErrJobNotCancellable = errors.New("job cannot be cancelled")
+	// ErrJobCancelled - bajarilayotgan job ctx si shu sabab bilan to'xtatiladi
+	ErrJobCancelled = errors.New("job cancelled")
+	// ErrUnknownJobType - bunday amal turi ro'yxatdan o'tmagan (yoki unga chegara qo'yilmagan)
+	ErrUnknownJobType = errors.New("unknown job type")
+
+	errWorkerShutdown = errors.New("worker shutting down")
+	errNoFreeSlot     = errors.New("no free worker slot within the job timeout")
+)
+
+// JobError - job nima uchun muvaffaqiyatsiz tugaganini tavsiflaydi
+type JobError struct {
+	Code      string
+	Transient bool // true bo'lsa, job backoff bilan qayta urinib ko'riladi
+	Err       error
+}
+
+func (e *JobError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *JobError) Unwrap() error {
+	return e.Err
+}
+
+// classifyJobError - handler qaytargan xatoni kod va vaqtinchalik/doimiy turga ajratadi
+func classifyJobError(err error) *JobError {
+	var jobErr *JobError
+	if errors.As(err, &jobErr) {
+		return jobErr
+	}
+
+	var (
+		statusErr    *gotenberg.StatusError
+		s3Err        *blobstore.S3Error
+		pgErr        *pgconn.PgError
+		netErr       net.Error
+		exitErr      *exec.ExitError
+		syntaxErr    *json.SyntaxError
+		unmarshalErr *json.UnmarshalTypeError
+	)
+
+	switch {
+	case errors.Is(err, ErrQuotaExceeded):
+		return &JobError{Code: ErrCodeQuotaExceeded, Err: err}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &JobError{Code: ErrCodeTimeout, Transient: true, Err: err}
+	case errors.As(err, &statusErr):
+		if statusErr.Temporary() {
+			return &JobError{Code: ErrCodeUpstreamUnavailable, Transient: true, Err: err}
+		}
+		return &JobError{Code: ErrCodeConversionFailed, Err: err}
+	case errors.As(err, &s3Err):
+		return &JobError{Code: ErrCodeUpstreamUnavailable, Transient: s3Err.Temporary(), Err: err}
+	case pgconn.Timeout(err):
+		return &JobError{Code: ErrCodeDatabase, Transient: true, Err: err}
+	case errors.As(err, &pgErr):
+		// 08 - connection exception, 40 - transaction rollback, 57P - operator intervention
+		transient := len(pgErr.Code) >= 2 && (pgErr.Code[:2] == "08" || pgErr.Code[:2] == "40" || pgErr.Code == "57P01")
+		return &JobError{Code: ErrCodeDatabase, Transient: transient, Err: err}
+	case errors.Is(err, pgx.ErrNoRows), errors.Is(err, os.ErrNotExist), errors.Is(err, blobstore.ErrNotFound):
+		return &JobError{Code: ErrCodeInputNotFound, Err: err}
+	case errors.As(err, &netErr):
+		return &JobError{Code: ErrCodeUpstreamUnavailable, Transient: true, Err: err}
+	case errors.As(err, &syntaxErr), errors.As(err, &unmarshalErr):
+		return &JobError{Code: ErrCodeInvalidInput, Err: err}
+	case errors.As(err, &exitErr):
+		return &JobError{Code: ErrCodeProcessingFailed, Err: err}
+	default:
+		return &JobError{Code: ErrCodeProcessingFailed, Err: err}
+	}
+}
+
+func panicError(r interface{}) *JobError {
+	return &JobError{Code: ErrCodePanic, Err: fmt.Errorf("job panicked: %v", r)}
+}
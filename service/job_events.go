@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+const jobEventsChannel = "pdfninja:job-events:"
+
+// JobEventService – job hodisalarini Redis pub/sub orqali barcha API instansiyalariga tarqatadi
+type JobEventService interface {
+	Publish(ctx context.Context, event models.JobEvent)
+	Subscribe(ctx context.Context, jobID string) (<-chan models.JobEvent, func(), error)
+	AllowedOrigin(origin string) bool
+}
+
+type jobEventService struct {
+	redis   storage.IRedisStorage
+	log     logger.ILogger
+	origins map[string]bool
+}
+
+func NewJobEventService(redis storage.IRedisStorage, log logger.ILogger, cfg config.Config) JobEventService {
+	origins := make(map[string]bool)
+	for _, origin := range strings.Split(cfg.WSAllowedOrigins, ",") {
+		if origin = normalizeOrigin(origin); origin != "" {
+			origins[origin] = true
+		}
+	}
+
+	return &jobEventService{
+		redis:   redis,
+		log:     log,
+		origins: origins,
+	}
+}
+
+// Publish - hodisani yuboradi; xato bo'lsa faqat log qilinadi, job to'xtamaydi
+func (s *jobEventService) Publish(ctx context.Context, event models.JobEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		s.log.Error("failed to marshal job event", logger.Error(err))
+		return
+	}
+
+	if err := s.redis.Publish(ctx, jobEventsChannel+event.JobID, data); err != nil {
+		s.log.Error("failed to publish job event", logger.String("jobID", event.JobID), logger.Error(err))
+	}
+}
+
+// Subscribe - bitta job hodisalariga obuna; qaytgan funksiya obunani yopadi
+func (s *jobEventService) Subscribe(ctx context.Context, jobID string) (<-chan models.JobEvent, func(), error) {
+	messages, closeFn, err := s.redis.Subscribe(ctx, jobEventsChannel+jobID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events := make(chan models.JobEvent)
+	go func() {
+		defer close(events)
+		for msg := range messages {
+			var event models.JobEvent
+			if err := json.Unmarshal([]byte(msg), &event); err != nil {
+				s.log.Error("invalid job event", logger.String("raw", msg), logger.Error(err))
+				continue
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, func() { _ = closeFn() }, nil
+}
+
+// AllowedOrigin - WS_ALLOWED_ORIGINS da bormi (API ning o'z manzilini handler tekshiradi)
+func (s *jobEventService) AllowedOrigin(origin string) bool {
+	return s.origins[normalizeOrigin(origin)]
+}
+
+// normalizeOrigin - "https://App.uz/" va "https://app.uz" bir xil
+func normalizeOrigin(origin string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(origin)), "/")
+}
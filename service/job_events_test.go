@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+
+	"test/config"
+)
+
+func TestJobEventsAllowedOrigin(t *testing.T) {
+	events := NewJobEventService(newFakeRedis(), nopLogger{}, config.Config{
+		WSAllowedOrigins: " https://App.example.uz/ ,http://localhost:3000,,",
+	})
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://app.example.uz", true},
+		{"https://APP.example.uz/", true},
+		{"http://localhost:3000", true},
+		{"http://localhost:3001", false},
+		{"http://app.example.uz", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := events.AllowedOrigin(tt.origin); got != tt.want {
+			t.Errorf("AllowedOrigin(%q) = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+
+	if NewJobEventService(newFakeRedis(), nopLogger{}, config.Config{}).AllowedOrigin("") {
+		t.Error("empty WS_ALLOWED_ORIGINS allows the empty origin")
+	}
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"testing"
+
+	"test/config"
+)
+
+func TestJobAccessToken(t *testing.T) {
+	jobs := NewJobService(&fakeStorage{}, nopLogger{}, config.Config{JobTokenSecret: "secret"}, nil)
+
+	token := jobs.AccessToken("job-1")
+	if !jobs.CheckAccessToken("job-1", token) {
+		t.Fatal("token issued for the job must be accepted")
+	}
+	if jobs.CheckAccessToken("job-2", token) {
+		t.Error("token of another job must be rejected")
+	}
+	if jobs.CheckAccessToken("job-1", "") {
+		t.Error("empty token must be rejected")
+	}
+
+	// Boshqa kalit bilan imzolangan token qabul qilinmaydi; kalit berilmasa JWT kaliti ishlatiladi
+	other := NewJobService(&fakeStorage{}, nopLogger{}, config.Config{JobTokenSecret: "other"}, nil)
+	if jobs.CheckAccessToken("job-1", other.AccessToken("job-1")) {
+		t.Error("token signed with another secret must be rejected")
+	}
+	fallback := NewJobService(&fakeStorage{}, nopLogger{}, config.Config{JWTSecretKey: "secret"}, nil)
+	if fallback.AccessToken("job-1") != token {
+		t.Error("JWT secret must be used when JOB_TOKEN_SECRET is empty")
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/jung-kurt/gofpdf"
@@ -17,18 +16,21 @@ import (
 
 type JPGToPDFService interface {
 	CreateJob(ctx context.Context, userID *string, inputFileIDs []string) (string, error)
-	GetJobByID(ctx context.Context, id string) (*models.JPGToPDFJob, error)
+	GetJobByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type jpgToPDFService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewJPGToPDFService(stg storage.IStorage, log logger.ILogger) JPGToPDFService {
+func NewJPGToPDFService(stg storage.IStorage, log logger.ILogger, queue QueueService) JPGToPDFService {
 	return &jpgToPDFService{
-		stg: stg,
-		log: log,
+		stg:   stg,
+		log:   log,
+		queue: queue,
 	}
 }
 
@@ -39,38 +41,66 @@ func (s *jpgToPDFService) CreateJob(ctx context.Context, userID *string, inputFi
 		return "", fmt.Errorf("no input files provided")
 	}
 
-	var inputPaths []string
-	// Retrieve all file paths corresponding to input file IDs
+	// Check that all input files exist before queueing the job
 	for _, fileID := range inputFileIDs {
 		file, err := s.stg.File().GetByID(ctx, fileID)
 		if err != nil {
 			s.log.Error("file not found", logger.String("fileID", fileID), logger.Error(err))
 			return "", fmt.Errorf("file not found: %s", fileID)
 		}
-		// Check if the file path exists before proceeding
-		if _, err := os.Stat(file.FilePath); os.IsNotExist(err) {
-			s.log.Error("Input file does not exist", logger.String("filePath", file.FilePath))
+		if _, err := s.stg.Blob().Stat(ctx, file.FilePath); err != nil {
+			s.log.Error("Input file does not exist", logger.String("filePath", file.FilePath), logger.Error(err))
 			return "", fmt.Errorf("input file does not exist: %s", file.FilePath)
 		}
-		inputPaths = append(inputPaths, file.FilePath)
 	}
 
-	// Generate a new job ID
-	jobID := uuid.New().String()
-	job := &models.JPGToPDFJob{
-		ID:           jobID,
-		UserID:       userID, // Pass nil for guest users
-		InputFileIDs: inputFileIDs,
-		Status:       "pending",
-		CreatedAt:    time.Now(),
+	// Create the job and put it on the queue, the conversion is done by a worker
+	job, err := newJob(models.JobTypeJPGToPDF, userID, inputFileIDs, nil)
+	if err != nil {
+		return "", err
 	}
 
-	// Create a job entry in DB
-	if err := s.stg.JPGToPDF().Create(ctx, job); err != nil {
-		s.log.Error("failed to create job entry in DB", logger.Error(err))
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("failed to submit job", logger.Error(err))
 		return "", err
 	}
 
+	return job.ID, nil
+}
+
+// Process runs a queued JPG to PDF job
+func (s *jpgToPDFService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	outputID, err := s.convert(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputID}
+
+	s.log.Info("JPG to PDF conversion job completed", logger.String("jobID", job.ID))
+	return nil
+}
+
+func (s *jpgToPDFService) convert(ctx context.Context, job *models.Job) (string, error) {
+	var inputPaths, imageTypes []string
+	// Retrieve all file paths corresponding to input file IDs
+	for _, fileID := range job.InputFileIDs {
+		file, err := s.stg.File().GetByID(ctx, fileID)
+		if err != nil {
+			s.log.Error("file not found", logger.String("fileID", fileID), logger.Error(err))
+			return "", fmt.Errorf("file not found: %s", fileID)
+		}
+
+		inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+		if err != nil {
+			return "", err
+		}
+		defer cleanup()
+
+		inputPaths = append(inputPaths, inputPath)
+		imageTypes = append(imageTypes, pdfImageType(file))
+	}
+
 	// Prepare output directory for PDF
 	outputID := uuid.New().String()
 	outputDir := filepath.Join("storage", "jpg_to_pdf")
@@ -83,56 +113,41 @@ func (s *jpgToPDFService) CreateJob(ctx context.Context, userID *string, inputFi
 
 	// Create a PDF from the JPG images
 	pdf := gofpdf.New("P", "mm", "A4", "")
-	for _, imgPath := range inputPaths {
+	for i, imgPath := range inputPaths {
 		pdf.AddPage()
 		// Ensure that each image is added to the PDF correctly
-		pdf.ImageOptions(imgPath, 10, 10, 190, 0, false, gofpdf.ImageOptions{ImageType: "JPG", ReadDpi: true}, 0, "")
+		pdf.ImageOptions(imgPath, 10, 10, 190, 0, false, gofpdf.ImageOptions{ImageType: imageTypes[i], ReadDpi: true}, 0, "")
 	}
 
 	// Output the generated PDF to a file
-	err := pdf.OutputFileAndClose(outputPath)
-	if err != nil {
+	if err := pdf.OutputFileAndClose(outputPath); err != nil {
 		s.log.Error("failed to generate PDF", logger.Error(err))
 		return "", fmt.Errorf("failed to generate PDF: %w", err)
 	}
 
-	// Save output PDF file metadata
-	fi, err := os.Stat(outputPath)
-	if err != nil {
-		s.log.Error("cannot stat output PDF", logger.Error(err))
-		return "", err
-	}
-
-	newFile := models.File{
-		ID:         outputID,
-		UserID:     userID, // Pass nil for guest users
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/pdf",
-		FileSize:   fi.Size(),
-		UploadedAt: time.Now(),
-	}
-
 	// Save the file information to the storage
-	if _, err := s.stg.File().Save(ctx, newFile); err != nil {
+	if err := saveOutputFile(ctx, s.stg, outputID, job.UserID, outputPath, "application/pdf"); err != nil {
 		s.log.Error("failed to save output file", logger.Error(err))
 		return "", err
 	}
 
-	// Update job status and output file ID in DB
-	job.OutputFileID = &outputID
-	job.Status = "done"
-	if err := s.stg.JPGToPDF().UpdateStatusAndOutput(ctx, job.ID, job.Status, *job.OutputFileID); err != nil {
-		s.log.Error("failed to update job status in DB", logger.Error(err))
-		return "", err
-	}
+	return outputID, nil
+}
 
-	s.log.Info("JPG to PDF conversion job completed", logger.String("jobID", jobID))
-	return jobID, nil
+// pdfImageType - gofpdf uchun rasm turi; fayl turi yuklashda mazmunidan aniqlangan
+func pdfImageType(file models.File) string {
+	switch fileMIMEType(file) {
+	case mimePNG:
+		return "PNG"
+	case mimeGIF:
+		return "GIF"
+	default:
+		return "JPG"
+	}
 }
 
-func (s *jpgToPDFService) GetJobByID(ctx context.Context, id string) (*models.JPGToPDFJob, error) {
-	job, err := s.stg.JPGToPDF().GetByID(ctx, id)
+func (s *jpgToPDFService) GetJobByID(ctx context.Context, id string) (*models.Job, error) {
+	job, err := getJobByType(ctx, s.stg, id, models.JobTypeJPGToPDF)
 	if err != nil {
 		s.log.Error("failed to get job by ID", logger.Error(err))
 		return nil, err
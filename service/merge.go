@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/pdfcpu/pdfcpu/pkg/api"
@@ -18,21 +17,21 @@ import (
 
 type MergeService interface {
 	Create(ctx context.Context, userID *string, inputFileIDs []string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.MergeJob, error)
-	ProcessJob(ctx context.Context, jobID string) (string, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type mergeService struct {
-	stg storage.IMergeStorage
-	fs  storage.IFileStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewMergeService(stg storage.IStorage, log logger.ILogger) MergeService {
+func NewMergeService(stg storage.IStorage, log logger.ILogger, queue QueueService) MergeService {
 	return &mergeService{
-		stg: stg.Merge(),
-		fs:  stg.File(),
-		log: log,
+		stg:   stg,
+		log:   log,
+		queue: queue,
 	}
 }
 
@@ -43,20 +42,17 @@ func (s *mergeService) Create(ctx context.Context, userID *string, inputFileIDs
 		s.log.Info("📥 MergeService.Create", logger.String("userID", *userID))
 	}
 
-	job := &models.MergeJob{
-		ID:        uuid.New().String(),
-		UserID:    userID,
-		Status:    "pending",
-		CreatedAt: time.Now(),
+	if len(inputFileIDs) < 2 {
+		return "", fmt.Errorf("need at least two files to merge")
 	}
 
-	if err := s.stg.Create(ctx, job); err != nil {
-		s.log.Error("❌ failed to create merge job", logger.Error(err))
+	job, err := newJob(models.JobTypeMerge, userID, inputFileIDs, nil)
+	if err != nil {
 		return "", err
 	}
 
-	if err := s.stg.AddInputFiles(ctx, job.ID, inputFileIDs); err != nil {
-		s.log.Error("❌ failed to add input files", logger.Error(err))
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("❌ failed to submit merge job", logger.Error(err))
 		return "", err
 	}
 
@@ -64,10 +60,10 @@ func (s *mergeService) Create(ctx context.Context, userID *string, inputFileIDs
 	return job.ID, nil
 }
 
-func (s *mergeService) GetByID(ctx context.Context, id string) (*models.MergeJob, error) {
+func (s *mergeService) GetByID(ctx context.Context, id string) (*models.Job, error) {
 	s.log.Info("📥 MergeService.GetByID", logger.String("jobID", id))
 
-	job, err := s.stg.GetByID(ctx, id)
+	job, err := getJobByType(ctx, s.stg, id, models.JobTypeMerge)
 	if err != nil {
 		s.log.Error("❌ failed to get merge job", logger.Error(err))
 		return nil, err
@@ -76,42 +72,36 @@ func (s *mergeService) GetByID(ctx context.Context, id string) (*models.MergeJob
 	return job, nil
 }
 
-func (s *mergeService) ProcessJob(ctx context.Context, jobID string) (string, error) {
-	s.log.Info("📥 MergeService.ProcessJob", logger.String("jobID", jobID))
-
+// Process - navbatdan olingan merge jobni bajaradi
+func (s *mergeService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
 	// Output folder
 	if err := os.MkdirAll("storage/merge", os.ModePerm); err != nil {
 		s.log.Error("❌ failed to create merge dir", logger.Error(err))
-		return "", err
-	}
-
-	// Job ni olish
-	job, err := s.stg.GetByID(ctx, jobID)
-	if err != nil {
-		s.log.Error("❌ job not found", logger.Error(err))
-		return "", fmt.Errorf("job not found: %w", err)
+		return err
 	}
 
 	if len(job.InputFileIDs) < 2 {
 		s.log.Error("❌ not enough input files", logger.Any("input_ids", job.InputFileIDs))
-		return "", fmt.Errorf("need at least two files to merge")
+		return fmt.Errorf("need at least two files to merge")
 	}
 
 	var inputPaths []string
 	for _, fileID := range job.InputFileIDs {
-		file, err := s.fs.GetByID(ctx, fileID)
+		file, err := s.stg.File().GetByID(ctx, fileID)
 		if err != nil {
 			s.log.Error("❌ failed to get input file", logger.String("fileID", fileID), logger.Error(err))
-			return "", fmt.Errorf("failed to get file: %w", err)
+			return fmt.Errorf("failed to get file: %w", err)
 		}
 		s.log.Info("✅ got input file", logger.String("fileID", fileID), logger.String("path", file.FilePath))
 
-		if _, err := os.Stat(file.FilePath); os.IsNotExist(err) {
-			s.log.Error("❌ input file not found", logger.String("filePath", file.FilePath))
-			return "", fmt.Errorf("input file does not exist: %s", file.FilePath)
+		inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+		if err != nil {
+			s.log.Error("❌ input file not found", logger.String("filePath", file.FilePath), logger.Error(err))
+			return err
 		}
+		defer cleanup()
 
-		inputPaths = append(inputPaths, file.FilePath)
+		inputPaths = append(inputPaths, inputPath)
 	}
 
 	s.log.Info("📄 All input files ready", logger.Any("paths", inputPaths))
@@ -122,37 +112,17 @@ func (s *mergeService) ProcessJob(ctx context.Context, jobID string) (string, er
 	conf := model.NewDefaultConfiguration()
 	if err := api.MergeCreateFile(inputPaths, outputPath, false, conf); err != nil {
 		s.log.Error("❌ pdf merge failed", logger.Error(err))
-		return "", fmt.Errorf("merge failed: %w", err)
+		return fmt.Errorf("merge failed: %w", err)
 	}
 	s.log.Info("✅ PDF successfully merged", logger.String("outputPath", outputPath))
 
-	info, err := os.Stat(outputPath)
-	if err != nil {
-		s.log.Error("❌ failed to stat merged file", logger.Error(err))
-		return "", fmt.Errorf("output file stat failed: %w", err)
-	}
-
-	outputFile := models.File{
-		ID:         outputID,
-		UserID:     job.UserID,
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/pdf",
-		FileSize:   info.Size(),
-		UploadedAt: time.Now(),
-	}
-	if _, err = s.fs.Save(ctx, outputFile); err != nil {
+	if err := saveOutputFile(ctx, s.stg, outputID, job.UserID, outputPath, "application/pdf"); err != nil {
 		s.log.Error("❌ failed to save merged file", logger.Error(err))
-		return "", fmt.Errorf("failed to save output file: %w", err)
+		return err
 	}
 
-	job.OutputFileID = &outputID
-	job.Status = "done"
-	if err := s.stg.Update(ctx, job); err != nil {
-		s.log.Error("❌ failed to update merge job", logger.Error(err))
-		return "", fmt.Errorf("failed to update merge job: %w", err)
-	}
+	job.OutputFileIDs = []string{outputID}
 
 	s.log.Info("🎉 Merge job done", logger.String("outputID", outputID))
-	return outputID, nil
+	return nil
 }
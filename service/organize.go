@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+
+	"test/api/models"
+	"test/pkg/logger"
+	jwt "test/pkg/parse"
+	"test/storage"
+)
+
+// ErrInvalidOrganize - new_order yoki rotations noto'g'ri (mijoz xatosi)
+var ErrInvalidOrganize = errors.New("invalid organize request")
+
+// OrganizeService – sahifalarni qayta tartiblaydi: bitta so'rovda tartiblash, nusxalash,
+// bo'sh sahifa qo'yish va alohida sahifalarni burish mumkin
+type OrganizeService interface {
+	Create(ctx context.Context, req models.CreateOrganizeJobRequest, userID *string) (string, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
+}
+
+type organizeService struct {
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
+}
+
+func NewOrganizeService(stg storage.IStorage, log logger.ILogger, queue QueueService) OrganizeService {
+	return &organizeService{
+		stg:   stg,
+		log:   log,
+		queue: queue,
+	}
+}
+
+func (s *organizeService) Create(ctx context.Context, req models.CreateOrganizeJobRequest, userID *string) (string, error) {
+	s.log.Info("OrganizeService.Create called")
+
+	// 1. Faylni tekshirish
+	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
+	if err != nil {
+		s.log.Error("input file not found", logger.Error(err))
+		return "", fmt.Errorf("input file not found: %w", err)
+	}
+
+	// 2. Tartibni oldindan tekshirish; sahifa soni noma'lum bo'lsa (shifrlangan PDF) Process da tekshiriladi
+	pageCount := 0
+	if file.PageCount != nil {
+		pageCount = *file.PageCount
+	}
+	if err := validateOrganize(req, pageCount); err != nil {
+		return "", err
+	}
+
+	// 3. Job yaratish va navbatga qo'yish
+	job, err := newJob(models.JobTypeOrganize, userID, []string{req.InputFileID}, req)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("failed to submit organize job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan organize jobni bajaradi
+func (s *organizeService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.CreateOrganizeJobRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid organize params: %w", err)
+	}
+
+	outputFileID, err := s.organize(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputFileID}
+
+	s.log.Info("organize job completed", logger.String("jobID", job.ID))
+	return nil
+}
+
+func (s *organizeService) organize(ctx context.Context, job *models.Job, req models.CreateOrganizeJobRequest) (string, error) {
+	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
+	if err != nil {
+		return "", fmt.Errorf("input file not found: %w", err)
+	}
+
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	// 1. Sahifa sonini haqiqiy fayldan olib qayta tekshirish (pipeline/batch da fayl oldindan noma'lum)
+	pageCount, err := api.PageCountFile(inputPath)
+	if err != nil {
+		s.log.Error("failed to read page count", logger.Error(err))
+		return "", err
+	}
+	if err := validateOrganize(req, pageCount); err != nil {
+		return "", &JobError{Code: ErrCodeInvalidInput, Err: err}
+	}
+
+	outputFileID := uuid.NewString()
+	outputPath := filepath.Join("storage/organize", outputFileID+".pdf")
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	if err := organizePages(inputPath, outputPath, req); err != nil {
+		s.log.Error("failed to organize pages", logger.Error(err))
+		return "", err
+	}
+
+	// 2. Yangi faylni saqlash
+	if err := saveOutputFile(ctx, s.stg, outputFileID, job.UserID, outputPath, "application/pdf"); err != nil {
+		return "", err
+	}
+
+	return outputFileID, nil
+}
+
+func (s *organizeService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	return getJobByType(ctx, s.stg, id, models.JobTypeOrganize)
+}
+
+// organizePages - natijaviy PDF ni pdfcpu bilan quradi; req oldindan tekshirilgan bo'lishi kerak
+func organizePages(inputPath, outputPath string, req models.CreateOrganizeJobRequest) error {
+	// 1. Haqiqiy sahifalarni yangi tartibda yig'ish (takrorlangan sahifa nusxalanadi)
+	var pages []string
+	for _, page := range req.NewOrder {
+		if page > 0 {
+			pages = append(pages, strconv.Itoa(page))
+		}
+	}
+	if err := api.CollectFile(inputPath, outputPath, pages, model.NewDefaultConfiguration()); err != nil {
+		return fmt.Errorf("collect pages: %w", err)
+	}
+
+	// 2. Bo'sh sahifalarni o'z o'rniga qo'yish; chapdan o'ngga borilgani uchun oldingi o'rinlar allaqachon joyida
+	for i, page := range req.NewOrder {
+		if page != 0 {
+			continue
+		}
+		var err error
+		if i == 0 {
+			err = api.InsertPagesFile(outputPath, "", []string{"1"}, true, nil, model.NewDefaultConfiguration())
+		} else {
+			err = api.InsertPagesFile(outputPath, "", []string{strconv.Itoa(i)}, false, nil, model.NewDefaultConfiguration())
+		}
+		if err != nil {
+			return fmt.Errorf("insert blank page: %w", err)
+		}
+	}
+
+	// 3. Natijadagi o'rinlar bo'yicha burish: bir xil burchakdagi sahifalar bitta chaqiruvda
+	byAngle := make(map[int][]string)
+	for position, angle := range req.Rotations {
+		if angle = normalizeAngle(angle); angle != 0 {
+			byAngle[angle] = append(byAngle[angle], strconv.Itoa(position))
+		}
+	}
+	for angle, positions := range byAngle {
+		if err := api.RotateFile(outputPath, "", angle, positions, model.NewDefaultConfiguration()); err != nil {
+			return fmt.Errorf("rotate pages: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateOrganize - har bir sahifa hujjatda borligini va burish o'rinlari/burchaklarini tekshiradi.
+// pageCount <= 0 - sahifa soni noma'lum, faqat shakl tekshiriladi.
+func validateOrganize(req models.CreateOrganizeJobRequest, pageCount int) error {
+	if _, err := jwt.ParsePageOrder(req.NewOrder, pageCount); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidOrganize, err)
+	}
+
+	positions := make([]int, 0, len(req.Rotations))
+	for position := range req.Rotations {
+		positions = append(positions, position)
+	}
+	slices.Sort(positions)
+	for _, position := range positions {
+		if position < 1 || position > len(req.NewOrder) {
+			return fmt.Errorf("%w: rotation position %d is outside new_order (1-%d)", ErrInvalidOrganize, position, len(req.NewOrder))
+		}
+		if angle := req.Rotations[position]; angle%90 != 0 {
+			return fmt.Errorf("%w: rotation angle %d for position %d must be a multiple of 90", ErrInvalidOrganize, angle, position)
+		}
+	}
+	return nil
+}
+
+// normalizeAngle - burchakni 0, 90, 180 yoki 270 ga keltiradi
+func normalizeAngle(angle int) int {
+	return (angle%360 + 360) % 360
+}
@@ -1,12 +1,14 @@
 package service
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -18,144 +20,172 @@ import (
 
 type PDFToJPGService interface {
 	Create(ctx context.Context, req models.PDFToJPGRequest, userID *string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.PDFToJPGJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type pdfToJPGService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewPDFToJPGService(stg storage.IStorage, log logger.ILogger) PDFToJPGService {
+func NewPDFToJPGService(stg storage.IStorage, log logger.ILogger, queue QueueService) PDFToJPGService {
 	return &pdfToJPGService{
-		stg: stg,
-		log: log,
+		stg:   stg,
+		log:   log,
+		queue: queue,
 	}
 }
 
 func (s *pdfToJPGService) Create(ctx context.Context, req models.PDFToJPGRequest, userID *string) (string, error) {
 	s.log.Info("PDFToJPGService.Create called")
 
-	// PDF faylini saqlash
-	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
-	if err != nil {
+	// PDF faylini tekshirish
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
 		s.log.Error("PDF file not found", logger.Error(err))
 		return "", fmt.Errorf("failed to fetch PDF file: %w", err)
 	}
 
-	// Output faylni saqlash uchun papka yaratish
-	jobID := uuid.NewString()
-	outputDir := filepath.Join("storage/pdf_to_jpg", jobID)
-	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
-		s.log.Error("Failed to create output directory", logger.Error(err))
-		return "", fmt.Errorf("failed to create output directory: %w", err)
+	// Job yaratish va navbatga qo'yish
+	job, err := newJob(models.JobTypePDFToJPG, userID, []string{req.InputFileID}, req)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("Failed to submit job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan PDF -> JPG jobni bajaradi
+func (s *pdfToJPGService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.PDFToJPGRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid pdf to jpg params: %w", err)
 	}
 
-	// Job yaratish
-	job := &models.PDFToJPGJob{
-		ID:            jobID,
-		UserID:        userID, // UserIDni pointer qilib yuborish
-		InputFileID:   req.InputFileID,
-		OutputFileIDs: []string{},
-		Status:        "pending",
-		CreatedAt:     time.Now(),
+	if err := s.convert(ctx, job, req); err != nil {
+		return err
+	}
+
+	s.log.Info("PDF to JPG ZIP completed", logger.String("jobID", job.ID))
+	return nil
+}
+
+func (s *pdfToJPGService) convert(ctx context.Context, job *models.Job, req models.PDFToJPGRequest) error {
+	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
+	if err != nil {
+		s.log.Error("PDF file not found", logger.Error(err))
+		return fmt.Errorf("failed to fetch PDF file: %w", err)
 	}
 
-	// Jobni DBga qo'shish
-	if err := s.stg.PDFToJPG().Create(ctx, job); err != nil {
-		s.log.Error("Failed to create job entry in DB", logger.Error(err))
-		return "", fmt.Errorf("failed to create job entry in database: %w", err)
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	// Output faylni saqlash uchun papka yaratish
+	outputDir := filepath.Join("storage/pdf_to_jpg", job.ID)
+	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+		s.log.Error("Failed to create output directory", logger.Error(err))
+		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
 	// PDF -> JPGga aylantirish jarayonini boshlash
+	// -progress: pdftoppm har sahifadan keyin stderr ga "<sahifa> <jami> <fayl>" yozadi
 	outputPath := filepath.Join(outputDir, "page")
-	cmd := exec.Command("pdftoppm", "-jpeg", file.FilePath, outputPath)
+	cmd := exec.CommandContext(ctx, "pdftoppm", "-progress", "-jpeg", inputPath, outputPath)
 	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("failed to open pdftoppm stderr: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		s.log.Error("Failed to start pdftoppm", logger.Error(err))
+		return fmt.Errorf("conversion failed: %w", err)
+	}
+	s.reportProgress(ctx, job.ID, stderr)
+	if err := cmd.Wait(); err != nil {
+		// Qisman yaratilgan sahifalarni o'chirish (job bekor qilingan yoki vaqti tugagan bo'lishi mumkin)
+		_ = os.RemoveAll(outputDir)
+		if ctx.Err() != nil {
+			return fmt.Errorf("pdftoppm interrupted: %w", ctx.Err())
+		}
 		s.log.Error("Failed to convert PDF to JPG", logger.Error(err))
-		return "", fmt.Errorf("conversion failed: %w", err)
+		return fmt.Errorf("conversion failed: %w", err)
 	}
 
-	// JPG fayllarni yig'ish va saqlash
+	// JPG fayllarni yig'ish
 	var jpgPaths []string
 	err = filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
 		if filepath.Ext(path) == ".jpg" {
 			jpgPaths = append(jpgPaths, path)
-
-			fileID := uuid.NewString()
-			newFile := models.File{
-				ID:         fileID,
-				UserID:     userID,
-				FileName:   filepath.Base(path),
-				FilePath:   path,
-				FileType:   "image/jpeg",
-				FileSize:   info.Size(),
-				UploadedAt: time.Now(),
-			}
-			if _, err := s.stg.File().Save(ctx, newFile); err != nil {
-				s.log.Error("Failed to save JPG file", logger.Error(err))
-				return err
-			}
-			job.OutputFileIDs = append(job.OutputFileIDs, fileID)
 		}
 		return nil
 	})
 	if err != nil {
 		s.log.Error("Error collecting JPG files", logger.Error(err))
-		return "", fmt.Errorf("failed to collect JPG files: %w", err)
+		return fmt.Errorf("failed to collect JPG files: %w", err)
 	}
 
-	// Zip fayl yaratish
-	zipPath := filepath.Join("storage/pdf_to_jpg", jobID+".zip")
+	// Zip fayl yaratish (sahifalar blob storagega yuklanishidan oldin, chunki u lokal nusxani o'chirishi mumkin)
+	zipPath := filepath.Join("storage/pdf_to_jpg", job.ID+".zip")
 	zipFile, err := os.Create(zipPath)
 	if err != nil {
 		s.log.Error("Failed to create zip file", logger.Error(err))
-		return "", fmt.Errorf("failed to create zip file: %w", err)
+		return fmt.Errorf("failed to create zip file: %w", err)
 	}
-	defer zipFile.Close()
 
-	if err := createzipfromfiles.CreateZipFromFiles(zipFile, jpgPaths); err != nil {
+	err = createzipfromfiles.CreateZipFromFiles(zipFile, jpgPaths)
+	zipFile.Close()
+	if err != nil {
 		s.log.Error("Failed to write zip", logger.Error(err))
-		return "", fmt.Errorf("failed to create zip from JPG files: %w", err)
+		return fmt.Errorf("failed to create zip from JPG files: %w", err)
 	}
 
-	// Zip faylni saqlash
-	info, err := os.Stat(zipPath)
-	if err != nil {
-		s.log.Error("Failed to stat zip file", logger.Error(err))
-		return "", fmt.Errorf("failed to get stats of zip file: %w", err)
+	// Sahifalarni saqlash
+	for _, path := range jpgPaths {
+		fileID := uuid.NewString()
+		if err := saveOutputFile(ctx, s.stg, fileID, job.UserID, path, "image/jpeg"); err != nil {
+			s.log.Error("Failed to save JPG file", logger.Error(err))
+			return err
+		}
+		job.OutputFileIDs = append(job.OutputFileIDs, fileID)
 	}
 
+	// Zip faylni saqlash
 	zipID := uuid.NewString()
-	zipModel := models.File{
-		ID:         zipID,
-		UserID:     userID,
-		FileName:   filepath.Base(zipPath),
-		FilePath:   zipPath,
-		FileType:   "application/zip",
-		FileSize:   info.Size(),
-		UploadedAt: time.Now(),
-	}
-	if _, err := s.stg.File().Save(ctx, zipModel); err != nil {
+	if err := saveOutputFile(ctx, s.stg, zipID, job.UserID, zipPath, "application/zip"); err != nil {
 		s.log.Error("Failed to save zip file", logger.Error(err))
-		return "", fmt.Errorf("failed to save zip file to storage: %w", err)
+		return fmt.Errorf("failed to save zip file to storage: %w", err)
 	}
 
-	// Jobni yangilash
-	job.ZipFileID = &zipID
-	job.Status = "done"
-	if err := s.stg.PDFToJPG().Update(ctx, job); err != nil {
-		s.log.Error("Failed to update job status", logger.Error(err))
-		return "", fmt.Errorf("failed to update job status: %w", err)
-	}
+	return setJobResult(job, models.PDFToJPGResult{ZipFileID: zipID})
+}
 
-	s.log.Info("PDF to JPG ZIP completed", logger.String("jobID", jobID))
-	return jobID, nil
+// reportProgress - pdftoppm -progress chiqishini o'qib, sahifa jarayonini yuboradi;
+// boshqa qatorlar (ogohlantirishlar) odatdagidek log qilinadi
+func (s *pdfToJPGService) reportProgress(ctx context.Context, jobID string, r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		var page, total int
+		if n, _ := fmt.Sscanf(line, "%d %d", &page, &total); n == 2 {
+			s.queue.Progress(ctx, jobID, page, total)
+			continue
+		}
+		s.log.Warning("pdftoppm", logger.String("jobID", jobID), logger.String("output", line))
+	}
 }
 
-func (s *pdfToJPGService) GetByID(ctx context.Context, id string) (*models.PDFToJPGJob, error) {
-	job, err := s.stg.PDFToJPG().GetByID(ctx, id)
+func (s *pdfToJPGService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	job, err := getJobByType(ctx, s.stg, id, models.JobTypePDFToJPG)
 	if err != nil {
 		s.log.Error("Job not found", logger.Error(err))
 		return nil, err
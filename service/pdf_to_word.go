@@ -2,11 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -16,33 +15,77 @@ import (
 )
 
 type pdfToWordService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
 type PDFToWordService interface {
 	Create(ctx context.Context, req models.PDFToWordRequest, userID *string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.PDFToWordJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
-func NewPDFToWordService(stg storage.IStorage, log logger.ILogger) PDFToWordService {
+func NewPDFToWordService(stg storage.IStorage, log logger.ILogger, queue QueueService) PDFToWordService {
 	return &pdfToWordService{
-		stg: stg,
-		log: log,
+		stg:   stg,
+		log:   log,
+		queue: queue,
 	}
 }
 
 func (s *pdfToWordService) Create(ctx context.Context, req models.PDFToWordRequest, userID *string) (string, error) {
 	s.log.Info("PDFToWordService.Create called")
 
+	// 1. Faylni tekshirish
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
+		s.log.Error("input file not found", logger.Error(err))
+		return "", fmt.Errorf("input file not found: %w", err)
+	}
+
+	job, err := newJob(models.JobTypePDFToWord, userID, []string{req.InputFileID}, req)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("failed to submit pdf to word job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan konvertatsiya jobini bajaradi
+func (s *pdfToWordService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.PDFToWordRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid pdf to word params: %w", err)
+	}
+
+	outputFileID, err := s.convert(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputFileID}
+	return nil
+}
+
+func (s *pdfToWordService) convert(ctx context.Context, job *models.Job, req models.PDFToWordRequest) (string, error) {
 	// 1. Faylni olish
 	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
 	if err != nil {
 		s.log.Error("input file not found", logger.Error(err))
-		return "", fmt.Errorf("input file not found: %v", err)
+		return "", fmt.Errorf("input file not found: %w", err)
 	}
 
-	jobID := uuid.NewString()
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
 	outputFileID := uuid.NewString()
 	outputPath := filepath.Join("storage/pdf_to_word", outputFileID+".docx")
 
@@ -52,60 +95,28 @@ func (s *pdfToWordService) Create(ctx context.Context, req models.PDFToWordReque
 	}
 
 	// 2. Gotenberg orqali Word'ga konvertatsiya
-	cmd := exec.Command(
+	err = runTool(ctx, outputPath,
 		"curl", "-X", "POST",
-		"-F", fmt.Sprintf("files=@%s", file.FilePath),
+		"-F", fmt.Sprintf("files=@%s", inputPath),
 		"-F", "convert=pdf", // Gotenberg convert flag
 		"-o", outputPath,
 		"http://localhost:3000/forms/libreoffice/convert",
 	)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
-		s.log.Error("gotenberg conversion failed", logger.Error(err))
-		return "", err
-	}
-
-	fi, err := os.Stat(outputPath)
 	if err != nil {
-		s.log.Error("cannot stat output file", logger.Error(err))
+		s.log.Error("gotenberg conversion failed", logger.Error(err))
 		return "", err
 	}
 
-	newFile := models.File{
-		ID:         outputFileID,
-		UserID:     userID,
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
-		FileSize:   fi.Size(),
-		UploadedAt: time.Now(),
-	}
-	if _, err := s.stg.File().Save(ctx, newFile); err != nil {
+	if err := saveOutputFile(ctx, s.stg, outputFileID, job.UserID, outputPath, "application/vnd.openxmlformats-officedocument.wordprocessingml.document"); err != nil {
 		s.log.Error("failed to save output file", logger.Error(err))
 		return "", err
 	}
 
-	job := &models.PDFToWordJob{
-		ID:           jobID,
-		UserID:       userID,
-		InputFileID:  req.InputFileID,
-		OutputFileID: &outputFileID,
-		Status:       "done",
-		CreatedAt:    time.Now(),
-	}
-	if err := s.stg.PDFToWord().Create(ctx, job); err != nil {
-		s.log.Error("failed to create pdf to word job", logger.Error(err))
-		return "", err
-	}
-
-	s.log.Info("PDFToWord job completed", logger.String("jobID", jobID))
-	return jobID, nil
+	return outputFileID, nil
 }
 
-func (s *pdfToWordService) GetByID(ctx context.Context, id string) (*models.PDFToWordJob, error) {
-	job, err := s.stg.PDFToWord().GetByID(ctx, id)
+func (s *pdfToWordService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	job, err := getJobByType(ctx, s.stg, id, models.JobTypePDFToWord)
 	if err != nil {
 		s.log.Error("pdf to word job not found", logger.Error(err))
 		return nil, err
@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin/binding"
+
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+// ErrInvalidPipeline - pipeline qadamlari noto'g'ri (mijoz xatosi)
+var ErrInvalidPipeline = errors.New("invalid pipeline")
+
+// placeholderFileID - validatsiyada hali mavjud bo'lmagan kiruvchi fayl o'rniga qo'yiladi
+const placeholderFileID = "00000000-0000-0000-0000-000000000000"
+
+// jobStep - pipeline qadami yoki batch amali bo'la oladigan amal tavsifi
+type jobStep struct {
+	request    func() interface{} // amalning so'rov modeli (validatsiya uchun)
+	inputField string             // kiruvchi fayl(lar) JSON maydoni; "" - fayl qabul qilmaydi, faqat birinchi qadam bo'ladi
+	multiInput bool               // true - inputField fayl IDlari ro'yxati
+	secret     bool               // params (parol) bazada saqlanmaydi, faqat navbat payloadida uzatiladi
+}
+
+var jobSteps = map[string]jobStep{
+	models.JobTypeMerge:           {request: func() interface{} { return &models.CreateMergeJobRequest{} }, inputField: "input_file_ids", multiInput: true},
+	models.JobTypeJPGToPDF:        {request: func() interface{} { return &models.CreateJPGToPDFRequest{} }, inputField: "input_file_ids", multiInput: true},
+	models.JobTypeSplit:           {request: func() interface{} { return &models.CreateSplitJobRequest{} }, inputField: "input_file_id"},
+	models.JobTypeRemovePages:     {request: func() interface{} { return &models.RemovePagesRequest{} }, inputField: "input_file_id"},
+	models.JobTypeExtractPages:    {request: func() interface{} { return &models.ExtractPagesRequest{} }, inputField: "input_file_id"},
+	models.JobTypeCompress:        {request: func() interface{} { return &models.CompressRequest{} }, inputField: "input_file_id"},
+	models.JobTypePDFToJPG:        {request: func() interface{} { return &models.PDFToJPGRequest{} }, inputField: "input_file_id"},
+	models.JobTypeRotate:          {request: func() interface{} { return &models.RotatePDFRequest{} }, inputField: "input_file_id"},
+	models.JobTypeOrganize:        {request: func() interface{} { return &models.CreateOrganizeJobRequest{} }, inputField: "input_file_id"},
+	models.JobTypeWatermark:       {request: func() interface{} { return &models.AddWatermarkRequest{} }, inputField: "input_file_id"},
+	models.JobTypeAddBackground:   {request: func() interface{} { return &models.CreateAddBackgroundRequest{} }, inputField: "input_file_id"},
+	models.JobTypeCrop:            {request: func() interface{} { return &models.CropPDFRequest{} }, inputField: "input_file_id"},
+	models.JobTypeUnlock:          {request: func() interface{} { return &models.UnlockPDFRequest{} }, inputField: "input_file_id", secret: true},
+	models.JobTypeProtect:         {request: func() interface{} { return &models.ProtectPDFRequest{} }, inputField: "input_file_id", secret: true},
+	models.JobTypeAddPageNumbers:  {request: func() interface{} { return &models.AddPageNumbersRequest{} }, inputField: "input_file_id"},
+	models.JobTypeInspect:         {request: func() interface{} { return &models.InspectRequest{} }, inputField: "file_id"},
+	models.JobTypeHeaderFooter:    {request: func() interface{} { return &models.CreateAddHeaderFooterRequest{} }, inputField: "input_file_id"},
+	models.JobTypeDetectBlank:     {request: func() interface{} { return &models.DetectBlankPagesRequest{} }, inputField: "input_file_id"},
+	models.JobTypeQRCode:          {request: func() interface{} { return &models.CreateQRCodeRequest{} }, inputField: "input_file_id"},
+	models.JobTypePDFToWord:       {request: func() interface{} { return &models.PDFToWordRequest{} }, inputField: "input_file_id"},
+	models.JobTypeWordToPDF:       {request: func() interface{} { return &models.WordToPDFRequest{} }, inputField: "input_file_id"},
+	models.JobTypeExcelToPDF:      {request: func() interface{} { return &models.ExcelToPDFRequest{} }, inputField: "input_file_id"},
+	models.JobTypePowerPointToPDF: {request: func() interface{} { return &models.PowerPointToPDFRequest{} }, inputField: "input_file_id"},
+	models.JobTypeHTMLToPDF:       {request: func() interface{} { return &models.CreateHTMLToPDFRequest{} }},
+}
+
+type PipelineService interface {
+	Create(ctx context.Context, req models.CreatePipelineRequest, userID *string) (string, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
+}
+
+type pipelineService struct {
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
+}
+
+func NewPipelineService(stg storage.IStorage, log logger.ILogger, queue QueueService) PipelineService {
+	return &pipelineService{
+		stg:   stg,
+		log:   log,
+		queue: queue,
+	}
+}
+
+// Create - qadamlarni tekshiradi va pipeline jobni navbatga qo'yadi
+func (s *pipelineService) Create(ctx context.Context, req models.CreatePipelineRequest, userID *string) (string, error) {
+	s.log.Info("PipelineService.Create called", logger.Int("steps", len(req.Steps)))
+
+	var (
+		inputIDs []string
+		assetIDs []string
+		stored   = make([]models.PipelineStep, len(req.Steps))
+		secrets  = make(map[string]json.RawMessage)
+	)
+
+	for i, step := range req.Steps {
+		spec, err := validatePipelineStep(i, step)
+		if err != nil {
+			return "", err
+		}
+
+		if i == 0 {
+			if inputIDs, err = stepInputs(step.Params, spec); err != nil {
+				return "", fmt.Errorf("%w: step 1 (%s): %v", ErrInvalidPipeline, step.Type, err)
+			}
+			for _, id := range inputIDs {
+				if _, err := s.stg.File().GetByID(ctx, id); err != nil {
+					return "", fmt.Errorf("%w: step 1 (%s): input file %s not found", ErrInvalidPipeline, step.Type, id)
+				}
+			}
+			if err := checkInputFiles(ctx, s.stg, step.Type, userID, inputIDs); err != nil {
+				return "", err
+			}
+		}
+
+		assetIDs = append(assetIDs, jobAssetIDs(step.Type, step.Params)...)
+		stored[i] = step
+		if spec.secret {
+			// Parol bazaga tushmasligi uchun params navbat payloadiga ko'chiriladi
+			secrets[strconv.Itoa(i)] = step.Params
+			stored[i].Params = nil
+		}
+	}
+
+	// Qadamlardagi rasmlar (suv belgisi, orqa fon) ham pipeline tugaguncha o'chirilmasligi kerak
+	job, err := newJob(models.JobTypePipeline, userID, slices.Concat(inputIDs, assetIDs), models.CreatePipelineRequest{Steps: stored})
+	if err != nil {
+		return "", err
+	}
+
+	var payload interface{}
+	if len(secrets) > 0 {
+		payload = secrets
+	}
+
+	if err := s.queue.Submit(ctx, job, payload); err != nil {
+		s.log.Error("failed to submit pipeline job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+func (s *pipelineService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	return getJobByType(ctx, s.stg, id, models.JobTypePipeline)
+}
+
+// Process - qadamlarni ketma-ket bajaradi; har bir qadam alohida (parent_id bog'langan) job bo'lib,
+// uning natija fayllari keyingi qadamga kiruvchi fayl sifatida uzatiladi
+func (s *pipelineService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.CreatePipelineRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid pipeline params: %w", err)
+	}
+
+	secrets := make(map[string]json.RawMessage)
+	if len(task.Payload) > 0 {
+		if err := json.Unmarshal(task.Payload, &secrets); err != nil {
+			return fmt.Errorf("invalid pipeline payload: %w", err)
+		}
+	}
+
+	result := models.PipelineResult{
+		Steps:         make([]models.PipelineStepResult, len(req.Steps)),
+		OutputFileIDs: []string{},
+	}
+	for i, step := range req.Steps {
+		result.Steps[i] = models.PipelineStepResult{
+			Index:         i + 1,
+			Type:          step.Type,
+			Status:        models.Pending,
+			OutputFileIDs: []string{},
+		}
+	}
+
+	var inputIDs []string
+	for i, step := range req.Steps {
+		spec, ok := jobSteps[step.Type]
+		if !ok {
+			return &JobError{Code: ErrCodeInvalidInput, Err: fmt.Errorf("step %d: unsupported type %q", i+1, step.Type)}
+		}
+
+		params := step.Params
+		if spec.secret {
+			if params, ok = secrets[strconv.Itoa(i)]; !ok {
+				return &JobError{Code: ErrCodeInvalidInput, Err: fmt.Errorf("step %d (%s): parameters are missing", i+1, step.Type)}
+			}
+		}
+
+		var err error
+		if i == 0 {
+			inputIDs, err = stepInputs(params, spec)
+		} else if !spec.multiInput && len(inputIDs) != 1 {
+			err = fmt.Errorf("step %d (%s) expects one input file, previous step produced %d", i+1, step.Type, len(inputIDs))
+		} else {
+			params, err = setStepInputs(params, spec, inputIDs)
+		}
+		if err != nil {
+			return &JobError{Code: ErrCodeInvalidInput, Err: err}
+		}
+
+		// Qadam o'zi qayta urinilmaydi: vaqtinchalik xatoda butun pipeline qayta navbatga qo'yiladi
+		child, err := runChildJob(ctx, s.stg, s.queue, job, step.Type, spec.secret, params, inputIDs)
+		if child != nil {
+			result.Steps[i].JobID = child.ID
+			result.Steps[i].Status = child.Status
+			result.Steps[i].OutputFileIDs = child.OutputFileIDs
+			result.Steps[i].Result = child.Result
+			result.Steps[i].ErrorCode = child.ErrorCode
+			result.Steps[i].Error = child.Error
+		}
+		s.saveProgress(ctx, job, result)
+		s.queue.Progress(ctx, job.ID, i+1, len(req.Steps))
+
+		if err != nil {
+			return err
+		}
+		if child.Status != models.Done {
+			return stepError(i, child)
+		}
+
+		inputIDs = child.OutputFileIDs
+	}
+
+	result.OutputFileIDs = inputIDs
+	job.OutputFileIDs = inputIDs
+	if err := setJobResult(job, result); err != nil {
+		return err
+	}
+
+	s.log.Info("pipeline completed", logger.String("jobID", job.ID), logger.Int("steps", len(req.Steps)))
+	return nil
+}
+
+func (s *pipelineService) saveProgress(ctx context.Context, job *models.Job, result models.PipelineResult) {
+	if err := setJobResult(job, result); err != nil {
+		s.log.Error("failed to encode pipeline progress", logger.String("jobID", job.ID), logger.Error(err))
+		return
+	}
+	if err := s.stg.Job().Update(ctx, job); err != nil {
+		s.log.Error("failed to save pipeline progress", logger.String("jobID", job.ID), logger.Error(err))
+	}
+}
+
+// stepError - muvaffaqiyatsiz qadam xatosini pipeline xatosiga aylantiradi
+func stepError(index int, child *models.Job) *JobError {
+	code := ErrCodeProcessingFailed
+	if child.ErrorCode != nil {
+		code = *child.ErrorCode
+	}
+
+	msg := string(child.Status)
+	if child.Error != nil {
+		msg = *child.Error
+	}
+
+	return &JobError{
+		Code:      code,
+		Transient: child.Status == models.Dead,
+		Err:       fmt.Errorf("step %d (%s) %s: %s", index+1, child.Type, child.Status, msg),
+	}
+}
+
+// validatePipelineStep - qadam turini va params ni tekshiradi; ikkinchi qadamdan boshlab kiruvchi fayl keyin qo'yiladi
+func validatePipelineStep(i int, step models.PipelineStep) (jobStep, error) {
+	var inputIDs []string
+	if i > 0 {
+		if spec, ok := jobSteps[step.Type]; ok && spec.inputField == "" {
+			return spec, fmt.Errorf("%w: step %d (%s) can only be the first step", ErrInvalidPipeline, i+1, step.Type)
+		}
+		inputIDs = []string{placeholderFileID}
+	}
+
+	spec, err := validateStepParams(step.Type, step.Params, inputIDs)
+	if err != nil {
+		return spec, fmt.Errorf("%w: step %d: %v", ErrInvalidPipeline, i+1, err)
+	}
+	return spec, nil
+}
+
+// validateStepParams - params ni amalning o'z so'rov modeli va binding teglari bo'yicha tekshiradi.
+// inputIDs berilsa, ular kiruvchi fayl maydoniga qo'yiladi.
+func validateStepParams(jobType string, params json.RawMessage, inputIDs []string) (jobStep, error) {
+	spec, ok := jobSteps[jobType]
+	if !ok {
+		return spec, fmt.Errorf("unsupported type %q", jobType)
+	}
+
+	if len(inputIDs) > 0 {
+		var err error
+		if params, err = setStepInputs(params, spec, inputIDs); err != nil {
+			return spec, fmt.Errorf("%s: %v", jobType, err)
+		}
+	}
+	if len(params) == 0 {
+		params = json.RawMessage(`{}`)
+	}
+
+	req := spec.request()
+	if err := json.Unmarshal(params, req); err != nil {
+		return spec, fmt.Errorf("%s: %v", jobType, err)
+	}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return spec, fmt.Errorf("%s: %v", jobType, err)
+	}
+
+	return spec, nil
+}
+
+// stepInputs - birinchi qadam params idan kiruvchi fayl IDlarini oladi
+func stepInputs(params json.RawMessage, spec jobStep) ([]string, error) {
+	if spec.inputField == "" || len(params) == 0 {
+		return nil, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(params, &fields); err != nil {
+		return nil, err
+	}
+	raw, ok := fields[spec.inputField]
+	if !ok {
+		return nil, fmt.Errorf("%s is required", spec.inputField)
+	}
+
+	if spec.multiInput {
+		var ids []string
+		err := json.Unmarshal(raw, &ids)
+		return ids, err
+	}
+
+	var id string
+	if err := json.Unmarshal(raw, &id); err != nil {
+		return nil, err
+	}
+	return []string{id}, nil
+}
+
+// setStepInputs - params dagi kiruvchi fayl maydonini oldingi qadam natijasi bilan almashtiradi
+func setStepInputs(params json.RawMessage, spec jobStep, inputIDs []string) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &fields); err != nil {
+			return nil, err
+		}
+	}
+	// params bo'sh yoki null bo'lsa ham kiruvchi fayl maydoni qo'yiladi
+	if fields == nil {
+		fields = make(map[string]json.RawMessage)
+	}
+
+	var value interface{} = inputIDs
+	if !spec.multiInput {
+		value = inputIDs[0]
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	fields[spec.inputField] = raw
+
+	return json.Marshal(fields)
+}
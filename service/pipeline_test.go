@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"test/api/models"
+	"test/config"
+)
+
+func newTestPipeline(t *testing.T, stg *fakeStorage) (*pipelineService, *queueService) {
+	t.Helper()
+	q, _ := newTestQueue(t, stg, config.Config{JobTimeout: time.Minute})
+	return NewPipelineService(stg, nopLogger{}, q).(*pipelineService), q
+}
+
+func pipelineJob(t *testing.T, steps ...models.PipelineStep) *models.Job {
+	t.Helper()
+	params, err := json.Marshal(models.CreatePipelineRequest{Steps: steps})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &models.Job{ID: "pipeline-1", Type: models.JobTypePipeline, Params: params, Status: models.Processing}
+}
+
+func TestPipelineChainsStepOutputs(t *testing.T) {
+	stg := newFakeStorage()
+	s, q := newTestPipeline(t, stg)
+	stg.files.put(models.File{ID: "in", FileType: mimePDF})
+
+	var rotateParams models.RotatePDFRequest
+	q.Register(models.JobTypeCompress, func(_ context.Context, job *models.Job, _ models.QueueTask) error {
+		stg.files.put(models.File{ID: "compressed", FileType: mimePDF})
+		job.OutputFileIDs = []string{"compressed"}
+		return nil
+	})
+	q.Register(models.JobTypeRotate, func(_ context.Context, job *models.Job, _ models.QueueTask) error {
+		if err := json.Unmarshal(job.Params, &rotateParams); err != nil {
+			return err
+		}
+		job.OutputFileIDs = []string{"rotated"}
+		return nil
+	})
+
+	job := pipelineJob(t,
+		models.PipelineStep{Type: models.JobTypeCompress, Params: json.RawMessage(`{"input_file_id":"in"}`)},
+		models.PipelineStep{Type: models.JobTypeRotate, Params: json.RawMessage(`{"angle":90,"pages":"1"}`)},
+	)
+	if err := s.Process(context.Background(), job, models.QueueTask{JobID: job.ID}); err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+
+	steps := stg.jobs.children(job.ID)
+	if len(steps) != 2 {
+		t.Fatalf("got %d step jobs, want 2", len(steps))
+	}
+	// Ikkinchi qadam birinchisining natijasini oladi: ham input_file_ids da, ham params da
+	rotate := steps[1]
+	if rotate.Type != models.JobTypeRotate || !slices.Equal(rotate.InputFileIDs, []string{"compressed"}) {
+		t.Errorf("rotate step inputs = %v, want [compressed]", rotate.InputFileIDs)
+	}
+	if rotateParams.InputFileID != "compressed" || rotateParams.Angle != 90 {
+		t.Errorf("rotate step params = %+v", rotateParams)
+	}
+	if !slices.Equal(job.OutputFileIDs, []string{"rotated"}) {
+		t.Errorf("pipeline outputs = %v, want [rotated]", job.OutputFileIDs)
+	}
+
+	var result models.PipelineResult
+	if err := json.Unmarshal(job.Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	for i, step := range result.Steps {
+		if step.Status != models.Done || step.JobID != steps[i].ID {
+			t.Errorf("result step %d = %+v", i+1, step)
+		}
+	}
+}
+
+func TestPipelineStepFailureStopsChain(t *testing.T) {
+	stg := newFakeStorage()
+	s, q := newTestPipeline(t, stg)
+	stg.files.put(models.File{ID: "in", FileType: mimePDF})
+
+	q.Register(models.JobTypeCompress, func(context.Context, *models.Job, models.QueueTask) error {
+		return &JobError{Code: ErrCodeInvalidInput, Err: errors.New("broken pdf")}
+	})
+
+	job := pipelineJob(t,
+		models.PipelineStep{Type: models.JobTypeCompress, Params: json.RawMessage(`{"input_file_id":"in"}`)},
+		models.PipelineStep{Type: models.JobTypeRotate, Params: json.RawMessage(`{"angle":90,"pages":"1"}`)},
+	)
+	err := s.Process(context.Background(), job, models.QueueTask{JobID: job.ID})
+	var jobErr *JobError
+	if !errors.As(err, &jobErr) || jobErr.Code != ErrCodeInvalidInput || jobErr.Transient {
+		t.Fatalf("err = %v, want permanent invalid_input step error", err)
+	}
+	if steps := stg.jobs.children(job.ID); len(steps) != 1 {
+		t.Errorf("got %d step jobs, want 1 (chain must stop at the failed step)", len(steps))
+	}
+}
+
+func TestPipelineSecretParams(t *testing.T) {
+	stg := newFakeStorage()
+	s, q := newTestPipeline(t, stg)
+	stg.files.put(models.File{ID: "in", FileType: mimePDF})
+
+	steps := []models.PipelineStep{
+		{Type: models.JobTypeUnlock, Params: json.RawMessage(`{"input_file_id":"in","password":"s3cret"}`)},
+		{Type: models.JobTypeCompress, Params: json.RawMessage(`{"compression":"high"}`)},
+	}
+	id, err := s.Create(context.Background(), models.CreatePipelineRequest{Steps: steps}, nil)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Parol bazadagi job params iga tushmaydi, faqat navbat payloadida saqlanadi
+	job, _ := stg.jobs.GetByID(context.Background(), id)
+	if strings.Contains(string(job.Params), "s3cret") {
+		t.Errorf("pipeline params leak the password: %s", job.Params)
+	}
+	payload, err := stg.redis.Get(context.Background(), jobPayloadKey+id)
+	if err != nil || !strings.Contains(payload, "s3cret") {
+		t.Fatalf("payload = %q, %v; want the password", payload, err)
+	}
+
+	var unlockTask models.QueueTask
+	q.Register(models.JobTypeUnlock, func(_ context.Context, job *models.Job, task models.QueueTask) error {
+		unlockTask = task
+		stg.files.put(models.File{ID: "unlocked", FileType: mimePDF})
+		job.OutputFileIDs = []string{"unlocked"}
+		return nil
+	})
+	q.Register(models.JobTypeCompress, func(_ context.Context, job *models.Job, _ models.QueueTask) error {
+		job.OutputFileIDs = []string{"compressed"}
+		return nil
+	})
+
+	job.Status = models.Processing
+	if err := s.Process(context.Background(), job, models.QueueTask{JobID: id, Payload: json.RawMessage(payload)}); err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+
+	// Qadam paroli ham bazaga emas, qadam payloadiga yoziladi
+	if !strings.Contains(string(unlockTask.Payload), "s3cret") {
+		t.Errorf("unlock step payload = %s, want the password", unlockTask.Payload)
+	}
+	for _, step := range stg.jobs.children(id) {
+		if strings.Contains(string(step.Params), "s3cret") {
+			t.Errorf("step %s params leak the password: %s", step.Type, step.Params)
+		}
+	}
+
+	// Payload siz (masalan, muddati o'tgan) secret qadam bajarilmaydi
+	job = pipelineJob(t, steps...)
+	err = s.Process(context.Background(), job, models.QueueTask{JobID: job.ID})
+	var jobErr *JobError
+	if !errors.As(err, &jobErr) || jobErr.Code != ErrCodeInvalidInput {
+		t.Errorf("err = %v, want invalid_input for missing secret params", err)
+	}
+}
+
+func TestSetStepInputs(t *testing.T) {
+	single := jobSteps[models.JobTypeCompress]
+	multi := jobSteps[models.JobTypeMerge]
+
+	tests := []struct {
+		name   string
+		params string
+		spec   jobStep
+		want   string
+	}{
+		{"empty params", ``, single, `{"input_file_id":"f1"}`},
+		{"null params", `null`, single, `{"input_file_id":"f1"}`},
+		{"replaces input", `{"compression":"high","input_file_id":"old"}`, single, `{"compression":"high","input_file_id":"f1"}`},
+		{"multi input", `{}`, multi, `{"input_file_ids":["f1"]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := setStepInputs(json.RawMessage(tt.params), tt.spec, []string{"f1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("setStepInputs = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,9 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -16,39 +17,82 @@ import (
 
 type PowerPointToPDFService interface {
 	Create(ctx context.Context, req models.PowerPointToPDFRequest, userID *string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.PowerPointToPDFJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type powerPointToPDFService struct {
 	stg       storage.IStorage
 	log       logger.ILogger
 	gotClient gotenberg.Client
+	queue     QueueService
 }
 
-func NewPowerPointToPDFService(stg storage.IStorage, log logger.ILogger, gotClient gotenberg.Client) PowerPointToPDFService {
+func NewPowerPointToPDFService(stg storage.IStorage, log logger.ILogger, gotClient gotenberg.Client, queue QueueService) PowerPointToPDFService {
 	return &powerPointToPDFService{
 		stg:       stg,
 		log:       log,
 		gotClient: gotClient,
+		queue:     queue,
 	}
 }
 
 func (s *powerPointToPDFService) Create(ctx context.Context, req models.PowerPointToPDFRequest, userID *string) (string, error) {
 	s.log.Info("PowerPointToPDFService.Create called")
 
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
+		s.log.Error("input file not found", logger.Error(err))
+		return "", err
+	}
+
+	job, err := newJob(models.JobTypePowerPointToPDF, userID, []string{req.InputFileID}, req)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("failed to submit powerpoint to pdf job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan konvertatsiya jobini bajaradi
+func (s *powerPointToPDFService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.PowerPointToPDFRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid powerpoint to pdf params: %w", err)
+	}
+
+	outputFileID, err := s.convert(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputFileID}
+	return nil
+}
+
+func (s *powerPointToPDFService) convert(ctx context.Context, job *models.Job, req models.PowerPointToPDFRequest) (string, error) {
 	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
 	if err != nil {
 		s.log.Error("input file not found", logger.Error(err))
 		return "", err
 	}
 
-	resultBytes, err := s.gotClient.PowerPointToPDF(ctx, file.FilePath)
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	resultBytes, err := s.gotClient.PowerPointToPDF(ctx, inputPath)
 	if err != nil {
 		s.log.Error("Gotenberg conversion failed", logger.Error(err))
 		return "", err
 	}
 
-	jobID := uuid.NewString()
 	outputFileID := uuid.NewString()
 	outputPath := filepath.Join("storage/powerpoint_to_pdf", outputFileID+".pdf")
 
@@ -59,39 +103,16 @@ func (s *powerPointToPDFService) Create(ctx context.Context, req models.PowerPoi
 		return "", err
 	}
 
-	fi, _ := os.Stat(outputPath)
-	newFile := models.File{
-		ID:         outputFileID,
-		UserID:     userID,
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/pdf",
-		FileSize:   fi.Size(),
-		UploadedAt: time.Now(),
-	}
-	if _, err := s.stg.File().Save(ctx, newFile); err != nil {
+	if err := saveOutputFile(ctx, s.stg, outputFileID, job.UserID, outputPath, "application/pdf"); err != nil {
 		s.log.Error("failed to save output file", logger.Error(err))
 		return "", err
 	}
 
-	job := &models.PowerPointToPDFJob{
-		ID:           jobID,
-		UserID:       userID,
-		InputFileID:  req.InputFileID,
-		OutputFileID: &outputFileID,
-		Status:       "done",
-		CreatedAt:    time.Now(),
-	}
-	if err := s.stg.PowerPointToPDF().Create(ctx, job); err != nil {
-		s.log.Error("failed to save job", logger.Error(err))
-		return "", err
-	}
-
-	return jobID, nil
+	return outputFileID, nil
 }
 
-func (s *powerPointToPDFService) GetByID(ctx context.Context, id string) (*models.PowerPointToPDFJob, error) {
-	job, err := s.stg.PowerPointToPDF().GetByID(ctx, id)
+func (s *powerPointToPDFService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	job, err := getJobByType(ctx, s.stg, id, models.JobTypePowerPointToPDF)
 	if err != nil {
 		s.log.Error("PowerPointToPDF job not found", logger.Error(err))
 		return nil, err
@@ -2,11 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -17,33 +16,81 @@ import (
 
 type ProtectPDFService interface {
 	Create(ctx context.Context, req models.ProtectPDFRequest, userID *string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.ProtectPDFJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type protectPDFService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewProtectPDFService(stg storage.IStorage, log logger.ILogger) ProtectPDFService {
+func NewProtectPDFService(stg storage.IStorage, log logger.ILogger, queue QueueService) ProtectPDFService {
 	return &protectPDFService{
-		stg: stg,
-		log: log,
+		stg:   stg,
+		log:   log,
+		queue: queue,
 	}
 }
 
 func (s *protectPDFService) Create(ctx context.Context, req models.ProtectPDFRequest, userID *string) (string, error) {
 	s.log.Info("ProtectPDFService.Create called")
 
+	// 1. Faylni tekshirish
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
+		s.log.Error("input file not found", logger.Error(err))
+		return "", fmt.Errorf("file not found")
+	}
+
+	// 2. Job yaratish (parol bazada saqlanmaydi)
+	job, err := newJob(models.JobTypeProtect, userID, []string{req.InputFileID}, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// 3. Parol faqat navbatdagi vazifa ichida yuboriladi
+	if err := s.queue.Submit(ctx, job, req); err != nil {
+		s.log.Error("failed to submit job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan protect jobni bajaradi
+func (s *protectPDFService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.ProtectPDFRequest
+	if err := json.Unmarshal(task.Payload, &req); err != nil {
+		return fmt.Errorf("invalid protect payload: %w", err)
+	}
+
+	outputFileID, err := s.protect(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputFileID}
+
+	s.log.Info("PDF protection completed", logger.String("jobID", job.ID))
+	return nil
+}
+
+func (s *protectPDFService) protect(ctx context.Context, job *models.Job, req models.ProtectPDFRequest) (string, error) {
 	// 1. Faylni olish
 	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
 	if err != nil {
 		s.log.Error("input file not found", logger.Error(err))
-		return "", fmt.Errorf("file not found")
+		return "", fmt.Errorf("file not found: %w", err)
+	}
+
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return "", err
 	}
+	defer cleanup()
 
 	// 2. Output joyini tayyorlash
-	jobID := uuid.NewString()
 	outputFileID := uuid.NewString()
 	outputPath := filepath.Join("storage/protect_pdf", outputFileID+".pdf")
 
@@ -57,62 +104,25 @@ func (s *protectPDFService) Create(ctx context.Context, req models.ProtectPDFReq
 		"encrypt",
 		"-upw", req.Password,
 		"-opw", req.Password,
-		file.FilePath,
+		inputPath,
 		outputPath,
 	}
-	cmd := exec.Command("pdfcpu", args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
+	if err := runTool(ctx, outputPath, "pdfcpu", args...); err != nil {
 		s.log.Error("pdf encryption failed", logger.Error(err))
 		return "", err
 	}
 
 	// 4. Faylni bazaga yozish (files jadvaliga)
-	fi, err := os.Stat(outputPath)
-	if err != nil {
-		s.log.Error("output file not found", logger.Error(err))
-		return "", err
-	}
-
-	newFile := models.File{
-		ID:         outputFileID,
-		UserID:     userID,
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/pdf",
-		FileSize:   fi.Size(),
-		UploadedAt: time.Now(),
-	}
-
-	if _, err := s.stg.File().Save(ctx, newFile); err != nil {
+	if err := saveOutputFile(ctx, s.stg, outputFileID, job.UserID, outputPath, "application/pdf"); err != nil {
 		s.log.Error("failed to save output file", logger.Error(err))
 		return "", err
 	}
 
-	// 5. Protect Job yaratish (endi output_file_id bor)
-	job := &models.ProtectPDFJob{
-		ID:           jobID,
-		UserID:       userID,
-		InputFileID:  req.InputFileID,
-		OutputFileID: &outputFileID,
-		Password:     req.Password,
-		Status:       "done",
-		CreatedAt:    time.Now(),
-	}
-
-	if err := s.stg.Protect().Create(ctx, job); err != nil {
-		s.log.Error("failed to create job", logger.Error(err))
-		return "", err
-	}
-
-	s.log.Info("PDF protection completed", logger.String("jobID", jobID))
-	return jobID, nil
+	return outputFileID, nil
 }
 
-func (s *protectPDFService) GetByID(ctx context.Context, id string) (*models.ProtectPDFJob, error) {
-	job, err := s.stg.Protect().GetByID(ctx, id)
+func (s *protectPDFService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	job, err := getJobByType(ctx, s.stg, id, models.JobTypeProtect)
 	if err != nil {
 		s.log.Error("failed to get job", logger.Error(err))
 		return nil, err
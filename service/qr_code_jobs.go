@@ -2,10 +2,9 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/pdfcpu/pdfcpu/pkg/api"
@@ -20,50 +19,77 @@ import (
 
 type QRCodeService interface {
 	Create(ctx context.Context, req models.CreateQRCodeRequest, userID string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.QRCodeJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type qrCodeService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewQRCodeService(stg storage.IStorage, log logger.ILogger) QRCodeService {
-	return &qrCodeService{stg: stg, log: log}
+func NewQRCodeService(stg storage.IStorage, log logger.ILogger, queue QueueService) QRCodeService {
+	return &qrCodeService{stg: stg, log: log, queue: queue}
 }
 
 func (s *qrCodeService) Create(ctx context.Context, req models.CreateQRCodeRequest, userID string) (string, error) {
 	s.log.Info("QRCodeService.Create called")
 
-	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
-	if err != nil {
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
 		return "", fmt.Errorf("input file not found")
 	}
 
-	jobID := uuid.New().String()
-	job := &models.QRCodeJob{
-		ID:          jobID,
-		UserID:      userID,
-		InputFileID: req.InputFileID,
-		QRContent:   req.QRContent,
-		Position:    req.Position,
-		Size:        req.Size,
-		Status:      "pending",
-		CreatedAt:   time.Now(),
+	var owner *string
+	if userID != "" {
+		owner = &userID
 	}
 
-	if err := s.stg.QRCode().Create(ctx, job); err != nil {
+	job, err := newJob(models.JobTypeQRCode, owner, []string{req.InputFileID}, req)
+	if err != nil {
 		return "", err
 	}
 
-	// QR kod yaratish (PNG fayl sifatida)
-	qrPngPath := filepath.Join("storage/qr_code", jobID+".png")
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan QR kod jobini bajaradi
+func (s *qrCodeService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.CreateQRCodeRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid qr code params: %w", err)
+	}
+
+	outputID, err := s.addQRCode(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputID}
+	return nil
+}
+
+func (s *qrCodeService) addQRCode(ctx context.Context, job *models.Job, req models.CreateQRCodeRequest) (string, error) {
+	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
+	if err != nil {
+		return "", fmt.Errorf("input file not found: %w", err)
+	}
 
-	err = qrcode.WriteFile(req.QRContent, qrcode.Medium, req.Size, qrPngPath)
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
 	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	// QR kod yaratish (PNG fayl sifatida)
+	qrPngPath := filepath.Join("storage/qr_code", job.ID+".png")
+
+	if err := qrcode.WriteFile(req.QRContent, qrcode.Medium, req.Size, qrPngPath); err != nil {
 		s.log.Error("failed to generate qr code", logger.Error(err))
-		job.Status = "failed"
-		_ = s.stg.QRCode().Update(ctx, job)
 		return "", err
 	}
 
@@ -96,46 +122,22 @@ func (s *qrCodeService) Create(ctx context.Context, req models.CreateQRCodeReque
 	wm, err := pdfcpu.ParseImageWatermarkDetails(qrPngPath, wmDetails, true, conf.Unit)
 	if err != nil {
 		s.log.Error("failed to parse watermark", logger.Error(err))
-		job.Status = "failed"
-		_ = s.stg.QRCode().Update(ctx, job)
 		return "", err
 	}
 
-	err = api.AddWatermarksFile(file.FilePath, outputPath, nil, wm, conf)
-	if err != nil {
+	if err := api.AddWatermarksFile(inputPath, outputPath, nil, wm, conf); err != nil {
 		s.log.Error("failed to add qr watermark", logger.Error(err))
-		job.Status = "failed"
-		_ = s.stg.QRCode().Update(ctx, job)
 		return "", err
 	}
 
 	// Natija faylni DBga yozish
-	fi, _ := os.Stat(outputPath)
-	newFile := models.File{
-		ID:         outputID,
-		UserID:     &userID,
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/pdf",
-		FileSize:   fi.Size(),
-		UploadedAt: time.Now(),
-	}
-
-	_, err = s.stg.File().Save(ctx, newFile)
-	if err != nil {
-		return "", err
-	}
-
-	job.OutputFileID = outputID
-	job.Status = "done"
-	err = s.stg.QRCode().Update(ctx, job)
-	if err != nil {
+	if err := saveOutputFile(ctx, s.stg, outputID, job.UserID, outputPath, "application/pdf"); err != nil {
 		return "", err
 	}
 
-	return jobID, nil
+	return outputID, nil
 }
 
-func (s *qrCodeService) GetByID(ctx context.Context, id string) (*models.QRCodeJob, error) {
-	return s.stg.QRCode().GetByID(ctx, id)
+func (s *qrCodeService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	return getJobByType(ctx, s.stg, id, models.JobTypeQRCode)
 }
@@ -0,0 +1,768 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+const (
+	jobQueueKey         = "pdfninja:jobs"          // mehmonlar (tokensiz) navbati
+	jobPriorityQueueKey = "pdfninja:jobs:priority" // ro'yxatdan o'tgan foydalanuvchilar navbati
+	jobDelayedQueueKey  = "pdfninja:jobs:delayed"
+	jobProcessingKey    = "pdfninja:jobs:processing:" // + instansiya:worker - olingan, lekin hali ack qilinmagan vazifalar
+	workerHeartbeatKey  = "pdfninja:workers:"         // + instansiya - tirik instansiyalar
+	jobPayloadKey       = "pdfninja:job:payload:"
+	jobCancelChannel    = "pdfninja:job-cancel"   // bekor qilingan job IDsi barcha instansiyalarga yuboriladi
+	userSlotsKey        = "pdfninja:leases:user:" // + foydalanuvchi ID - job ID lari, score = lease muddati
+	typeSlotsKey        = "pdfninja:leases:type:" // + amal turi
+
+	queuePopTimeout    = time.Second // faqat birinchi yo'lak kutiladi, shuning uchun qisqa
+	delayedPollPeriod  = time.Second
+	delayedBatchSize   = 100
+	maxRetryBackoff    = 10 * time.Minute
+	payloadTTL         = 24 * time.Hour  // job tugamay qolsa ham payload (masalan, parol) shundan keyin o'chadi
+	slotWaitDelay      = 2 * time.Second // bo'sh slot bo'lmasa, vazifa shuncha vaqtdan keyin qayta olinadi
+	limitsRefreshEvery = 10 * time.Second
+)
+
+// parentJobTypes - qadamlarini (bola joblarni) o'zi bajaradigan turlar
+var parentJobTypes = map[string]bool{
+	models.JobTypePipeline: true,
+	models.JobTypeBatch:    true,
+}
+
+// JobHandler – navbatdan olingan jobni bajaradi.
+// Natijalar job.OutputFileIDs va job.Result ga yoziladi, holatni QueueService o'zi saqlaydi.
+type JobHandler func(ctx context.Context, job *models.Job, task models.QueueTask) error
+
+type QueueService interface {
+	Submit(ctx context.Context, job *models.Job, payload interface{}) error
+	Enqueue(ctx context.Context, jobID, jobType string, userID *string, payload interface{}) error
+	Execute(ctx context.Context, task models.QueueTask) error
+	ExecuteChild(ctx context.Context, task models.QueueTask) error
+	Retry(ctx context.Context, job *models.Job) error
+	Cancel(ctx context.Context, job *models.Job) error
+	Progress(ctx context.Context, jobID string, current, total int)
+	Register(jobType string, handler JobHandler)
+	Run(ctx context.Context)
+
+	Stats(ctx context.Context) (*models.QueueStats, error)
+	SetTypeLimit(ctx context.Context, jobType string, maxWorkers int) (*models.JobTypeLimit, error)
+	DeleteTypeLimit(ctx context.Context, jobType string) error
+}
+
+type queueService struct {
+	stg      storage.IStorage
+	redis    storage.IRedisStorage
+	log      logger.ILogger
+	cfg      config.Config
+	events   JobEventService
+	webhooks WebhookService
+	cache    JobCacheService
+
+	mu       sync.RWMutex
+	handlers map[string]JobHandler
+
+	runningMu sync.Mutex
+	running   map[string]context.CancelCauseFunc // shu instansiyada bajarilayotgan joblar
+
+	limitsMu sync.RWMutex
+	limits   map[string]int // amal turi -> bir vaqtda bajariladigan joblar chegarasi
+}
+
+func NewQueueService(stg storage.IStorage, redis storage.IRedisStorage, log logger.ILogger, cfg config.Config, events JobEventService, webhooks WebhookService, cache JobCacheService) QueueService {
+	return &queueService{
+		stg:      stg,
+		redis:    redis,
+		log:      log,
+		cfg:      cfg,
+		events:   events,
+		webhooks: webhooks,
+		cache:    cache,
+		handlers: make(map[string]JobHandler),
+		running:  make(map[string]context.CancelCauseFunc),
+		limits:   make(map[string]int),
+	}
+}
+
+// Submit - jobni bazaga pending holatda yozadi va navbatga qo'yadi
+func (q *queueService) Submit(ctx context.Context, job *models.Job, payload interface{}) error {
+	if job.Status == "" {
+		job.Status = models.Pending
+	}
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = time.Now()
+	}
+	if job.MaxAttempts < 1 {
+		job.MaxAttempts = q.cfg.JobMaxAttempts
+	}
+	if job.MaxAttempts < 1 {
+		job.MaxAttempts = 1
+	}
+	// Begona, noto'g'ri turdagi yoki karantindagi fayl navbatga tushmasdan rad etiladi
+	if err := checkInputFiles(ctx, q.stg, job.Type, job.UserID, job.InputFileIDs); err != nil {
+		return err
+	}
+	if cb, ok := callbackFromContext(ctx); ok {
+		if err := checkCallbackURL(ctx, cb.CallbackURL); err != nil {
+			return err
+		}
+		job.CallbackURL = &cb.CallbackURL
+		if cb.CallbackSecret != "" {
+			job.CallbackSecret = &cb.CallbackSecret
+		}
+	}
+
+	if err := q.stg.Job().Create(ctx, job); err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			q.markFailed(job, &JobError{Code: ErrCodeInvalidInput, Err: err})
+			return fmt.Errorf("failed to marshal job payload: %w", err)
+		}
+		// Reaper job ni qayta navbatga qo'yganda payload shu yerdan olinadi; job tugashi bilan o'chiriladi
+		if err := q.redis.SetX(ctx, jobPayloadKey+job.ID, string(data), payloadTTL); err != nil {
+			q.log.Error("failed to store job payload", logger.String("jobID", job.ID), logger.Error(err))
+		}
+	}
+
+	if err := q.Enqueue(ctx, job.ID, job.Type, job.UserID, payload); err != nil {
+		q.markFailed(job, classifyJobError(err))
+		return fmt.Errorf("failed to enqueue job: %w", err)
+	}
+
+	return nil
+}
+
+// Enqueue - jobni Redis navbatiga qo'yadi
+func (q *queueService) Enqueue(ctx context.Context, jobID, jobType string, userID *string, payload interface{}) error {
+	task := models.QueueTask{
+		JobID:      jobID,
+		JobType:    jobType,
+		UserID:     userID,
+		EnqueuedAt: time.Now(),
+	}
+
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal job payload: %w", err)
+		}
+		task.Payload = data
+	}
+
+	raw, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal queue task: %w", err)
+	}
+
+	if err := q.redis.Push(ctx, queueLaneKey(userID), raw); err != nil {
+		q.log.Error("failed to enqueue job", logger.String("jobID", jobID), logger.Error(err))
+		return err
+	}
+
+	q.log.Info("job enqueued", logger.String("jobID", jobID), logger.String("type", jobType), logger.String("lane", queueLane(userID)))
+	return nil
+}
+
+// Retry - failed yoki dead jobni qaytadan navbatga qo'yadi (urinishlar soni nolga tushadi)
+// Parolli (secret) joblar qayta ishga tushirilmaydi: ularning payloadi job tugashi bilan o'chiriladi.
+func (q *queueService) Retry(ctx context.Context, job *models.Job) error {
+	if jobHasSecrets(job) {
+		return fmt.Errorf("%w: secret parameters are not kept after the job finishes, submit it again", ErrJobNotRetryable)
+	}
+
+	reset, err := q.stg.Job().Reset(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+	if !reset {
+		return fmt.Errorf("%w: status is %q", ErrJobNotRetryable, job.Status)
+	}
+
+	if err := q.Enqueue(ctx, job.ID, job.Type, job.UserID, nil); err != nil {
+		q.markFailed(job, classifyJobError(err))
+		return err
+	}
+
+	job.Status = models.Pending
+	job.ErrorCode, job.Error = nil, nil
+	q.events.Publish(ctx, job.StatusEvent())
+
+	q.log.Info("job retried manually", logger.String("jobID", job.ID))
+	return nil
+}
+
+// Cancel - jobni cancelled holatiga o'tkazadi; job bajarilayotgan bo'lsa (istalgan instansiyada)
+// uning ctx si bekor qilinadi, tashqi jarayonlar o'ldiriladi va qisman natijalar o'chiriladi
+func (q *queueService) Cancel(ctx context.Context, job *models.Job) error {
+	cancelled, err := q.stg.Job().Cancel(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return fmt.Errorf("%w: status is %q", ErrJobNotCancellable, job.Status)
+	}
+
+	q.stopRunning(job.ID)
+	if err := q.redis.Publish(ctx, jobCancelChannel, job.ID); err != nil {
+		q.log.Error("failed to broadcast job cancel", logger.String("jobID", job.ID), logger.Error(err))
+	}
+
+	q.dropPayload(job.ID)
+
+	now := time.Now()
+	job.Status = models.Cancelled
+	job.NextRetryAt = nil
+	job.FinishedAt = &now
+	q.events.Publish(ctx, job.StatusEvent())
+
+	q.log.Info("job cancelled", logger.String("jobID", job.ID))
+	return nil
+}
+
+// Progress - job bajarilish jarayonini (masalan, 14/120 sahifa) obunachilarga yuboradi
+func (q *queueService) Progress(ctx context.Context, jobID string, current, total int) {
+	q.events.Publish(ctx, models.JobEvent{
+		JobID:    jobID,
+		Event:    models.JobEventProgress,
+		Status:   models.Processing,
+		Progress: &models.JobProgress{Current: current, Total: total},
+	})
+}
+
+// Register - job turi uchun bajaruvchini ro'yxatdan o'tkazadi
+func (q *queueService) Register(jobType string, handler JobHandler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[jobType] = handler
+}
+
+// Run - workerlarni ishga tushiradi va ctx tugaguncha bloklanadi
+func (q *queueService) Run(ctx context.Context) {
+	workers := q.cfg.WorkerCount
+	if workers < 1 {
+		workers = 1
+	}
+
+	q.log.Info("starting job workers", logger.Int("workers", workers))
+
+	// Server to'xtatilganda bajarilayotgan joblar ham to'xtatiladi va navbatga qaytariladi
+	runCtx, stopRun := context.WithCancelCause(context.Background())
+	go func() {
+		<-ctx.Done()
+		stopRun(errWorkerShutdown)
+	}()
+
+	var wg sync.WaitGroup
+	for i := 1; i <= workers; i++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			q.work(runCtx, workerID)
+		}(i)
+	}
+
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		q.promoteDelayed(runCtx)
+	}()
+	go func() {
+		defer wg.Done()
+		q.reap(runCtx)
+	}()
+	go func() {
+		defer wg.Done()
+		q.listenCancel(runCtx)
+	}()
+	go func() {
+		defer wg.Done()
+		q.refreshLimits(runCtx)
+	}()
+
+	wg.Wait()
+
+	q.log.Info("job workers stopped")
+}
+
+// work - vazifa navbatdan workerning processing ro'yxatiga o'tkaziladi va faqat bajarilgandan keyin
+// o'chiriladi (ack). Jarayon o'rtada o'lsa, vazifa ro'yxatda qoladi va qayta navbatga qaytariladi.
+func (q *queueService) work(ctx context.Context, workerID int) {
+	processing := q.processingKey(workerID)
+	// Oldingi ishga tushishda (shu instansiya, shu worker) ack qilinmay qolgan vazifalar
+	q.recoverTasks(processing)
+
+	for n := 1; ; n++ {
+		if ctx.Err() != nil {
+			return
+		}
+
+		raw, err := q.redis.Move(ctx, queuePopTimeout, processing, q.laneOrder(n)...)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			q.log.Error("failed to pop job from queue", logger.Int("worker", workerID), logger.Error(err))
+			time.Sleep(time.Second)
+			continue
+		}
+		if raw == "" {
+			continue
+		}
+
+		var task models.QueueTask
+		if err := json.Unmarshal([]byte(raw), &task); err != nil {
+			q.log.Error("invalid queue task", logger.String("raw", raw), logger.Error(err))
+			q.ack(processing, raw)
+			continue
+		}
+
+		// Qayta urinish, slot kutish yoki to'xtatishda vazifa handle ichida yangidan navbatga qo'yiladi
+		q.handle(ctx, task, workerID)
+		q.ack(processing, raw)
+	}
+}
+
+// promoteDelayed - vaqti kelgan qayta urinishlarni asosiy navbatga o'tkazadi
+func (q *queueService) promoteDelayed(ctx context.Context) {
+	ticker := time.NewTicker(delayedPollPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		due, err := q.redis.PopDue(ctx, jobDelayedQueueKey, time.Now(), delayedBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				q.log.Error("failed to read delayed jobs", logger.Error(err))
+			}
+			continue
+		}
+
+		for _, raw := range due {
+			// Vazifa o'z yo'lagiga qaytadi; buzilgan bo'lsa ham yo'qolmasin, work() uni log qiladi
+			lane := jobQueueKey
+			var task models.QueueTask
+			if err := json.Unmarshal([]byte(raw), &task); err == nil {
+				lane = queueLaneKey(task.UserID)
+			}
+
+			if err := q.redis.Push(ctx, lane, raw); err != nil {
+				q.log.Error("failed to promote delayed job", logger.Error(err))
+			}
+		}
+	}
+}
+
+// listenCancel - boshqa instansiyalarda bekor qilingan joblarni shu yerda ham to'xtatadi
+func (q *queueService) listenCancel(ctx context.Context) {
+	messages, closeSub, err := q.redis.Subscribe(ctx, jobCancelChannel)
+	if err != nil {
+		q.log.Error("failed to subscribe to job cancellations", logger.Error(err))
+		return
+	}
+	defer closeSub()
+
+	for jobID := range messages {
+		q.stopRunning(jobID)
+	}
+}
+
+func (q *queueService) stopRunning(jobID string) {
+	q.runningMu.Lock()
+	defer q.runningMu.Unlock()
+
+	if cancel, ok := q.running[jobID]; ok {
+		cancel(ErrJobCancelled)
+	}
+}
+
+func (q *queueService) track(jobID string, cancel context.CancelCauseFunc) func() {
+	q.runningMu.Lock()
+	q.running[jobID] = cancel
+	q.runningMu.Unlock()
+
+	return func() {
+		q.runningMu.Lock()
+		delete(q.running, jobID)
+		q.runningMu.Unlock()
+	}
+}
+
+func (q *queueService) handle(ctx context.Context, task models.QueueTask, workerID int) {
+	release, ok := q.acquireSlots(ctx, task)
+	if !ok {
+		q.waitForSlot(task)
+		return
+	}
+	defer release()
+
+	// Pipeline va batch vaqti qadamlar soniga bog'liq - ularning har bir qadami o'z JobTimeout i bilan cheklanadi
+	if !parentJobTypes[task.JobType] {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, q.cfg.JobTimeout)
+		defer cancel()
+	}
+
+	q.log.Info("job started", logger.Int("worker", workerID), logger.String("jobID", task.JobID), logger.String("type", task.JobType))
+	start := time.Now()
+
+	if err := q.Execute(ctx, task); errors.Is(err, ErrJobCancelled) || errors.Is(err, errWorkerShutdown) {
+		q.log.Info("job stopped", logger.String("jobID", task.JobID), logger.Any("reason", err.Error()))
+		return
+	} else if err != nil {
+		q.log.Error("job failed", logger.String("jobID", task.JobID), logger.String("type", task.JobType), logger.Error(err))
+		return
+	}
+
+	q.log.Info("job finished", logger.String("jobID", task.JobID), logger.Any("duration", time.Since(start).String()))
+}
+
+// ExecuteChild - pipeline/batch qadamini parent workerida bajaradi. Qadam ham foydalanuvchi va amal turi
+// slotlarini egallaydi (bo'sh slot ko'pi bilan JobTimeout kutiladi) va o'z JobTimeout i bilan cheklanadi.
+// Slot topilmasa qadam dead bo'ladi; parent to'xtatilsa, boshlanmagan qadam cancelled bo'ladi.
+func (q *queueService) ExecuteChild(ctx context.Context, task models.QueueTask) error {
+	release, err := q.waitForSlots(ctx, task)
+	if err != nil {
+		q.abandonChild(task.JobID, err)
+		return err
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(ctx, q.cfg.JobTimeout)
+	defer cancel()
+	return q.Execute(ctx, task)
+}
+
+// Execute - jobni "processing" holatiga o'tkazib, handlerni chaqiradi va natijani saqlaydi.
+// Job allaqachon boshqa worker tomonidan olingan bo'lsa, hech narsa qilmaydi.
+// Vaqtinchalik xatoda urinishlar tugamagan bo'lsa, job backoff bilan qayta navbatga qo'yiladi.
+// Job bekor qilinsa yoki server to'xtasa, qisman natijalar o'chiriladi.
+func (q *queueService) Execute(ctx context.Context, task models.QueueTask) (err error) {
+	q.mu.RLock()
+	handler, ok := q.handlers[task.JobType]
+	q.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no handler for job type %q", task.JobType)
+	}
+
+	claimed, err := q.stg.Job().Claim(ctx, task.JobID)
+	if err != nil {
+		return fmt.Errorf("failed to claim job: %w", err)
+	}
+	if !claimed {
+		q.log.Info("job already claimed, skipping", logger.String("jobID", task.JobID))
+		return nil
+	}
+
+	job, err := q.stg.Job().GetByID(ctx, task.JobID)
+	if err != nil {
+		return err
+	}
+	q.events.Publish(ctx, job.StatusEvent())
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	defer q.track(job.ID, cancel)()
+
+	defer func() {
+		r := recover()
+
+		// Bekor qilish yoki to'xtatishda handler xatosi muhim emas
+		switch cause := context.Cause(ctx); {
+		case errors.Is(cause, ErrJobCancelled):
+			q.discardOutputs(job)
+			q.markCancelled(job)
+			err = ErrJobCancelled
+			return
+		case errors.Is(cause, errWorkerShutdown):
+			q.discardOutputs(job)
+			// Pipeline qadami alohida navbatga qaytmaydi - pipeline o'zi qaytadan bajariladi
+			if job.ParentID != nil {
+				q.markCancelled(job)
+			} else {
+				q.requeue(job, task)
+			}
+			err = errWorkerShutdown
+			return
+		}
+
+		var jobErr *JobError
+		if r != nil {
+			jobErr = panicError(r)
+		} else if err != nil {
+			jobErr = classifyJobError(err)
+		}
+
+		if jobErr == nil {
+			q.markDone(job)
+			return
+		}
+
+		err = jobErr
+		if jobErr.Transient && job.Attempts < job.MaxAttempts {
+			q.scheduleRetry(job, task, jobErr)
+			return
+		}
+		q.markFailed(job, jobErr)
+	}()
+
+	return q.runHandler(ctx, handler, job, task)
+}
+
+// runHandler - bir xil fayllar va params bilan natija keshda bo'lsa, handler chaqirilmaydi;
+// muvaffaqiyatli natija keyingi joblar uchun keshga yoziladi. Kesh xatolari jobni to'xtatmaydi.
+func (q *queueService) runHandler(ctx context.Context, handler JobHandler, job *models.Job, task models.QueueTask) error {
+	// Pipeline/batch bola joblari Submit dan o'tmaydi, shuning uchun fayllar shu yerda ham tekshiriladi
+	if err := checkInputFiles(ctx, q.stg, job.Type, job.UserID, job.InputFileIDs); err != nil {
+		if errors.Is(err, ErrUnsupportedFileType) || errors.Is(err, ErrFileQuarantined) || errors.Is(err, ErrFileAccessDenied) {
+			return &JobError{Code: ErrCodeInvalidInput, Err: err}
+		}
+		return err
+	}
+
+	key, cacheable := q.cache.Key(ctx, job, task)
+	if cacheable {
+		hit, err := q.cache.Load(ctx, key, job)
+		if err != nil {
+			q.log.Error("failed to load cached result", logger.String("jobID", job.ID), logger.Error(err))
+		}
+		if hit {
+			q.log.Info("job result served from cache", logger.String("jobID", job.ID), logger.String("type", job.Type))
+			q.linkOutputs(ctx, job)
+			return nil
+		}
+	}
+
+	if err := handler(ctx, job, task); err != nil {
+		return err
+	}
+	q.linkOutputs(ctx, job)
+
+	if cacheable {
+		if err := q.cache.Store(ctx, key, job); err != nil {
+			q.log.Error("failed to cache job result", logger.String("jobID", job.ID), logger.Error(err))
+		}
+	}
+	return nil
+}
+
+// linkOutputs - natija fayllariga ularni yaratgan jobni yozadi (kutubxonada "X ning siqilgan nusxasi"
+// kabi qidiruv uchun). Xato jobni to'xtatmaydi.
+func (q *queueService) linkOutputs(ctx context.Context, job *models.Job) {
+	if len(job.OutputFileIDs) == 0 {
+		return
+	}
+	if err := q.stg.File().SetSourceJob(ctx, job.ID, job.OutputFileIDs); err != nil {
+		q.log.Error("failed to link job outputs", logger.String("jobID", job.ID), logger.Error(err))
+	}
+}
+
+// retryBackoff - n-urinishdan keyingi kutish: base, 2*base, 4*base, ... (maxRetryBackoff gacha)
+func (q *queueService) retryBackoff(attempt int) time.Duration {
+	backoff := q.cfg.JobRetryBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	for i := 1; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return backoff
+}
+
+func (q *queueService) scheduleRetry(job *models.Job, task models.QueueTask, jobErr *JobError) {
+	retryAt := time.Now().Add(q.retryBackoff(job.Attempts))
+	msg := jobErr.Error()
+
+	job.Status = models.Pending
+	job.ErrorCode = &jobErr.Code
+	job.Error = &msg
+	job.NextRetryAt = &retryAt
+	job.Result = nil
+	q.discardOutputs(job)
+	q.save(job)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	task.EnqueuedAt = time.Now()
+	raw, err := json.Marshal(task)
+	if err == nil {
+		err = q.redis.AddDelayed(ctx, jobDelayedQueueKey, string(raw), retryAt)
+	}
+	if err != nil {
+		q.log.Error("failed to schedule job retry", logger.String("jobID", job.ID), logger.Error(err))
+		q.markFailed(job, classifyJobError(err))
+		return
+	}
+
+	q.log.Info("job retry scheduled",
+		logger.String("jobID", job.ID),
+		logger.Int("attempt", job.Attempts),
+		logger.Any("retry_at", retryAt.Format(time.RFC3339)),
+	)
+}
+
+// requeue - server to'xtatilganda uzilgan jobni pending holatida asosiy navbatga qaytaradi
+func (q *queueService) requeue(job *models.Job, task models.QueueTask) {
+	job.Status = models.Pending
+	job.StartedAt = nil
+	job.OutputFileIDs = nil
+	job.Result = nil
+	q.save(job)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	task.EnqueuedAt = time.Now()
+	raw, err := json.Marshal(task)
+	if err == nil {
+		err = q.redis.Push(ctx, queueLaneKey(task.UserID), raw)
+	}
+	if err != nil {
+		q.log.Error("failed to requeue interrupted job", logger.String("jobID", job.ID), logger.Error(err))
+		return
+	}
+	q.log.Info("interrupted job requeued", logger.String("jobID", job.ID))
+}
+
+// markCancelled - job bazada hali processing bo'lsa (masalan, bekor qilingan pipeline qadami), uni cancelled qiladi
+func (q *queueService) markCancelled(job *models.Job) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cancelled, err := q.stg.Job().Cancel(ctx, job.ID)
+	if err != nil {
+		q.log.Error("failed to mark job cancelled", logger.String("jobID", job.ID), logger.Error(err))
+		return
+	}
+	if cancelled {
+		job.Status = models.Cancelled
+		q.events.Publish(ctx, job.StatusEvent())
+		q.dropPayload(job.ID)
+	}
+}
+
+// discardOutputs - to'xtatilgan job yozib ulgurgan natija fayllarini diskdan va bazadan o'chiradi
+func (q *queueService) discardOutputs(job *models.Job) {
+	if len(job.OutputFileIDs) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, id := range job.OutputFileIDs {
+		file, err := q.stg.File().GetByID(ctx, id)
+		if err != nil {
+			continue
+		}
+		if err := q.stg.File().Delete(ctx, id); err != nil {
+			q.log.Error("failed to delete partial output", logger.String("fileID", id), logger.Error(err))
+			continue
+		}
+		if err := releaseBlob(q.stg, file.FilePath, file.SHA256); err != nil {
+			q.log.Error("failed to remove partial output", logger.String("path", file.FilePath), logger.Error(err))
+		}
+	}
+	job.OutputFileIDs = nil
+}
+
+func (q *queueService) markDone(job *models.Job) {
+	now := time.Now()
+	job.Status = models.Done
+	job.ErrorCode = nil
+	job.Error = nil
+	job.NextRetryAt = nil
+	job.FinishedAt = &now
+	q.save(job)
+	q.dropPayload(job.ID)
+	q.webhooks.Notify(job)
+}
+
+// markFailed - doimiy xatoda "failed", urinishlari tugagan vaqtinchalik xatoda "dead"
+func (q *queueService) markFailed(job *models.Job, jobErr *JobError) {
+	now := time.Now()
+	msg := jobErr.Error()
+
+	job.Status = models.Failed
+	if jobErr.Transient {
+		job.Status = models.Dead
+	}
+	job.ErrorCode = &jobErr.Code
+	job.Error = &msg
+	job.NextRetryAt = nil
+	job.FinishedAt = &now
+	q.discardOutputs(job)
+	q.save(job)
+	q.dropPayload(job.ID)
+	q.webhooks.Notify(job)
+}
+
+// dropPayload - tugagan job payloadi (masalan, parol) Redis da ochiq holda qolmasligi kerak
+func (q *queueService) dropPayload(jobID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := q.redis.Del(ctx, jobPayloadKey+jobID); err != nil {
+		q.log.Error("failed to delete job payload", logger.String("jobID", jobID), logger.Error(err))
+	}
+}
+
+// jobHasSecrets - job (yoki batch/pipeline qadami) params i bazada emas, faqat payloadda saqlanadimi
+func jobHasSecrets(job *models.Job) bool {
+	if spec, ok := jobSteps[job.Type]; ok {
+		return spec.secret
+	}
+
+	switch job.Type {
+	case models.JobTypeBatch:
+		var req models.CreateBatchRequest
+		if err := json.Unmarshal(job.Params, &req); err == nil {
+			return jobSteps[req.Type].secret
+		}
+	case models.JobTypePipeline:
+		var req models.CreatePipelineRequest
+		if err := json.Unmarshal(job.Params, &req); err == nil {
+			for _, step := range req.Steps {
+				if jobSteps[step.Type].secret {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// save - job ctx si tugagan bo'lsa ham holat bazaga yozilishi va obunachilarga yuborilishi kerak
+func (q *queueService) save(job *models.Job) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := q.stg.Job().Update(ctx, job); err != nil {
+		q.log.Error("failed to save job state", logger.String("jobID", job.ID), logger.Error(err))
+		return
+	}
+
+	q.events.Publish(ctx, job.StatusEvent())
+}
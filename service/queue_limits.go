@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"test/api/models"
+	"test/pkg/logger"
+)
+
+// queueLane - ro'yxatdan o'tgan foydalanuvchi joblari priority yo'lagiga, tokensizlar guest yo'lagiga tushadi
+func queueLane(userID *string) string {
+	if userID != nil && *userID != "" {
+		return models.QueueLanePriority
+	}
+	return models.QueueLaneGuest
+}
+
+func queueLaneKey(userID *string) string {
+	if queueLane(userID) == models.QueueLanePriority {
+		return jobPriorityQueueKey
+	}
+	return jobQueueKey
+}
+
+// laneOrder - workerning n-urinishida navbatlar qaysi tartibda tekshirilishi.
+// Odatda priority yo'lak birinchi, lekin har (JobPriorityWeight+1)-urinishda guest yo'lak birinchi
+// tekshiriladi, shunda ro'yxatdan o'tganlar ko'p bo'lsa ham mehmonlar butunlay och qolmaydi.
+func (q *queueService) laneOrder(n int) []string {
+	weight := q.cfg.JobPriorityWeight
+	if weight > 0 && n%(weight+1) == 0 {
+		return []string{jobQueueKey, jobPriorityQueueKey}
+	}
+	return []string{jobPriorityQueueKey, jobQueueKey}
+}
+
+type slot struct {
+	key   string
+	limit int
+}
+
+// acquireSlots - foydalanuvchi va amal turi chegaralari bo'yicha job uchun slot band qiladi (barcha instansiyalar
+// uchun umumiy). Slot bo'lmasa false qaytadi; Redis xatosida job to'xtatilmaydi, faqat chegara qo'llanmaydi.
+// Har bir slot - job IDsi bilan lease: qayta urinish yangi slot egallamaydi, o'chib qolgan instansiyaning
+// leasei esa muddati o'tgach hisobdan chiqadi.
+func (q *queueService) acquireSlots(ctx context.Context, task models.QueueTask) (func(), bool) {
+	var slots []slot
+	if task.UserID != nil && q.cfg.JobUserConcurrency > 0 {
+		limit := q.cfg.JobUserConcurrency
+		// Qadamlarni kutib turgan parent ham foydalanuvchi slotini egallaydi, lekin o'zi ish bajarmaydi
+		if task.ParentID != "" {
+			limit++
+		}
+		slots = append(slots, slot{key: userSlotsKey + *task.UserID, limit: limit})
+	}
+	if limit := q.typeLimit(task.JobType); limit > 0 {
+		slots = append(slots, slot{key: typeSlotsKey + task.JobType, limit: limit})
+	}
+
+	// Instansiya o'chib qolsa, band qilingan slotlar shu vaqtdan keyin o'z-o'zidan bo'shaydi
+	until := time.Now().Add(q.cfg.JobTimeout + time.Minute)
+
+	var acquired []string
+	release := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		for _, key := range acquired {
+			if err := q.redis.ReleaseLease(ctx, key, task.JobID); err != nil {
+				q.log.Error("failed to release job slot", logger.String("key", key), logger.Error(err))
+			}
+		}
+	}
+
+	for _, s := range slots {
+		ok, err := q.redis.AcquireLease(ctx, s.key, task.JobID, int64(s.limit), until)
+		if err != nil {
+			q.log.Error("failed to acquire job slot", logger.String("key", s.key), logger.Error(err))
+			continue
+		}
+		if !ok {
+			release()
+			return nil, false
+		}
+		acquired = append(acquired, s.key)
+	}
+
+	return release, true
+}
+
+// waitForSlots - pipeline/batch qadami uchun slot bo'shashini kutadi (ko'pi bilan JobTimeout).
+// Qadam navbatga qaytarilmaydi - parent uni o'z workerida bajaradi.
+func (q *queueService) waitForSlots(ctx context.Context, task models.QueueTask) (func(), error) {
+	deadline := time.NewTimer(q.cfg.JobTimeout)
+	defer deadline.Stop()
+
+	for {
+		if release, ok := q.acquireSlots(ctx, task); ok {
+			return release, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline.C:
+			return nil, errNoFreeSlot
+		case <-time.After(slotWaitDelay):
+		}
+	}
+}
+
+// abandonChild - slot kutib boshlanmagan qadamni yakunlaydi, aks holda u pending holatda qolib ketadi
+func (q *queueService) abandonChild(jobID string, cause error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if !errors.Is(cause, errNoFreeSlot) {
+		if _, err := q.stg.Job().Cancel(ctx, jobID); err != nil {
+			q.log.Error("failed to cancel pending step", logger.String("jobID", jobID), logger.Error(err))
+		}
+		return
+	}
+
+	claimed, err := q.stg.Job().Claim(ctx, jobID)
+	if err != nil || !claimed {
+		return
+	}
+	job, err := q.stg.Job().GetByID(ctx, jobID)
+	if err != nil {
+		return
+	}
+	q.markFailed(job, &JobError{Code: ErrCodeTimeout, Transient: true, Err: cause})
+}
+
+// waitForSlot - chegaraga yetgan vazifani kechiktirilgan navbatga qo'yadi; u o'z yo'lagining oxiriga
+// qaytadi va boshqa foydalanuvchilar joblari oldinroq olinadi
+func (q *queueService) waitForSlot(task models.QueueTask) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	raw, err := json.Marshal(task)
+	if err == nil {
+		err = q.redis.AddDelayed(ctx, jobDelayedQueueKey, string(raw), time.Now().Add(slotWaitDelay))
+	}
+	if err != nil {
+		q.log.Error("failed to postpone job", logger.String("jobID", task.JobID), logger.Error(err))
+		return
+	}
+	q.log.Info("job postponed, concurrency limit reached", logger.String("jobID", task.JobID), logger.String("type", task.JobType))
+}
+
+func (q *queueService) typeLimit(jobType string) int {
+	q.limitsMu.RLock()
+	defer q.limitsMu.RUnlock()
+	return q.limits[jobType]
+}
+
+// refreshLimits - boshqa instansiyada o'zgartirilgan chegaralarni vaqti-vaqti bilan bazadan o'qiydi
+func (q *queueService) refreshLimits(ctx context.Context) {
+	ticker := time.NewTicker(limitsRefreshEvery)
+	defer ticker.Stop()
+
+	for {
+		if err := q.loadLimits(ctx); err != nil && ctx.Err() == nil {
+			q.log.Error("failed to load job type limits", logger.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (q *queueService) loadLimits(ctx context.Context) error {
+	list, err := q.stg.JobLimit().GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	limits := make(map[string]int, len(list))
+	for _, l := range list {
+		limits[l.JobType] = l.MaxWorkers
+	}
+
+	q.limitsMu.Lock()
+	q.limits = limits
+	q.limitsMu.Unlock()
+	return nil
+}
+
+// Stats - yo'laklar, kechiktirilgan vazifalar va amal turlari bo'yicha navbat holati
+func (q *queueService) Stats(ctx context.Context) (*models.QueueStats, error) {
+	stats := &models.QueueStats{
+		Lanes:           make(map[string]int64),
+		UserConcurrency: q.cfg.JobUserConcurrency,
+	}
+
+	for lane, key := range map[string]string{
+		models.QueueLanePriority: jobPriorityQueueKey,
+		models.QueueLaneGuest:    jobQueueKey,
+	} {
+		n, err := q.redis.Len(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s lane length: %w", lane, err)
+		}
+		stats.Lanes[lane] = n
+	}
+
+	delayed, err := q.redis.DelayedLen(ctx, jobDelayedQueueKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delayed queue length: %w", err)
+	}
+	stats.Delayed = delayed
+
+	active, err := q.stg.Job().CountActiveByType(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.loadLimits(ctx); err != nil {
+		return nil, err
+	}
+
+	// Har bir ro'yxatdan o'tgan tur ko'rsatiladi, hozir navbatda joblari bo'lmasa ham
+	byType := make(map[string]models.JobTypeStat)
+	q.mu.RLock()
+	for jobType := range q.handlers {
+		byType[jobType] = models.JobTypeStat{JobType: jobType}
+	}
+	q.mu.RUnlock()
+	for _, s := range active {
+		byType[s.JobType] = s
+	}
+
+	for jobType, s := range byType {
+		s.MaxWorkers = q.typeLimit(jobType)
+		stats.Types = append(stats.Types, s)
+	}
+	sort.Slice(stats.Types, func(i, j int) bool { return stats.Types[i].JobType < stats.Types[j].JobType })
+
+	return stats, nil
+}
+
+// SetTypeLimit - amal turi uchun bir vaqtda bajariladigan joblar sonini belgilaydi
+func (q *queueService) SetTypeLimit(ctx context.Context, jobType string, maxWorkers int) (*models.JobTypeLimit, error) {
+	q.mu.RLock()
+	_, ok := q.handlers[jobType]
+	q.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownJobType, jobType)
+	}
+
+	limit := models.JobTypeLimit{
+		JobType:    jobType,
+		MaxWorkers: maxWorkers,
+		UpdatedAt:  time.Now(),
+	}
+	if err := q.stg.JobLimit().Upsert(ctx, limit); err != nil {
+		return nil, err
+	}
+
+	q.limitsMu.Lock()
+	q.limits[jobType] = maxWorkers
+	q.limitsMu.Unlock()
+
+	q.log.Info("job type limit updated", logger.String("type", jobType), logger.Int("max_workers", maxWorkers))
+	return &limit, nil
+}
+
+// DeleteTypeLimit - amal turi chegarasini olib tashlaydi (cheklanmagan bo'ladi)
+func (q *queueService) DeleteTypeLimit(ctx context.Context, jobType string) error {
+	deleted, err := q.stg.JobLimit().Delete(ctx, jobType)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("%w: %s", ErrUnknownJobType, jobType)
+	}
+
+	q.limitsMu.Lock()
+	delete(q.limits, jobType)
+	q.limitsMu.Unlock()
+
+	q.log.Info("job type limit removed", logger.String("type", jobType))
+	return nil
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"test/api/models"
+	"test/config"
+)
+
+func TestQueueLane(t *testing.T) {
+	empty, userID := "", "user-1"
+	tests := []struct {
+		name     string
+		userID   *string
+		wantLane string
+		wantKey  string
+	}{
+		{"guest", nil, models.QueueLaneGuest, jobQueueKey},
+		{"empty user id", &empty, models.QueueLaneGuest, jobQueueKey},
+		{"registered user", &userID, models.QueueLanePriority, jobPriorityQueueKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := queueLane(tt.userID); got != tt.wantLane {
+				t.Errorf("queueLane = %q, want %q", got, tt.wantLane)
+			}
+			if got := queueLaneKey(tt.userID); got != tt.wantKey {
+				t.Errorf("queueLaneKey = %q, want %q", got, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestLaneOrder(t *testing.T) {
+	priorityFirst := []string{jobPriorityQueueKey, jobQueueKey}
+	guestFirst := []string{jobQueueKey, jobPriorityQueueKey}
+
+	tests := []struct {
+		name   string
+		weight int
+		n      int
+		want   []string
+	}{
+		{"no weight", 0, 0, priorityFirst},
+		{"no weight later", 0, 7, priorityFirst},
+		{"weight 3 first attempt", 3, 1, priorityFirst},
+		{"weight 3 third attempt", 3, 3, priorityFirst},
+		{"weight 3 fourth attempt", 3, 4, guestFirst},
+		{"weight 3 eighth attempt", 3, 8, guestFirst},
+		{"weight 1 alternates odd", 1, 1, priorityFirst},
+		{"weight 1 alternates even", 1, 2, guestFirst},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := newTestQueue(t, newFakeStorage(), config.Config{JobPriorityWeight: tt.weight})
+			if got := q.laneOrder(tt.n); !slices.Equal(got, tt.want) {
+				t.Errorf("laneOrder(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAcquireSlots(t *testing.T) {
+	userID := "user-1"
+	userKey := userSlotsKey + userID
+	typeKey := typeSlotsKey + models.JobTypeCompress
+
+	tests := []struct {
+		name            string
+		userConcurrency int
+		typeLimit       int
+		userID          *string
+		userLeases      []string // boshqa joblar egallagan slotlar
+		typeLeases      []string
+		wantOK          bool
+		wantUser        int // acquireSlots dan keyingi leaselar soni
+		wantType        int
+	}{
+		{name: "no limits", userID: &userID, wantOK: true},
+		{name: "guest ignores user limit", userConcurrency: 1, wantOK: true},
+		{name: "user slot free", userConcurrency: 2, userID: &userID, userLeases: []string{"job-a"}, wantOK: true, wantUser: 2},
+		{name: "user slots full", userConcurrency: 2, userID: &userID, userLeases: []string{"job-a", "job-b"}, wantOK: false, wantUser: 2},
+		{name: "own lease is reused", userConcurrency: 1, userID: &userID, userLeases: []string{"job-1"}, wantOK: true, wantUser: 1},
+		{name: "type slot free", typeLimit: 1, wantOK: true, wantType: 1},
+		{name: "type slots full", typeLimit: 1, typeLeases: []string{"job-a"}, wantOK: false, wantType: 1},
+		{name: "type full releases user slot", userConcurrency: 2, typeLimit: 1, userID: &userID, typeLeases: []string{"job-a"}, wantOK: false, wantUser: 0, wantType: 1},
+		{name: "both free", userConcurrency: 1, typeLimit: 1, userID: &userID, wantOK: true, wantUser: 1, wantType: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stg := newFakeStorage()
+			q, _ := newTestQueue(t, stg, config.Config{JobUserConcurrency: tt.userConcurrency, JobTimeout: time.Minute})
+			if tt.typeLimit > 0 {
+				q.limits[models.JobTypeCompress] = tt.typeLimit
+			}
+			ctx := context.Background()
+			for _, id := range tt.userLeases {
+				_, _ = stg.redis.AcquireLease(ctx, userKey, id, 100, time.Now().Add(time.Hour))
+			}
+			for _, id := range tt.typeLeases {
+				_, _ = stg.redis.AcquireLease(ctx, typeKey, id, 100, time.Now().Add(time.Hour))
+			}
+
+			release, ok := q.acquireSlots(ctx, models.QueueTask{JobID: "job-1", JobType: models.JobTypeCompress, UserID: tt.userID})
+			if ok != tt.wantOK || (release != nil) != tt.wantOK {
+				t.Fatalf("acquireSlots = %v (release set: %v), want %v", ok, release != nil, tt.wantOK)
+			}
+			if got := len(stg.redis.delayed(userKey)); got != tt.wantUser {
+				t.Errorf("user leases = %d, want %d", got, tt.wantUser)
+			}
+			if got := len(stg.redis.delayed(typeKey)); got != tt.wantType {
+				t.Errorf("type leases = %d, want %d", got, tt.wantType)
+			}
+
+			// release faqat shu jobning leaseini o'chiradi
+			if ok {
+				release()
+				want := len(tt.userLeases)
+				if slices.Contains(tt.userLeases, "job-1") {
+					want--
+				}
+				if got := len(stg.redis.delayed(userKey)); got != want {
+					t.Errorf("user leases after release = %d, want %d", got, want)
+				}
+				if got := len(stg.redis.delayed(typeKey)); got != len(tt.typeLeases) {
+					t.Errorf("type leases after release = %d, want %d", got, len(tt.typeLeases))
+				}
+			}
+		})
+	}
+}
+
+func TestAcquireSlotsExpiredLease(t *testing.T) {
+	stg := newFakeStorage()
+	q, _ := newTestQueue(t, stg, config.Config{JobTimeout: time.Minute})
+	q.limits[models.JobTypeCompress] = 1
+	ctx := context.Background()
+	key := typeSlotsKey + models.JobTypeCompress
+
+	// O'chib qolgan instansiya qoldirgan lease
+	_, _ = stg.redis.AcquireLease(ctx, key, "job-crashed", 1, time.Now().Add(-time.Second))
+
+	release, ok := q.acquireSlots(ctx, models.QueueTask{JobID: "job-1", JobType: models.JobTypeCompress})
+	if !ok {
+		t.Fatal("slot held by an expired lease")
+	}
+	if leases := stg.redis.delayed(key); len(leases) != 1 || !leases["job-1"].After(time.Now()) {
+		t.Errorf("leases = %v, want only job-1", leases)
+	}
+
+	// Slot band bo'lganda qayta urinishlar leaseni uzaytirmaydi va hisobni oshirmaydi
+	for i := 0; i < 3; i++ {
+		if _, ok := q.acquireSlots(ctx, models.QueueTask{JobID: "job-2", JobType: models.JobTypeCompress}); ok {
+			t.Fatal("second job got the only slot")
+		}
+	}
+	if leases := stg.redis.delayed(key); len(leases) != 1 {
+		t.Errorf("leases after denied retries = %v", leases)
+	}
+
+	release()
+	if leases := stg.redis.delayed(key); len(leases) != 0 {
+		t.Errorf("leases after release = %v", leases)
+	}
+}
+
+func TestExecuteChildSlots(t *testing.T) {
+	userID := "user-1"
+	parent := &models.Job{ID: "parent-1", Type: models.JobTypeBatch, UserID: &userID}
+
+	tests := []struct {
+		name       string
+		typeLeases []string // boshqa joblar egallagan compress slotlari
+		cancel     bool     // parent slot kutayotganda to'xtatiladi
+		wantStatus models.JobStatus
+		wantCode   string
+		wantRuns   int
+	}{
+		{name: "parent lease does not block its step", wantStatus: models.Done, wantRuns: 1},
+		{name: "no free type slot", typeLeases: []string{"job-a"}, wantStatus: models.Dead, wantCode: ErrCodeTimeout},
+		{name: "parent stopped while waiting", typeLeases: []string{"job-a"}, cancel: true, wantStatus: models.Cancelled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stg := newFakeStorage()
+			q, _ := newTestQueue(t, stg, config.Config{JobUserConcurrency: 1, JobTimeout: 100 * time.Millisecond})
+			q.limits[models.JobTypeCompress] = 1
+
+			runs := 0
+			q.Register(models.JobTypeCompress, func(context.Context, *models.Job, models.QueueTask) error {
+				runs++
+				return nil
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			// Parent o'zi foydalanuvchining yagona slotini egallab turibdi
+			_, _ = stg.redis.AcquireLease(ctx, userSlotsKey+userID, parent.ID, 1, time.Now().Add(time.Hour))
+			for _, id := range tt.typeLeases {
+				_, _ = stg.redis.AcquireLease(ctx, typeSlotsKey+models.JobTypeCompress, id, 1, time.Now().Add(time.Hour))
+			}
+			if tt.cancel {
+				time.AfterFunc(20*time.Millisecond, cancel)
+			}
+
+			child, err := runChildJob(ctx, stg, q, parent, models.JobTypeCompress, false, nil, nil)
+			if tt.cancel {
+				if err == nil {
+					t.Fatal("runChildJob succeeded after the parent was stopped")
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			children := stg.jobs.children(parent.ID)
+			if len(children) != 1 {
+				t.Fatalf("children = %v, want one step", children)
+			}
+			got := children[0]
+			if got.Status != tt.wantStatus || (tt.wantCode != "" && (got.ErrorCode == nil || *got.ErrorCode != tt.wantCode)) {
+				t.Errorf("step = %s (code %v), want %s %s", got.Status, got.ErrorCode, tt.wantStatus, tt.wantCode)
+			}
+			if child != nil && child.Status != got.Status {
+				t.Errorf("runChildJob returned %s, stored %s", child.Status, got.Status)
+			}
+			if runs != tt.wantRuns {
+				t.Errorf("handler ran %d times, want %d", runs, tt.wantRuns)
+			}
+			if _, held := stg.redis.delayed(typeSlotsKey + models.JobTypeCompress)[got.ID]; held {
+				t.Error("step kept its type slot")
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"test/api/models"
+	"test/pkg/logger"
+)
+
+const (
+	reaperPeriod      = 30 * time.Second
+	heartbeatTTL      = 3 * reaperPeriod // instansiya shuncha vaqt javob bermasa, uning vazifalari qaytariladi
+	staleJobGrace     = time.Minute      // JobTimeout dan keyin processing job shuncha kutiladi
+	ackTimeout        = 5 * time.Second
+	processingPattern = jobProcessingKey + "*"
+)
+
+// processingKey - workerning olingan, lekin hali bajarib bo'linmagan vazifalari ro'yxati
+func (q *queueService) processingKey(workerID int) string {
+	return fmt.Sprintf("%s%s:%d", jobProcessingKey, q.cfg.InstanceID, workerID)
+}
+
+// processingInstance - processing kalitidan instansiya nomi
+func processingInstance(key string) string {
+	rest := strings.TrimPrefix(key, jobProcessingKey)
+	if i := strings.LastIndex(rest, ":"); i >= 0 {
+		return rest[:i]
+	}
+	return rest
+}
+
+// ack - bajarilgan vazifani processing ro'yxatidan o'chiradi (server to'xtayotgan bo'lsa ham)
+func (q *queueService) ack(processing, raw string) {
+	ctx, cancel := context.WithTimeout(context.Background(), ackTimeout)
+	defer cancel()
+
+	if err := q.redis.Remove(ctx, processing, raw); err != nil {
+		q.log.Error("failed to ack queue task", logger.String("key", processing), logger.Error(err))
+	}
+}
+
+// recoverTasks - processing ro'yxatidagi vazifalarni o'z yo'laklariga qaytaradi. Job allaqachon olingan
+// bo'lsa, takroriy vazifani Claim o'tkazib yuboradi, shuning uchun ikki marta qaytarish xavfsiz.
+func (q *queueService) recoverTasks(processing string) int {
+	ctx, cancel := context.WithTimeout(context.Background(), ackTimeout)
+	defer cancel()
+
+	tasks, err := q.redis.Range(ctx, processing)
+	if err != nil {
+		q.log.Error("failed to read processing tasks", logger.String("key", processing), logger.Error(err))
+		return 0
+	}
+
+	recovered := 0
+	for _, raw := range tasks {
+		lane := jobQueueKey
+		var task models.QueueTask
+		if err := json.Unmarshal([]byte(raw), &task); err == nil {
+			lane = queueLaneKey(task.UserID)
+		}
+
+		if err := q.redis.Push(ctx, lane, raw); err != nil {
+			q.log.Error("failed to recover queue task", logger.String("key", processing), logger.Error(err))
+			continue
+		}
+		if err := q.redis.Remove(ctx, processing, raw); err != nil {
+			q.log.Error("failed to remove recovered task", logger.String("key", processing), logger.Error(err))
+		}
+		recovered++
+	}
+
+	if recovered > 0 {
+		q.log.Info("unacked queue tasks recovered", logger.String("key", processing), logger.Int("count", recovered))
+	}
+	return recovered
+}
+
+// reap - instansiya tirikligini bildiradi, o'chib qolgan instansiyalarning vazifalarini qaytaradi va
+// processing holatida osilib qolgan joblarni qayta navbatga qo'yadi
+func (q *queueService) reap(ctx context.Context) {
+	ticker := time.NewTicker(reaperPeriod)
+	defer ticker.Stop()
+
+	for {
+		if err := q.redis.SetX(ctx, workerHeartbeatKey+q.cfg.InstanceID, time.Now().Format(time.RFC3339), heartbeatTTL); err != nil && ctx.Err() == nil {
+			q.log.Error("failed to write worker heartbeat", logger.Error(err))
+		}
+		q.reapOrphanTasks(ctx)
+		q.reapStaleJobs(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reapOrphanTasks - heartbeat i tugagan instansiyalarning processing ro'yxatlarini navbatga qaytaradi
+func (q *queueService) reapOrphanTasks(ctx context.Context) {
+	keys, err := q.redis.Keys(ctx, processingPattern)
+	if err != nil {
+		if ctx.Err() == nil {
+			q.log.Error("failed to list processing queues", logger.Error(err))
+		}
+		return
+	}
+
+	alive := map[string]bool{q.cfg.InstanceID: true}
+	for _, key := range keys {
+		instance := processingInstance(key)
+		if _, checked := alive[instance]; !checked {
+			beat, err := q.redis.Get(ctx, workerHeartbeatKey+instance)
+			alive[instance] = err == nil && beat != ""
+		}
+		if !alive[instance] {
+			q.recoverTasks(key)
+		}
+	}
+}
+
+// reapStaleJobs - JobTimeout dan ancha oldin boshlangan processing joblar (worker jarayoni o'lgan)
+// qayta navbatga qo'yiladi; urinishlari tugagan bo'lsa dead bo'ladi
+func (q *queueService) reapStaleJobs(ctx context.Context) {
+	jobs, err := q.stg.Job().ReapStale(ctx, q.cfg.JobTimeout+staleJobGrace)
+	if err != nil {
+		if ctx.Err() == nil {
+			q.log.Error("failed to reap stale jobs", logger.Error(err))
+		}
+		return
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		q.events.Publish(ctx, job.StatusEvent())
+
+		if job.Status != models.Pending {
+			q.log.Error("stale job marked dead", logger.String("jobID", job.ID), logger.Int("attempts", job.Attempts))
+			q.dropPayload(job.ID)
+			q.webhooks.Notify(job)
+			continue
+		}
+
+		var payload interface{}
+		if data, err := q.redis.Get(ctx, jobPayloadKey+job.ID); err == nil && data != "" {
+			payload = json.RawMessage(data)
+		}
+		if err := q.Enqueue(ctx, job.ID, job.Type, job.UserID, payload); err != nil {
+			q.markFailed(job, classifyJobError(err))
+			continue
+		}
+		q.log.Info("stale job requeued", logger.String("jobID", job.ID), logger.Int("attempts", job.Attempts))
+	}
+}
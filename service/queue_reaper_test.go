@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"test/api/models"
+	"test/config"
+)
+
+func newTestQueue(t *testing.T, stg *fakeStorage, cfg config.Config) (*queueService, *fakeWebhooks) {
+	t.Helper()
+	webhooks := &fakeWebhooks{}
+	q := NewQueueService(stg, stg.redis, nopLogger{}, cfg, &fakeEvents{}, webhooks, fakeCache{}).(*queueService)
+	return q, webhooks
+}
+
+func rawTask(t *testing.T, jobID string, userID *string) string {
+	t.Helper()
+	raw, err := json.Marshal(models.QueueTask{JobID: jobID, JobType: models.JobTypeCompress, UserID: userID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(raw)
+}
+
+func TestWorkerAckOnlyAfterHandling(t *testing.T) {
+	stg := newFakeStorage()
+	q, _ := newTestQueue(t, stg, config.Config{InstanceID: "api-1"})
+	ctx := context.Background()
+	processing := q.processingKey(1)
+
+	_ = stg.redis.Push(ctx, jobQueueKey, rawTask(t, "job-1", nil))
+
+	// Olingan vazifa navbatdan yo'qoladi, lekin ack gacha processing ro'yxatida turadi
+	raw, err := stg.redis.Move(ctx, queuePopTimeout, processing, q.laneOrder(1)...)
+	if err != nil || raw == "" {
+		t.Fatalf("Move = %q, %v", raw, err)
+	}
+	if got := stg.redis.list(processing); len(got) != 1 || got[0] != raw {
+		t.Fatalf("processing list = %v, want [%s]", got, raw)
+	}
+
+	q.ack(processing, raw)
+	if got := stg.redis.list(processing); len(got) != 0 {
+		t.Fatalf("processing list after ack = %v", got)
+	}
+}
+
+func TestRecoverTasks(t *testing.T) {
+	stg := newFakeStorage()
+	q, _ := newTestQueue(t, stg, config.Config{InstanceID: "api-1"})
+	ctx := context.Background()
+	processing := q.processingKey(2)
+
+	userID := "user-1"
+	guest, user := rawTask(t, "job-guest", nil), rawTask(t, "job-user", &userID)
+	_ = stg.redis.Push(ctx, processing, guest)
+	_ = stg.redis.Push(ctx, processing, user)
+
+	if n := q.recoverTasks(processing); n != 2 {
+		t.Fatalf("recovered %d tasks, want 2", n)
+	}
+	if got := stg.redis.list(processing); len(got) != 0 {
+		t.Errorf("processing list = %v, want empty", got)
+	}
+	if got := stg.redis.list(jobQueueKey); len(got) != 1 || got[0] != guest {
+		t.Errorf("guest lane = %v, want [%s]", got, guest)
+	}
+	if got := stg.redis.list(jobPriorityQueueKey); len(got) != 1 || got[0] != user {
+		t.Errorf("priority lane = %v, want [%s]", got, user)
+	}
+}
+
+func TestReapOrphanTasks(t *testing.T) {
+	stg := newFakeStorage()
+	q, _ := newTestQueue(t, stg, config.Config{InstanceID: "api-1"})
+	ctx := context.Background()
+
+	own := q.processingKey(1)
+	live := jobProcessingKey + "api-2:1"
+	dead := jobProcessingKey + "api-3:4"
+	for _, key := range []string{own, live, dead} {
+		_ = stg.redis.Push(ctx, key, rawTask(t, "job-"+key, nil))
+	}
+	_ = stg.redis.SetX(ctx, workerHeartbeatKey+"api-2", "alive", heartbeatTTL)
+
+	q.reapOrphanTasks(ctx)
+
+	// Faqat heartbeat i yo'q instansiyaning vazifasi qaytariladi; o'zimizniki va tirik instansiyaniki qoladi
+	if got := stg.redis.list(dead); len(got) != 0 {
+		t.Errorf("dead instance list = %v, want empty", got)
+	}
+	if got := stg.redis.list(own); len(got) != 1 {
+		t.Errorf("own list = %v, want untouched", got)
+	}
+	if got := stg.redis.list(live); len(got) != 1 {
+		t.Errorf("live instance list = %v, want untouched", got)
+	}
+	if got := stg.redis.list(jobQueueKey); len(got) != 1 {
+		t.Errorf("guest lane = %v, want the recovered task", got)
+	}
+}
+
+func TestProcessingInstance(t *testing.T) {
+	tests := map[string]string{
+		jobProcessingKey + "api-1:3":          "api-1",
+		jobProcessingKey + "host:with:port:1": "host:with:port",
+		jobProcessingKey + ":2":               "",
+	}
+	for key, want := range tests {
+		if got := processingInstance(key); got != want {
+			t.Errorf("processingInstance(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestReapStaleJobs(t *testing.T) {
+	stg := newFakeStorage()
+	q, webhooks := newTestQueue(t, stg, config.Config{InstanceID: "api-1", JobTimeout: time.Minute})
+	ctx := context.Background()
+
+	userID := "user-1"
+	stg.jobs.stale = []models.Job{
+		{ID: "job-pending", Type: models.JobTypeProtect, UserID: &userID, Status: models.Pending, Attempts: 1, MaxAttempts: 3},
+		{ID: "job-dead", Type: models.JobTypeCompress, Status: models.Dead, Attempts: 3, MaxAttempts: 3},
+	}
+	_ = stg.redis.SetX(ctx, jobPayloadKey+"job-pending", `{"password":"x"}`, time.Hour)
+
+	q.reapStaleJobs(ctx)
+
+	lane := stg.redis.list(jobPriorityQueueKey)
+	if len(lane) != 1 {
+		t.Fatalf("priority lane = %v, want requeued job", lane)
+	}
+	var task models.QueueTask
+	if err := json.Unmarshal([]byte(lane[0]), &task); err != nil {
+		t.Fatal(err)
+	}
+	if task.JobID != "job-pending" || string(task.Payload) != `{"password":"x"}` {
+		t.Errorf("requeued task = %+v, want job-pending with its payload", task)
+	}
+	if got := stg.redis.list(jobQueueKey); len(got) != 0 {
+		t.Errorf("dead job requeued: %v", got)
+	}
+	if len(webhooks.notified) != 1 || webhooks.notified[0] != "job-dead" {
+		t.Errorf("webhooks notified for %v, want [job-dead]", webhooks.notified)
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"test/api/models"
+	"test/config"
+)
+
+func TestTerminalJobDropsPayload(t *testing.T) {
+	tests := []struct {
+		name   string
+		finish func(q *queueService, job *models.Job)
+		status models.JobStatus
+	}{
+		{"done", func(q *queueService, job *models.Job) { q.markDone(job) }, models.Done},
+		{"failed", func(q *queueService, job *models.Job) {
+			q.markFailed(job, &JobError{Code: ErrCodeInvalidInput, Err: errors.New("bad password")})
+		}, models.Failed},
+		{"dead", func(q *queueService, job *models.Job) {
+			q.markFailed(job, &JobError{Code: ErrCodeTimeout, Err: errors.New("timeout"), Transient: true})
+		}, models.Dead},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stg := newFakeStorage()
+			q, _ := newTestQueue(t, stg, config.Config{})
+			ctx := context.Background()
+
+			job := &models.Job{ID: "job-1", Type: models.JobTypeProtect, Status: models.Processing}
+			stg.jobs.put(job)
+			_ = stg.redis.SetX(ctx, jobPayloadKey+job.ID, `{"password":"x"}`, payloadTTL)
+
+			tt.finish(q, job)
+
+			if job.Status != tt.status {
+				t.Errorf("status = %q, want %q", job.Status, tt.status)
+			}
+			if _, err := stg.redis.Get(ctx, jobPayloadKey+job.ID); err == nil {
+				t.Error("payload still stored in redis")
+			}
+		})
+	}
+}
+
+func TestFailedAttemptDiscardsOutputs(t *testing.T) {
+	tests := []struct {
+		name   string
+		finish func(q *queueService, job *models.Job)
+	}{
+		{"retry", func(q *queueService, job *models.Job) {
+			q.scheduleRetry(job, models.QueueTask{JobID: job.ID, JobType: job.Type}, &JobError{Code: ErrCodeProcessingFailed, Err: errors.New("io"), Transient: true})
+		}},
+		{"failed", func(q *queueService, job *models.Job) {
+			q.markFailed(job, &JobError{Code: ErrCodeProcessingFailed, Err: errors.New("io")})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stg := newFakeStorage()
+			q, _ := newTestQueue(t, stg, config.Config{JobRetryBackoff: time.Second})
+
+			stg.files.put(models.File{ID: "out-1", FilePath: "outputs/out-1.pdf"})
+			job := &models.Job{ID: "job-1", Type: models.JobTypeCompress, Status: models.Processing, Attempts: 1, MaxAttempts: 3, OutputFileIDs: []string{"out-1"}}
+			stg.jobs.put(job)
+
+			tt.finish(q, job)
+
+			if len(job.OutputFileIDs) != 0 {
+				t.Errorf("output ids = %v, want none", job.OutputFileIDs)
+			}
+			if _, err := stg.files.GetByID(context.Background(), "out-1"); err == nil {
+				t.Error("output file record not deleted")
+			}
+			if len(stg.blobs.deleted) != 1 || stg.blobs.deleted[0] != "outputs/out-1.pdf" {
+				t.Errorf("deleted blobs = %v, want [outputs/out-1.pdf]", stg.blobs.deleted)
+			}
+		})
+	}
+}
+
+func TestJobHasSecrets(t *testing.T) {
+	params := func(v interface{}) json.RawMessage {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	tests := []struct {
+		name string
+		job  models.Job
+		want bool
+	}{
+		{"protect", models.Job{Type: models.JobTypeProtect}, true},
+		{"unlock", models.Job{Type: models.JobTypeUnlock}, true},
+		{"compress", models.Job{Type: models.JobTypeCompress}, false},
+		{"batch protect", models.Job{Type: models.JobTypeBatch, Params: params(models.CreateBatchRequest{Type: models.JobTypeProtect})}, true},
+		{"batch rotate", models.Job{Type: models.JobTypeBatch, Params: params(models.CreateBatchRequest{Type: models.JobTypeRotate})}, false},
+		{"pipeline with unlock", models.Job{Type: models.JobTypePipeline, Params: params(models.CreatePipelineRequest{
+			Steps: []models.PipelineStep{{Type: models.JobTypeUnlock}, {Type: models.JobTypeCompress}},
+		})}, true},
+		{"pipeline without secrets", models.Job{Type: models.JobTypePipeline, Params: params(models.CreatePipelineRequest{
+			Steps: []models.PipelineStep{{Type: models.JobTypeRotate}, {Type: models.JobTypeCompress}},
+		})}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jobHasSecrets(&tt.job); got != tt.want {
+				t.Errorf("jobHasSecrets = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryRejectsSecretJob(t *testing.T) {
+	stg := newFakeStorage()
+	q, _ := newTestQueue(t, stg, config.Config{})
+
+	err := q.Retry(context.Background(), &models.Job{ID: "job-1", Type: models.JobTypeProtect, Status: models.Failed})
+	if !errors.Is(err, ErrJobNotRetryable) {
+		t.Fatalf("err = %v, want ErrJobNotRetryable", err)
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+var (
+	// ErrQuotaExceeded - fayl foydalanuvchining xotira yoki fayllar soni chegarasiga sig'maydi
+	ErrQuotaExceeded = errors.New("storage quota exceeded")
+	// ErrUnknownQuotaPlan - bunday tarif yo'q
+	ErrUnknownQuotaPlan = errors.New("unknown quota plan")
+	// ErrUserNotFound - kvota belgilanayotgan foydalanuvchi topilmadi
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// QuotaService – foydalanuvchi egallagan joy va admin tomonidan kvotalarni boshqarish.
+// Chegaralarning o'zi fayl yozilayotganda saveFileRecord da tekshiriladi.
+type QuotaService interface {
+	GetUsage(ctx context.Context, userID string) (*models.UserUsage, error)
+	GetPlans(ctx context.Context) ([]models.QuotaPlan, error)
+	SetQuota(ctx context.Context, userID string, req models.SetUserQuotaRequest) (*models.UserUsage, error)
+	ResetQuota(ctx context.Context, userID string) (*models.UserUsage, error)
+}
+
+type quotaService struct {
+	stg storage.IStorage
+	log logger.ILogger
+}
+
+func NewQuotaService(stg storage.IStorage, log logger.ILogger) QuotaService {
+	return &quotaService{
+		stg: stg,
+		log: log,
+	}
+}
+
+func (s *quotaService) GetUsage(ctx context.Context, userID string) (*models.UserUsage, error) {
+	quota, err := s.stg.Quota().GetEffective(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := s.stg.Quota().GetUsage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.UserUsage{
+		UserID:       userID,
+		StorageUsage: usage,
+		Quota:        quota,
+	}
+	if quota.MaxBytes > 0 {
+		remaining := max(quota.MaxBytes-usage.UsedBytes, 0)
+		result.RemainingBytes = &remaining
+	}
+	if quota.MaxFiles > 0 {
+		remaining := max(quota.MaxFiles-usage.FileCount, 0)
+		result.RemainingFiles = &remaining
+	}
+	return result, nil
+}
+
+func (s *quotaService) GetPlans(ctx context.Context) ([]models.QuotaPlan, error) {
+	return s.stg.Quota().GetPlans(ctx)
+}
+
+// SetQuota - foydalanuvchi tarifini va/yoki chegaralarini belgilaydi; avvalgi override to'liq almashtiriladi
+func (s *quotaService) SetQuota(ctx context.Context, userID string, req models.SetUserQuotaRequest) (*models.UserUsage, error) {
+	if err := s.checkUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	override := models.UserQuotaOverride{
+		UserID:   userID,
+		MaxBytes: req.MaxBytes,
+		MaxFiles: req.MaxFiles,
+	}
+
+	if req.Plan != "" {
+		plans, err := s.stg.Quota().GetPlans(ctx)
+		if err != nil {
+			return nil, err
+		}
+		known := slices.ContainsFunc(plans, func(plan models.QuotaPlan) bool { return plan.Name == req.Plan })
+		if !known {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownQuotaPlan, req.Plan)
+		}
+		override.Plan = &req.Plan
+	}
+
+	if err := s.stg.Quota().Upsert(ctx, override); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("user quota updated", logger.String("userID", userID), logger.String("plan", req.Plan))
+	return s.GetUsage(ctx, userID)
+}
+
+// ResetQuota - admin override ni olib tashlaydi, foydalanuvchi standart tarifga qaytadi
+func (s *quotaService) ResetQuota(ctx context.Context, userID string) (*models.UserUsage, error) {
+	if err := s.checkUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.stg.Quota().Delete(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.GetUsage(ctx, userID)
+}
+
+func (s *quotaService) checkUser(ctx context.Context, userID string) error {
+	_, err := s.stg.User().GetByID(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrUserNotFound
+	}
+	return err
+}
+
+// checkQuota - hajmi oldindan ma'lum fayl (yuklash) kvotaga sig'ishini tezkor tekshiradi, shunda katta
+// fayl bekorga qabul qilinmaydi. Yakuniy tekshiruv saveFileRecord da qulf ostida bajariladi.
+func checkQuota(ctx context.Context, stg storage.IStorage, userID *string, size int64) error {
+	if userID == nil {
+		return nil
+	}
+
+	quota, err := stg.Quota().GetEffective(ctx, *userID)
+	if err != nil {
+		return err
+	}
+	if quota.MaxBytes == 0 && quota.MaxFiles == 0 {
+		return nil
+	}
+
+	usage, err := stg.Quota().GetUsage(ctx, *userID)
+	if err != nil {
+		return err
+	}
+	return quotaError(quota, usage, size)
+}
+
+func quotaError(quota models.UserQuota, usage models.StorageUsage, size int64) error {
+	if quota.MaxBytes > 0 && usage.UsedBytes+size > quota.MaxBytes {
+		return fmt.Errorf("%w: %d of %d bytes used, file needs %d", ErrQuotaExceeded, usage.UsedBytes, quota.MaxBytes, size)
+	}
+	if quota.MaxFiles > 0 && usage.FileCount+1 > quota.MaxFiles {
+		return fmt.Errorf("%w: file limit of %d reached", ErrQuotaExceeded, quota.MaxFiles)
+	}
+	return nil
+}
+
+// saveFileRecord - files yozuvini egasining kvotasi doirasida saqlaydi. Mehmon fayllari
+// kvotaga kirmaydi (ular yuklash hajmi bilan cheklangan va muddati o'tgach o'chiriladi).
+func saveFileRecord(ctx context.Context, stg storage.IStorage, file models.File) error {
+	if file.UserID == nil {
+		_, err := stg.File().Save(ctx, file)
+		return err
+	}
+
+	quota, err := stg.Quota().GetEffective(ctx, *file.UserID)
+	if err != nil {
+		return err
+	}
+
+	saved, err := stg.File().SaveWithinQuota(ctx, file, quota.MaxBytes, quota.MaxFiles)
+	if err != nil {
+		return err
+	}
+	if !saved {
+		usage, err := stg.Quota().GetUsage(ctx, *file.UserID)
+		if err != nil {
+			return ErrQuotaExceeded
+		}
+		if err := quotaError(quota, usage, file.FileSize); err != nil {
+			return err
+		}
+		return ErrQuotaExceeded
+	}
+	return nil
+}
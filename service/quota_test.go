@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"test/api/models"
+)
+
+func TestQuotaBoundary(t *testing.T) {
+	quota := models.UserQuota{MaxBytes: 1000, MaxFiles: 10}
+
+	tests := []struct {
+		name    string
+		quota   models.UserQuota
+		usage   models.StorageUsage
+		size    int64
+		wantErr bool
+	}{
+		{"fills bytes exactly", quota, models.StorageUsage{UsedBytes: 900, FileCount: 3}, 100, false},
+		{"one byte over", quota, models.StorageUsage{UsedBytes: 900, FileCount: 3}, 101, true},
+		{"last free file slot", quota, models.StorageUsage{UsedBytes: 0, FileCount: 9}, 1, false},
+		{"file limit reached", quota, models.StorageUsage{UsedBytes: 0, FileCount: 10}, 1, true},
+		{"already over after a plan downgrade", quota, models.StorageUsage{UsedBytes: 1500, FileCount: 3}, 0, true},
+		{"unlimited", models.UserQuota{}, models.StorageUsage{UsedBytes: 1 << 40, FileCount: 1 << 20}, 1 << 30, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := quotaError(tt.quota, tt.usage, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("quotaError = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrQuotaExceeded) {
+				t.Errorf("err = %v, want ErrQuotaExceeded", err)
+			}
+		})
+	}
+}
+
+func TestCheckQuota(t *testing.T) {
+	stg := newFakeStorage()
+	ctx := context.Background()
+
+	uid := "u1"
+	stg.quotas.quotas[uid] = models.UserQuota{Plan: "free", MaxBytes: 1000}
+	stg.quotas.usage[uid] = models.StorageUsage{UsedBytes: 900, FileCount: 5}
+
+	if err := checkQuota(ctx, stg, &uid, 100); err != nil {
+		t.Errorf("file that fills the quota exactly: %v", err)
+	}
+	if err := checkQuota(ctx, stg, &uid, 101); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("file one byte over the quota: err = %v, want ErrQuotaExceeded", err)
+	}
+	// Mehmon fayllari kvotaga kirmaydi
+	if err := checkQuota(ctx, stg, nil, 1<<40); err != nil {
+		t.Errorf("guest upload: %v", err)
+	}
+
+	// Yuklash ham yaratilayotganda shu chegarada rad etiladi
+	stg.quotas.usage[uid] = models.StorageUsage{UsedBytes: 950, FileCount: 5}
+	uploads := newTestUploads(stg)
+	if _, err := uploads.Create(ctx, models.CreateUploadRequest{UserID: &uid, FileName: "a.pdf", Length: 50}); err != nil {
+		t.Errorf("upload that fills the quota: %v", err)
+	}
+	if _, err := uploads.Create(ctx, models.CreateUploadRequest{UserID: &uid, FileName: "a.pdf", Length: 51}); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("upload over the quota: err = %v, want ErrQuotaExceeded", err)
+	}
+}
+
+func TestQuotaUsageRemaining(t *testing.T) {
+	stg := newFakeStorage()
+	s := NewQuotaService(stg, nopLogger{})
+
+	stg.quotas.quotas["u1"] = models.UserQuota{MaxBytes: 1000, MaxFiles: 10}
+	stg.quotas.usage["u1"] = models.StorageUsage{UsedBytes: 1200, FileCount: 4}
+
+	usage, err := s.GetUsage(context.Background(), "u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Chegaradan oshgan foydalanuvchida qolgan joy manfiy emas, 0
+	if usage.RemainingBytes == nil || *usage.RemainingBytes != 0 {
+		t.Errorf("remaining bytes = %v, want 0", usage.RemainingBytes)
+	}
+	if usage.RemainingFiles == nil || *usage.RemainingFiles != 6 {
+		t.Errorf("remaining files = %v, want 6", usage.RemainingFiles)
+	}
+
+	// Cheklanmagan kvotada qolgan joy ko'rsatilmaydi
+	usage, err = s.GetUsage(context.Background(), "u2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.RemainingBytes != nil || usage.RemainingFiles != nil {
+		t.Errorf("unlimited quota has remaining = %v/%v, want nil", usage.RemainingBytes, usage.RemainingFiles)
+	}
+}
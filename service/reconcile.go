@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"test/api/models"
+	"test/config"
+	"test/pkg/blobstore"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+const (
+	reconcileBatchSize     = 500
+	reconcileDefaultMinAge = 60 // daqiqa
+	// reconcileReportLimit - hisobotdagi har bir ro'yxatning maksimal uzunligi (job.Result juda kattalashmasligi uchun)
+	reconcileReportLimit = 1000
+)
+
+// reconcileBlobDirs - lokal blob ildizidagi kontent manzilli va eski (dedupdan oldingi) yuklash papkalari
+var reconcileBlobDirs = []string{"blobs", "uploads"}
+
+// ReconcileService – disk va files jadvali orasidagi nomuvofiqliklarni topadi: mazmuni yo'qolgan
+// files yozuvlari va hech qaysi yozuv havola qilmaydigan diskdagi fayllar. dry_run da faqat hisobot
+// tuziladi, aks holda ikkalasi ham tuzatiladi (yozuv o'chiriladi, yetim fayl o'chiriladi).
+type ReconcileService interface {
+	Create(ctx context.Context, req models.ReconcileRequest, userID string) (string, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
+}
+
+type reconcileService struct {
+	stg   storage.IStorage
+	log   logger.ILogger
+	cfg   config.Config
+	queue QueueService
+}
+
+func NewReconcileService(stg storage.IStorage, log logger.ILogger, cfg config.Config, queue QueueService) ReconcileService {
+	return &reconcileService{
+		stg:   stg,
+		log:   log,
+		cfg:   cfg,
+		queue: queue,
+	}
+}
+
+func (s *reconcileService) Create(ctx context.Context, req models.ReconcileRequest, userID string) (string, error) {
+	dryRun := req.DryRun == nil || *req.DryRun
+	params := models.ReconcileRequest{
+		DryRun:        &dryRun,
+		MinAgeMinutes: req.MinAgeMinutes,
+	}
+	if params.MinAgeMinutes <= 0 {
+		params.MinAgeMinutes = reconcileDefaultMinAge
+	}
+
+	job, err := newJob(models.JobTypeReconcile, &userID, []string{}, params)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("failed to submit reconcile job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+func (s *reconcileService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	return getJobByType(ctx, s.stg, id, models.JobTypeReconcile)
+}
+
+// Process - navbatdan olingan reconcile jobni bajaradi; hisobot job.Result ga yoziladi
+func (s *reconcileService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var params models.ReconcileRequest
+	if len(job.Params) > 0 {
+		if err := json.Unmarshal(job.Params, &params); err != nil {
+			return fmt.Errorf("invalid reconcile params: %w", err)
+		}
+	}
+	if params.MinAgeMinutes <= 0 {
+		params.MinAgeMinutes = reconcileDefaultMinAge
+	}
+
+	report := &models.ReconcileReport{
+		DryRun:        params.DryRun == nil || *params.DryRun,
+		MissingBlobs:  []models.ReconcileMissingBlob{},
+		OrphanedPaths: []models.ReconcileOrphanedPath{},
+	}
+
+	if err := s.checkFiles(ctx, report); err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-time.Duration(params.MinAgeMinutes) * time.Minute)
+	if err := s.checkPaths(ctx, report, cutoff); err != nil {
+		return err
+	}
+
+	if err := setJobResult(job, report); err != nil {
+		return err
+	}
+
+	s.log.Info("reconcile completed",
+		logger.String("jobID", job.ID),
+		logger.Int("missing", report.MissingCount),
+		logger.Int("orphaned", report.OrphanedCount))
+	return nil
+}
+
+// checkFiles - har bir files yozuvining blobi mavjudligini tekshiradi
+func (s *reconcileService) checkFiles(ctx context.Context, report *models.ReconcileReport) error {
+	afterID := ""
+	for {
+		files, err := s.stg.File().ListAfter(ctx, afterID, reconcileBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+
+		for _, file := range files {
+			report.ScannedFiles++
+
+			_, err := s.stg.Blob().Stat(ctx, file.FilePath)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, blobstore.ErrNotFound) {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				s.addError(report, fmt.Errorf("stat %s: %w", file.FilePath, err))
+				continue
+			}
+
+			report.MissingCount++
+			if len(report.MissingBlobs) < reconcileReportLimit {
+				report.MissingBlobs = append(report.MissingBlobs, models.ReconcileMissingBlob{
+					FileID:     file.ID,
+					FilePath:   file.FilePath,
+					UserID:     file.UserID,
+					UploadedAt: file.UploadedAt,
+				})
+			} else {
+				report.Truncated = true
+			}
+
+			if report.DryRun {
+				continue
+			}
+			if err := s.stg.File().DeleteByID(ctx, file.ID); err != nil {
+				s.addError(report, fmt.Errorf("delete file %s: %w", file.ID, err))
+				continue
+			}
+			// Blob yo'q, lekin file_blobs dagi havola hisoblagichi ham kamayishi kerak
+			if err := releaseBlob(s.stg, file.FilePath, file.SHA256); err != nil {
+				s.addError(report, fmt.Errorf("release blob %s: %w", file.FilePath, err))
+			}
+			report.DeletedRows++
+		}
+
+		if len(files) < reconcileBatchSize {
+			return nil
+		}
+		afterID = files[len(files)-1].ID
+	}
+}
+
+// checkPaths - ishchi papkalar va lokal blob papkalaridagi havolasiz fayllarni topadi
+func (s *reconcileService) checkPaths(ctx context.Context, report *models.ReconcileReport, cutoff time.Time) error {
+	roots := slices.Clone(retentionWorkDirs)
+	if isLocalBlobDriver(s.cfg) {
+		for _, dir := range reconcileBlobDirs {
+			roots = append(roots, filepath.Join(s.cfg.BlobLocalRoot, dir))
+		}
+	}
+	// Tugallanmagan bo'laklab yuklashlar files da emas, uploads jadvalida - ularni upload tozalash o'chiradi
+	chunkDir := filepath.Join(s.cfg.BlobLocalRoot, strings.TrimSuffix(uploadChunkPrefix, "/"))
+
+	for _, root := range roots {
+		touched := make(map[string]bool)
+
+		dirs, err := walkStaleFiles(ctx, root, chunkDir, cutoff, func(path string, info fs.FileInfo) error {
+			report.ScannedPaths++
+
+			referenced, err := isReferencedOnDisk(ctx, s.stg, s.cfg, path)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				s.addError(report, fmt.Errorf("check %s: %w", path, err))
+				return nil
+			}
+			if referenced {
+				return nil
+			}
+
+			report.OrphanedCount++
+			report.OrphanedBytes += info.Size()
+			if len(report.OrphanedPaths) < reconcileReportLimit {
+				report.OrphanedPaths = append(report.OrphanedPaths, models.ReconcileOrphanedPath{
+					Path:    filepath.ToSlash(path),
+					Size:    info.Size(),
+					ModTime: info.ModTime(),
+				})
+			} else {
+				report.Truncated = true
+			}
+
+			if report.DryRun {
+				return nil
+			}
+			if err := os.Remove(path); err != nil {
+				s.addError(report, fmt.Errorf("remove %s: %w", path, err))
+				return nil
+			}
+			report.RemovedPaths++
+			touched[filepath.Dir(path)] = true
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to scan %s: %w", root, err)
+		}
+
+		if !report.DryRun {
+			report.RemovedPaths += removeEmptyDirs(dirs, cutoff, touched)
+		}
+	}
+	return nil
+}
+
+func (s *reconcileService) addError(report *models.ReconcileReport, err error) {
+	s.log.Error("reconcile error", logger.Error(err))
+	if len(report.Errors) < reconcileReportLimit {
+		report.Errors = append(report.Errors, err.Error())
+	} else {
+		report.Truncated = true
+	}
+}
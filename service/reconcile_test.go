@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"test/api/models"
+	"test/config"
+)
+
+// runReconcile - reconcile jobni bajarib, job.Result dagi hisobotni qaytaradi
+func runReconcile(t *testing.T, s ReconcileService, dryRun bool) models.ReconcileReport {
+	t.Helper()
+	params, err := json.Marshal(models.ReconcileRequest{DryRun: &dryRun, MinAgeMinutes: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := &models.Job{ID: "reconcile-1", Type: models.JobTypeReconcile, Params: params}
+	if err := s.Process(context.Background(), job, models.QueueTask{}); err != nil {
+		t.Fatal(err)
+	}
+	var report models.ReconcileReport
+	if err := json.Unmarshal(job.Result, &report); err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+// writeAged - blob ildizi ichida fayl yaratib, o'zgartirilgan vaqtini age ga suradi
+func writeAged(t *testing.T, root, key string, age time.Duration) string {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("data"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Now().Add(-age)
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReconcileMissingBlobs(t *testing.T) {
+	stg := newFakeStorage()
+	s := NewReconcileService(stg, nopLogger{}, config.Config{BlobDriver: "s3"}, nil)
+
+	present := storeTestFile(t, stg, "present", "%PDF-1.7 present", nil, time.Now())
+	lost := storeTestFile(t, stg, "lost", "%PDF-1.7 lost", nil, time.Now())
+	delete(stg.blobs.data, lost.FilePath)
+
+	// dry_run: faqat hisobot, yozuv va hisoblagich o'zgarmaydi
+	report := runReconcile(t, s, true)
+	if report.ScannedFiles != 2 || report.MissingCount != 1 || len(report.MissingBlobs) != 1 || report.MissingBlobs[0].FileID != "lost" {
+		t.Fatalf("dry run report = %+v, want only %q missing", report, "lost")
+	}
+	if _, err := stg.files.GetByID(context.Background(), "lost"); err != nil || report.DeletedRows != 0 {
+		t.Fatalf("dry run must not delete rows: %v, deleted %d", err, report.DeletedRows)
+	}
+
+	report = runReconcile(t, s, false)
+	if report.MissingCount != 1 || report.DeletedRows != 1 {
+		t.Fatalf("report = %+v, want one deleted row", report)
+	}
+	if _, err := stg.files.GetByID(context.Background(), "lost"); err == nil {
+		t.Error("row with a missing blob must be deleted")
+	}
+	if _, ok := stg.fileBlobs.refs[lost.FilePath]; ok {
+		t.Error("refcount of the missing blob must be released")
+	}
+	if _, err := stg.files.GetByID(context.Background(), "present"); err != nil || stg.fileBlobs.refs[present.FilePath] != 1 {
+		t.Errorf("healthy file must stay: %v, refs %d", err, stg.fileBlobs.refs[present.FilePath])
+	}
+}
+
+func TestReconcileOrphanedPaths(t *testing.T) {
+	root := t.TempDir()
+	stg := newFakeStorage()
+	s := NewReconcileService(stg, nopLogger{}, config.Config{BlobLocalRoot: root}, nil)
+
+	old := 2 * time.Hour
+	byRow := writeAged(t, root, "uploads/2024/row.pdf", old)
+	stg.files.put(models.File{ID: "row", FilePath: "uploads/2024/row.pdf"})
+	stg.blobs.data["uploads/2024/row.pdf"] = []byte("data")
+	byRef := writeAged(t, root, "blobs/ab/abcd.pdf", old)
+	stg.fileBlobs.refs["blobs/ab/abcd.pdf"] = 1
+	orphan := writeAged(t, root, "blobs/cd/cdef.pdf", old)
+	// Yangi fayl endi yozilayotgan bo'lishi mumkin, tugallanmagan bo'laklar esa upload tozalashniki
+	fresh := writeAged(t, root, "blobs/ef/efgh.pdf", time.Minute)
+	chunk := writeAged(t, root, uploadChunkPrefix+"up-1/0", old)
+
+	report := runReconcile(t, s, true)
+	if report.OrphanedCount != 1 || len(report.OrphanedPaths) != 1 || report.OrphanedPaths[0].Path != filepath.ToSlash(orphan) {
+		t.Fatalf("dry run report = %+v, want only %s orphaned", report, orphan)
+	}
+	if report.OrphanedBytes != 4 || report.RemovedPaths != 0 {
+		t.Errorf("dry run: orphaned bytes %d, removed %d; want 4, 0", report.OrphanedBytes, report.RemovedPaths)
+	}
+	if _, err := os.Stat(orphan); err != nil {
+		t.Fatalf("dry run must not remove files: %v", err)
+	}
+
+	report = runReconcile(t, s, false)
+	if report.OrphanedCount != 1 || report.RemovedPaths != 2 {
+		t.Errorf("report = %+v, want the orphan and its emptied directory removed", report)
+	}
+	if _, err := os.Stat(orphan); !os.IsNotExist(err) {
+		t.Errorf("orphan must be removed: %v", err)
+	}
+	var kept []string
+	for _, path := range []string{byRow, byRef, fresh, chunk} {
+		if _, err := os.Stat(path); err == nil {
+			kept = append(kept, path)
+		}
+	}
+	if !slices.Equal(kept, []string{byRow, byRef, fresh, chunk}) {
+		t.Errorf("kept = %v, want referenced, fresh and chunk files", kept)
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"test/api/models"
+	"test/pkg/blobstore"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+const reencryptBatchSize = 500
+
+// ErrEncryptionDisabled - BLOB_ENCRYPTION_KEYS berilmagan, blob store shifrlamaydi
+var ErrEncryptionDisabled = errors.New("blob encryption is not configured")
+
+// ReencryptService – saqlangan fayllarni joriy master kalitga o'tkazadi: shifrlash yoqilishidan oldin
+// yozilgan fayllar shifrlanadi, eski kalitdagi fayllarning ma'lumot kaliti joriy kalit bilan qayta
+// o'raladi. Qayta ishga tushirish xavfsiz - joriy kalitdagi fayllar o'tkazib yuboriladi.
+type ReencryptService interface {
+	Run(ctx context.Context) (*models.ReencryptReport, error)
+}
+
+type reencryptService struct {
+	stg storage.IStorage
+	log logger.ILogger
+}
+
+func NewReencryptService(stg storage.IStorage, log logger.ILogger) ReencryptService {
+	return &reencryptService{
+		stg: stg,
+		log: log,
+	}
+}
+
+func (s *reencryptService) Run(ctx context.Context) (*models.ReencryptReport, error) {
+	re, ok := s.stg.Blob().(blobstore.Reencrypter)
+	if !ok {
+		return nil, ErrEncryptionDisabled
+	}
+
+	report := &models.ReencryptReport{}
+	if err := s.reencryptBlobs(ctx, re, report); err != nil {
+		return report, err
+	}
+	if err := s.reencryptLegacyFiles(ctx, re, report); err != nil {
+		return report, err
+	}
+
+	s.log.Info("re-encryption completed",
+		logger.Int("scanned", report.Scanned),
+		logger.Int("reencrypted", report.Reencrypted),
+		logger.Int("failed", report.Failed))
+	return report, nil
+}
+
+// reencryptBlobs - kontent manzilli bloblar; qulf ostida, shunda oxirgi havola o'chirilayotgan blob qayta yozilmaydi
+func (s *reencryptService) reencryptBlobs(ctx context.Context, re blobstore.Reencrypter, report *models.ReencryptReport) error {
+	afterKey := ""
+	for {
+		blobs, err := s.stg.FileBlob().ListAfter(ctx, afterKey, reencryptBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list blobs: %w", err)
+		}
+
+		for _, blob := range blobs {
+			report.Scanned++
+
+			var changed bool
+			used, err := s.stg.FileBlob().Rewrite(ctx, blob.Key, func(ctx context.Context) error {
+				var err error
+				changed, err = re.Reencrypt(ctx, blob.Key)
+				return err
+			})
+			if err == nil && !used {
+				report.Skipped++
+				continue
+			}
+			if err := s.count(ctx, report, blob.Key, changed, err); err != nil {
+				return err
+			}
+		}
+
+		if len(blobs) < reencryptBatchSize {
+			return nil
+		}
+		afterKey = blobs[len(blobs)-1].Key
+	}
+}
+
+// reencryptLegacyFiles - sha256 siz (dedup dan oldingi) fayllar; ularning bloblari file_blobs da yo'q
+func (s *reencryptService) reencryptLegacyFiles(ctx context.Context, re blobstore.Reencrypter, report *models.ReencryptReport) error {
+	afterID := ""
+	for {
+		files, err := s.stg.File().ListAfter(ctx, afterID, reencryptBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+
+		for _, file := range files {
+			if file.SHA256 != "" {
+				continue
+			}
+			report.Scanned++
+
+			changed, err := re.Reencrypt(ctx, file.FilePath)
+			if err := s.count(ctx, report, file.FilePath, changed, err); err != nil {
+				return err
+			}
+		}
+
+		if len(files) < reencryptBatchSize {
+			return nil
+		}
+		afterID = files[len(files)-1].ID
+	}
+}
+
+// count - natijani hisobotga qo'shadi; faqat context bekor qilinganda xato qaytaradi
+func (s *reencryptService) count(ctx context.Context, report *models.ReencryptReport, key string, changed bool, err error) error {
+	switch {
+	case err == nil && changed:
+		report.Reencrypted++
+	case err == nil:
+		report.Current++
+	case errors.Is(err, blobstore.ErrNotFound):
+		report.Missing++
+	case ctx.Err() != nil:
+		return ctx.Err()
+	default:
+		s.log.Error("failed to re-encrypt blob", logger.String("key", key), logger.Error(err))
+		report.Failed++
+		if len(report.Errors) < reconcileReportLimit {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", key, err))
+		}
+	}
+	return nil
+}
@@ -2,12 +2,12 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/pdfcpu/pdfcpu/pkg/api"
@@ -21,59 +21,96 @@ import (
 
 type RemovePageService interface {
 	Create(ctx context.Context, req models.RemovePagesRequest, userID *string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.RemoveJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type removePageService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewRemoveService(stg storage.IStorage, log logger.ILogger) RemovePageService {
+func NewRemoveService(stg storage.IStorage, log logger.ILogger, queue QueueService) RemovePageService {
 	return &removePageService{
-		stg: stg,
-		log: log,
+		stg:   stg,
+		log:   log,
+		queue: queue,
 	}
 }
 
 func (s *removePageService) Create(ctx context.Context, req models.RemovePagesRequest, userID *string) (string, error) {
 	s.log.Info("RemoveService.Create called")
 
-	// 1. Kiruvchi faylni olish
-	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
-	if err != nil {
+	// 1. Kiruvchi faylni tekshirish
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
 		s.log.Error("input file not found", logger.Error(err))
 		return "", err
 	}
 
-	// 2. Job modelini tayyorlash
-	job := &models.RemoveJob{
-		ID:            uuid.New().String(),
-		UserID:        userID,
-		InputFileID:   req.InputFileID,
-		PagesToRemove: req.PagesToRemove,
-		Status:        "pending",
-		CreatedAt:     time.Now(),
+	// 2. Sahifalar ro‘yxatini oldindan tekshirish
+	if _, err := parsePageList(req.PagesToRemove); err != nil {
+		s.log.Error("invalid page list", logger.Error(err))
+		return "", err
 	}
 
-	// 3. Job'ni DB ga yozish
-	if err := s.stg.RemovePage().Create(ctx, job); err != nil {
-		s.log.Error("failed to create remove job", logger.Error(err))
+	// 3. Job yaratish va navbatga qo'yish
+	job, err := newJob(models.JobTypeRemovePages, userID, []string{req.InputFileID}, req)
+	if err != nil {
 		return "", err
 	}
 
-	// 4. Output fayl uchun papkani yaratish
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("failed to submit remove job", logger.Error(err))
+		return "", err
+	}
+
+	s.log.Info("remove pages job queued", logger.String("jobID", job.ID))
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan remove pages jobni bajaradi
+func (s *removePageService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.RemovePagesRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid remove pages params: %w", err)
+	}
+
+	outputID, err := s.removePages(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputID}
+
+	s.log.Info("✅ remove pages completed", logger.String("jobID", job.ID))
+	return nil
+}
+
+func (s *removePageService) removePages(ctx context.Context, job *models.Job, req models.RemovePagesRequest) (string, error) {
+	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
+	if err != nil {
+		s.log.Error("input file not found", logger.Error(err))
+		return "", err
+	}
+
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	// 1. Output fayl uchun papkani yaratish
 	outputDir := "storage/remove"
 	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
 		s.log.Error("failed to create output dir", logger.Error(err))
 		return "", err
 	}
 
-	inputPath := file.FilePath
 	outputID := uuid.New().String()
 	outputPath := filepath.Join(outputDir, outputID+".pdf")
 
-	// 5. Sahifalar ro‘yxatini parse qilish
+	// 2. Sahifalar ro‘yxatini parse qilish
 	pageList, err := parsePageList(req.PagesToRemove)
 	if err != nil {
 		s.log.Error("invalid page list", logger.Error(err))
@@ -82,7 +119,7 @@ func (s *removePageService) Create(ctx context.Context, req models.RemovePagesRe
 
 	pageStrs := pkg.IntSliceToStringSlice(pageList)
 
-	// 6. PDF sahifalarni olib tashlash
+	// 3. PDF sahifalarni olib tashlash
 	config := model.NewDefaultConfiguration()
 	err = api.RemovePagesFile(inputPath, outputPath, pageStrs, config)
 	if err != nil {
@@ -90,44 +127,17 @@ func (s *removePageService) Create(ctx context.Context, req models.RemovePagesRe
 		return "", err
 	}
 
-	// 7. Yaratilgan output faylni bazaga yozish
-	info, err := os.Stat(outputPath)
-	if err != nil {
-		s.log.Error("cannot stat output file", logger.Error(err))
-		return "", err
-	}
-
-	newFile := models.File{
-		ID:         outputID,
-		UserID:     userID,
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/pdf",
-		FileSize:   info.Size(),
-		UploadedAt: time.Now(),
-	}
-
-	_, err = s.stg.File().Save(ctx, newFile)
-	if err != nil {
+	// 4. Yaratilgan output faylni bazaga yozish
+	if err := saveOutputFile(ctx, s.stg, outputID, job.UserID, outputPath, "application/pdf"); err != nil {
 		s.log.Error("failed to save output file", logger.Error(err))
 		return "", err
 	}
 
-	// 8. Job ni yangilash (output_file_id, status)
-	job.OutputFileID = &outputID
-	job.Status = "done"
-
-	if err := s.stg.RemovePage().Update(ctx, job); err != nil {
-		s.log.Error("failed to update job", logger.Error(err))
-		return "", err
-	}
-
-	s.log.Info("✅ remove pages completed", logger.String("jobID", job.ID))
-	return job.ID, nil
+	return outputID, nil
 }
 
-func (s *removePageService) GetByID(ctx context.Context, id string) (*models.RemoveJob, error) {
-	job, err := s.stg.RemovePage().GetByID(ctx, id)
+func (s *removePageService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	job, err := getJobByType(ctx, s.stg, id, models.JobTypeRemovePages)
 	if err != nil {
 		s.log.Error("failed to get remove job", logger.Error(err))
 		return nil, err
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+const (
+	retentionLockKey = "retention:lock"
+	// retentionLockTTL - tozalash jarayoni o'lib qolsa, qulf shu vaqtdan keyin o'zi ochiladi
+	retentionLockTTL = 30 * time.Minute
+	// retentionRunTimeout - bitta tozalash uchun ajratilgan vaqt
+	retentionRunTimeout = 10 * time.Minute
+)
+
+// retentionWorkDirs - amallar vaqtinchalik va natija fayllarini yozadigan papkalar. storage/ ning o'zi
+// emas, faqat amal papkalari ko'riladi: storage/ ichida Go paketlari ham bor.
+var retentionWorkDirs = []string{
+	"tmp",
+	"storage/add_background",
+	"storage/add_page_numbers",
+	"storage/batch",
+	"storage/compress",
+	"storage/crop_pdfs",
+	"storage/excel_to_pdf",
+	"storage/extract",
+	"storage/header_footer",
+	"storage/html_to_pdf",
+	"storage/merge",
+	"storage/organize",
+	"storage/pdf_to_jpg",
+	"storage/pdf_to_word",
+	"storage/powerpoint_to_pdf",
+	"storage/protect_pdf",
+	"storage/qr_code",
+	"storage/qr_code_pdf",
+	"storage/remove",
+	"storage/rotate_files",
+	"storage/split",
+	"storage/unlock_pdf",
+	"storage/watermark",
+	"storage/word_to_pdf",
+}
+
+// ErrCleanupRunning - boshqa tozalash hozir bajarilmoqda
+var ErrCleanupRunning = errors.New("cleanup is already running")
+
+// RetentionService – saqlash muddati o'tgan fayllarni, muddati o'tgan ulashish havolalarini va
+// ishchi papkalardagi yetim fayllarni davriy ravishda o'chiradi; har bir tozalash tarixga yoziladi.
+// Biriktirilgan (pinned) fayllar, amaldagi havolasi bor va bajarilayotgan job kiruvchi fayllari o'chirilmaydi.
+type RetentionService interface {
+	Run(ctx context.Context, trigger string) (*models.CleanupRun, error)
+	GetRuns(ctx context.Context, limit int) ([]models.CleanupRun, error)
+	RunScheduler(ctx context.Context)
+}
+
+type retentionService struct {
+	stg storage.IStorage
+	log logger.ILogger
+	cfg config.Config
+}
+
+func NewRetentionService(stg storage.IStorage, log logger.ILogger, cfg config.Config) RetentionService {
+	return &retentionService{
+		stg: stg,
+		log: log,
+		cfg: cfg,
+	}
+}
+
+// Run - bitta tozalash. Bir nechta instance bo'lsa ham bir vaqtda faqat bittasi ishlaydi (Redis qulfi),
+// aks holda ErrCleanupRunning. Qisman xato bo'lsa ham bajarilgan ish tarixga yoziladi.
+func (s *retentionService) Run(ctx context.Context, trigger string) (*models.CleanupRun, error) {
+	lock := uuid.NewString()
+	acquired, err := s.stg.Redis().SetNX(ctx, retentionLockKey, lock, retentionLockTTL)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, ErrCleanupRunning
+	}
+	defer s.unlock(lock)
+
+	run := &models.CleanupRun{
+		ID:        uuid.NewString(),
+		Trigger:   trigger,
+		Status:    models.CleanupRunning,
+		StartedAt: time.Now(),
+	}
+	if err := s.stg.CleanupRun().Create(ctx, run); err != nil {
+		return nil, err
+	}
+
+	runErr := s.cleanup(ctx, run)
+
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.Status = models.CleanupDone
+	if runErr != nil {
+		msg := runErr.Error()
+		run.Status = models.CleanupFailed
+		run.Error = &msg
+	}
+
+	// Run ctx si tugagan bo'lishi mumkin - natija baribir yozilishi kerak
+	finishCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.stg.CleanupRun().Finish(finishCtx, run); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("cleanup finished",
+		logger.String("trigger", trigger),
+		logger.String("status", run.Status),
+		logger.Int("deleted_files", run.DeletedFiles),
+		logger.Int("deleted_links", run.DeletedLinks),
+		logger.Int("removed_paths", run.RemovedPaths))
+	return run, runErr
+}
+
+func (s *retentionService) GetRuns(ctx context.Context, limit int) ([]models.CleanupRun, error) {
+	return s.stg.CleanupRun().GetList(ctx, limit)
+}
+
+// RunScheduler - RETENTION_INTERVAL da bir marta tozalashni ishga tushiradi
+func (s *retentionService) RunScheduler(ctx context.Context) {
+	if !s.cfg.RetentionEnabled || s.cfg.RetentionInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.RetentionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		runCtx, cancel := context.WithTimeout(ctx, retentionRunTimeout)
+		_, err := s.Run(runCtx, models.CleanupTriggerScheduled)
+		cancel()
+		if err != nil && !errors.Is(err, ErrCleanupRunning) {
+			s.log.Error("scheduled cleanup failed", logger.Error(err))
+		}
+	}
+}
+
+func (s *retentionService) cleanup(ctx context.Context, run *models.CleanupRun) error {
+	// Avval havolalar: muddati o'tgan havola endi faylni saqlab turmaydi
+	links, err := s.stg.SharedLink().DeleteExpired(ctx)
+	if err != nil {
+		return err
+	}
+	run.DeletedLinks = int(links)
+
+	if err := s.deleteExpiredFiles(ctx, run); err != nil {
+		return err
+	}
+
+	removed, err := s.sweepWorkDirs(ctx)
+	run.RemovedPaths = removed
+	return err
+}
+
+func (s *retentionService) deleteExpiredFiles(ctx context.Context, run *models.CleanupRun) error {
+	now := time.Now()
+	guestBefore := now.Add(-time.Duration(s.cfg.RetentionGuestHours) * time.Hour)
+
+	var userBefore *time.Time
+	if s.cfg.RetentionUserDays > 0 {
+		t := now.AddDate(0, 0, -s.cfg.RetentionUserDays)
+		userBefore = &t
+	}
+
+	files, err := s.stg.File().GetExpired(ctx, guestBefore, userBefore, s.cfg.RetentionBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if err := s.stg.File().DeleteByID(ctx, file.ID); err != nil {
+			s.log.Error("failed to delete expired file", logger.String("id", file.ID), logger.Error(err))
+			continue
+		}
+		// Blob boshqa (yangiroq) fayllar bilan umumiy bo'lishi mumkin - faqat havola kamayadi
+		if err := releaseBlob(s.stg, file.FilePath, file.SHA256); err != nil {
+			s.log.Error("failed to release file blob", logger.String("path", file.FilePath), logger.Error(err))
+		}
+		run.DeletedFiles++
+		run.FreedBytes += file.FileSize
+	}
+	return nil
+}
+
+// sweepWorkDirs - ishchi papkalardagi RETENTION_WORKDIR_GRACE dan eski, files jadvalida havolasi yo'q
+// fayllarni va bo'sh qolgan ichki papkalarni o'chiradi. Lokal blob drayverida eski natija fayllari
+// shu papkalarda saqlangan bo'lishi mumkin, shuning uchun havolasi bor fayllarga tegilmaydi.
+func (s *retentionService) sweepWorkDirs(ctx context.Context) (int, error) {
+	if s.cfg.RetentionWorkdirGrace <= 0 {
+		return 0, nil
+	}
+	cutoff := time.Now().Add(-s.cfg.RetentionWorkdirGrace)
+
+	removed := 0
+	for _, root := range retentionWorkDirs {
+		n, err := s.sweepDir(ctx, root, cutoff)
+		removed += n
+		if err != nil {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+func (s *retentionService) sweepDir(ctx context.Context, root string, cutoff time.Time) (int, error) {
+	removed := 0
+	touched := make(map[string]bool)
+
+	dirs, err := walkStaleFiles(ctx, root, "", cutoff, func(path string, info fs.FileInfo) error {
+		if referenced, err := isReferencedOnDisk(ctx, s.stg, s.cfg, path); err != nil || referenced {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			s.log.Error("failed to remove orphaned file", logger.String("path", path), logger.Error(err))
+			return nil
+		}
+		removed++
+		touched[filepath.Dir(path)] = true
+		return nil
+	})
+	if err != nil {
+		return removed, err
+	}
+
+	return removed + removeEmptyDirs(dirs, cutoff, touched), nil
+}
+
+// walkStaleFiles - root ichidagi cutoff dan eski oddiy fayllar uchun fn ni chaqiradi va ichki papkalarni
+// ichkaridagisi birinchi tartibda qaytaradi. root yo'q bo'lsa xato emas; exclude papkasiga kirilmaydi.
+func walkStaleFiles(ctx context.Context, root, exclude string, cutoff time.Time, fn func(path string, info fs.FileInfo) error) ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.IsDir() {
+			if exclude != "" && path == exclude {
+				return fs.SkipDir
+			}
+			if path != root {
+				dirs = append(dirs, path)
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			return nil
+		}
+		return fn(path, info)
+	})
+
+	slices.Reverse(dirs)
+	return dirs, err
+}
+
+// removeEmptyDirs - bo'sh papkalarni o'chiradi. Yangi papka endi yaratilayotgan job niki bo'lishi mumkin,
+// shuning uchun faqat eski yoki shu jarayonda bo'shatilgan (touched) papkalar o'chiriladi.
+func removeEmptyDirs(dirs []string, cutoff time.Time, touched map[string]bool) int {
+	removed := 0
+	for _, dir := range dirs {
+		info, err := os.Stat(dir)
+		if err != nil || (info.ModTime().After(cutoff) && !touched[dir]) {
+			continue
+		}
+		// os.Remove faqat bo'sh papkani o'chiradi
+		if err := os.Remove(dir); err == nil {
+			removed++
+			touched[filepath.Dir(dir)] = true
+		}
+	}
+	return removed
+}
+
+// isReferencedOnDisk - disk yo'li biror files yozuvi yoki kontent manzilli blobning kaliti bo'lib
+// xizmat qiladimi. Lokal bo'lmagan drayverda diskdagi fayllar blob emas.
+func isReferencedOnDisk(ctx context.Context, stg storage.IStorage, cfg config.Config, path string) (bool, error) {
+	key, ok := blobKeyForPath(cfg, path)
+	if !ok {
+		return false, nil
+	}
+
+	exists, err := stg.File().ExistsByPath(ctx, key)
+	if err != nil || exists {
+		return exists, err
+	}
+	return stg.FileBlob().Exists(ctx, key)
+}
+
+// blobKeyForPath - lokal drayverda disk yo'liga mos blob kaliti; yo'l blob ildizidan tashqarida bo'lsa false
+func blobKeyForPath(cfg config.Config, path string) (string, bool) {
+	if !isLocalBlobDriver(cfg) {
+		return "", false
+	}
+
+	root, err := filepath.Abs(cfg.BlobLocalRoot)
+	if err != nil {
+		return "", false
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	key, err := filepath.Rel(root, abs)
+	if err != nil || key == ".." || strings.HasPrefix(key, "../") {
+		return "", false
+	}
+	return filepath.ToSlash(key), true
+}
+
+func isLocalBlobDriver(cfg config.Config) bool {
+	return cfg.BlobDriver == "" || cfg.BlobDriver == "local"
+}
+
+func (s *retentionService) unlock(lock string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Qulf muddati tugab, boshqa instance olgan bo'lsa, uni ochib yubormaslik kerak
+	if current, err := s.stg.Redis().Get(ctx, retentionLockKey); err == nil && current == lock {
+		_ = s.stg.Redis().Del(ctx, retentionLockKey)
+	}
+}
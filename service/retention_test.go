@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"test/api/models"
+	"test/config"
+)
+
+func TestRetentionExclusions(t *testing.T) {
+	stg := newFakeStorage()
+	s := NewRetentionService(stg, nopLogger{}, config.Config{RetentionGuestHours: 24, RetentionBatchSize: 100}).(*retentionService)
+	files := NewFileService(stg, nopLogger{}, config.Config{}, nil)
+	ctx := context.Background()
+
+	old := time.Now().Add(-48 * time.Hour)
+	expired := storeTestFile(t, stg, "expired", "%PDF-1.7 same", nil, old)
+	pinned := storeTestFile(t, stg, "pinned", "%PDF-1.7 same", nil, old)
+	if _, err := files.SetPinned(ctx, "pinned", true); err != nil {
+		t.Fatal(err)
+	}
+	shared := storeTestFile(t, stg, "shared", "%PDF-1.7 shared", nil, old)
+	stg.files.links["shared"] = []*time.Time{nil}
+	storeTestFile(t, stg, "input", "%PDF-1.7 input", nil, old)
+	stg.jobs.put(&models.Job{ID: "job-1", Status: models.Processing, InputFileIDs: []string{"input"}})
+
+	// Muddati o'tgan havola va tugagan job faylni himoya qilmaydi
+	linkExpired := storeTestFile(t, stg, "link-expired", "%PDF-1.7 link", nil, old)
+	stg.files.links["link-expired"] = []*time.Time{ptr(time.Now().Add(-time.Hour))}
+	jobDone := storeTestFile(t, stg, "job-done", "%PDF-1.7 done", nil, old)
+	stg.jobs.put(&models.Job{ID: "job-2", Status: models.Done, InputFileIDs: []string{"job-done"}})
+
+	run := &models.CleanupRun{}
+	if err := s.deleteExpiredFiles(ctx, run); err != nil {
+		t.Fatal(err)
+	}
+
+	var left []string
+	for _, id := range []string{"expired", "pinned", "shared", "input", "link-expired", "job-done"} {
+		if _, err := stg.files.GetByID(ctx, id); err == nil {
+			left = append(left, id)
+		}
+	}
+	if !slices.Equal(left, []string{"pinned", "shared", "input"}) {
+		t.Errorf("files left = %v, want pinned, shared and running job input", left)
+	}
+	if run.DeletedFiles != 3 || run.FreedBytes != expired.FileSize+linkExpired.FileSize+jobDone.FileSize {
+		t.Errorf("run = %d files, %d bytes; want 3", run.DeletedFiles, run.FreedBytes)
+	}
+
+	// Biriktirilgan fayl bilan umumiy blob qoladi, faqat havola kamayadi; himoyalangan blobga tegilmaydi
+	if pinned.FilePath != expired.FilePath || stg.fileBlobs.refs[pinned.FilePath] != 1 {
+		t.Errorf("shared blob refs = %d, want 1 kept by the pinned file", stg.fileBlobs.refs[pinned.FilePath])
+	}
+	if stg.fileBlobs.refs[shared.FilePath] != 1 {
+		t.Errorf("shared-link blob refs = %d, want 1", stg.fileBlobs.refs[shared.FilePath])
+	}
+	deleted := slices.Clone(stg.blobs.deleted)
+	slices.Sort(deleted)
+	want := []string{linkExpired.FilePath, jobDone.FilePath}
+	slices.Sort(want)
+	if !slices.Equal(deleted, want) {
+		t.Errorf("deleted blobs = %v, want %v", deleted, want)
+	}
+
+	// Biriktirish olib tashlangach keyingi tozalash faylni va oxirgi havola bilan blobni o'chiradi
+	if _, err := files.SetPinned(ctx, "pinned", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.deleteExpiredFiles(ctx, &models.CleanupRun{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := stg.fileBlobs.refs[pinned.FilePath]; ok || !slices.Contains(stg.blobs.deleted, pinned.FilePath) {
+		t.Errorf("unpinned file's blob must be released and deleted: deleted %v", stg.blobs.deleted)
+	}
+}
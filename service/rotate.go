@@ -2,11 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -17,52 +16,80 @@ import (
 
 type RotateService interface {
 	Create(ctx context.Context, req models.RotatePDFRequest, userID *string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.RotateJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type rotateService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewRotateService(stg storage.IStorage, log logger.ILogger) RotateService {
-	return &rotateService{stg: stg, log: log}
+func NewRotateService(stg storage.IStorage, log logger.ILogger, queue QueueService) RotateService {
+	return &rotateService{stg: stg, log: log, queue: queue}
 }
 
 func (s *rotateService) Create(ctx context.Context, req models.RotatePDFRequest, userID *string) (string, error) {
 	s.log.Info("RotateService.Create called")
 
-	// 1. Faylni olish
-	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
-	if err != nil {
+	// 1. Faylni tekshirish
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
 		s.log.Error("input file not found", logger.Error(err))
-		return "", fmt.Errorf("input file not found: %v", err)
+		return "", fmt.Errorf("input file not found: %w", err)
 	}
 
-	// 2. Job yaratish (output_file_id keyinchalik belgilanadi)
-	jobID := uuid.NewString()
-	outputFileID := uuid.NewString()
-	outputPath := filepath.Join("storage/rotate_files", outputFileID+".pdf")
+	// 2. Job yaratish va navbatga qo'yish
+	job, err := newJob(models.JobTypeRotate, userID, []string{req.InputFileID}, req)
+	if err != nil {
+		return "", err
+	}
 
-	if err := os.MkdirAll(filepath.Dir(outputPath), os.ModePerm); err != nil {
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
 		return "", err
 	}
 
-	job := &models.RotateJob{
-		ID:          jobID,
-		UserID:      userID,
-		InputFileID: req.InputFileID,
-		Status:      "pending",
-		Angle:       req.Angle,
-		Pages:       req.Pages,
-		CreatedAt:   time.Now(),
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan rotate jobni bajaradi
+func (s *rotateService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.RotatePDFRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid rotate params: %w", err)
+	}
+
+	outputFileID, err := s.rotate(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputFileID}
+
+	s.log.Info("Rotate job completed", logger.String("jobID", job.ID))
+	return nil
+}
+
+func (s *rotateService) rotate(ctx context.Context, job *models.Job, req models.RotatePDFRequest) (string, error) {
+	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
+	if err != nil {
+		return "", fmt.Errorf("input file not found: %w", err)
+	}
+
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return "", err
 	}
+	defer cleanup()
 
-	if err := s.stg.Rotate().Create(ctx, job); err != nil {
+	outputFileID := uuid.NewString()
+	outputPath := filepath.Join("storage/rotate_files", outputFileID+".pdf")
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), os.ModePerm); err != nil {
 		return "", err
 	}
 
-	// 3. Faylni burish: pdfcpu CLI orqali
+	// Faylni burish: pdfcpu CLI orqali
 	args := []string{
 		"rotate",
 	}
@@ -72,51 +99,24 @@ func (s *rotateService) Create(ctx context.Context, req models.RotatePDFRequest,
 	}
 
 	args = append(args,
-		file.FilePath,                // input file
+		inputPath,                    // input file
 		fmt.Sprintf("%d", req.Angle), // rotation angle
 		outputPath,                   // output file
 	)
 
-	cmd := exec.Command("pdfcpu", args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := runTool(ctx, outputPath, "pdfcpu", args...); err != nil {
 		s.log.Error("pdfcpu failed", logger.Error(err))
 		return "", err
 	}
 
-	// 4. Yangi faylni saqlash
-	fi, err := os.Stat(outputPath)
-	if err != nil {
-		return "", err
-	}
-
-	newFile := models.File{
-		ID:         outputFileID,
-		UserID:     userID,
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/pdf",
-		FileSize:   fi.Size(),
-		UploadedAt: time.Now(),
-	}
-
-	if _, err := s.stg.File().Save(ctx, newFile); err != nil {
-		return "", err
-	}
-
-	// 5. Job holatini yangilash
-	job.OutputFileID = &outputFileID
-	job.Status = "done"
-
-	if err := s.stg.Rotate().Update(ctx, job); err != nil {
+	// Yangi faylni saqlash
+	if err := saveOutputFile(ctx, s.stg, outputFileID, job.UserID, outputPath, "application/pdf"); err != nil {
 		return "", err
 	}
 
-	s.log.Info("Rotate job completed", logger.String("jobID", jobID))
-	return jobID, nil
+	return outputFileID, nil
 }
 
-func (s *rotateService) GetByID(ctx context.Context, id string) (*models.RotateJob, error) {
-	return s.stg.Rotate().GetByID(ctx, id)
+func (s *rotateService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	return getJobByType(ctx, s.stg, id, models.JobTypeRotate)
 }
@@ -1,9 +1,12 @@
 package service
 
 import (
+	"test/api/models"
+	"test/config"
 	"test/pkg/gotenberg"
 	"test/pkg/logger"
 	"test/pkg/mailer"
+	"test/pkg/scanner"
 	"test/storage"
 )
 
@@ -23,6 +26,9 @@ type IServiceManager interface {
 	Compress() CompressService
 	PDFToJPG() PDFToJPGService
 	Rotate() RotateService
+	Organize() OrganizeService
+	Watermark() WatermarkService
+	AddBackground() AddBackgroundService
 	AddPageNumber() AddPageNumberService
 	Crop() CropPDFService
 	Unlock() UnlockService
@@ -41,6 +47,20 @@ type IServiceManager interface {
 	ExcelToPDF() ExcelToPDFService
 	PowerPointToPDF() PowerPointToPDFService
 	HTMLToPDF() HTMLToPDFService
+
+	Queue() QueueService
+	Job() JobService
+	JobEvents() JobEventService
+	Webhook() WebhookService
+	Pipeline() PipelineService
+	Batch() BatchService
+	Idempotency() IdempotencyService
+	Upload() UploadService
+	JobCache() JobCacheService
+	Quota() QuotaService
+	Retention() RetentionService
+	Reconcile() ReconcileService
+	Folder() FolderService
 }
 
 type service struct {
@@ -57,6 +77,9 @@ type service struct {
 	compressService      CompressService
 	pdfToJPGService      PDFToJPGService
 	rotateSrvice         RotateService
+	organizeService      OrganizeService
+	watermarkService     WatermarkService
+	addBackgroundService AddBackgroundService
 	addPageNumberService AddPageNumberService
 	cropPDFService       CropPDFService
 	unlockService        UnlockService
@@ -75,42 +98,110 @@ type service struct {
 	excelToPDF             ExcelToPDFService
 	powerPointToPDF        PowerPointToPDFService
 	hTMLToPDF              HTMLToPDFService
-}
 
-func New(storage storage.IStorage, log logger.ILogger, mailerCore *mailer.Mailer, redis storage.IRedisStorage, gotClient gotenberg.Client) IServiceManager {
-	return &service{
+	queueService QueueService
+	jobService   JobService
+	jobEvents    JobEventService
+	webhooks     WebhookService
+	pipeline     PipelineService
+	batch        BatchService
+	idempotency  IdempotencyService
+	uploads      UploadService
+	jobCache     JobCacheService
+	quotas       QuotaService
+	retention    RetentionService
+	reconcile    ReconcileService
+	folders      FolderService
+}
+
+func New(cfg config.Config, storage storage.IStorage, log logger.ILogger, mailerCore *mailer.Mailer, redis storage.IRedisStorage, gotClient gotenberg.Client, fileScanner scanner.Scanner) IServiceManager {
+	jobEvents := NewJobEventService(redis, log, cfg)
+	webhooks := NewWebhookService(storage, redis, log, cfg, nil)
+	jobCache := NewJobCacheService(storage, log, cfg)
+	queue := NewQueueService(storage, redis, log, cfg, jobEvents, webhooks, jobCache)
+	files := NewFileService(storage, log, cfg, fileScanner)
+
+	srv := &service{
 		userService:          NewUserService(storage, log),
 		otpService:           NewOtpService(storage, log, mailerCore, redis),
 		roleService:          NewRoleService(storage, log),
 		sysUserService:       NewSysUserService(storage, log),
 		mailer:               NewMailerService(mailerCore),
-		mergeService:         NewMergeService(storage, log),
-		fileService:          NewFileService(storage, log),
-		splitService:         NewSplitService(storage, log),
-		removepageService:    NewRemoveService(storage, log),
-		extractPageService:   NewExtractService(storage, log),
-		compressService:      NewCompressService(storage, log),
-		pdfToJPGService:      NewPDFToJPGService(storage, log),
-		rotateSrvice:         NewRotateService(storage, log),
-		addPageNumberService: NewAddPageNumberService(storage, log),
-		cropPDFService:       NewCropPDFService(storage, log),
-		unlockService:        NewUnlockService(storage, log),
-		protectPDFService:    NewProtectPDFService(storage, log),
+		mergeService:         NewMergeService(storage, log, queue),
+		fileService:          files,
+		splitService:         NewSplitService(storage, log, queue),
+		removepageService:    NewRemoveService(storage, log, queue),
+		extractPageService:   NewExtractService(storage, log, queue),
+		compressService:      NewCompressService(storage, log, queue),
+		pdfToJPGService:      NewPDFToJPGService(storage, log, queue),
+		rotateSrvice:         NewRotateService(storage, log, queue),
+		organizeService:      NewOrganizeService(storage, log, queue),
+		watermarkService:     NewWatermarkService(storage, log, queue),
+		addBackgroundService: NewAddBackgroundService(storage, log, queue),
+		addPageNumberService: NewAddPageNumberService(storage, log, queue),
+		cropPDFService:       NewCropPDFService(storage, log, queue),
+		unlockService:        NewUnlockService(storage, log, queue),
+		protectPDFService:    NewProtectPDFService(storage, log, queue),
 		statsService:         NewStatsService(storage, log),
 		logService:           NewLogService(storage, log),
-		jPGToPDF:             NewJPGToPDFService(storage, log),
-		inspect:              NewInspectService(storage, log),
+		jPGToPDF:             NewJPGToPDFService(storage, log, queue),
+		inspect:              NewInspectService(storage, log, queue),
 
 		sharedLinkService:      NewSharedLinkService(storage, log),
-		addHeaderFooterService: NewAddHeaderFooterService(storage, log),
-		detectBlankService:     NewDetectBlankService(storage, log),
-		qRCodeService:          NewQRCodeService(storage, log),
-		pdfToWordService:       NewPDFToWordService(storage, log),
-		wordToPDFService:       NewWordToPDFService(storage, log, gotClient),
-		excelToPDF:             NewExcelToPDFService(storage, log, gotClient),
-		powerPointToPDF:        NewPowerPointToPDFService(storage, log, gotClient),
-		hTMLToPDF:              NewHTMLToPDFService(storage, log, gotClient),
+		addHeaderFooterService: NewAddHeaderFooterService(storage, log, queue),
+		detectBlankService:     NewDetectBlankService(storage, log, queue),
+		qRCodeService:          NewQRCodeService(storage, log, queue),
+		pdfToWordService:       NewPDFToWordService(storage, log, queue),
+		wordToPDFService:       NewWordToPDFService(storage, log, gotClient, queue),
+		excelToPDF:             NewExcelToPDFService(storage, log, gotClient, queue),
+		powerPointToPDF:        NewPowerPointToPDFService(storage, log, gotClient, queue),
+		hTMLToPDF:              NewHTMLToPDFService(storage, log, gotClient, queue),
+
+		queueService: queue,
+		jobService:   NewJobService(storage, log, cfg, queue),
+		jobEvents:    jobEvents,
+		webhooks:     webhooks,
+		pipeline:     NewPipelineService(storage, log, queue),
+		batch:        NewBatchService(storage, log, cfg, queue),
+		idempotency:  NewIdempotencyService(redis, log, cfg),
+		uploads:      NewUploadService(storage, log, cfg, files),
+		jobCache:     jobCache,
+		quotas:       NewQuotaService(storage, log),
+		retention:    NewRetentionService(storage, log, cfg),
+		reconcile:    NewReconcileService(storage, log, cfg, queue),
+		folders:      NewFolderService(storage, log),
 	}
+
+	// Navbatdagi job turlarini bajaruvchi servicelarga bog'lash
+	queue.Register(models.JobTypeMerge, srv.mergeService.Process)
+	queue.Register(models.JobTypeSplit, srv.splitService.Process)
+	queue.Register(models.JobTypeRemovePages, srv.removepageService.Process)
+	queue.Register(models.JobTypeExtractPages, srv.extractPageService.Process)
+	queue.Register(models.JobTypeCompress, srv.compressService.Process)
+	queue.Register(models.JobTypeJPGToPDF, srv.jPGToPDF.Process)
+	queue.Register(models.JobTypePDFToJPG, srv.pdfToJPGService.Process)
+	queue.Register(models.JobTypeRotate, srv.rotateSrvice.Process)
+	queue.Register(models.JobTypeOrganize, srv.organizeService.Process)
+	queue.Register(models.JobTypeWatermark, srv.watermarkService.Process)
+	queue.Register(models.JobTypeAddBackground, srv.addBackgroundService.Process)
+	queue.Register(models.JobTypeCrop, srv.cropPDFService.Process)
+	queue.Register(models.JobTypeUnlock, srv.unlockService.Process)
+	queue.Register(models.JobTypeProtect, srv.protectPDFService.Process)
+	queue.Register(models.JobTypeAddPageNumbers, srv.addPageNumberService.Process)
+	queue.Register(models.JobTypeInspect, srv.inspect.Process)
+	queue.Register(models.JobTypeHeaderFooter, srv.addHeaderFooterService.Process)
+	queue.Register(models.JobTypeDetectBlank, srv.detectBlankService.Process)
+	queue.Register(models.JobTypeQRCode, srv.qRCodeService.Process)
+	queue.Register(models.JobTypePDFToWord, srv.pdfToWordService.Process)
+	queue.Register(models.JobTypeWordToPDF, srv.wordToPDFService.Process)
+	queue.Register(models.JobTypeExcelToPDF, srv.excelToPDF.Process)
+	queue.Register(models.JobTypePowerPointToPDF, srv.powerPointToPDF.Process)
+	queue.Register(models.JobTypeHTMLToPDF, srv.hTMLToPDF.Process)
+	queue.Register(models.JobTypePipeline, srv.pipeline.Process)
+	queue.Register(models.JobTypeBatch, srv.batch.Process)
+	queue.Register(models.JobTypeReconcile, srv.reconcile.Process)
+
+	return srv
 }
 
 func (s *service) User() UserService {
@@ -165,6 +256,18 @@ func (s *service) Rotate() RotateService {
 	return s.rotateSrvice
 }
 
+func (s *service) Organize() OrganizeService {
+	return s.organizeService
+}
+
+func (s *service) Watermark() WatermarkService {
+	return s.watermarkService
+}
+
+func (s *service) AddBackground() AddBackgroundService {
+	return s.addBackgroundService
+}
+
 func (s *service) AddPageNumber() AddPageNumberService {
 	return s.addPageNumberService
 }
@@ -231,3 +334,55 @@ func (s *service) PowerPointToPDF() PowerPointToPDFService {
 func (s *service) HTMLToPDF() HTMLToPDFService {
 	return s.hTMLToPDF
 }
+
+func (s *service) Queue() QueueService {
+	return s.queueService
+}
+
+func (s *service) Job() JobService {
+	return s.jobService
+}
+
+func (s *service) JobEvents() JobEventService {
+	return s.jobEvents
+}
+
+func (s *service) Webhook() WebhookService {
+	return s.webhooks
+}
+
+func (s *service) Pipeline() PipelineService {
+	return s.pipeline
+}
+
+func (s *service) Batch() BatchService {
+	return s.batch
+}
+
+func (s *service) Idempotency() IdempotencyService {
+	return s.idempotency
+}
+
+func (s *service) Upload() UploadService {
+	return s.uploads
+}
+
+func (s *service) JobCache() JobCacheService {
+	return s.jobCache
+}
+
+func (s *service) Quota() QuotaService {
+	return s.quotas
+}
+
+func (s *service) Retention() RetentionService {
+	return s.retention
+}
+
+func (s *service) Reconcile() ReconcileService {
+	return s.reconcile
+}
+
+func (s *service) Folder() FolderService {
+	return s.folders
+}
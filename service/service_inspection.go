@@ -2,9 +2,8 @@ package service
 
 import (
 	"context"
-	"time"
+	"fmt"
 
-	"github.com/google/uuid"
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 
 	"test/api/models"
@@ -14,67 +13,103 @@ import (
 
 type InspectService interface {
 	Create(ctx context.Context, fileID, userID string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.InspectJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type inspectService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewInspectService(stg storage.IStorage, log logger.ILogger) InspectService {
+func NewInspectService(stg storage.IStorage, log logger.ILogger, queue QueueService) InspectService {
 	return &inspectService{
-		stg: stg,
-		log: log,
+		stg:   stg,
+		log:   log,
+		queue: queue,
 	}
 }
 
 func (s *inspectService) Create(ctx context.Context, fileID, userID string) (string, error) {
 	s.log.Info("InspectService.Create called")
 
-	// 1. Faylni olish
+	// 1. Faylni tekshirish
+	if _, err := s.stg.File().GetByID(ctx, fileID); err != nil {
+		s.log.Error("failed to get input file", logger.Error(err))
+		return "", err
+	}
+
+	// 2. Job yaratish (metadata keyinchalik job.Result ga yoziladi)
+	var owner *string
+	if userID != "" {
+		owner = &userID
+	}
+
+	job, err := newJob(models.JobTypeInspect, owner, []string{fileID}, models.InspectRequest{FileID: fileID})
+	if err != nil {
+		return "", err
+	}
+
+	// 3. Jobni navbatga qo'yish
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("failed to submit inspect job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan inspect jobni bajaradi
+func (s *inspectService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	if len(job.InputFileIDs) == 0 {
+		return fmt.Errorf("inspect job has no input file")
+	}
+
+	result, err := s.inspect(ctx, job.InputFileIDs[0])
+	if err != nil {
+		return err
+	}
+
+	if err := setJobResult(job, result); err != nil {
+		return err
+	}
+
+	s.log.Info("PDF inspect completed", logger.String("jobID", job.ID))
+	return nil
+}
+
+func (s *inspectService) inspect(ctx context.Context, fileID string) (*models.InspectResult, error) {
 	file, err := s.stg.File().GetByID(ctx, fileID)
 	if err != nil {
 		s.log.Error("failed to get input file", logger.Error(err))
-		return "", err
+		return nil, err
 	}
 
-	jobID := uuid.NewString()
-	pdfPath := file.FilePath
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
 
-	// 3. Context ni o‘qish
-	ctxPDF, err := api.ReadContextFile(pdfPath)
+	// Context ni o‘qish
+	ctxPDF, err := api.ReadContextFile(inputPath)
 	if err != nil {
 		s.log.Error("failed to read PDF context", logger.Error(err))
-		return "", err
+		return nil, err
 	}
 
-	// 4. Job ma’lumotlarini yaratish
-	job := &models.InspectJob{
-		ID:        jobID,
-		UserID:    userID,
-		FileID:    fileID,
+	return &models.InspectResult{
 		PageCount: ctxPDF.PageCount,
 		Title:     ctxPDF.Title,
 		Author:    ctxPDF.Author,
 		Subject:   ctxPDF.Subject,
 		Keywords:  ctxPDF.Keywords,
-		Status:    "done",
-		CreatedAt: time.Now().Format(time.RFC3339),
-	}
-
-	// 5. Bazaga yozish
-	if err := s.stg.Inspect().Create(ctx, job); err != nil {
-		s.log.Error("failed to save inspect job", logger.Error(err))
-		return "", err
-	}
-
-	s.log.Info("PDF inspect completed", logger.String("jobID", jobID))
-	return jobID, nil
+	}, nil
 }
 
-func (s *inspectService) GetByID(ctx context.Context, id string) (*models.InspectJob, error) {
-	job, err := s.stg.Inspect().GetByID(ctx, id)
+func (s *inspectService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	job, err := getJobByType(ctx, s.stg, id, models.JobTypeInspect)
 	if err != nil {
 		s.log.Error("failed to get inspect job", logger.Error(err))
 		return nil, err
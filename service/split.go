@@ -2,11 +2,11 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/pdfcpu/pdfcpu/pkg/api"
@@ -19,18 +19,21 @@ import (
 
 type SplitService interface {
 	Create(ctx context.Context, req models.CreateSplitJobRequest, userID *string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.SplitJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type splitService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewSplitService(stg storage.IStorage, log logger.ILogger) SplitService {
+func NewSplitService(stg storage.IStorage, log logger.ILogger, queue QueueService) SplitService {
 	return &splitService{
-		stg: stg,
-		log: log,
+		stg:   stg,
+		log:   log,
+		queue: queue,
 	}
 }
 
@@ -52,103 +55,121 @@ func (s *splitService) Create(ctx context.Context, req models.CreateSplitJobRequ
 	}
 	s.log.Info("✅ Input file found", logger.String("file_path", file.FilePath))
 
-	// 2. SplitJob struct
-	job := &models.SplitJob{
-		ID:            uuid.New().String(),
-		UserID:        userID,
-		InputFileID:   req.InputFileID,
-		SplitRanges:   req.SplitRanges,
-		OutputFileIDs: []string{},
-		Status:        "pending",
-		CreatedAt:     time.Now(),
-	}
-	s.log.Info("✅ Split job created", logger.String("job_id", job.ID))
-
-	// 3. Jobni bazaga yozish
-	if err := s.stg.Split().Create(ctx, job); err != nil {
-		s.log.Error("❌ failed to create split job", logger.Error(err))
+	// 2. Span qiymatini oldindan tekshirish
+	if _, err := parseSplitSpan(req.SplitRanges); err != nil {
+		s.log.Error("❌ invalid split range span", logger.String("value", req.SplitRanges), logger.Error(err))
 		return "", err
 	}
-	s.log.Info("✅ Split job saved to DB")
 
-	// 4. Split qilish
-	inputPath := file.FilePath
+	// 3. Job yaratish va navbatga qo'yish
+	job, err := newJob(models.JobTypeSplit, userID, []string{req.InputFileID}, req)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("❌ failed to submit split job", logger.Error(err))
+		return "", err
+	}
+	s.log.Info("✅ Split job queued", logger.String("job_id", job.ID))
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan split jobni bajaradi
+func (s *splitService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.CreateSplitJobRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid split params: %w", err)
+	}
+
+	outputIDs, err := s.split(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = outputIDs
+	s.log.Info("✅ Split job updated with output files", logger.Int("total_files", len(outputIDs)))
+
+	return nil
+}
+
+func (s *splitService) split(ctx context.Context, job *models.Job, req models.CreateSplitJobRequest) ([]string, error) {
+	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
+	if err != nil {
+		s.log.Error("❌ input file not found", logger.Error(err))
+		return nil, err
+	}
+
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	// 1. Split qilish
 	outputDir := fmt.Sprintf("storage/split/%s", job.ID)
 
 	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
 		s.log.Error("❌ failed to create output dir", logger.Error(err))
-		return "", err
+		return nil, err
 	}
 	s.log.Info("✅ Output directory created", logger.String("output_dir", outputDir))
 
-	// 5. Split PDF into separate files
+	// 2. Split PDF into separate files
 	config := model.NewDefaultConfiguration()
-	span := 1
-	if strings.TrimSpace(req.SplitRanges) != "" {
-		if n, err := fmt.Sscanf(req.SplitRanges, "%d", &span); err != nil || n != 1 || span < 1 {
-			s.log.Error("❌ invalid split range span", logger.String("value", req.SplitRanges), logger.Error(err))
-			return "", fmt.Errorf("invalid split range span: %s", req.SplitRanges)
-		}
+	span, err := parseSplitSpan(req.SplitRanges)
+	if err != nil {
+		return nil, err
 	}
 	s.log.Info("✅ Starting PDF split...", logger.Int("span", span))
 
 	if err := api.SplitFile(inputPath, outputDir, span, config); err != nil {
 		s.log.Error("❌ failed to split PDF", logger.Error(err))
-		return "", err
+		return nil, err
 	}
 	s.log.Info("✅ PDF split completed")
 
-	// 6. Output fayllarni o‘qish va DBga saqlash
+	// 3. Output fayllarni o‘qish va DBga saqlash
 	files, err := os.ReadDir(outputDir)
 	if err != nil {
 		s.log.Error("❌ failed to read output dir", logger.Error(err))
-		return "", err
+		return nil, err
 	}
 
+	outputIDs := []string{}
 	for _, f := range files {
 		if f.IsDir() || !strings.HasSuffix(f.Name(), ".pdf") {
 			continue
 		}
 
 		fullPath := filepath.Join(outputDir, f.Name())
-		fi, err := os.Stat(fullPath)
-		if err != nil {
-			s.log.Error("❌ failed to stat split file", logger.String("path", fullPath), logger.Error(err))
-			continue
-		}
-
-		newFile := models.File{
-			ID:         uuid.New().String(),
-			UserID:     userID,
-			FileName:   f.Name(),
-			FilePath:   fullPath,
-			FileType:   "application/pdf",
-			FileSize:   fi.Size(),
-			UploadedAt: time.Now(),
-		}
-
-		fileID, err := s.stg.File().Save(ctx, newFile)
-		if err != nil {
+		fileID := uuid.New().String()
+		if err := saveOutputFile(ctx, s.stg, fileID, job.UserID, fullPath, "application/pdf"); err != nil {
 			s.log.Error("❌ failed to save split file", logger.String("file", f.Name()), logger.Error(err))
 			continue
 		}
 		s.log.Info("✅ Output file saved", logger.String("file_id", fileID), logger.String("name", f.Name()))
 
-		job.OutputFileIDs = append(job.OutputFileIDs, fileID)
+		outputIDs = append(outputIDs, fileID)
 	}
 
-	// 7. Jobni yangilash
-	if err := s.stg.Split().UpdateOutputFiles(ctx, job.ID, job.OutputFileIDs); err != nil {
-		s.log.Error("❌ failed to update split job", logger.Error(err))
-		return "", err
-	}
-	s.log.Info("✅ Split job updated with output files", logger.Int("total_files", len(job.OutputFileIDs)))
+	return outputIDs, nil
+}
 
-	return job.ID, nil
+// parseSplitSpan - split_ranges dan har bir bo'lakdagi sahifalar sonini oladi
+func parseSplitSpan(splitRanges string) (int, error) {
+	span := 1
+	if strings.TrimSpace(splitRanges) != "" {
+		if n, err := fmt.Sscanf(splitRanges, "%d", &span); err != nil || n != 1 || span < 1 {
+			return 0, fmt.Errorf("invalid split range span: %s", splitRanges)
+		}
+	}
+	return span, nil
 }
 
-func (s *splitService) GetByID(ctx context.Context, id string) (*models.SplitJob, error) {
-	job, err := s.stg.Split().GetByID(ctx, id)
+func (s *splitService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	job, err := getJobByType(ctx, s.stg, id, models.JobTypeSplit)
 	if err != nil {
 		s.log.Error("failed to get split job", logger.Error(err))
 		return nil, err
@@ -2,11 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -17,30 +16,77 @@ import (
 
 type UnlockService interface {
 	Create(ctx context.Context, req models.UnlockPDFRequest, userID *string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.UnlockPDFJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type unlockService struct {
-	stg storage.IStorage
-	log logger.ILogger
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
 }
 
-func NewUnlockService(stg storage.IStorage, log logger.ILogger) UnlockService {
-	return &unlockService{stg: stg, log: log}
+func NewUnlockService(stg storage.IStorage, log logger.ILogger, queue QueueService) UnlockService {
+	return &unlockService{stg: stg, log: log, queue: queue}
 }
 
 func (s *unlockService) Create(ctx context.Context, req models.UnlockPDFRequest, userID *string) (string, error) {
 	s.log.Info("UnlockService.Create called")
 
+	// 1. Kiruvchi faylni tekshirish
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
+		s.log.Error("input file not found", logger.Error(err))
+		return "", fmt.Errorf("input file not found: %w", err)
+	}
+
+	// 2. Job yaratish (parol params ichida saqlanmaydi)
+	job, err := newJob(models.JobTypeUnlock, userID, []string{req.InputFileID}, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// 3. Parol faqat navbatdagi vazifa ichida yuboriladi
+	if err := s.queue.Submit(ctx, job, req); err != nil {
+		s.log.Error("failed to submit unlock job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan unlock jobni bajaradi
+func (s *unlockService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.UnlockPDFRequest
+	if err := json.Unmarshal(task.Payload, &req); err != nil {
+		return fmt.Errorf("invalid unlock payload: %w", err)
+	}
+
+	outputFileID, err := s.unlock(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputFileID}
+
+	s.log.Info("Unlock job completed", logger.String("jobID", job.ID))
+	return nil
+}
+
+func (s *unlockService) unlock(ctx context.Context, job *models.Job, req models.UnlockPDFRequest) (string, error) {
 	// 1. Kiruvchi faylni olish
 	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
 	if err != nil {
 		s.log.Error("input file not found", logger.Error(err))
-		return "", fmt.Errorf("input file not found: %v", err)
+		return "", fmt.Errorf("input file not found: %w", err)
 	}
 
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
 	// 2. Yangi fayl nomi va joylashuv
-	jobID := uuid.NewString()
 	outputFileID := uuid.NewString()
 	outputPath := filepath.Join("storage/unlock_pdf", outputFileID+".pdf")
 
@@ -53,60 +99,25 @@ func (s *unlockService) Create(ctx context.Context, req models.UnlockPDFRequest,
 	args := []string{
 		"decrypt",
 		"-upw", req.Password,
-		file.FilePath,
+		inputPath,
 		outputPath,
 	}
-	cmd := exec.Command("pdfcpu", args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
+	if err := runTool(ctx, outputPath, "pdfcpu", args...); err != nil {
 		s.log.Error("pdfcpu decrypt failed", logger.Error(err))
 		return "", err
 	}
 
-	// 4. Output fayl mavjudligini tekshirish
-	fi, err := os.Stat(outputPath)
-	if err != nil {
-		s.log.Error("cannot stat output file", logger.Error(err))
-		return "", err
-	}
-
-	// 5. Faylni DBga saqlash (avval files jadvaliga)
-	newFile := models.File{
-		ID:         outputFileID,
-		UserID:     userID,
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/pdf",
-		FileSize:   fi.Size(),
-		UploadedAt: time.Now(),
-	}
-	if _, err := s.stg.File().Save(ctx, newFile); err != nil {
+	// 4. Faylni DBga saqlash (files jadvaliga)
+	if err := saveOutputFile(ctx, s.stg, outputFileID, job.UserID, outputPath, "application/pdf"); err != nil {
 		s.log.Error("failed to save output file", logger.Error(err))
 		return "", err
 	}
 
-	// 6. Job obyektini yaratish (keyin unlock_jobs jadvaliga)
-	job := &models.UnlockPDFJob{
-		ID:           jobID,
-		UserID:       userID,
-		InputFileID:  req.InputFileID,
-		OutputFileID: &outputFileID,
-		Status:       "done",
-		CreatedAt:    time.Now(),
-	}
-	if err := s.stg.Unlock().Create(ctx, job); err != nil {
-		s.log.Error("failed to create unlock job", logger.Error(err))
-		return "", err
-	}
-
-	s.log.Info("Unlock job completed", logger.String("jobID", jobID))
-	return jobID, nil
+	return outputFileID, nil
 }
 
-func (s *unlockService) GetByID(ctx context.Context, id string) (*models.UnlockPDFJob, error) {
-	job, err := s.stg.Unlock().GetByID(ctx, id)
+func (s *unlockService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	job, err := getJobByType(ctx, s.stg, id, models.JobTypeUnlock)
 	if err != nil {
 		s.log.Error("unlock job not found", logger.Error(err))
 		return nil, err
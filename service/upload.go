@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"test/api/models"
+	"test/config"
+	"test/pkg/blobstore"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+const (
+	// uploadChunkPrefix - tugallanmagan uploadlarning bo'laklari shu prefiks ostida saqlanadi
+	uploadChunkPrefix = "uploads/partial/"
+	// uploadMaxChunks - juda mayda bo'laklar bilan yig'ish cheksiz uzayib ketmasligi uchun
+	uploadMaxChunks = 10000
+	// uploadFinalizeStale - shuncha vaqtdan beri finalizing holatida qolgan upload qayta yig'iladi
+	uploadFinalizeStale  = 30 * time.Minute
+	uploadCleanupPeriod  = 10 * time.Minute
+	uploadCleanupBatch   = 100
+	uploadFileTypeMaxLen = 20
+)
+
+var (
+	ErrUploadNotFound       = errors.New("upload not found")
+	ErrUploadExpired        = errors.New("upload expired")
+	ErrUploadTooLarge       = errors.New("upload exceeds the size limit")
+	ErrUploadOffsetMismatch = errors.New("upload offset does not match")
+	ErrUploadTooManyChunks  = errors.New("upload has too many chunks")
+	ErrUploadIncomplete     = errors.New("upload is not complete yet")
+	ErrUploadFinalizing     = errors.New("upload is being finalized")
+)
+
+// UploadService – tus uslubidagi davom ettiriladigan yuklash: yaratish, PATCH bilan bo'laklar,
+// HEAD bilan offsetni so'rash va oxirgi bo'lakdan keyin bitta files yozuviga yig'ish
+type UploadService interface {
+	Create(ctx context.Context, req models.CreateUploadRequest) (*models.Upload, error)
+	Get(ctx context.Context, id string) (*models.Upload, error)
+	WriteChunk(ctx context.Context, upload *models.Upload, offset int64, body io.Reader, size int64) (*models.Upload, error)
+	Finalize(ctx context.Context, upload *models.Upload) (*models.Upload, error)
+	Terminate(ctx context.Context, upload *models.Upload) error
+	MaxSize(userID *string) int64
+	RunCleanup(ctx context.Context)
+}
+
+type uploadService struct {
+	stg   storage.IStorage
+	log   logger.ILogger
+	cfg   config.Config
+	files FileService
+}
+
+func NewUploadService(stg storage.IStorage, log logger.ILogger, cfg config.Config, files FileService) UploadService {
+	return &uploadService{
+		stg:   stg,
+		log:   log,
+		cfg:   cfg,
+		files: files,
+	}
+}
+
+// MaxSize - tarif bo'yicha chegara: mehmon yoki ro'yxatdan o'tgan foydalanuvchi
+func (s *uploadService) MaxSize(userID *string) int64 {
+	if userID == nil {
+		return s.cfg.UploadGuestMaxSize
+	}
+	return s.cfg.UploadUserMaxSize
+}
+
+func (s *uploadService) Create(ctx context.Context, req models.CreateUploadRequest) (*models.Upload, error) {
+	if req.Length > s.MaxSize(req.UserID) {
+		return nil, fmt.Errorf("%w: %d bytes allowed", ErrUploadTooLarge, s.MaxSize(req.UserID))
+	}
+	if err := checkQuota(ctx, s.stg, req.UserID, req.Length); err != nil {
+		return nil, err
+	}
+
+	fileType := filepath.Ext(req.FileName)
+	if len(fileType) > uploadFileTypeMaxLen {
+		fileType = ""
+	}
+
+	now := time.Now()
+	upload := &models.Upload{
+		ID:        uuid.NewString(),
+		UserID:    req.UserID,
+		FileName:  req.FileName,
+		FileType:  strings.ToLower(fileType),
+		Length:    req.Length,
+		Status:    models.UploadUploading,
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: now.Add(s.cfg.UploadExpiry),
+	}
+
+	if err := s.stg.Upload().Create(ctx, upload); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+func (s *uploadService) Get(ctx context.Context, id string) (*models.Upload, error) {
+	upload, err := s.stg.Upload().GetByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if upload.Status != models.UploadCompleted && time.Now().After(upload.ExpiresAt) {
+		return nil, ErrUploadExpired
+	}
+	return upload, nil
+}
+
+// WriteChunk - offset dan boshlab size baytni alohida blob sifatida saqlaydi. Bo'lak faqat
+// yozib bo'lingandan keyin hisobga olinadi, shuning uchun uzilgan PATCH dan keyin mijoz
+// HEAD bilan offsetni so'rab, shu joydan davom ettiradi. Oxirgi bo'lakdan keyin fayl yig'iladi.
+func (s *uploadService) WriteChunk(ctx context.Context, upload *models.Upload, offset int64, body io.Reader, size int64) (*models.Upload, error) {
+	if upload.Status != models.UploadUploading || offset != upload.Offset {
+		return nil, fmt.Errorf("%w: expected %d", ErrUploadOffsetMismatch, upload.Offset)
+	}
+	if offset+size > upload.Length {
+		return nil, fmt.Errorf("%w: chunk ends at %d, upload length is %d", ErrUploadTooLarge, offset+size, upload.Length)
+	}
+	if size == 0 {
+		return upload, nil
+	}
+	if len(upload.ChunkKeys) >= uploadMaxChunks {
+		return nil, ErrUploadTooManyChunks
+	}
+
+	// Bir xil offsetga parallel kelgan PATCH lar bir-birining bo'lagini ustidan yozmasligi uchun kalit noyob
+	key := fmt.Sprintf("%s%s-%020d-%s", uploadChunkPrefix, upload.ID, offset, uuid.NewString())
+	if err := s.stg.Blob().Put(ctx, key, body, size, "application/offset+octet-stream"); err != nil {
+		s.log.Error("failed to store upload chunk", logger.String("uploadID", upload.ID), logger.Error(err))
+		return nil, err
+	}
+
+	ok, err := s.stg.Upload().AppendChunk(ctx, upload.ID, offset, size, key, time.Now().Add(s.cfg.UploadExpiry))
+	if err != nil || !ok {
+		s.deleteChunks([]string{key})
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrUploadOffsetMismatch
+	}
+
+	updated, err := s.Get(ctx, upload.ID)
+	if err != nil {
+		return nil, err
+	}
+	if updated.Offset < updated.Length {
+		return updated, nil
+	}
+
+	// Yig'ish xatosi bo'lakni bekor qilmaydi: upload to'liq qoladi, mijoz finalize ni qayta chaqirishi mumkin
+	finalized, err := s.Finalize(ctx, updated)
+	if err != nil {
+		s.log.Error("failed to finalize upload", logger.String("uploadID", upload.ID), logger.Error(err))
+		return updated, nil
+	}
+	return finalized, nil
+}
+
+// Finalize - bo'laklarni tartib bilan bitta faylga yig'adi va files yozuvini yaratadi.
+// Allaqachon yig'ilgan uploadda qayta chaqirish xavfsiz.
+func (s *uploadService) Finalize(ctx context.Context, upload *models.Upload) (*models.Upload, error) {
+	if upload.Status == models.UploadCompleted {
+		return upload, nil
+	}
+	if upload.Offset < upload.Length {
+		return nil, fmt.Errorf("%w: %d of %d bytes received", ErrUploadIncomplete, upload.Offset, upload.Length)
+	}
+
+	claimed, err := s.stg.Upload().ClaimFinalize(ctx, upload.ID, time.Now().Add(-uploadFinalizeStale))
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.Get(ctx, upload.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		if current.Status == models.UploadCompleted {
+			return current, nil
+		}
+		return nil, ErrUploadFinalizing
+	}
+
+	content := blobstore.Concat(ctx, s.stg.Blob(), current.ChunkKeys)
+	file, err := s.files.Upload(ctx, models.File{
+		UserID:   current.UserID,
+		FileName: current.FileName,
+		FileType: current.FileType,
+		FileSize: current.Length,
+	}, content)
+	content.Close()
+	if err != nil {
+		_ = s.stg.Upload().ReleaseFinalize(context.Background(), current.ID)
+		// Mazmuni rad etilgan fayl qayta yig'ilganda ham o'tmaydi - bo'laklar darhol o'chiriladi
+		if isRejectedContent(err) {
+			_ = s.Terminate(context.Background(), current)
+		}
+		return nil, err
+	}
+
+	if err := s.stg.Upload().Complete(ctx, current.ID, file.ID, time.Now().Add(s.cfg.UploadExpiry)); err != nil {
+		_ = s.stg.Upload().ReleaseFinalize(context.Background(), current.ID)
+		return nil, err
+	}
+	s.deleteChunks(current.ChunkKeys)
+
+	s.log.Info("upload finalized", logger.String("uploadID", current.ID), logger.String("fileID", file.ID))
+	return s.Get(ctx, current.ID)
+}
+
+// Terminate - uploadni bekor qiladi va yuklangan bo'laklarni o'chiradi (yig'ilgan fayl o'chirilmaydi)
+func (s *uploadService) Terminate(ctx context.Context, upload *models.Upload) error {
+	deleted, err := s.stg.Upload().Delete(ctx, upload.ID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrUploadFinalizing
+	}
+	s.deleteChunks(upload.ChunkKeys)
+	return nil
+}
+
+// RunCleanup - muddati o'tgan uploadlarni va ularning bo'laklarini davriy ravishda o'chiradi
+func (s *uploadService) RunCleanup(ctx context.Context) {
+	ticker := time.NewTicker(uploadCleanupPeriod)
+	defer ticker.Stop()
+
+	for {
+		s.cleanupExpired(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *uploadService) cleanupExpired(ctx context.Context) {
+	uploads, err := s.stg.Upload().GetExpired(ctx, time.Now(), uploadCleanupBatch)
+	if err != nil {
+		s.log.Error("failed to get expired uploads", logger.Error(err))
+		return
+	}
+
+	for _, upload := range uploads {
+		if deleted, err := s.stg.Upload().Delete(ctx, upload.ID); err != nil || !deleted {
+			continue
+		}
+		s.deleteChunks(upload.ChunkKeys)
+	}
+
+	if len(uploads) > 0 {
+		s.log.Info("expired uploads cleaned up", logger.Int("count", len(uploads)))
+	}
+}
+
+// deleteChunks - bo'laklarni o'chiradi; bittasida xato bo'lsa log qilinadi va qolganlari o'chirilaveradi
+func (s *uploadService) deleteChunks(keys []string) {
+	for _, key := range keys {
+		if err := deleteBlob(s.stg, key); err != nil {
+			s.log.Error("failed to delete upload chunk", logger.String("key", key), logger.Error(err))
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"test/api/models"
+	"test/config"
+)
+
+func newTestUploads(stg *fakeStorage) *uploadService {
+	cfg := config.Config{UploadGuestMaxSize: 10, UploadUserMaxSize: 100, UploadExpiry: time.Hour}
+	return NewUploadService(stg, nopLogger{}, cfg, nil).(*uploadService)
+}
+
+func TestUploadSizeLimit(t *testing.T) {
+	s := newTestUploads(newFakeStorage())
+	ctx := context.Background()
+
+	// Chegaraga teng hajm ruxsat etiladi, bir bayt ortig'i - yo'q
+	if _, err := s.Create(ctx, models.CreateUploadRequest{FileName: "a.pdf", Length: 10}); err != nil {
+		t.Errorf("upload at the limit: %v", err)
+	}
+	if _, err := s.Create(ctx, models.CreateUploadRequest{FileName: "a.pdf", Length: 11}); !errors.Is(err, ErrUploadTooLarge) {
+		t.Errorf("upload over the limit: err = %v, want ErrUploadTooLarge", err)
+	}
+
+	uid := "u1"
+	if got := s.MaxSize(&uid); got != 100 {
+		t.Errorf("user limit = %d, want 100", got)
+	}
+	if got := s.MaxSize(nil); got != 10 {
+		t.Errorf("guest limit = %d, want 10", got)
+	}
+}
+
+func TestWriteChunk(t *testing.T) {
+	stg := newFakeStorage()
+	s := newTestUploads(stg)
+	ctx := context.Background()
+
+	upload, err := s.Create(ctx, models.CreateUploadRequest{FileName: "a.pdf", Length: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := *upload
+
+	upload, err = s.WriteChunk(ctx, upload, 0, strings.NewReader("%PDF"), 4)
+	if err != nil {
+		t.Fatalf("first chunk: %v", err)
+	}
+	if upload.Offset != 4 || len(upload.ChunkKeys) != 1 {
+		t.Fatalf("after first chunk: offset %d, chunks %d", upload.Offset, len(upload.ChunkKeys))
+	}
+
+	tests := []struct {
+		name   string
+		upload *models.Upload
+		offset int64
+		body   string
+		want   error
+	}{
+		// Mijoz offsetni noto'g'ri yuborgan (HEAD bilan so'rab, shu joydan davom etishi kerak)
+		{"offset behind", upload, 0, "abcd", ErrUploadOffsetMismatch},
+		{"offset ahead", upload, 6, "ab", ErrUploadOffsetMismatch},
+		// Bo'lak e'lon qilingan uzunlikdan oshib ketadi
+		{"past length", upload, 4, "abcdefg", ErrUploadTooLarge},
+		// Parallel PATCH: eski holat bo'yicha offset mos, lekin bazada boshqa bo'lak allaqachon yozilgan
+		{"lost race", &stale, 0, "abcd", ErrUploadOffsetMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.WriteChunk(ctx, tt.upload, tt.offset, strings.NewReader(tt.body), int64(len(tt.body)))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+
+			// Rad etilgan bo'lak saqlanib qolmaydi va upload holati o'zgarmaydi
+			if keys := stg.blobs.keys(); len(keys) != 1 || keys[0] != upload.ChunkKeys[0] {
+				t.Errorf("stored chunks = %v, want only %s", keys, upload.ChunkKeys[0])
+			}
+			if current, _ := s.Get(ctx, upload.ID); current.Offset != 4 {
+				t.Errorf("offset = %d, want 4", current.Offset)
+			}
+		})
+	}
+}
+
+func TestWriteChunkExpired(t *testing.T) {
+	stg := newFakeStorage()
+	s := newTestUploads(stg)
+	ctx := context.Background()
+
+	upload, err := s.Create(ctx, models.CreateUploadRequest{FileName: "a.pdf", Length: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stg.uploads.uploads[upload.ID].ExpiresAt = time.Now().Add(-time.Minute)
+
+	if _, err := s.Get(ctx, upload.ID); !errors.Is(err, ErrUploadExpired) {
+		t.Errorf("Get: err = %v, want ErrUploadExpired", err)
+	}
+	if _, err := s.WriteChunk(ctx, upload, 0, strings.NewReader("%PDF"), 4); !errors.Is(err, ErrUploadOffsetMismatch) {
+		t.Errorf("WriteChunk: err = %v, want ErrUploadOffsetMismatch", err)
+	}
+	if keys := stg.blobs.keys(); len(keys) != 0 {
+		t.Errorf("chunk of an expired upload was kept: %v", keys)
+	}
+}
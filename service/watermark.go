@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+
+	"test/api/models"
+	"test/pkg/logger"
+	"test/pkg/watermark"
+	"test/storage"
+)
+
+const (
+	watermarkTypeText  = "text"
+	watermarkTypeImage = "image"
+
+	watermarkModeStamp     = "stamp"
+	watermarkModeWatermark = "watermark"
+
+	watermarkDefaultOpacity   = 0.5
+	watermarkDefaultScale     = 0.5
+	watermarkDefaultTileScale = 0.25
+	watermarkDefaultFont      = "Helvetica"
+	watermarkDefaultColor     = "#808080"
+)
+
+// ErrInvalidWatermark - suv belgisi parametrlari noto'g'ri (mijoz xatosi)
+var ErrInvalidWatermark = errors.New("invalid watermark request")
+
+var hexColorRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// WatermarkService – PDF ga matnli yoki rasmli suv belgisi qo'shadi. mode=stamp - kontent ustida,
+// mode=watermark - kontent ortida (to'liq rasmli sahifalarda ko'rinmasligi mumkin).
+type WatermarkService interface {
+	Create(ctx context.Context, req models.AddWatermarkRequest, userID *string) (string, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
+}
+
+type watermarkService struct {
+	stg   storage.IStorage
+	log   logger.ILogger
+	queue QueueService
+}
+
+func NewWatermarkService(stg storage.IStorage, log logger.ILogger, queue QueueService) WatermarkService {
+	return &watermarkService{
+		stg:   stg,
+		log:   log,
+		queue: queue,
+	}
+}
+
+func (s *watermarkService) Create(ctx context.Context, req models.AddWatermarkRequest, userID *string) (string, error) {
+	s.log.Info("WatermarkService.Create called", logger.String("type", req.Type))
+
+	// 1. Parametrlarni va fayllarni tekshirish
+	if _, err := watermarkParams(req); err != nil {
+		return "", err
+	}
+
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
+		s.log.Error("input file not found", logger.Error(err))
+		return "", fmt.Errorf("input file not found: %w", err)
+	}
+
+	// Rasm ham kiruvchi fayl: egasi va turi Submit da tekshiriladi, retention uni o'chirmaydi
+	inputIDs := []string{req.InputFileID}
+	if req.Type == watermarkTypeImage {
+		if _, err := imageAsset(ctx, s.stg, userID, req.ImageFileID, ErrInvalidWatermark); err != nil {
+			return "", err
+		}
+		inputIDs = append(inputIDs, req.ImageFileID)
+	}
+
+	// 2. Job yaratish va navbatga qo'yish
+	job, err := newJob(models.JobTypeWatermark, userID, inputIDs, req)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("failed to submit watermark job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan watermark jobni bajaradi
+func (s *watermarkService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.AddWatermarkRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid watermark params: %w", err)
+	}
+
+	outputFileID, err := s.addWatermark(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputFileID}
+
+	s.log.Info("watermark job completed", logger.String("jobID", job.ID))
+	return nil
+}
+
+func (s *watermarkService) addWatermark(ctx context.Context, job *models.Job, req models.AddWatermarkRequest) (string, error) {
+	// Pipeline/batch qadamlari Create dan o'tmaydi, shuning uchun parametrlar shu yerda ham tekshiriladi
+	params, err := watermarkParams(req)
+	if err != nil {
+		return "", &JobError{Code: ErrCodeInvalidInput, Err: err}
+	}
+
+	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
+	if err != nil {
+		return "", fmt.Errorf("input file not found: %w", err)
+	}
+
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+	params.InputPath = inputPath
+
+	if req.Type == watermarkTypeImage {
+		image, err := imageAsset(ctx, s.stg, job.UserID, req.ImageFileID, ErrInvalidWatermark)
+		if err != nil {
+			if errors.Is(err, ErrInvalidWatermark) || errors.Is(err, ErrUnsupportedFileType) || errors.Is(err, ErrFileQuarantined) || errors.Is(err, ErrFileAccessDenied) {
+				return "", &JobError{Code: ErrCodeInvalidInput, Err: err}
+			}
+			return "", err
+		}
+
+		imagePath, cleanupImage, err := fetchInput(ctx, s.stg, image)
+		if err != nil {
+			return "", err
+		}
+		defer cleanupImage()
+		params.ImagePath = imagePath
+	}
+
+	outputFileID := uuid.NewString()
+	params.OutputPath = filepath.Join("storage/watermark", outputFileID+".pdf")
+
+	if err := os.MkdirAll(filepath.Dir(params.OutputPath), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	if err := watermark.AddWatermark(params); err != nil {
+		s.log.Error("failed to add watermark", logger.Error(err))
+		return "", err
+	}
+
+	if err := saveOutputFile(ctx, s.stg, outputFileID, job.UserID, params.OutputPath, "application/pdf"); err != nil {
+		return "", err
+	}
+
+	return outputFileID, nil
+}
+
+func (s *watermarkService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	return getJobByType(ctx, s.stg, id, models.JobTypeWatermark)
+}
+
+// watermarkParams - so'rovni tekshiradi va standart qiymatlar bilan pkg/watermark parametrlariga aylantiradi
+func watermarkParams(req models.AddWatermarkRequest) (watermark.Params, error) {
+	params := watermark.Params{
+		FontName:  watermarkDefaultFont,
+		FontSize:  req.FontSize,
+		Color:     watermarkDefaultColor,
+		Opacity:   watermarkDefaultOpacity,
+		Rotation:  req.Rotation,
+		Diagonal:  req.Diagonal,
+		Tile:      req.Tile,
+		Position:  "center",
+		Scale:     watermarkDefaultScale,
+		PageRange: strings.TrimSpace(req.PageRange),
+		OnTop:     true,
+	}
+
+	switch req.Type {
+	case watermarkTypeText:
+		params.Text = strings.TrimSpace(req.Text)
+		if params.Text == "" {
+			return params, fmt.Errorf("%w: text is required for text watermark", ErrInvalidWatermark)
+		}
+	case watermarkTypeImage:
+		if req.ImageFileID == "" {
+			return params, fmt.Errorf("%w: image_file_id is required for image watermark", ErrInvalidWatermark)
+		}
+	default:
+		return params, fmt.Errorf("%w: type must be %q or %q", ErrInvalidWatermark, watermarkTypeText, watermarkTypeImage)
+	}
+
+	switch req.Mode {
+	case "", watermarkModeStamp:
+	case watermarkModeWatermark:
+		params.OnTop = false
+	default:
+		return params, fmt.Errorf("%w: mode must be %q or %q", ErrInvalidWatermark, watermarkModeStamp, watermarkModeWatermark)
+	}
+
+	if req.Position != "" {
+		if _, ok := watermark.Positions[req.Position]; !ok {
+			return params, fmt.Errorf("%w: unknown position %q", ErrInvalidWatermark, req.Position)
+		}
+		params.Position = req.Position
+	}
+
+	if req.FontName != "" {
+		if !font.SupportedFont(req.FontName) {
+			return params, fmt.Errorf("%w: unsupported font %q (supported: %s)",
+				ErrInvalidWatermark, req.FontName, strings.Join(font.CoreFontNames(), ", "))
+		}
+		params.FontName = req.FontName
+	}
+
+	if req.Color != "" {
+		if !hexColorRe.MatchString(req.Color) {
+			return params, fmt.Errorf("%w: color must be #RRGGBB", ErrInvalidWatermark)
+		}
+		params.Color = req.Color
+	}
+
+	if req.Opacity != nil {
+		params.Opacity = *req.Opacity
+	}
+	if params.Tile {
+		params.Scale = watermarkDefaultTileScale
+	}
+	if req.Scale > 0 {
+		params.Scale = req.Scale
+	}
+
+	if params.PageRange != "" {
+		if _, err := api.ParsePageSelection(params.PageRange); err != nil {
+			return params, fmt.Errorf("%w: invalid page_range %q", ErrInvalidWatermark, params.PageRange)
+		}
+	}
+
+	return params, nil
+}
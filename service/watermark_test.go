@@ -0,0 +1,89 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"test/api/models"
+	"test/pkg/watermark"
+)
+
+func TestWatermarkParams(t *testing.T) {
+	opacity := 0.8
+	defaults := watermark.Params{
+		FontName: watermarkDefaultFont,
+		Color:    watermarkDefaultColor,
+		Opacity:  watermarkDefaultOpacity,
+		Position: "center",
+		Scale:    watermarkDefaultScale,
+		OnTop:    true,
+	}
+
+	tests := []struct {
+		name    string
+		req     models.AddWatermarkRequest
+		want    func(p *watermark.Params) // defaults dan farqi
+		wantErr bool
+	}{
+		{
+			name: "text defaults",
+			req:  models.AddWatermarkRequest{Type: watermarkTypeText, Text: "  DRAFT "},
+			want: func(p *watermark.Params) { p.Text = "DRAFT" },
+		},
+		{
+			name: "image",
+			req:  models.AddWatermarkRequest{Type: watermarkTypeImage, ImageFileID: "img"},
+			want: func(p *watermark.Params) {},
+		},
+		{
+			name: "all options",
+			req: models.AddWatermarkRequest{
+				Type: watermarkTypeText, Text: "X", Mode: watermarkModeWatermark, Opacity: &opacity,
+				Rotation: 30, Position: "top-left", Scale: 0.3, FontName: "Courier", FontSize: 20,
+				Color: "#FF0000", PageRange: " 1-3,5 ",
+			},
+			want: func(p *watermark.Params) {
+				p.Text, p.OnTop, p.Opacity, p.Rotation, p.Position = "X", false, 0.8, 30, "top-left"
+				p.Scale, p.FontName, p.FontSize, p.Color, p.PageRange = 0.3, "Courier", 20, "#FF0000", "1-3,5"
+			},
+		},
+		{
+			name: "tile uses smaller scale",
+			req:  models.AddWatermarkRequest{Type: watermarkTypeText, Text: "X", Tile: true},
+			want: func(p *watermark.Params) { p.Text, p.Tile, p.Scale = "X", true, watermarkDefaultTileScale },
+		},
+		{
+			name: "tile with explicit scale",
+			req:  models.AddWatermarkRequest{Type: watermarkTypeText, Text: "X", Tile: true, Scale: 0.4},
+			want: func(p *watermark.Params) { p.Text, p.Tile, p.Scale = "X", true, 0.4 },
+		},
+		{name: "unknown type", req: models.AddWatermarkRequest{Type: "video"}, wantErr: true},
+		{name: "blank text", req: models.AddWatermarkRequest{Type: watermarkTypeText, Text: "  "}, wantErr: true},
+		{name: "image without id", req: models.AddWatermarkRequest{Type: watermarkTypeImage}, wantErr: true},
+		{name: "unknown mode", req: models.AddWatermarkRequest{Type: watermarkTypeText, Text: "X", Mode: "under"}, wantErr: true},
+		{name: "unknown position", req: models.AddWatermarkRequest{Type: watermarkTypeText, Text: "X", Position: "middle"}, wantErr: true},
+		{name: "unsupported font", req: models.AddWatermarkRequest{Type: watermarkTypeText, Text: "X", FontName: "Comic Sans"}, wantErr: true},
+		{name: "short color", req: models.AddWatermarkRequest{Type: watermarkTypeText, Text: "X", Color: "#FFF"}, wantErr: true},
+		{name: "named color", req: models.AddWatermarkRequest{Type: watermarkTypeText, Text: "X", Color: "red"}, wantErr: true},
+		{name: "invalid page range", req: models.AddWatermarkRequest{Type: watermarkTypeText, Text: "X", PageRange: "a-b"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := watermarkParams(tt.req)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidWatermark) {
+					t.Fatalf("err = %v, want ErrInvalidWatermark", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := defaults
+			tt.want(&want)
+			if got != want {
+				t.Errorf("watermarkParams = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,406 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+
+	"test/api/models"
+	"test/config"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+// Webhook so'rov sarlavhalari
+const (
+	WebhookSignatureHeader = "X-PDFNinja-Signature" // "sha256=<hex>", faqat secret berilganda
+	WebhookEventHeader     = "X-PDFNinja-Event"     // masalan: job.done, job.failed
+	WebhookDeliveryHeader  = "X-PDFNinja-Delivery"  // yuborish IDsi (job_webhook_deliveries.id)
+
+	webhookDelayedKey = "pdfninja:webhooks:delayed" // score = urinish vaqti
+	maxWebhookBackoff = 5 * time.Minute
+)
+
+// ErrInvalidCallbackURL - callback_url ga yuborib bo'lmaydi (ichki tarmoq manzili, resolve bo'lmaydigan host va h.k.)
+var ErrInvalidCallbackURL = errors.New("invalid callback url")
+
+// errWebhookDestination - ulanish paytida host ichki manzilga resolve bo'ldi (DNS rebinding)
+var errWebhookDestination = errors.New("webhook destination not allowed")
+
+// carrierGradeNAT - 100.64.0.0/10, net.IP.IsPrivate ga kirmaydi, lekin tashqaridan yetib bo'lmaydi
+var carrierGradeNAT = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// blockedWebhookIP - loopback, xususiy, link-local (shu jumladan 169.254.169.254 metadata) va maxsus manzillar
+func blockedWebhookIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified() ||
+		carrierGradeNAT.Contains(ip) ||
+		(ip.To4() != nil && ip.To4()[0] == 0)
+}
+
+// checkCallbackURL - callback_url faqat http/https bo'lishi va host faqat ochiq internet manzillariga
+// resolve bo'lishi kerak; aks holda server ichki xizmatlarga so'rov yuborib qo'yadi (SSRF)
+func checkCallbackURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("%w: must be an absolute http(s) url", ErrInvalidCallbackURL)
+	}
+
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if blockedWebhookIP(ip) {
+			return fmt.Errorf("%w: %s is not a public address", ErrInvalidCallbackURL, host)
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("%w: cannot resolve host %q", ErrInvalidCallbackURL, host)
+	}
+	for _, addr := range addrs {
+		if blockedWebhookIP(addr.IP) {
+			return fmt.Errorf("%w: %s resolves to a non-public address", ErrInvalidCallbackURL, host)
+		}
+	}
+	return nil
+}
+
+// newWebhookClient - ulanish paytida haqiqiy IP ni tekshiradi (Submit dagi tekshiruvdan keyin DNS
+// o'zgarsa ham ichki manzilga ulanmaydi) va redirectlarga ergashmaydi
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || blockedWebhookIP(ip) {
+				return errWebhookDestination
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:       timeout,
+		Transport:     transport,
+		CheckRedirect: refuseRedirect,
+	}
+}
+
+// refuseRedirect - 3xx javob o'zi qaytadi va muvaffaqiyatsiz yuborish sifatida logga yoziladi
+func refuseRedirect(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+}
+
+// webhookErrorMessage - logga (API orqali ko'rinadi) ichki tarmoq haqida ma'lumot bermaydigan umumiy xato
+func webhookErrorMessage(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, errWebhookDestination):
+		return "destination not allowed"
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "request timed out"
+	default:
+		return "connection failed"
+	}
+}
+
+type callbackKey struct{}
+
+// WithCallback - handler tomonidan berilgan webhook sozlamalarini ctx orqali QueueService.Submit ga uzatadi
+func WithCallback(ctx context.Context, cb models.JobCallback) context.Context {
+	if cb.CallbackURL == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, callbackKey{}, cb)
+}
+
+func callbackFromContext(ctx context.Context) (models.JobCallback, bool) {
+	cb, ok := ctx.Value(callbackKey{}).(models.JobCallback)
+	return cb, ok
+}
+
+// SignWebhookPayload - payload uchun HMAC-SHA256 imzo ("sha256=<hex>"); qabul qiluvchi shu qiymatni solishtiradi
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookService – job done/failed bo'lganda callback_url ga imzolangan POST yuboradi.
+// Har bir urinish Redis dagi kechiktirilgan navbat orqali rejalashtiriladi, shuning uchun server
+// qayta ishga tushsa ham qayta urinishlar yo'qolmaydi.
+type WebhookService interface {
+	Notify(job *models.Job)
+	GetDeliveries(ctx context.Context, jobID string) ([]models.WebhookDelivery, error)
+	RunDeliveries(ctx context.Context)
+}
+
+// webhookTask - kechiktirilgan navbatdagi bitta yuborish urinishi. Payload va secret navbatga
+// yozilmaydi: yuborish paytida jobning bazadagi holatidan tuziladi.
+type webhookTask struct {
+	JobID   string `json:"job_id"`
+	Attempt int    `json:"attempt"`
+}
+
+type webhookService struct {
+	stg    storage.IStorage
+	redis  storage.IRedisStorage
+	log    logger.ILogger
+	cfg    config.Config
+	client *http.Client
+}
+
+func NewWebhookService(stg storage.IStorage, redis storage.IRedisStorage, log logger.ILogger, cfg config.Config, client *http.Client) WebhookService {
+	if client == nil {
+		client = newWebhookClient(cfg.WebhookTimeout)
+	} else {
+		c := *client
+		c.CheckRedirect = refuseRedirect
+		client = &c
+	}
+	return &webhookService{
+		stg:    stg,
+		redis:  redis,
+		log:    log,
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+// Notify - callback_url bo'lsa, birinchi urinishni navbatga qo'yadi (job bajarilishini kutib turmaydi)
+func (s *webhookService) Notify(job *models.Job) {
+	if job.CallbackURL == nil || *job.CallbackURL == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := s.schedule(ctx, webhookTask{JobID: job.ID, Attempt: 1}, time.Now()); err != nil {
+		s.log.Error("failed to schedule webhook", logger.String("jobID", job.ID), logger.Error(err))
+	}
+}
+
+func (s *webhookService) GetDeliveries(ctx context.Context, jobID string) ([]models.WebhookDelivery, error) {
+	deliveries, err := s.stg.WebhookDelivery().GetByJobID(ctx, jobID)
+	if err != nil {
+		s.log.Error("failed to get webhook deliveries", logger.String("jobID", jobID), logger.Error(err))
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// RunDeliveries - vaqti kelgan urinishlarni navbatdan olib yuboradi, ctx tugaguncha bloklanadi
+func (s *webhookService) RunDeliveries(ctx context.Context) {
+	ticker := time.NewTicker(delayedPollPeriod)
+	defer ticker.Stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		due, err := s.redis.PopDue(ctx, webhookDelayedKey, time.Now(), delayedBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.log.Error("failed to read due webhooks", logger.Error(err))
+			}
+			continue
+		}
+
+		for _, raw := range due {
+			var task webhookTask
+			if err := json.Unmarshal([]byte(raw), &task); err != nil {
+				s.log.Error("invalid webhook task", logger.String("raw", raw), logger.Error(err))
+				continue
+			}
+
+			// Sekin javob beruvchi manzil boshqa yuborishlarni ushlab turmasin
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.deliver(ctx, task)
+			}()
+		}
+	}
+}
+
+// schedule - urinishni "at" vaqtida bajarish uchun kechiktirilgan navbatga qo'yadi
+func (s *webhookService) schedule(ctx context.Context, task webhookTask, at time.Time) (string, error) {
+	raw, err := json.Marshal(task)
+	if err != nil {
+		return "", err
+	}
+	if err := s.redis.AddDelayed(ctx, webhookDelayedKey, string(raw), at); err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+// retryBackoff - n-urinishdan keyingi kutish: base, 2*base, 4*base, ... (maxWebhookBackoff gacha)
+func (s *webhookService) retryBackoff(attempt int) time.Duration {
+	backoff := s.cfg.WebhookRetryBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	for i := 1; i < attempt && backoff < maxWebhookBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxWebhookBackoff)
+}
+
+// deliver - bitta urinish. Keyingi urinish yuborishdan oldin rejalashtiriladi va muvaffaqiyatli
+// bo'lsa bekor qilinadi, shuning uchun yuborish paytida server o'chsa ham webhook yo'qolmaydi.
+func (s *webhookService) deliver(ctx context.Context, task webhookTask) {
+	job, err := s.stg.Job().GetByID(ctx, task.JobID)
+	if err != nil {
+		s.log.Error("webhook job not found", logger.String("jobID", task.JobID), logger.Error(err))
+		return
+	}
+	// Job o'chirilgan callback bilan yoki qo'lda retry qilinib qayta navbatda bo'lsa, yuborilmaydi
+	if job.CallbackURL == nil || *job.CallbackURL == "" {
+		return
+	}
+	switch job.Status {
+	case models.Done, models.Failed, models.Dead:
+	default:
+		return
+	}
+
+	body, err := json.Marshal(webhookPayload(job))
+	if err != nil {
+		s.log.Error("failed to marshal webhook payload", logger.String("jobID", job.ID), logger.Error(err))
+		return
+	}
+
+	var secret string
+	if job.CallbackSecret != nil {
+		secret = *job.CallbackSecret
+	}
+
+	attempts := max(s.cfg.WebhookMaxAttempts, 1)
+
+	var next string
+	if task.Attempt < attempts {
+		retry := webhookTask{JobID: job.ID, Attempt: task.Attempt + 1}
+		if next, err = s.schedule(ctx, retry, time.Now().Add(s.retryBackoff(task.Attempt))); err != nil {
+			s.log.Error("failed to schedule webhook retry", logger.String("jobID", job.ID), logger.Error(err))
+		}
+	}
+
+	delivery := s.send(ctx, job.ID, *job.CallbackURL, secret, "job."+string(job.Status), body, task.Attempt)
+
+	logCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.stg.WebhookDelivery().Create(logCtx, delivery); err != nil {
+		s.log.Error("failed to log webhook delivery", logger.String("jobID", job.ID), logger.Error(err))
+	}
+
+	if delivery.Success {
+		if next != "" {
+			if err := s.redis.RemoveDelayed(logCtx, webhookDelayedKey, next); err != nil {
+				s.log.Error("failed to cancel webhook retry", logger.String("jobID", job.ID), logger.Error(err))
+			}
+		}
+		s.log.Info("webhook delivered", logger.String("jobID", job.ID), logger.Int("attempt", task.Attempt))
+		return
+	}
+
+	if task.Attempt >= attempts {
+		s.log.Error("webhook delivery failed, giving up", logger.String("jobID", job.ID), logger.Int("attempts", attempts))
+	}
+}
+
+func webhookPayload(job *models.Job) models.WebhookPayload {
+	payload := models.WebhookPayload{
+		JobID:         job.ID,
+		Type:          job.Type,
+		Status:        job.Status,
+		OutputFileIDs: job.OutputFileIDs,
+		ErrorCode:     job.ErrorCode,
+		Error:         job.Error,
+		FinishedAt:    job.FinishedAt,
+	}
+	if payload.OutputFileIDs == nil {
+		payload.OutputFileIDs = []string{}
+	}
+	return payload
+}
+
+func (s *webhookService) send(ctx context.Context, jobID, callbackURL, secret, event string, body []byte, attempt int) *models.WebhookDelivery {
+	delivery := &models.WebhookDelivery{
+		ID:        uuid.NewString(),
+		JobID:     jobID,
+		URL:       callbackURL,
+		Attempt:   attempt,
+		CreatedAt: time.Now(),
+	}
+	fail := func(msg string) *models.WebhookDelivery {
+		delivery.Error = &msg
+		delivery.DurationMS = time.Since(delivery.CreatedAt).Milliseconds()
+		return delivery
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return fail("invalid callback url")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "pdfninja-webhook")
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID)
+	if secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(secret, body))
+	}
+
+	// Manzil ulanish paytida ham tekshiriladi (newWebhookClient); xatoning to'liq matni faqat server logida
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.log.Error("webhook request failed", logger.String("jobID", jobID), logger.Int("attempt", attempt), logger.Error(err))
+		return fail(webhookErrorMessage(err))
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	delivery.StatusCode = &resp.StatusCode
+	delivery.DurationMS = time.Since(delivery.CreatedAt).Milliseconds()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fail(fmt.Sprintf("unexpected status %d", resp.StatusCode))
+	}
+
+	delivery.Success = true
+	return delivery
+}
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"test/api/models"
+	"test/config"
+)
+
+// webhookReceiver - callback_url sifatida ishlaydigan test server; javob kodlari navbat bilan qaytariladi
+type webhookReceiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedWebhook
+}
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+func newWebhookReceiver(t *testing.T, statuses ...int) *webhookReceiver {
+	t.Helper()
+	rcv := &webhookReceiver{statuses: statuses}
+	rcv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		rcv.mu.Lock()
+		rcv.requests = append(rcv.requests, receivedWebhook{header: r.Header.Clone(), body: body})
+		status := http.StatusOK
+		if len(rcv.statuses) > 0 {
+			status, rcv.statuses = rcv.statuses[0], rcv.statuses[1:]
+		}
+		rcv.mu.Unlock()
+
+		if status == http.StatusFound {
+			http.Redirect(w, r, "/elsewhere", status)
+			return
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(rcv.Close)
+	return rcv
+}
+
+func (r *webhookReceiver) received() []receivedWebhook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedWebhook(nil), r.requests...)
+}
+
+func newTestWebhookService(t *testing.T, rcv *webhookReceiver, maxAttempts int) (*webhookService, *fakeStorage) {
+	t.Helper()
+	stg := newFakeStorage()
+	cfg := config.Config{
+		WebhookMaxAttempts:  maxAttempts,
+		WebhookRetryBackoff: time.Second,
+		WebhookTimeout:      5 * time.Second,
+	}
+	// httptest server 127.0.0.1 da ishlaydi, shuning uchun uning mijozi beriladi (standart mijoz loopback ni rad etadi)
+	svc := NewWebhookService(stg, stg.redis, nopLogger{}, cfg, rcv.Client()).(*webhookService)
+	return svc, stg
+}
+
+func doneJob(callbackURL, secret string) *models.Job {
+	now := time.Now().UTC().Truncate(time.Second)
+	job := &models.Job{
+		ID:            "job-1",
+		Type:          models.JobTypeCompress,
+		Status:        models.Done,
+		OutputFileIDs: []string{"out-1"},
+		CallbackURL:   &callbackURL,
+		FinishedAt:    &now,
+	}
+	if secret != "" {
+		job.CallbackSecret = &secret
+	}
+	return job
+}
+
+func TestWebhookDeliverySigned(t *testing.T) {
+	rcv := newWebhookReceiver(t, http.StatusOK)
+	svc, stg := newTestWebhookService(t, rcv, 3)
+	stg.jobs.put(doneJob(rcv.URL, "s3cr3t"))
+
+	svc.deliver(context.Background(), webhookTask{JobID: "job-1", Attempt: 1})
+
+	reqs := rcv.received()
+	if len(reqs) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(reqs))
+	}
+	req := reqs[0]
+
+	if got, want := req.header.Get(WebhookSignatureHeader), SignWebhookPayload("s3cr3t", req.body); got != want {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+	if got := req.header.Get(WebhookEventHeader); got != "job.done" {
+		t.Errorf("event = %q, want job.done", got)
+	}
+
+	var payload models.WebhookPayload
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload.JobID != "job-1" || payload.Status != models.Done || len(payload.OutputFileIDs) != 1 {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+
+	logs, _ := stg.deliveries.GetByJobID(context.Background(), "job-1")
+	if len(logs) != 1 || !logs[0].Success || logs[0].StatusCode == nil || *logs[0].StatusCode != http.StatusOK {
+		t.Fatalf("unexpected delivery log %+v", logs)
+	}
+	if got := req.header.Get(WebhookDeliveryHeader); got != logs[0].ID {
+		t.Errorf("delivery header = %q, want log id %q", got, logs[0].ID)
+	}
+
+	// Muvaffaqiyatli yuborishdan keyin oldindan rejalashtirilgan qayta urinish bekor qilinadi
+	if pending := stg.redis.delayed(webhookDelayedKey); len(pending) != 0 {
+		t.Errorf("retry left in delayed queue: %v", pending)
+	}
+}
+
+func TestWebhookDeliveryUnsignedWithoutSecret(t *testing.T) {
+	rcv := newWebhookReceiver(t, http.StatusOK)
+	svc, stg := newTestWebhookService(t, rcv, 1)
+	stg.jobs.put(doneJob(rcv.URL, ""))
+
+	svc.deliver(context.Background(), webhookTask{JobID: "job-1", Attempt: 1})
+
+	reqs := rcv.received()
+	if len(reqs) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(reqs))
+	}
+	if got := reqs[0].header.Get(WebhookSignatureHeader); got != "" {
+		t.Errorf("signature header = %q, want none", got)
+	}
+}
+
+func TestWebhookDeliveryRetry(t *testing.T) {
+	rcv := newWebhookReceiver(t, http.StatusInternalServerError, http.StatusOK)
+	svc, stg := newTestWebhookService(t, rcv, 3)
+	stg.jobs.put(doneJob(rcv.URL, "s3cr3t"))
+	ctx := context.Background()
+
+	before := time.Now()
+	svc.deliver(ctx, webhookTask{JobID: "job-1", Attempt: 1})
+
+	// Keyingi urinish Redis dagi kechiktirilgan navbatda - server qayta ishga tushsa ham saqlanadi
+	pending := stg.redis.delayed(webhookDelayedKey)
+	if len(pending) != 1 {
+		t.Fatalf("delayed queue = %v, want one retry", pending)
+	}
+	for raw, at := range pending {
+		var task webhookTask
+		if err := json.Unmarshal([]byte(raw), &task); err != nil {
+			t.Fatalf("invalid task %q: %v", raw, err)
+		}
+		if task.JobID != "job-1" || task.Attempt != 2 {
+			t.Errorf("retry task = %+v, want job-1 attempt 2", task)
+		}
+		if at.Before(before.Add(time.Second)) {
+			t.Errorf("retry scheduled at %v, want after backoff", at)
+		}
+	}
+
+	due, _ := stg.redis.PopDue(ctx, webhookDelayedKey, time.Now().Add(time.Hour), delayedBatchSize)
+	var task webhookTask
+	if err := json.Unmarshal([]byte(due[0]), &task); err != nil {
+		t.Fatal(err)
+	}
+	svc.deliver(ctx, task)
+
+	logs, _ := stg.deliveries.GetByJobID(ctx, "job-1")
+	if len(logs) != 2 {
+		t.Fatalf("delivery log has %d entries, want 2", len(logs))
+	}
+	first, second := logs[0], logs[1]
+	if first.Attempt != 1 || first.Success || first.StatusCode == nil || *first.StatusCode != http.StatusInternalServerError {
+		t.Errorf("first attempt = %+v", first)
+	}
+	if first.Error == nil || *first.Error != "unexpected status 500" {
+		t.Errorf("first attempt error = %v", first.Error)
+	}
+	if second.Attempt != 2 || !second.Success {
+		t.Errorf("second attempt = %+v", second)
+	}
+	if pending := stg.redis.delayed(webhookDelayedKey); len(pending) != 0 {
+		t.Errorf("retry left in delayed queue: %v", pending)
+	}
+
+	// Ikkala urinish bir xil imzolangan payload bilan yuboriladi
+	reqs := rcv.received()
+	if len(reqs) != 2 || string(reqs[0].body) != string(reqs[1].body) {
+		t.Fatalf("retry payload differs from first attempt")
+	}
+	if got, want := reqs[1].header.Get(WebhookSignatureHeader), SignWebhookPayload("s3cr3t", reqs[1].body); got != want {
+		t.Errorf("retry signature = %q, want %q", got, want)
+	}
+}
+
+func TestWebhookDeliveryGivesUp(t *testing.T) {
+	rcv := newWebhookReceiver(t, http.StatusBadGateway, http.StatusBadGateway)
+	svc, stg := newTestWebhookService(t, rcv, 2)
+	stg.jobs.put(doneJob(rcv.URL, ""))
+	ctx := context.Background()
+
+	svc.deliver(ctx, webhookTask{JobID: "job-1", Attempt: 1})
+	// RunDeliveries kabi: vaqti kelgan urinishlar navbat bo'shaguncha bajariladi
+	for i := 0; i < 5; i++ {
+		due, _ := stg.redis.PopDue(ctx, webhookDelayedKey, time.Now().Add(time.Hour), delayedBatchSize)
+		for _, raw := range due {
+			var task webhookTask
+			if err := json.Unmarshal([]byte(raw), &task); err != nil {
+				t.Fatal(err)
+			}
+			svc.deliver(ctx, task)
+		}
+	}
+
+	logs, _ := stg.deliveries.GetByJobID(ctx, "job-1")
+	if len(logs) != 2 {
+		t.Fatalf("delivery log has %d entries, want 2", len(logs))
+	}
+	for i, d := range logs {
+		if d.Attempt != i+1 || d.Success {
+			t.Errorf("attempt %d log = %+v", i+1, d)
+		}
+	}
+	if pending := stg.redis.delayed(webhookDelayedKey); len(pending) != 0 {
+		t.Errorf("attempt scheduled beyond max attempts: %v", pending)
+	}
+}
+
+func TestWebhookDeliverySkipsNonTerminalJob(t *testing.T) {
+	rcv := newWebhookReceiver(t)
+	svc, stg := newTestWebhookService(t, rcv, 3)
+	job := doneJob(rcv.URL, "")
+	job.Status = models.Pending // qo'lda retry qilingan
+	stg.jobs.put(job)
+
+	svc.deliver(context.Background(), webhookTask{JobID: "job-1", Attempt: 1})
+
+	if n := len(rcv.received()); n != 0 {
+		t.Errorf("receiver got %d requests for pending job", n)
+	}
+}
+
+func TestWebhookRedirectNotFollowed(t *testing.T) {
+	rcv := newWebhookReceiver(t, http.StatusFound)
+	svc, stg := newTestWebhookService(t, rcv, 1)
+	stg.jobs.put(doneJob(rcv.URL, ""))
+
+	svc.deliver(context.Background(), webhookTask{JobID: "job-1", Attempt: 1})
+
+	if n := len(rcv.received()); n != 1 {
+		t.Fatalf("receiver got %d requests, want 1 (redirect must not be followed)", n)
+	}
+	logs, _ := stg.deliveries.GetByJobID(context.Background(), "job-1")
+	if len(logs) != 1 || logs[0].Success || logs[0].StatusCode == nil || *logs[0].StatusCode != http.StatusFound {
+		t.Fatalf("unexpected delivery log %+v", logs)
+	}
+}
+
+func TestWebhookDefaultClientRefusesLoopback(t *testing.T) {
+	rcv := newWebhookReceiver(t)
+	stg := newFakeStorage()
+	svc := NewWebhookService(stg, stg.redis, nopLogger{}, config.Config{WebhookMaxAttempts: 1, WebhookTimeout: time.Second}, nil).(*webhookService)
+	stg.jobs.put(doneJob(rcv.URL, ""))
+
+	svc.deliver(context.Background(), webhookTask{JobID: "job-1", Attempt: 1})
+
+	if n := len(rcv.received()); n != 0 {
+		t.Fatalf("loopback receiver got %d requests", n)
+	}
+	logs, _ := stg.deliveries.GetByJobID(context.Background(), "job-1")
+	if len(logs) != 1 || logs[0].Error == nil || *logs[0].Error != "destination not allowed" {
+		t.Fatalf("unexpected delivery log %+v", logs)
+	}
+}
+
+func TestCheckCallbackURL(t *testing.T) {
+	tests := []struct {
+		url string
+		ok  bool
+	}{
+		{"https://93.184.216.34/hook", true},
+		{"http://[2606:2800:220:1:248:1893:25c8:1946]:8080/hook", true},
+		{"ftp://93.184.216.34/hook", false},
+		{"/relative/hook", false},
+		{"http://127.0.0.1:8080/hook", false},
+		{"http://localhost/hook", false},
+		{"http://[::1]/hook", false},
+		{"http://10.0.0.5/hook", false},
+		{"http://172.16.3.4/hook", false},
+		{"http://192.168.1.1/hook", false},
+		{"http://169.254.169.254/latest/meta-data/", false},
+		{"http://[fd00:ec2::254]/", false},
+		{"http://100.64.0.1/hook", false},
+		{"http://0.0.0.0/hook", false},
+		{"http://[::ffff:127.0.0.1]/hook", false},
+		{"http://host.invalid/hook", false},
+	}
+
+	for _, tt := range tests {
+		err := checkCallbackURL(context.Background(), tt.url)
+		if tt.ok && err != nil {
+			t.Errorf("checkCallbackURL(%q) = %v, want ok", tt.url, err)
+		}
+		if !tt.ok && (err == nil || !strings.Contains(err.Error(), ErrInvalidCallbackURL.Error())) {
+			t.Errorf("checkCallbackURL(%q) = %v, want ErrInvalidCallbackURL", tt.url, err)
+		}
+	}
+}
+
+func TestWebhookRetryBackoff(t *testing.T) {
+	svc := &webhookService{cfg: config.Config{WebhookRetryBackoff: 5 * time.Second}}
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 5 * time.Second},
+		{2, 10 * time.Second},
+		{3, 20 * time.Second},
+		{10, maxWebhookBackoff},
+	}
+	for _, tt := range tests {
+		if got := svc.retryBackoff(tt.attempt); got != tt.want {
+			t.Errorf("retryBackoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookNotifyRunDeliveries(t *testing.T) {
+	rcv := newWebhookReceiver(t, http.StatusOK)
+	svc, stg := newTestWebhookService(t, rcv, 3)
+	job := doneJob(rcv.URL, "s3cr3t")
+	stg.jobs.put(job)
+
+	// Notify faqat navbatga qo'yadi; yuborishni RunDeliveries bajaradi
+	svc.Notify(job)
+	if n := len(stg.redis.delayed(webhookDelayedKey)); n != 1 {
+		t.Fatalf("delayed queue has %d tasks after Notify, want 1", n)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	go svc.RunDeliveries(ctx)
+
+	for len(rcv.received()) == 0 {
+		select {
+		case <-ctx.Done():
+			t.Fatal("webhook was not delivered")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
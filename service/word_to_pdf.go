@@ -2,9 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -16,40 +17,83 @@ import (
 
 type WordToPDFService interface {
 	Create(ctx context.Context, req models.WordToPDFRequest, userID *string) (string, error)
-	GetByID(ctx context.Context, id string) (*models.WordToPDFJob, error)
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	Process(ctx context.Context, job *models.Job, task models.QueueTask) error
 }
 
 type wordToPDFService struct {
 	stg       storage.IStorage
 	log       logger.ILogger
 	gotClient gotenberg.Client // ⬅️ Gotenberg interfeysi bilan
+	queue     QueueService
 }
 
-func NewWordToPDFService(stg storage.IStorage, log logger.ILogger, gotClient gotenberg.Client) WordToPDFService {
+func NewWordToPDFService(stg storage.IStorage, log logger.ILogger, gotClient gotenberg.Client, queue QueueService) WordToPDFService {
 	return &wordToPDFService{
 		stg:       stg,
 		log:       log,
 		gotClient: gotClient,
+		queue:     queue,
 	}
 }
 
 func (s *wordToPDFService) Create(ctx context.Context, req models.WordToPDFRequest, userID *string) (string, error) {
 	s.log.Info("WordToPDFService.Create called")
 
+	if _, err := s.stg.File().GetByID(ctx, req.InputFileID); err != nil {
+		s.log.Error("input file not found", logger.Error(err))
+		return "", err
+	}
+
+	job, err := newJob(models.JobTypeWordToPDF, userID, []string{req.InputFileID}, req)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Submit(ctx, job, nil); err != nil {
+		s.log.Error("failed to submit word to pdf job", logger.Error(err))
+		return "", err
+	}
+
+	return job.ID, nil
+}
+
+// Process - navbatdan olingan konvertatsiya jobini bajaradi
+func (s *wordToPDFService) Process(ctx context.Context, job *models.Job, task models.QueueTask) error {
+	var req models.WordToPDFRequest
+	if err := json.Unmarshal(job.Params, &req); err != nil {
+		return fmt.Errorf("invalid word to pdf params: %w", err)
+	}
+
+	outputFileID, err := s.convert(ctx, job, req)
+	if err != nil {
+		return err
+	}
+
+	job.OutputFileIDs = []string{outputFileID}
+	return nil
+}
+
+func (s *wordToPDFService) convert(ctx context.Context, job *models.Job, req models.WordToPDFRequest) (string, error) {
 	file, err := s.stg.File().GetByID(ctx, req.InputFileID)
 	if err != nil {
 		s.log.Error("input file not found", logger.Error(err))
 		return "", err
 	}
 
+	inputPath, cleanup, err := fetchInput(ctx, s.stg, file)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
 	// 🔥 Gotenbergdan PDF faylni olish
-	resultBytes, err := s.gotClient.WordToPDF(ctx, file.FilePath)
+	resultBytes, err := s.gotClient.WordToPDF(ctx, inputPath)
 	if err != nil {
 		s.log.Error("Gotenberg conversion failed", logger.Error(err))
 		return "", err
 	}
 
-	jobID := uuid.NewString()
 	outputFileID := uuid.NewString()
 	outputPath := filepath.Join("storage/word_to_pdf", outputFileID+".pdf")
 
@@ -60,40 +104,16 @@ func (s *wordToPDFService) Create(ctx context.Context, req models.WordToPDFReque
 		return "", err
 	}
 
-	fi, _ := os.Stat(outputPath)
-
-	newFile := models.File{
-		ID:         outputFileID,
-		UserID:     userID,
-		FileName:   filepath.Base(outputPath),
-		FilePath:   outputPath,
-		FileType:   "application/pdf",
-		FileSize:   fi.Size(),
-		UploadedAt: time.Now(),
-	}
-	if _, err := s.stg.File().Save(ctx, newFile); err != nil {
+	if err := saveOutputFile(ctx, s.stg, outputFileID, job.UserID, outputPath, "application/pdf"); err != nil {
 		s.log.Error("failed to save output file", logger.Error(err))
 		return "", err
 	}
 
-	job := &models.WordToPDFJob{
-		ID:           jobID,
-		UserID:       userID,
-		InputFileID:  req.InputFileID,
-		OutputFileID: &outputFileID,
-		Status:       "done",
-		CreatedAt:    time.Now(),
-	}
-	if err := s.stg.WordToPDF().Create(ctx, job); err != nil {
-		s.log.Error("failed to save job", logger.Error(err))
-		return "", err
-	}
-
-	return jobID, nil
+	return outputFileID, nil
 }
 
-func (s *wordToPDFService) GetByID(ctx context.Context, id string) (*models.WordToPDFJob, error) {
-	job, err := s.stg.WordToPDF().GetByID(ctx, id)
+func (s *wordToPDFService) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	job, err := getJobByType(ctx, s.stg, id, models.JobTypeWordToPDF)
 	if err != nil {
 		s.log.Error("wordToPDF job not found", logger.Error(err))
 		return nil, err
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+type cleanupRunRepo struct {
+	db  *pgxpool.Pool
+	log logger.ILogger
+}
+
+func NewCleanupRunRepo(db *pgxpool.Pool, log logger.ILogger) storage.ICleanupRunStorage {
+	return &cleanupRunRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *cleanupRunRepo) Create(ctx context.Context, run *models.CleanupRun) error {
+	query := `
+		INSERT INTO cleanup_runs (id, trigger, status, started_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := r.db.Exec(ctx, query, run.ID, run.Trigger, run.Status, run.StartedAt)
+	if err != nil {
+		r.log.Error("failed to create cleanup run", logger.Error(err))
+	}
+	return err
+}
+
+// Finish - tozalash natijasi va yakuniy holatini yozadi
+func (r *cleanupRunRepo) Finish(ctx context.Context, run *models.CleanupRun) error {
+	query := `
+		UPDATE cleanup_runs
+		SET status = $2, deleted_files = $3, freed_bytes = $4, deleted_links = $5,
+			removed_paths = $6, error = $7, finished_at = $8
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(ctx, query, run.ID, run.Status, run.DeletedFiles, run.FreedBytes,
+		run.DeletedLinks, run.RemovedPaths, run.Error, run.FinishedAt)
+	if err != nil {
+		r.log.Error("failed to finish cleanup run", logger.String("id", run.ID), logger.Error(err))
+	}
+	return err
+}
+
+// GetList - oxirgi tozalashlar, yangisi birinchi
+func (r *cleanupRunRepo) GetList(ctx context.Context, limit int) ([]models.CleanupRun, error) {
+	query := `
+		SELECT id, trigger, status, deleted_files, freed_bytes, deleted_links, removed_paths,
+			error, started_at, finished_at
+		FROM cleanup_runs
+		ORDER BY started_at DESC
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []models.CleanupRun{}
+	for rows.Next() {
+		var run models.CleanupRun
+		if err := rows.Scan(&run.ID, &run.Trigger, &run.Status, &run.DeletedFiles, &run.FreedBytes,
+			&run.DeletedLinks, &run.RemovedPaths, &run.Error, &run.StartedAt, &run.FinishedAt); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
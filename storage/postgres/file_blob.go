@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+type fileBlobRepo struct {
+	db  *pgxpool.Pool
+	log logger.ILogger
+}
+
+func NewFileBlobRepo(db *pgxpool.Pool, log logger.ILogger) storage.IFileBlobStorage {
+	return &fileBlobRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// lockBlob - kalit bo'yicha tranzaksiya oxirigacha ushlanadigan advisory lock
+func lockBlob(ctx context.Context, tx pgx.Tx, key string) error {
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, key)
+	return err
+}
+
+func (r *fileBlobRepo) Acquire(ctx context.Context, blob models.FileBlob, put func(ctx context.Context) error) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockBlob(ctx, tx, blob.Key); err != nil {
+		r.log.Error("failed to lock blob", logger.String("key", blob.Key), logger.Error(err))
+		return false, err
+	}
+
+	tag, err := tx.Exec(ctx, `UPDATE file_blobs SET ref_count = ref_count + 1 WHERE blob_key = $1`, blob.Key)
+	if err != nil {
+		r.log.Error("failed to reference blob", logger.String("key", blob.Key), logger.Error(err))
+		return false, err
+	}
+
+	created := tag.RowsAffected() == 0
+	if created {
+		if err := put(ctx); err != nil {
+			return false, err
+		}
+
+		query := `
+			INSERT INTO file_blobs (blob_key, sha256, size, ref_count, created_at)
+			VALUES ($1, $2, $3, 1, NOW())
+		`
+		if _, err := tx.Exec(ctx, query, blob.Key, blob.SHA256, blob.Size); err != nil {
+			r.log.Error("failed to insert blob", logger.String("key", blob.Key), logger.Error(err))
+			return false, err
+		}
+	}
+
+	return created, tx.Commit(ctx)
+}
+
+// Release - blobni o'chirish muvaffaqiyatsiz bo'lsa ham ref_count = 0 saqlanadi: yozuv qoladi va
+// keyingi Acquire uni qayta ishlatadi yoki tozalash jarayoni o'chiradi
+func (r *fileBlobRepo) Release(ctx context.Context, key string, remove func(ctx context.Context) error) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockBlob(ctx, tx, key); err != nil {
+		r.log.Error("failed to lock blob", logger.String("key", key), logger.Error(err))
+		return false, err
+	}
+
+	var refs int
+	err = tx.QueryRow(ctx, `
+		UPDATE file_blobs SET ref_count = ref_count - 1
+		WHERE blob_key = $1 AND ref_count > 0
+		RETURNING ref_count
+	`, key).Scan(&refs)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		r.log.Error("failed to release blob", logger.String("key", key), logger.Error(err))
+		return false, err
+	}
+
+	if refs > 0 {
+		return false, tx.Commit(ctx)
+	}
+
+	if err := remove(ctx); err != nil {
+		if commitErr := tx.Commit(ctx); commitErr != nil {
+			r.log.Error("failed to save released blob", logger.String("key", key), logger.Error(commitErr))
+		}
+		return false, err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM file_blobs WHERE blob_key = $1`, key); err != nil {
+		r.log.Error("failed to delete blob", logger.String("key", key), logger.Error(err))
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+func (r *fileBlobRepo) Exists(ctx context.Context, key string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM file_blobs WHERE blob_key = $1)`, key).Scan(&exists)
+	return exists, err
+}
+
+func (r *fileBlobRepo) Rewrite(ctx context.Context, key string, rewrite func(ctx context.Context) error) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockBlob(ctx, tx, key); err != nil {
+		r.log.Error("failed to lock blob", logger.String("key", key), logger.Error(err))
+		return false, err
+	}
+
+	var used bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM file_blobs WHERE blob_key = $1 AND ref_count > 0)`, key).Scan(&used)
+	if err != nil {
+		r.log.Error("failed to check blob", logger.String("key", key), logger.Error(err))
+		return false, err
+	}
+	if !used {
+		return false, nil
+	}
+
+	if err := rewrite(ctx); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+func (r *fileBlobRepo) ListAfter(ctx context.Context, afterKey string, limit int) ([]models.FileBlob, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT blob_key, sha256, size, ref_count, created_at
+		FROM file_blobs
+		WHERE blob_key > $1
+		ORDER BY blob_key
+		LIMIT $2
+	`, afterKey, limit)
+	if err != nil {
+		r.log.Error("failed to list blobs", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blobs []models.FileBlob
+	for rows.Next() {
+		var blob models.FileBlob
+		if err := rows.Scan(&blob.Key, &blob.SHA256, &blob.Size, &blob.RefCount, &blob.CreatedAt); err != nil {
+			return nil, err
+		}
+		blobs = append(blobs, blob)
+	}
+	return blobs, rows.Err()
+}
@@ -2,7 +2,11 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"test/api/models"
@@ -22,15 +26,25 @@ func NewFileRepo(db *pgxpool.Pool, log logger.ILogger) storage.IFileStorage {
 	}
 }
 
+// fileColumns - files va natija fayllari uchun ularni yaratgan job (f, j aliaslari bilan fileFrom da)
+const fileColumns = `f.id, f.user_id, f.file_name, f.file_path, f.file_type, f.file_size, COALESCE(f.sha256, ''),
+	f.page_count, f.encrypted, f.pinned, f.folder_id, f.tags, f.uploaded_at, f.scan_status, f.scan_signature, f.scanned_at,
+	f.source_job_id, j.type, j.input_file_ids`
+
+const fileFrom = ` FROM files f LEFT JOIN jobs j ON j.id = f.source_job_id`
+
+const insertFileQuery = `
+		INSERT INTO files (id, user_id, file_name, file_path, file_type, file_size, sha256, page_count, encrypted, uploaded_at,
+			scan_status, scan_signature, scanned_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9, $10, COALESCE(NULLIF($11, ''), 'unscanned'), $12, $13)
+	`
+
 // Save - faylni DBga yozish
 func (f *fileRepo) Save(ctx context.Context, file models.File) (string, error) {
-	query := `
-		INSERT INTO files (id, user_id, file_name, file_path, file_type, file_size, uploaded_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-	`
-	_, err := f.db.Exec(ctx, query,
+	_, err := f.db.Exec(ctx, insertFileQuery,
 		file.ID, file.UserID, file.FileName, file.FilePath,
-		file.FileType, file.FileSize, file.UploadedAt)
+		file.FileType, file.FileSize, file.SHA256, file.PageCount, file.Encrypted, file.UploadedAt,
+		file.ScanStatus, file.ScanSignature, file.ScannedAt)
 	if err != nil {
 		f.log.Error("DB insert error", logger.Error(err))
 		return "", err
@@ -38,21 +52,48 @@ func (f *fileRepo) Save(ctx context.Context, file models.File) (string, error) {
 	return file.ID, nil
 }
 
+// SaveWithinQuota - faylni egasining chegaralari doirasida yozadi (0 - cheklanmagan).
+// Foydalanuvchi bo'yicha advisory lock bir vaqtdagi yuklashlar chegaradan oshib ketmasligi uchun;
+// false - fayl sig'madi, hech narsa yozilmadi.
+func (f *fileRepo) SaveWithinQuota(ctx context.Context, file models.File, maxBytes, maxFiles int64) (bool, error) {
+	tx, err := f.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('quota:' || $1::text))`, file.UserID); err != nil {
+		f.log.Error("failed to lock user quota", logger.Error(err))
+		return false, err
+	}
+
+	var usedBytes, fileCount int64
+	err = tx.QueryRow(ctx, `SELECT COALESCE(SUM(file_size), 0)::BIGINT, COUNT(*) FROM files WHERE user_id = $1`, file.UserID).
+		Scan(&usedBytes, &fileCount)
+	if err != nil {
+		f.log.Error("failed to get storage usage", logger.Error(err))
+		return false, err
+	}
+	if (maxBytes > 0 && usedBytes+file.FileSize > maxBytes) || (maxFiles > 0 && fileCount+1 > maxFiles) {
+		return false, nil
+	}
+
+	_, err = tx.Exec(ctx, insertFileQuery,
+		file.ID, file.UserID, file.FileName, file.FilePath,
+		file.FileType, file.FileSize, file.SHA256, file.PageCount, file.Encrypted, file.UploadedAt,
+		file.ScanStatus, file.ScanSignature, file.ScannedAt)
+	if err != nil {
+		f.log.Error("DB insert error", logger.Error(err))
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
 // GetByID - faylni ID bo‘yicha olish
 func (f *fileRepo) GetByID(ctx context.Context, id string) (models.File, error) {
-	var file models.File
-	query := `
-		SELECT id, 
-		user_id, 
-		file_name, 
-		file_path,
-		 file_type,
-		  file_size,
-		   uploaded_at
-		FROM files WHERE id = $1
-	`
-	err := f.db.QueryRow(ctx, query, id).Scan(
-		&file.ID, &file.UserID, &file.FileName, &file.FilePath, &file.FileType, &file.FileSize, &file.UploadedAt)
+	query := `SELECT ` + fileColumns + fileFrom + ` WHERE f.id = $1`
+
+	file, err := scanFile(f.db.QueryRow(ctx, query, id))
 	if err != nil {
 		f.log.Error("failed to fetch file", logger.Error(err))
 		return models.File{}, err
@@ -70,42 +111,188 @@ func (f *fileRepo) Delete(ctx context.Context, id string) error {
 	return err
 }
 
-// ListByUser - foydalanuvchiga tegishli fayllar
-func (f *fileRepo) ListByUser(ctx context.Context, userID string) ([]models.File, error) {
-	query := `
-		SELECT id, user_id, file_name, file_path, file_type, file_size, uploaded_at
-		FROM files WHERE user_id = $1 ORDER BY uploaded_at DESC
+// GetByHash - foydalanuvchining shu mazmunli eng oxirgi fayli
+func (f *fileRepo) GetByHash(ctx context.Context, userID, sha256 string) (models.File, error) {
+	query := `SELECT ` + fileColumns + fileFrom + `
+		WHERE f.user_id = $1 AND f.sha256 = $2
+		ORDER BY f.uploaded_at DESC
+		LIMIT 1
 	`
-	rows, err := f.db.Query(ctx, query, userID)
+	return scanFile(f.db.QueryRow(ctx, query, userID, sha256))
+}
+
+// fileSortColumns - kutubxonada ruxsat etilgan saralash ustunlari
+var fileSortColumns = map[string]string{
+	"name":        "f.file_name",
+	"size":        "f.file_size",
+	"type":        "f.file_type",
+	"uploaded_at": "f.uploaded_at",
+}
+
+// Search - foydalanuvchi fayllari filtr, saralash va sahifalash bilan; ikkinchi qiymat - filtrga mos jami fayllar
+func (f *fileRepo) Search(ctx context.Context, filter models.FileFilter) ([]models.File, int, error) {
+	conditions := []string{"f.user_id = $1"}
+	args := []interface{}{filter.UserID}
+
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(cond, len(args)))
+	}
+
+	switch filter.FolderID {
+	case "":
+	case "root":
+		conditions = append(conditions, "f.folder_id IS NULL")
+	default:
+		add("f.folder_id = $%d", filter.FolderID)
+	}
+	if filter.Tag != "" {
+		add("$%d = ANY(f.tags)", filter.Tag)
+	}
+	if len(filter.FileTypes) > 0 {
+		add("f.file_type = ANY($%d)", filter.FileTypes)
+	}
+	if filter.Query != "" {
+		add(`f.file_name ILIKE '%%' || $%d::text || '%%' ESCAPE '\'`, escapeLike(filter.Query))
+	}
+	if filter.From != nil {
+		add("f.uploaded_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("f.uploaded_at < $%d", *filter.To)
+	}
+	if filter.MinSize != nil {
+		add("f.file_size >= $%d", *filter.MinSize)
+	}
+	if filter.MaxSize != nil {
+		add("f.file_size <= $%d", *filter.MaxSize)
+	}
+	if filter.SourceFileID != "" {
+		add("$%d = ANY(j.input_file_ids)", filter.SourceFileID)
+	}
+	if filter.JobType != "" {
+		add("j.type = $%d", filter.JobType)
+	}
+
+	where := ` WHERE ` + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := f.db.QueryRow(ctx, `SELECT COUNT(*)`+fileFrom+where, args...).Scan(&total); err != nil {
+		f.log.Error("failed to count user files", logger.Error(err))
+		return nil, 0, err
+	}
+
+	sortColumn, ok := fileSortColumns[filter.Sort]
+	if !ok {
+		sortColumn = fileSortColumns["uploaded_at"]
+	}
+	order := "ASC"
+	if filter.Desc {
+		order = "DESC"
+	}
+
+	args = append(args, filter.Limit, (filter.Page-1)*filter.Limit)
+	query := `SELECT ` + fileColumns + fileFrom + where +
+		fmt.Sprintf(` ORDER BY %s %s, f.id %s LIMIT $%d OFFSET $%d`, sortColumn, order, order, len(args)-1, len(args))
+
+	rows, err := f.db.Query(ctx, query, args...)
 	if err != nil {
 		f.log.Error("failed to fetch user files", logger.Error(err))
-		return nil, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
-	var files []models.File
+	files := []models.File{}
 	for rows.Next() {
-		var file models.File
-		err = rows.Scan(&file.ID, &file.UserID, &file.FileName, &file.FilePath, &file.FileType, &file.FileSize, &file.UploadedAt)
+		file, err := scanFile(rows)
 		if err != nil {
 			f.log.Error("error scanning row", logger.Error(err))
-			continue
+			return nil, 0, err
 		}
 		files = append(files, file)
 	}
-	return files, nil
+	return files, total, rows.Err()
 }
 
+// Update - fayl nomi, papkasi va teglari
+func (f *fileRepo) Update(ctx context.Context, file models.File) error {
+	query := `UPDATE files SET file_name = $2, folder_id = $3, tags = $4 WHERE id = $1`
 
-func (r *fileRepo) GetOldFiles(ctx context.Context, olderThanDays int) ([]models.OldFile, error) {
+	tags := file.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	_, err := f.db.Exec(ctx, query, file.ID, file.FileName, file.FolderID, tags)
+	if err != nil {
+		f.log.Error("failed to update file", logger.String("id", file.ID), logger.Error(err))
+	}
+	return err
+}
+
+// ListTags - foydalanuvchi fayllaridagi teglar va ular qo'yilgan fayllar soni
+func (f *fileRepo) ListTags(ctx context.Context, userID string) ([]models.TagCount, error) {
+	query := `
+		SELECT tag, COUNT(*)
+		FROM files, UNNEST(tags) AS tag
+		WHERE user_id = $1
+		GROUP BY tag
+		ORDER BY COUNT(*) DESC, tag
+	`
+
+	rows, err := f.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []models.TagCount{}
+	for rows.Next() {
+		var tag models.TagCount
+		if err := rows.Scan(&tag.Tag, &tag.Count); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// SetSourceJob - natija fayllarini ularni yaratgan jobga bog'laydi. Allaqachon bog'langan fayl
+// (masalan, pipeline qadami natijasi) o'zgarmaydi.
+func (f *fileRepo) SetSourceJob(ctx context.Context, jobID string, fileIDs []string) error {
+	query := `UPDATE files SET source_job_id = $1 WHERE id = ANY($2) AND source_job_id IS NULL`
+
+	_, err := f.db.Exec(ctx, query, jobID, fileIDs)
+	if err != nil {
+		f.log.Error("failed to set file source job", logger.String("jobID", jobID), logger.Error(err))
+	}
+	return err
+}
+
+// GetExpired - saqlash muddati o'tgan fayllar: mehmonniki guestBefore dan, foydalanuvchiniki userBefore dan
+// oldin yuklangan (userBefore nil - foydalanuvchi fayllari o'chirilmaydi). Biriktirilgan, amaldagi ulashish
+// havolasi bor yoki bajarilayotgan job kiruvchi fayli bo'lgan fayllar qaytmaydi.
+func (r *fileRepo) GetExpired(ctx context.Context, guestBefore time.Time, userBefore *time.Time, limit int) ([]models.OldFile, error) {
 	query := `
-		SELECT id, file_path
-		FROM files
-		WHERE uploaded_at < NOW() - ($1 || ' days')::INTERVAL
+		SELECT f.id, f.file_path, COALESCE(f.sha256, ''), f.file_size
+		FROM files f
+		WHERE NOT f.pinned
+			AND ((f.user_id IS NULL AND f.uploaded_at < $1)
+				OR (f.user_id IS NOT NULL AND f.uploaded_at < $2))
+			AND NOT EXISTS (
+				SELECT 1 FROM shared_links l
+				WHERE l.file_id = f.id AND (l.expires_at IS NULL OR l.expires_at > NOW())
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM jobs j
+				WHERE j.status IN ('pending', 'processing') AND f.id = ANY(j.input_file_ids)
+			)
+		ORDER BY f.uploaded_at
+		LIMIT $3
 	`
 
-	rows, err := r.db.Query(ctx, query, olderThanDays)
+	rows, err := r.db.Query(ctx, query, guestBefore, userBefore, limit)
 	if err != nil {
+		r.log.Error("failed to get expired files", logger.Error(err))
 		return nil, err
 	}
 	defer rows.Close()
@@ -113,13 +300,60 @@ func (r *fileRepo) GetOldFiles(ctx context.Context, olderThanDays int) ([]models
 	var oldFiles []models.OldFile
 	for rows.Next() {
 		var f models.OldFile
-		if err := rows.Scan(&f.ID, &f.FilePath); err != nil {
-			continue
+		if err := rows.Scan(&f.ID, &f.FilePath, &f.SHA256, &f.FileSize); err != nil {
+			return nil, err
 		}
 		oldFiles = append(oldFiles, f)
 	}
 
-	return oldFiles, nil
+	return oldFiles, rows.Err()
+}
+
+// SetPinned - faylni saqlash muddatidan ozod qiladi yoki qaytaradi
+func (r *fileRepo) SetPinned(ctx context.Context, id string, pinned bool) error {
+	_, err := r.db.Exec(ctx, `UPDATE files SET pinned = $2 WHERE id = $1`, id, pinned)
+	if err != nil {
+		r.log.Error("failed to update pinned flag", logger.String("id", id), logger.Error(err))
+	}
+	return err
+}
+
+// ExistsByPath - shu blob kalitiga havola qiluvchi files yozuvi bormi
+func (r *fileRepo) ExistsByPath(ctx context.Context, path string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM files WHERE file_path = $1)`, path).Scan(&exists)
+	return exists, err
+}
+
+// ListAfter - barcha fayllar id bo'yicha tartibda, afterID dan keyingilari (keyset pagination)
+func (r *fileRepo) ListAfter(ctx context.Context, afterID string, limit int) ([]models.File, error) {
+	query := `SELECT ` + fileColumns + fileFrom + `
+		WHERE $1::uuid IS NULL OR f.id > $1::uuid
+		ORDER BY f.id
+		LIMIT $2
+	`
+
+	var after *string
+	if afterID != "" {
+		after = &afterID
+	}
+
+	rows, err := r.db.Query(ctx, query, after, limit)
+	if err != nil {
+		r.log.Error("failed to list files", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var files []models.File
+	for rows.Next() {
+		file, err := scanFile(rows)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, rows.Err()
 }
 
 func (r *fileRepo) DeleteByID(ctx context.Context, id string) error {
@@ -127,3 +361,19 @@ func (r *fileRepo) DeleteByID(ctx context.Context, id string) error {
 	_, err := r.db.Exec(ctx, query, id)
 	return err
 }
+
+func scanFile(row pgx.Row) (models.File, error) {
+	var file models.File
+	err := row.Scan(&file.ID, &file.UserID, &file.FileName, &file.FilePath, &file.FileType, &file.FileSize,
+		&file.SHA256, &file.PageCount, &file.Encrypted, &file.Pinned, &file.FolderID, &file.Tags, &file.UploadedAt,
+		&file.ScanStatus, &file.ScanSignature, &file.ScannedAt, &file.SourceJobID, &file.SourceJobType, &file.SourceFileIDs)
+	if err != nil {
+		return models.File{}, err
+	}
+	return file, nil
+}
+
+// escapeLike - LIKE naqshidagi maxsus belgilarni oddiy belgiga aylantiradi
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
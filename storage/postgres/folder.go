@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+const folderColumns = `id, user_id, parent_id, name, created_at, updated_at`
+
+type folderRepo struct {
+	db  *pgxpool.Pool
+	log logger.ILogger
+}
+
+func NewFolderRepo(db *pgxpool.Pool, log logger.ILogger) storage.IFolderStorage {
+	return &folderRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *folderRepo) Create(ctx context.Context, folder *models.Folder) error {
+	query := `
+		INSERT INTO folders (id, user_id, parent_id, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.Exec(ctx, query, folder.ID, folder.UserID, folder.ParentID, folder.Name, folder.CreatedAt, folder.UpdatedAt)
+	if err != nil {
+		r.log.Error("failed to create folder", logger.Error(err))
+	}
+	return err
+}
+
+func (r *folderRepo) GetByID(ctx context.Context, id string) (*models.Folder, error) {
+	query := `SELECT ` + folderColumns + ` FROM folders WHERE id = $1`
+	return scanFolder(r.db.QueryRow(ctx, query, id))
+}
+
+// GetList - papka ichidagi papkalar; parentID nil - ildizdagilar
+func (r *folderRepo) GetList(ctx context.Context, userID string, parentID *string) ([]models.Folder, error) {
+	query := `
+		SELECT ` + folderColumns + ` FROM folders
+		WHERE user_id = $1 AND parent_id IS NOT DISTINCT FROM $2
+		ORDER BY name
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, parentID)
+	if err != nil {
+		r.log.Error("failed to list folders", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	folders := []models.Folder{}
+	for rows.Next() {
+		folder, err := scanFolder(rows)
+		if err != nil {
+			return nil, err
+		}
+		folders = append(folders, *folder)
+	}
+	return folders, rows.Err()
+}
+
+func (r *folderRepo) Update(ctx context.Context, folder *models.Folder) error {
+	query := `UPDATE folders SET parent_id = $2, name = $3, updated_at = $4 WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, query, folder.ID, folder.ParentID, folder.Name, folder.UpdatedAt)
+	if err != nil {
+		r.log.Error("failed to update folder", logger.String("id", folder.ID), logger.Error(err))
+	}
+	return err
+}
+
+// Delete - papka va ichki papkalarni o'chiradi; ulardagi fayllar ildizga o'tadi
+func (r *folderRepo) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM folders WHERE id = $1`, id)
+	if err != nil {
+		r.log.Error("failed to delete folder", logger.String("id", id), logger.Error(err))
+	}
+	return err
+}
+
+// IsDescendant - folderID ancestorID ning o'zi yoki uning ichidagi papkami (ko'chirishda sikl bo'lmasligi uchun)
+func (r *folderRepo) IsDescendant(ctx context.Context, folderID, ancestorID string) (bool, error) {
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT id FROM folders WHERE id = $2
+			UNION
+			SELECT f.id FROM folders f JOIN tree t ON f.parent_id = t.id
+		)
+		SELECT EXISTS (SELECT 1 FROM tree WHERE id = $1)
+	`
+
+	var exists bool
+	err := r.db.QueryRow(ctx, query, folderID, ancestorID).Scan(&exists)
+	return exists, err
+}
+
+func scanFolder(row pgx.Row) (*models.Folder, error) {
+	var folder models.Folder
+	err := row.Scan(&folder.ID, &folder.UserID, &folder.ParentID, &folder.Name, &folder.CreatedAt, &folder.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
@@ -0,0 +1,340 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+type jobRepo struct {
+	db  *pgxpool.Pool
+	log logger.ILogger
+}
+
+func NewJobRepo(db *pgxpool.Pool, log logger.ILogger) storage.IJobStorage {
+	return &jobRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+const jobColumns = `
+	id, user_id, parent_id, type, params, input_file_ids, output_file_ids,
+	result, status, error_code, error, attempts, max_attempts, next_retry_at,
+	callback_url, callback_secret, cache_hit, created_at, started_at, finished_at
+`
+
+func (r *jobRepo) Create(ctx context.Context, job *models.Job) error {
+	query := `
+		INSERT INTO jobs (
+			id, user_id, parent_id, type, params, input_file_ids, output_file_ids, status, max_attempts,
+			callback_url, callback_secret, created_at
+		) VALUES ($1, $2, $3, $4, COALESCE($5, '{}'::jsonb), $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	var userID interface{}
+	if job.UserID != nil && *job.UserID != "" {
+		userID = *job.UserID
+	} else {
+		userID = nil // guest bo'lsa NULL
+	}
+
+	inputIDs := job.InputFileIDs
+	if inputIDs == nil {
+		inputIDs = []string{}
+	}
+	outputIDs := job.OutputFileIDs
+	if outputIDs == nil {
+		outputIDs = []string{}
+	}
+
+	_, err := r.db.Exec(ctx, query,
+		job.ID,
+		userID,
+		job.ParentID,
+		job.Type,
+		job.Params,
+		inputIDs,
+		outputIDs,
+		job.Status,
+		job.MaxAttempts,
+		job.CallbackURL,
+		job.CallbackSecret,
+		job.CreatedAt,
+	)
+	if err != nil {
+		r.log.Error("failed to insert job", logger.String("type", job.Type), logger.Error(err))
+	}
+	return err
+}
+
+func (r *jobRepo) GetByID(ctx context.Context, id string) (*models.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
+
+	job, err := scanJob(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		return nil, fmt.Errorf("get job by id: %w", err)
+	}
+	return job, nil
+}
+
+func (r *jobRepo) GetList(ctx context.Context, filter models.JobFilter) ([]models.Job, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if filter.UserID != "" {
+		args = append(args, filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := `SELECT ` + jobColumns + ` FROM jobs`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		r.log.Error("failed to query jobs", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []models.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			r.log.Error("failed to scan job row", logger.Error(err))
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+
+	return jobs, rows.Err()
+}
+
+func (r *jobRepo) Update(ctx context.Context, job *models.Job) error {
+	query := `
+		UPDATE jobs
+		SET output_file_ids = $1,
+			result = $2,
+			status = $3,
+			error_code = $4,
+			error = $5,
+			next_retry_at = $6,
+			started_at = $7,
+			finished_at = $8,
+			cache_hit = $9
+		WHERE id = $10 AND status <> 'cancelled'
+	`
+
+	outputIDs := job.OutputFileIDs
+	if outputIDs == nil {
+		outputIDs = []string{}
+	}
+
+	_, err := r.db.Exec(ctx, query,
+		outputIDs,
+		job.Result,
+		job.Status,
+		job.ErrorCode,
+		job.Error,
+		job.NextRetryAt,
+		job.StartedAt,
+		job.FinishedAt,
+		job.CacheHit,
+		job.ID,
+	)
+	if err != nil {
+		r.log.Error("failed to update job", logger.String("jobID", job.ID), logger.Error(err))
+	}
+	return err
+}
+
+func (r *jobRepo) Claim(ctx context.Context, id string) (bool, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'processing', started_at = NOW(), attempts = attempts + 1, next_retry_at = NULL
+		WHERE id = $1 AND status = 'pending'
+	`
+
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		r.log.Error("failed to claim job", logger.String("jobID", id), logger.Error(err))
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ReapStale - olderThan dan oldin boshlangan va hali processing bo'lgan (worker o'chib qolgan) joblarni
+// qaytaradi: urinishlari qolgan bo'lsa pending, aks holda dead. Pipeline qadamlari cancelled qilinadi -
+// pipeline o'zi qaytadan bajariladi. Pipeline/batch ning umumiy vaqti cheklanmagan: qadamlari yaqinda yaratilgan,
+// boshlangan yoki tugagan bo'lsa, u tirik hisoblanadi.
+// Bir nechta instansiya bir vaqtda chaqirsa ham har bir job bir marta qaytadi.
+func (r *jobRepo) ReapStale(ctx context.Context, olderThan time.Duration) ([]models.Job, error) {
+	query := `
+		WITH steps AS (
+			UPDATE jobs
+			SET status = 'cancelled', finished_at = NOW()
+			WHERE status = 'processing' AND parent_id IS NOT NULL
+				AND started_at < NOW() - make_interval(secs => $1)
+		)
+		UPDATE jobs
+		SET status = CASE WHEN attempts >= max_attempts THEN 'dead' ELSE 'pending' END,
+			error_code = 'timeout', error = 'worker stopped before the job finished',
+			started_at = NULL,
+			finished_at = CASE WHEN attempts >= max_attempts THEN NOW() END
+		WHERE status = 'processing' AND parent_id IS NULL
+			AND started_at < NOW() - make_interval(secs => $1)
+			AND NOT EXISTS (
+				SELECT 1 FROM jobs step
+				WHERE step.parent_id = jobs.id
+					AND GREATEST(step.created_at, step.started_at, step.finished_at) >= NOW() - make_interval(secs => $1)
+			)
+		RETURNING ` + jobColumns
+
+	rows, err := r.db.Query(ctx, query, olderThan.Seconds())
+	if err != nil {
+		r.log.Error("failed to reap stale jobs", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []models.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			r.log.Error("failed to scan job row", logger.Error(err))
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+
+	return jobs, rows.Err()
+}
+
+// Reset - failed, dead yoki cancelled jobni qayta bajarish uchun pending holatiga qaytaradi
+func (r *jobRepo) Reset(ctx context.Context, id string) (bool, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'pending', attempts = 0, error_code = NULL, error = NULL,
+			next_retry_at = NULL, started_at = NULL, finished_at = NULL, cache_hit = FALSE
+		WHERE id = $1 AND status IN ('failed', 'dead', 'cancelled')
+	`
+
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		r.log.Error("failed to reset job", logger.String("jobID", id), logger.Error(err))
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Cancel - hali tugamagan jobni bekor qiladi; bekor qilingan jobni Update qayta yozmaydi
+func (r *jobRepo) Cancel(ctx context.Context, id string) (bool, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'cancelled', next_retry_at = NULL, finished_at = NOW()
+		WHERE id = $1 AND status IN ('pending', 'processing')
+	`
+
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		r.log.Error("failed to cancel job", logger.String("jobID", id), logger.Error(err))
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *jobRepo) Delete(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM jobs WHERE id = $1`, id)
+	if err != nil {
+		r.log.Error("failed to delete job", logger.String("jobID", id), logger.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func scanJob(row pgx.Row) (*models.Job, error) {
+	var job models.Job
+	err := row.Scan(
+		&job.ID,
+		&job.UserID,
+		&job.ParentID,
+		&job.Type,
+		&job.Params,
+		&job.InputFileIDs,
+		&job.OutputFileIDs,
+		&job.Result,
+		&job.Status,
+		&job.ErrorCode,
+		&job.Error,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.NextRetryAt,
+		&job.CallbackURL,
+		&job.CallbackSecret,
+		&job.CacheHit,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CountActiveByType - amal turi bo'yicha pending va processing joblar soni
+func (r *jobRepo) CountActiveByType(ctx context.Context) ([]models.JobTypeStat, error) {
+	query := `
+		SELECT
+			type,
+			COUNT(*) FILTER (WHERE status = 'pending'),
+			COUNT(*) FILTER (WHERE status = 'processing')
+		FROM jobs
+		WHERE status IN ('pending', 'processing')
+		GROUP BY type
+		ORDER BY type
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		r.log.Error("failed to count active jobs", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []models.JobTypeStat{}
+	for rows.Next() {
+		var s models.JobTypeStat
+		if err := rows.Scan(&s.JobType, &s.Pending, &s.Processing); err != nil {
+			r.log.Error("failed to scan job stat", logger.Error(err))
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+const jobCacheColumns = `cache_key, job_type, source_job_id, outputs, result, size, hits, created_at, last_hit_at, expires_at`
+
+type jobCacheRepo struct {
+	db  *pgxpool.Pool
+	log logger.ILogger
+}
+
+func NewJobCacheRepo(db *pgxpool.Pool, log logger.ILogger) storage.IJobCacheStorage {
+	return &jobCacheRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+// Get - muddati o'tmagan kesh yozuvi
+func (r *jobCacheRepo) Get(ctx context.Context, key string) (*models.JobCacheEntry, error) {
+	query := `SELECT ` + jobCacheColumns + ` FROM job_cache WHERE cache_key = $1 AND expires_at > NOW()`
+
+	entry, err := scanJobCache(r.db.QueryRow(ctx, query, key))
+	if err != nil {
+		return nil, fmt.Errorf("get job cache entry: %w", err)
+	}
+	return entry, nil
+}
+
+// Create - false: shu kalit bilan yozuv allaqachon bor (parallel bajarilgan bir xil job)
+func (r *jobCacheRepo) Create(ctx context.Context, entry *models.JobCacheEntry) (bool, error) {
+	query := `
+		INSERT INTO job_cache (cache_key, job_type, source_job_id, outputs, result, size, hits, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, 0, $7, $8)
+		ON CONFLICT (cache_key) DO NOTHING
+	`
+
+	tag, err := r.db.Exec(ctx, query,
+		entry.Key, entry.JobType, entry.SourceJobID, entry.Outputs, entry.Result,
+		entry.Size, entry.CreatedAt, entry.ExpiresAt)
+	if err != nil {
+		r.log.Error("failed to create job cache entry", logger.String("type", entry.JobType), logger.Error(err))
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *jobCacheRepo) Hit(ctx context.Context, key string) error {
+	_, err := r.db.Exec(ctx, `UPDATE job_cache SET hits = hits + 1, last_hit_at = NOW() WHERE cache_key = $1`, key)
+	if err != nil {
+		r.log.Error("failed to record job cache hit", logger.Error(err))
+	}
+	return err
+}
+
+// Delete - filtrga mos yozuvlarni o'chiradi va ularni qaytaradi (bloblarga havolalarni bo'shatish uchun)
+func (r *jobCacheRepo) Delete(ctx context.Context, filter models.JobCacheFilter) ([]models.JobCacheEntry, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if filter.Key != "" {
+		args = append(args, filter.Key)
+		conditions = append(conditions, fmt.Sprintf("cache_key = $%d", len(args)))
+	}
+	if filter.JobType != "" {
+		args = append(args, filter.JobType)
+		conditions = append(conditions, fmt.Sprintf("job_type = $%d", len(args)))
+	}
+	if filter.ExpiredOnly {
+		conditions = append(conditions, "expires_at <= NOW()")
+	}
+
+	query := `DELETE FROM job_cache`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` RETURNING ` + jobCacheColumns
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		r.log.Error("failed to delete job cache entries", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.JobCacheEntry{}
+	for rows.Next() {
+		entry, err := scanJobCache(rows)
+		if err != nil {
+			r.log.Error("failed to scan job cache entry", logger.Error(err))
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *jobCacheRepo) Stats(ctx context.Context) ([]models.JobCacheTypeStat, error) {
+	query := `
+		SELECT job_type, COUNT(*), COALESCE(SUM(hits), 0), COALESCE(SUM(size), 0)
+		FROM job_cache
+		WHERE expires_at > NOW()
+		GROUP BY job_type
+		ORDER BY job_type
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		r.log.Error("failed to query job cache stats", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []models.JobCacheTypeStat{}
+	for rows.Next() {
+		var s models.JobCacheTypeStat
+		if err := rows.Scan(&s.JobType, &s.Entries, &s.Hits, &s.Size); err != nil {
+			r.log.Error("failed to scan job cache stat", logger.Error(err))
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+func scanJobCache(row pgx.Row) (*models.JobCacheEntry, error) {
+	var e models.JobCacheEntry
+	err := row.Scan(&e.Key, &e.JobType, &e.SourceJobID, &e.Outputs, &e.Result, &e.Size,
+		&e.Hits, &e.CreatedAt, &e.LastHitAt, &e.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
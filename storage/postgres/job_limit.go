@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+type jobLimitRepo struct {
+	db  *pgxpool.Pool
+	log logger.ILogger
+}
+
+func NewJobLimitRepo(db *pgxpool.Pool, log logger.ILogger) storage.IJobLimitStorage {
+	return &jobLimitRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *jobLimitRepo) GetAll(ctx context.Context) ([]models.JobTypeLimit, error) {
+	rows, err := r.db.Query(ctx, `SELECT job_type, max_workers, updated_at FROM job_type_limits ORDER BY job_type`)
+	if err != nil {
+		r.log.Error("failed to query job type limits", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	limits := []models.JobTypeLimit{}
+	for rows.Next() {
+		var l models.JobTypeLimit
+		if err := rows.Scan(&l.JobType, &l.MaxWorkers, &l.UpdatedAt); err != nil {
+			r.log.Error("failed to scan job type limit", logger.Error(err))
+			return nil, err
+		}
+		limits = append(limits, l)
+	}
+
+	return limits, rows.Err()
+}
+
+func (r *jobLimitRepo) Upsert(ctx context.Context, limit models.JobTypeLimit) error {
+	query := `
+		INSERT INTO job_type_limits (job_type, max_workers, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (job_type) DO UPDATE
+		SET max_workers = EXCLUDED.max_workers, updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := r.db.Exec(ctx, query, limit.JobType, limit.MaxWorkers, limit.UpdatedAt); err != nil {
+		r.log.Error("failed to save job type limit", logger.String("type", limit.JobType), logger.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *jobLimitRepo) Delete(ctx context.Context, jobType string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM job_type_limits WHERE job_type = $1`, jobType)
+	if err != nil {
+		r.log.Error("failed to delete job type limit", logger.String("type", jobType), logger.Error(err))
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"test/config"
+	"test/pkg/blobstore"
 	"test/pkg/logger"
 	"test/storage"
 )
@@ -20,9 +21,10 @@ type Store struct {
 	pool  *pgxpool.Pool
 	log   logger.ILogger
 	redis storage.IRedisStorage
+	blob  blobstore.BlobStore
 }
 
-func New(ctx context.Context, cfg config.Config, log logger.ILogger, redis storage.IRedisStorage) (storage.IStorage, error) {
+func New(ctx context.Context, cfg config.Config, log logger.ILogger, redis storage.IRedisStorage, blob blobstore.BlobStore) (storage.IStorage, error) {
 	url := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
 		cfg.PostgresUser,
 		cfg.PostgresPassword,
@@ -63,6 +65,7 @@ func New(ctx context.Context, cfg config.Config, log logger.ILogger, redis stora
 		pool:  pool,
 		log:   log,
 		redis: redis,
+		blob:  blob,
 	}, nil
 }
 
@@ -90,50 +93,48 @@ func (s *Store) Redis() storage.IRedisStorage {
 	return s.redis
 }
 
-func (s *Store) Merge() storage.IMergeStorage {
-	return NewMergeRepo(s.pool, s.log)
+func (s *Store) Blob() blobstore.BlobStore {
+	return s.blob
 }
 
 func (s *Store) File() storage.IFileStorage {
 	return NewFileRepo(s.pool, s.log)
 }
 
-func (s *Store) Split() storage.ISplitStorage {
-	return NewSplitRepo(s.pool, s.log)
+func (s *Store) Job() storage.IJobStorage {
+	return NewJobRepo(s.pool, s.log)
 }
 
-func (s *Store) RemovePage() storage.IRemovePageStorage {
-	return NewRemovePageRepo(s.pool, s.log)
+func (s *Store) WebhookDelivery() storage.IWebhookDeliveryStorage {
+	return NewWebhookDeliveryRepo(s.pool, s.log)
 }
 
-func (s *Store) ExtractPage() storage.IExtractPageStorage {
-	return NewExtractPageRepo(s.pool, s.log)
+func (s *Store) JobLimit() storage.IJobLimitStorage {
+	return NewJobLimitRepo(s.pool, s.log)
 }
 
-func (s *Store) Compress() storage.ICompressStorage {
-	return NewCompressRepo(s.pool, s.log)
+func (s *Store) Upload() storage.IUploadStorage {
+	return NewUploadRepo(s.pool, s.log)
 }
 
-func (s *Store) PDFToJPG() storage.IPDFToJPGStorage {
-	return NewPDFToJPGRepo(s.pool, s.log)
+func (s *Store) FileBlob() storage.IFileBlobStorage {
+	return NewFileBlobRepo(s.pool, s.log)
 }
 
-func (s *Store) Rotate() storage.IRotateStorage {
-	return NewRotateRepo(s.pool, s.log)
+func (s *Store) JobCache() storage.IJobCacheStorage {
+	return NewJobCacheRepo(s.pool, s.log)
 }
 
-func (s *Store) AddPageNumber() storage.IAddPageNumberStorage {
-	return NewAddPageNumberRepo(s.pool, s.log)
-}
-func (s *Store) Crop() storage.ICropPDFStorage {
-	return NewCropRepo(s.pool, s.log)
+func (s *Store) Quota() storage.IQuotaStorage {
+	return NewQuotaRepo(s.pool, s.log)
 }
 
-func (s *Store) Unlock() storage.IUnlockPDFStorage {
-	return NewUnlockRepo(s.pool, s.log)
+func (s *Store) CleanupRun() storage.ICleanupRunStorage {
+	return NewCleanupRunRepo(s.pool, s.log)
 }
-func (s *Store) Protect() storage.IProtectStorage {
-	return NewProtectRepo(s.pool, s.log)
+
+func (s *Store) Folder() storage.IFolderStorage {
+	return NewFolderRepo(s.pool, s.log)
 }
 
 func (s *Store) Stat() storage.IStatStorage {
@@ -142,44 +143,7 @@ func (s *Store) Stat() storage.IStatStorage {
 func (s *Store) Log() storage.ILogService {
 	return NewLogRepo(s.pool, s.log)
 }
-func (s *Store) JPGToPDF() storage.IJPGToPDFStorage {
-	return NewJPGToPDFRepo(s.pool, s.log)
-}
-func (s *Store) Inspect() storage.IInspectStorage {
-	return NewInspectRepo(s.pool, s.log)
-}
 
 func (s *Store) SharedLink() storage.ISharedLinkStorage {
 	return NewSharedLinkRepo(s.pool, s.log)
 }
-
-func (s *Store) AddHeaderFooter() storage.AddHeaderFooterStorage {
-	return NewAddHeaderFooterRepo(s.pool, s.log)
-}
-
-func (s *Store) DetectBlankPages() storage.IDetectBlankPagesStorage {
-	return NewDetectBlankPagesRepo(s.pool, s.log)
-}
-
-func (s *Store) QRCode() storage.IQRCodeStorage {
-	return NewQRCodeRepo(s.pool, s.log)
-}
-
-func (s *Store) PDFToWord() storage.IPDFToWordStorage {
-	return NewPDFToWordRepo(s.pool, s.log)
-}
-
-func (s *Store) WordToPDF() storage.IWordToPDFStorage {
-	return NewWordToPDFRepo(s.pool, s.log)
-}
-
-func (s *Store) ExcelToPDF() storage.IExcelToPDFStorage {
-	return NewExcelToPDFRepo(s.pool, s.log)
-}
-func (s *Store) PowerPointToPDF() storage.IPowerPointToPDFStorage {
-	return NewPowerPointToPDFRepo(s.pool, s.log)
-}
-
-func (s *Store) HTMLToPDF() storage.IHTMLToPDFStorage {
-	return NewHTMLToPDFRepo(s.pool, s.log)
-}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+type quotaRepo struct {
+	db  *pgxpool.Pool
+	log logger.ILogger
+}
+
+func NewQuotaRepo(db *pgxpool.Pool, log logger.ILogger) storage.IQuotaStorage {
+	return &quotaRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *quotaRepo) GetPlans(ctx context.Context) ([]models.QuotaPlan, error) {
+	rows, err := r.db.Query(ctx, `SELECT name, max_bytes, max_files, is_default FROM quota_plans ORDER BY name`)
+	if err != nil {
+		r.log.Error("failed to get quota plans", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var plans []models.QuotaPlan
+	for rows.Next() {
+		var plan models.QuotaPlan
+		if err := rows.Scan(&plan.Name, &plan.MaxBytes, &plan.MaxFiles, &plan.IsDefault); err != nil {
+			r.log.Error("failed to scan quota plan", logger.Error(err))
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, rows.Err()
+}
+
+// GetEffective - foydalanuvchi tarifi (yoki standart tarif) va uning ustidan admin override.
+// Standart tarif belgilanmagan bo'lsa, chegara yo'q deb hisoblanadi.
+func (r *quotaRepo) GetEffective(ctx context.Context, userID string) (models.UserQuota, error) {
+	query := `
+		SELECT p.name,
+			COALESCE(q.max_bytes, p.max_bytes),
+			COALESCE(q.max_files, p.max_files),
+			q.user_id IS NOT NULL
+		FROM quota_plans p
+		LEFT JOIN user_quotas q ON q.user_id = $1
+		WHERE p.name = COALESCE(q.plan, (SELECT name FROM quota_plans WHERE is_default))
+	`
+
+	var quota models.UserQuota
+	err := r.db.QueryRow(ctx, query, userID).Scan(&quota.Plan, &quota.MaxBytes, &quota.MaxFiles, &quota.Overridden)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.UserQuota{}, nil
+	}
+	if err != nil {
+		r.log.Error("failed to get user quota", logger.String("userID", userID), logger.Error(err))
+		return models.UserQuota{}, err
+	}
+	return quota, nil
+}
+
+func (r *quotaRepo) GetUsage(ctx context.Context, userID string) (models.StorageUsage, error) {
+	var usage models.StorageUsage
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(SUM(file_size), 0)::BIGINT, COUNT(*) FROM files WHERE user_id = $1
+	`, userID).Scan(&usage.UsedBytes, &usage.FileCount)
+	if err != nil {
+		r.log.Error("failed to get storage usage", logger.String("userID", userID), logger.Error(err))
+		return models.StorageUsage{}, err
+	}
+	return usage, nil
+}
+
+func (r *quotaRepo) Upsert(ctx context.Context, override models.UserQuotaOverride) error {
+	query := `
+		INSERT INTO user_quotas (user_id, plan, max_bytes, max_files, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET plan = EXCLUDED.plan,
+			max_bytes = EXCLUDED.max_bytes,
+			max_files = EXCLUDED.max_files,
+			updated_at = NOW()
+	`
+	_, err := r.db.Exec(ctx, query, override.UserID, override.Plan, override.MaxBytes, override.MaxFiles)
+	if err != nil {
+		r.log.Error("failed to save user quota", logger.String("userID", override.UserID), logger.Error(err))
+	}
+	return err
+}
+
+func (r *quotaRepo) Delete(ctx context.Context, userID string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM user_quotas WHERE user_id = $1`, userID)
+	if err != nil {
+		r.log.Error("failed to delete user quota", logger.String("userID", userID), logger.Error(err))
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
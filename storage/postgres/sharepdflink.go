@@ -76,3 +76,13 @@ func (r *sharedLinkRepo) GetByToken(ctx context.Context, token string) (*models.
 	r.log.Info("retrieved shared link", logger.String("token", token))
 	return &link, nil
 }
+
+// DeleteExpired - muddati o'tgan havolalarni o'chiradi, o'chirilganlar sonini qaytaradi
+func (r *sharedLinkRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM shared_links WHERE expires_at IS NOT NULL AND expires_at <= NOW()`)
+	if err != nil {
+		r.log.Error("failed to delete expired shared links", logger.Error(err))
+		return 0, fmt.Errorf("failed to delete expired shared links: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+const uploadColumns = `id, user_id, file_name, file_type, upload_length, upload_offset, chunk_keys, status, file_id, created_at, updated_at, expires_at`
+
+type uploadRepo struct {
+	db  *pgxpool.Pool
+	log logger.ILogger
+}
+
+func NewUploadRepo(db *pgxpool.Pool, log logger.ILogger) storage.IUploadStorage {
+	return &uploadRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *uploadRepo) Create(ctx context.Context, upload *models.Upload) error {
+	query := `
+		INSERT INTO uploads (id, user_id, file_name, file_type, upload_length, upload_offset, chunk_keys, status, created_at, updated_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, 0, '{}', $6, $7, $7, $8)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		upload.ID, upload.UserID, upload.FileName, upload.FileType, upload.Length,
+		upload.Status, upload.CreatedAt, upload.ExpiresAt)
+	if err != nil {
+		r.log.Error("failed to create upload", logger.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *uploadRepo) GetByID(ctx context.Context, id string) (*models.Upload, error) {
+	query := `SELECT ` + uploadColumns + ` FROM uploads WHERE id = $1`
+
+	upload, err := scanUpload(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		return nil, fmt.Errorf("get upload by id: %w", err)
+	}
+	return upload, nil
+}
+
+// AppendChunk - offset mos kelsagina bo'lakni qo'shadi; false - boshqa so'rov oldinroq yozgan yoki yuklash tugagan/muddati o'tgan
+func (r *uploadRepo) AppendChunk(ctx context.Context, id string, offset, size int64, key string, expiresAt time.Time) (bool, error) {
+	query := `
+		UPDATE uploads
+		SET upload_offset = upload_offset + $3,
+			chunk_keys = array_append(chunk_keys, $4),
+			updated_at = NOW(),
+			expires_at = $5
+		WHERE id = $1 AND status = 'uploading' AND upload_offset = $2
+			AND upload_offset + $3 <= upload_length AND expires_at > NOW()
+	`
+
+	tag, err := r.db.Exec(ctx, query, id, offset, size, key, expiresAt)
+	if err != nil {
+		r.log.Error("failed to append upload chunk", logger.String("id", id), logger.Error(err))
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ClaimFinalize - to'liq yuklangan uploadni yig'ish uchun band qiladi. staleBefore dan beri finalizing
+// holatida qolgan (yig'ayotgan instansiya o'lgan) upload ham qayta olinadi.
+func (r *uploadRepo) ClaimFinalize(ctx context.Context, id string, staleBefore time.Time) (bool, error) {
+	query := `
+		UPDATE uploads
+		SET status = 'finalizing', updated_at = NOW()
+		WHERE id = $1 AND upload_offset = upload_length
+			AND (status = 'uploading' OR (status = 'finalizing' AND updated_at < $2))
+	`
+
+	tag, err := r.db.Exec(ctx, query, id, staleBefore)
+	if err != nil {
+		r.log.Error("failed to claim upload", logger.String("id", id), logger.Error(err))
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ReleaseFinalize - yig'ish muvaffaqiyatsiz bo'lsa uploadni qayta urinish uchun uploading holatiga qaytaradi
+func (r *uploadRepo) ReleaseFinalize(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `UPDATE uploads SET status = 'uploading', updated_at = NOW() WHERE id = $1 AND status = 'finalizing'`, id)
+	if err != nil {
+		r.log.Error("failed to release upload", logger.String("id", id), logger.Error(err))
+	}
+	return err
+}
+
+func (r *uploadRepo) Complete(ctx context.Context, id, fileID string, expiresAt time.Time) error {
+	query := `
+		UPDATE uploads
+		SET status = 'completed', file_id = $2, chunk_keys = '{}', updated_at = NOW(), expires_at = $3
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, fileID, expiresAt); err != nil {
+		r.log.Error("failed to complete upload", logger.String("id", id), logger.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Delete - yig'ilayotgan uploadni o'chirmaydi; false - upload yo'q yoki finalizing holatida
+func (r *uploadRepo) Delete(ctx context.Context, id string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM uploads WHERE id = $1 AND status <> 'finalizing'`, id)
+	if err != nil {
+		r.log.Error("failed to delete upload", logger.String("id", id), logger.Error(err))
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// GetExpired - muddati o'tgan (tashlab ketilgan yoki allaqachon yig'ilgan) uploadlar
+func (r *uploadRepo) GetExpired(ctx context.Context, before time.Time, limit int) ([]models.Upload, error) {
+	query := `SELECT ` + uploadColumns + ` FROM uploads WHERE expires_at < $1 AND status <> 'finalizing' ORDER BY expires_at LIMIT $2`
+
+	rows, err := r.db.Query(ctx, query, before, limit)
+	if err != nil {
+		r.log.Error("failed to query expired uploads", logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	uploads := []models.Upload{}
+	for rows.Next() {
+		upload, err := scanUpload(rows)
+		if err != nil {
+			r.log.Error("failed to scan upload", logger.Error(err))
+			return nil, err
+		}
+		uploads = append(uploads, *upload)
+	}
+
+	return uploads, rows.Err()
+}
+
+func scanUpload(row pgx.Row) (*models.Upload, error) {
+	var u models.Upload
+	err := row.Scan(&u.ID, &u.UserID, &u.FileName, &u.FileType, &u.Length, &u.Offset, &u.ChunkKeys,
+		&u.Status, &u.FileID, &u.CreatedAt, &u.UpdatedAt, &u.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
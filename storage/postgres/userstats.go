@@ -26,21 +26,26 @@ func (r *statsRepo) GetUserStats(ctx context.Context, userID string) (models.Use
 
 	query := `
 	SELECT
-		(SELECT COUNT(*) FROM merge_jobs WHERE user_id = $1) AS merged,
-		(SELECT COUNT(*) FROM split_jobs WHERE user_id = $1) AS splitted,
-		(SELECT COUNT(*) FROM remove_pages_jobs WHERE user_id = $1) AS removed_pages,
-		(SELECT COUNT(*) FROM compress_jobs WHERE user_id = $1) AS compressed,
-		(SELECT COUNT(*) FROM extract_pages_jobs WHERE user_id = $1) AS extracted,
-		(SELECT COUNT(*) FROM organize_jobs WHERE user_id = $1) AS organized,
-		(SELECT COUNT(*) FROM convert_to_pdf_jobs WHERE user_id = $1 AND conversion_type = 'jpg_to_pdf') AS jpg_to_pdf,
-		(SELECT COUNT(*) FROM convert_from_pdf_jobs WHERE user_id = $1 AND conversion_type = 'pdf_to_jpg') AS pdf_to_jpg,
-		(SELECT COUNT(*) FROM convert_from_pdf_jobs WHERE user_id = $1 AND conversion_type = 'pdf_to_word') AS pdf_to_word,
-		(SELECT COUNT(*) FROM rotate_jobs WHERE user_id = $1) AS rotated,
-		(SELECT COUNT(*) FROM add_page_number_jobs WHERE user_id = $1) AS page_numbered,
-		(SELECT COUNT(*) FROM crop_pdf_jobs WHERE user_id = $1) AS cropped,
-		(SELECT COUNT(*) FROM watermark_jobs WHERE user_id = $1) AS watermarked,
-		(SELECT COUNT(*) FROM protect_jobs WHERE user_id = $1) AS protected,
-		(SELECT COUNT(*) FROM unlock_jobs WHERE user_id = $1) AS unlocked;
+		COUNT(*) FILTER (WHERE type = 'merge') AS merged,
+		COUNT(*) FILTER (WHERE type = 'split') AS splitted,
+		COUNT(*) FILTER (WHERE type = 'remove_pages') AS removed_pages,
+		COUNT(*) FILTER (WHERE type = 'compress') AS compressed,
+		COUNT(*) FILTER (WHERE type = 'extract_pages') AS extracted,
+		COUNT(*) FILTER (WHERE type = 'organize') AS organized,
+		COUNT(*) FILTER (WHERE type = 'jpg_to_pdf') AS jpg_to_pdf,
+		COUNT(*) FILTER (WHERE type = 'pdf_to_jpg') AS pdf_to_jpg,
+		COUNT(*) FILTER (WHERE type = 'pdf_to_word') AS pdf_to_word,
+		COUNT(*) FILTER (WHERE type = 'rotate') AS rotated,
+		COUNT(*) FILTER (WHERE type = 'add_page_numbers') AS page_numbered,
+		COUNT(*) FILTER (WHERE type = 'crop') AS cropped,
+		COUNT(*) FILTER (WHERE type = 'watermark') AS watermarked,
+		COUNT(*) FILTER (WHERE type = 'add_background') AS added_backgrounds,
+		COUNT(*) FILTER (WHERE type = 'protect') AS protected,
+		COUNT(*) FILTER (WHERE type = 'unlock') AS unlocked,
+		(SELECT COUNT(*) FROM files WHERE user_id = $1) AS total_files,
+		(SELECT ROUND(COALESCE(SUM(file_size), 0) / 1048576.0)::INT FROM files WHERE user_id = $1) AS used_storage_mb
+	FROM jobs
+	WHERE user_id = $1;
 	`
 
 	err := r.db.QueryRow(ctx, query, userID).Scan(
@@ -57,8 +62,11 @@ func (r *statsRepo) GetUserStats(ctx context.Context, userID string) (models.Use
 		&stats.AddedPageNumbers,
 		&stats.Cropped,
 		&stats.Watermarked,
+		&stats.AddedBackgrounds,
 		&stats.Protected,
 		&stats.Unlocked,
+		&stats.TotalFiles,
+		&stats.UsedStorageMB,
 	)
 	if err != nil {
 		r.log.Error("failed to get user stats", logger.Error(err))
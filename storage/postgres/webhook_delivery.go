@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"test/api/models"
+	"test/pkg/logger"
+	"test/storage"
+)
+
+type webhookDeliveryRepo struct {
+	db  *pgxpool.Pool
+	log logger.ILogger
+}
+
+func NewWebhookDeliveryRepo(db *pgxpool.Pool, log logger.ILogger) storage.IWebhookDeliveryStorage {
+	return &webhookDeliveryRepo{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *webhookDeliveryRepo) Create(ctx context.Context, d *models.WebhookDelivery) error {
+	query := `
+		INSERT INTO job_webhook_deliveries (
+			id, job_id, url, attempt, status_code, error, success, duration_ms, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		d.ID,
+		d.JobID,
+		d.URL,
+		d.Attempt,
+		d.StatusCode,
+		d.Error,
+		d.Success,
+		d.DurationMS,
+		d.CreatedAt,
+	)
+	if err != nil {
+		r.log.Error("failed to insert webhook delivery", logger.String("jobID", d.JobID), logger.Error(err))
+	}
+	return err
+}
+
+func (r *webhookDeliveryRepo) GetByJobID(ctx context.Context, jobID string) ([]models.WebhookDelivery, error) {
+	query := `
+		SELECT id, job_id, url, attempt, status_code, error, success, duration_ms, created_at
+		FROM job_webhook_deliveries
+		WHERE job_id = $1
+		ORDER BY created_at, attempt
+	`
+
+	rows, err := r.db.Query(ctx, query, jobID)
+	if err != nil {
+		r.log.Error("failed to query webhook deliveries", logger.String("jobID", jobID), logger.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var d models.WebhookDelivery
+		if err := rows.Scan(
+			&d.ID,
+			&d.JobID,
+			&d.URL,
+			&d.Attempt,
+			&d.StatusCode,
+			&d.Error,
+			&d.Success,
+			&d.DurationMS,
+			&d.CreatedAt,
+		); err != nil {
+			r.log.Error("failed to scan webhook delivery", logger.Error(err))
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
@@ -2,6 +2,8 @@ package redis
 
 import (
 	"context"
+	"errors"
+	"strconv"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -37,3 +39,173 @@ func (r *redisRepo) Get(ctx context.Context, key string) (string, error) {
 	}
 	return result, nil
 }
+
+// SetNX - kalit mavjud bo'lmasa qiymatni yozadi; yozilgan bo'lsa true qaytaradi
+func (r *redisRepo) SetNX(ctx context.Context, key string, value interface{}, duration time.Duration) (bool, error) {
+	return r.db.SetNX(ctx, key, value, duration).Result()
+}
+
+func (r *redisRepo) Del(ctx context.Context, key string) error {
+	return r.db.Del(ctx, key).Err()
+}
+
+// Push - qiymatni navbat boshiga qo'shadi
+func (r *redisRepo) Push(ctx context.Context, key string, value interface{}) error {
+	return r.db.LPush(ctx, key, value).Err()
+}
+
+// Move - navbatlardan birining oxiridan qiymatni dest ro'yxatiga atomik o'tkazadi (kalitlar berilgan
+// tartibda tekshiriladi). Hammasi bo'sh bo'lsa birinchi navbat timeout gacha kutiladi (timeout <= 0 - kutilmaydi).
+// Hech narsa kelmasa "" qaytaradi. Qiymat dest dan Remove bilan o'chirilguncha yo'qolmaydi.
+func (r *redisRepo) Move(ctx context.Context, timeout time.Duration, dest string, keys ...string) (string, error) {
+	for _, key := range keys {
+		result, err := r.db.LMove(ctx, key, dest, "RIGHT", "LEFT").Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		return result, err
+	}
+	if len(keys) == 0 || timeout <= 0 {
+		return "", nil
+	}
+
+	result, err := r.db.BLMove(ctx, keys[0], dest, "RIGHT", "LEFT", timeout).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return result, err
+}
+
+// Remove - ro'yxatdan qiymatni (bitta nusxasini) o'chiradi
+func (r *redisRepo) Remove(ctx context.Context, key string, value string) error {
+	return r.db.LRem(ctx, key, 1, value).Err()
+}
+
+// Range - ro'yxatdagi barcha qiymatlar
+func (r *redisRepo) Range(ctx context.Context, key string) ([]string, error) {
+	return r.db.LRange(ctx, key, 0, -1).Result()
+}
+
+// Keys - pattern ga mos kalitlar (SCAN bilan, Redis ni bloklamaydi)
+func (r *redisRepo) Keys(ctx context.Context, pattern string) ([]string, error) {
+	var keys []string
+	iter := r.db.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	return keys, iter.Err()
+}
+
+// Len - navbat uzunligi
+func (r *redisRepo) Len(ctx context.Context, key string) (int64, error) {
+	return r.db.LLen(ctx, key).Result()
+}
+
+// AddDelayed - qiymatni "at" vaqtida bajarish uchun sorted setga qo'shadi
+func (r *redisRepo) AddDelayed(ctx context.Context, key string, value string, at time.Time) error {
+	return r.db.ZAdd(ctx, key, redis.Z{Score: float64(at.Unix()), Member: value}).Err()
+}
+
+// PopDue - vaqti kelgan qiymatlarni sorted setdan olib tashlab qaytaradi.
+// ZRem faqat bitta chaqiruvchida 1 qaytaradi, shuning uchun bir nechta instansiya xavfsiz.
+func (r *redisRepo) PopDue(ctx context.Context, key string, until time.Time, limit int64) ([]string, error) {
+	members, err := r.db.ZRangeByScore(ctx, key, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(until.Unix(), 10),
+		Count: limit,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var due []string
+	for _, m := range members {
+		removed, err := r.db.ZRem(ctx, key, m).Result()
+		if err != nil {
+			return due, err
+		}
+		if removed == 1 {
+			due = append(due, m)
+		}
+	}
+	return due, nil
+}
+
+// RemoveDelayed - kechiktirilgan qiymatni vaqti kelmasdan olib tashlaydi
+func (r *redisRepo) RemoveDelayed(ctx context.Context, key string, value string) error {
+	return r.db.ZRem(ctx, key, value).Err()
+}
+
+// DelayedLen - kechiktirilgan vazifalar soni
+func (r *redisRepo) DelayedLen(ctx context.Context, key string) (int64, error) {
+	return r.db.ZCard(ctx, key).Result()
+}
+
+// acquireLeaseScript - muddati o'tgan leaselarni o'chiradi, keyin member ni joy bo'lsa (yoki u allaqachon
+// egallagan bo'lsa) until gacha band qiladi. Kalit oxirgi lease muddati bilan birga o'chadi.
+// KEYS[1] - slot kaliti; ARGV: now (ms), until (ms), member, limit
+var acquireLeaseScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+if not redis.call('ZSCORE', KEYS[1], ARGV[3]) and redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[4]) then
+	return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[3])
+local last = redis.call('ZRANGE', KEYS[1], -1, -1, 'WITHSCORES')
+redis.call('PEXPIREAT', KEYS[1], last[2])
+return 1
+`)
+
+// AcquireLease - slotni member uchun until gacha band qiladi; bo'sh slot bo'lmasa false qaytaradi.
+// Lease muddati o'tsa (instansiya o'chib qolsa), slot keyingi AcquireLease da o'z-o'zidan bo'shaydi.
+func (r *redisRepo) AcquireLease(ctx context.Context, key, member string, limit int64, until time.Time) (bool, error) {
+	n, err := acquireLeaseScript.Run(ctx, r.db, []string{key},
+		time.Now().UnixMilli(), until.UnixMilli(), member, limit).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// ReleaseLease - member ning leaseini o'chiradi (lease yo'q bo'lsa hech narsa qilmaydi)
+func (r *redisRepo) ReleaseLease(ctx context.Context, key, member string) error {
+	return r.db.ZRem(ctx, key, member).Err()
+}
+
+// Publish - kanalga xabar yuboradi
+func (r *redisRepo) Publish(ctx context.Context, channel string, message interface{}) error {
+	return r.db.Publish(ctx, channel, message).Err()
+}
+
+// Subscribe - kanalga obuna bo'ladi; qaytgan funksiya obunani yopadi
+func (r *redisRepo) Subscribe(ctx context.Context, channel string) (<-chan string, func() error, error) {
+	pubsub := r.db.Subscribe(ctx, channel)
+
+	// Obuna haqiqatan o'rnatilganini kutamiz, aks holda birinchi xabarlar yo'qolishi mumkin
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, nil, err
+	}
+
+	messages := make(chan string)
+	go func() {
+		defer close(messages)
+		ch := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- msg.Payload:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return messages, pubsub.Close, nil
+}
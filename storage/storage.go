@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"test/api/models"
+	"test/pkg/blobstore"
 )
 
 type IStorage interface {
@@ -13,35 +14,23 @@ type IStorage interface {
 	Role() IRoleStorage
 	Sysuser() ISysuserStorage
 	Redis() IRedisStorage
+	Blob() blobstore.BlobStore
 	Close()
-	Merge() IMergeStorage
 	File() IFileStorage
-	Split() ISplitStorage
-	RemovePage() IRemovePageStorage
-	ExtractPage() IExtractPageStorage
-
-	Compress() ICompressStorage
-	PDFToJPG() IPDFToJPGStorage
-	Rotate() IRotateStorage
-	AddPageNumber() IAddPageNumberStorage
-	Crop() ICropPDFStorage
-	Unlock() IUnlockPDFStorage
-	Protect() IProtectStorage
+	Job() IJobStorage
+	WebhookDelivery() IWebhookDeliveryStorage
+	JobLimit() IJobLimitStorage
+	Upload() IUploadStorage
+	FileBlob() IFileBlobStorage
+	JobCache() IJobCacheStorage
+	Quota() IQuotaStorage
+	CleanupRun() ICleanupRunStorage
+	Folder() IFolderStorage
+
 	Stat() IStatStorage
 	Log() ILogService
-	JPGToPDF() IJPGToPDFStorage
-	Inspect() IInspectStorage
 
 	SharedLink() ISharedLinkStorage
-	AddHeaderFooter() AddHeaderFooterStorage
-	DetectBlankPages() IDetectBlankPagesStorage
-	QRCode() IQRCodeStorage
-
-	PDFToWord() IPDFToWordStorage
-	WordToPDF() IWordToPDFStorage
-	ExcelToPDF() IExcelToPDFStorage
-	PowerPointToPDF() IPowerPointToPDFStorage
-	HTMLToPDF() IHTMLToPDFStorage
 }
 
 type IUserStorage interface {
@@ -73,89 +62,131 @@ type ISysuserStorage interface {
 type IRedisStorage interface {
 	SetX(ctx context.Context, key string, value interface{}, duration time.Duration) error
 	Get(ctx context.Context, key string) (string, error)
+	SetNX(ctx context.Context, key string, value interface{}, duration time.Duration) (bool, error)
+	Del(ctx context.Context, key string) error
+
+	// Navbat (queue) amallari
+	Push(ctx context.Context, key string, value interface{}) error
+	Move(ctx context.Context, timeout time.Duration, dest string, keys ...string) (string, error)
+	Remove(ctx context.Context, key string, value string) error
+	Range(ctx context.Context, key string) ([]string, error)
+	Keys(ctx context.Context, pattern string) ([]string, error)
+	Len(ctx context.Context, key string) (int64, error)
+
+	// Kechiktirilgan vazifalar (sorted set, score = bajarilish vaqti)
+	AddDelayed(ctx context.Context, key string, value string, at time.Time) error
+	PopDue(ctx context.Context, key string, until time.Time, limit int64) ([]string, error)
+	RemoveDelayed(ctx context.Context, key string, value string) error
+	DelayedLen(ctx context.Context, key string) (int64, error)
+
+	// Slotlar (bir vaqtda bajarilayotgan joblar): har bir job muddatli lease egallaydi
+	AcquireLease(ctx context.Context, key, member string, limit int64, until time.Time) (bool, error)
+	ReleaseLease(ctx context.Context, key, member string) error
+
+	// Pub/Sub (bir nechta API instansiyasi orasida hodisalarni tarqatish)
+	Publish(ctx context.Context, channel string, message interface{}) error
+	Subscribe(ctx context.Context, channel string) (<-chan string, func() error, error)
 }
 
 type IFileStorage interface {
 	Save(ctx context.Context, file models.File) (string, error)
+	SaveWithinQuota(ctx context.Context, file models.File, maxBytes, maxFiles int64) (bool, error)
 	GetByID(ctx context.Context, id string) (models.File, error)
+	GetByHash(ctx context.Context, userID, sha256 string) (models.File, error)
 	Delete(ctx context.Context, id string) error
-	ListByUser(ctx context.Context, userID string) ([]models.File, error)
-
-	GetOldFiles(ctx context.Context, olderThanDays int) ([]models.OldFile, error)
+	Search(ctx context.Context, filter models.FileFilter) ([]models.File, int, error)
+	Update(ctx context.Context, file models.File) error
+	ListTags(ctx context.Context, userID string) ([]models.TagCount, error)
+	SetSourceJob(ctx context.Context, jobID string, fileIDs []string) error
+
+	GetExpired(ctx context.Context, guestBefore time.Time, userBefore *time.Time, limit int) ([]models.OldFile, error)
+	SetPinned(ctx context.Context, id string, pinned bool) error
+	ExistsByPath(ctx context.Context, path string) (bool, error)
+	ListAfter(ctx context.Context, afterID string, limit int) ([]models.File, error)
 	DeleteByID(ctx context.Context, id string) error
 }
-type IMergeStorage interface {
-	Create(ctx context.Context, job *models.MergeJob) error
-	GetByID(ctx context.Context, id string) (*models.MergeJob, error)
-	AddInputFiles(ctx context.Context, jobID string, fileIDs []string) error
-	GetInputFiles(ctx context.Context, jobID string) ([]string, error)
-	Update(ctx context.Context, job *models.MergeJob) error // 👉 YANGI QATOR
-
-}
-
-type ISplitStorage interface {
-	Create(ctx context.Context, job *models.SplitJob) error
-	GetByID(ctx context.Context, id string) (*models.SplitJob, error)
-	UpdateOutputFiles(ctx context.Context, jobID string, outputIDs []string) error
-}
-
-type IRemovePageStorage interface {
-	Create(ctx context.Context, job *models.RemoveJob) error
-	Update(ctx context.Context, job *models.RemoveJob) error
-	GetByID(ctx context.Context, id string) (*models.RemoveJob, error)
-}
-
-type IExtractPageStorage interface {
-	Create(ctx context.Context, job *models.ExtractJob) error
-	Update(ctx context.Context, job *models.ExtractJob) error
-	GetByID(ctx context.Context, id string) (*models.ExtractJob, error)
-}
-
-type IOrganizeStorage interface {
-	Create(ctx context.Context, job *models.OrganizeJob) error
-	Update(ctx context.Context, job *models.OrganizeJob) error
-	GetByID(ctx context.Context, id string) (*models.OrganizeJob, error)
-}
-
-type ICompressStorage interface {
-	Create(ctx context.Context, job *models.CompressJob) error
-	Update(ctx context.Context, job *models.CompressJob) error
-	GetByID(ctx context.Context, id string) (*models.CompressJob, error)
-}
-
-type IPDFToJPGStorage interface {
-	Create(ctx context.Context, job *models.PDFToJPGJob) error
-	Update(ctx context.Context, job *models.PDFToJPGJob) error
-	GetByID(ctx context.Context, id string) (*models.PDFToJPGJob, error)
-}
-
-type IRotateStorage interface {
-	Create(ctx context.Context, job *models.RotateJob) error
-	GetByID(ctx context.Context, id string) (*models.RotateJob, error)
-	Update(ctx context.Context, job *models.RotateJob) error
-}
-
-type IAddPageNumberStorage interface {
-	Create(ctx context.Context, job *models.AddPageNumberJob) error
-	GetByID(ctx context.Context, id string) (*models.AddPageNumberJob, error)
-	Update(ctx context.Context, job *models.AddPageNumberJob) error
-}
-
-type ICropPDFStorage interface {
-	Create(ctx context.Context, job *models.CropPDFJob) error
-	GetByID(ctx context.Context, id string) (*models.CropPDFJob, error)
-	Update(ctx context.Context, job *models.CropPDFJob) error
-}
-
-type IUnlockPDFStorage interface {
-	Create(ctx context.Context, job *models.UnlockPDFJob) error
-	GetByID(ctx context.Context, id string) (*models.UnlockPDFJob, error)
-	Update(ctx context.Context, job *models.UnlockPDFJob) error
-}
-type IProtectStorage interface {
-	Create(ctx context.Context, job *models.ProtectPDFJob) error
-	GetByID(ctx context.Context, id string) (*models.ProtectPDFJob, error)
-	Update(ctx context.Context, job *models.ProtectPDFJob) error
+// IFolderStorage – foydalanuvchi fayllari kutubxonasidagi papkalar
+type IFolderStorage interface {
+	Create(ctx context.Context, folder *models.Folder) error
+	GetByID(ctx context.Context, id string) (*models.Folder, error)
+	GetList(ctx context.Context, userID string, parentID *string) ([]models.Folder, error)
+	Update(ctx context.Context, folder *models.Folder) error
+	Delete(ctx context.Context, id string) error
+	IsDescendant(ctx context.Context, folderID, ancestorID string) (bool, error)
+}
+
+// IJobStorage – barcha amallar uchun umumiy `jobs` jadvali
+type IJobStorage interface {
+	Create(ctx context.Context, job *models.Job) error
+	GetByID(ctx context.Context, id string) (*models.Job, error)
+	GetList(ctx context.Context, filter models.JobFilter) ([]models.Job, error)
+	Update(ctx context.Context, job *models.Job) error
+	Claim(ctx context.Context, id string) (bool, error)  // pending -> processing, faqat bitta worker oladi
+	Reset(ctx context.Context, id string) (bool, error)  // failed/dead/cancelled -> pending, qo'lda qayta urinish uchun
+	Cancel(ctx context.Context, id string) (bool, error) // pending/processing -> cancelled
+	// ReapStale - worker o'chib qolgani uchun osilib qolgan processing -> pending (urinishlar tugagan bo'lsa dead)
+	ReapStale(ctx context.Context, olderThan time.Duration) ([]models.Job, error)
+	Delete(ctx context.Context, id string) error
+	CountActiveByType(ctx context.Context) ([]models.JobTypeStat, error)
+}
+
+// IJobLimitStorage – amal turlari bo'yicha worker chegaralari
+type IJobLimitStorage interface {
+	GetAll(ctx context.Context) ([]models.JobTypeLimit, error)
+	Upsert(ctx context.Context, limit models.JobTypeLimit) error
+	Delete(ctx context.Context, jobType string) (bool, error)
+}
+
+// IFileBlobStorage – kontent manzilli bloblarning havola hisoblagichi. put/remove bir xil kalit
+// uchun qulf ostida chaqiriladi, shuning uchun bir vaqtda saqlash va o'chirish bir-biriga xalaqit bermaydi.
+type IFileBlobStorage interface {
+	// Acquire - havolani oshiradi; blob hali yo'q bo'lsa avval put chaqiriladi (true - yangi blob yozildi)
+	Acquire(ctx context.Context, blob models.FileBlob, put func(ctx context.Context) error) (bool, error)
+	// Release - havolani kamaytiradi; oxirgisi bo'lsa remove chaqiriladi (true - blob o'chirildi)
+	Release(ctx context.Context, key string, remove func(ctx context.Context) error) (bool, error)
+	// Exists - kalit file_blobs da bormi (ref_count = 0 bo'lsa ham - u qayta ishlatilishi mumkin)
+	Exists(ctx context.Context, key string) (bool, error)
+	// Rewrite - blob ishlatilayotgan bo'lsa (ref_count > 0) qulf ostida rewrite chaqiriladi, shunda u
+	// qayta yozilayotganda o'chirilmaydi (false - blob yo'q yoki havolasi qolmagan)
+	Rewrite(ctx context.Context, key string, rewrite func(ctx context.Context) error) (bool, error)
+	// ListAfter - kalit bo'yicha tartiblangan sahifa (afterKey "" - boshidan)
+	ListAfter(ctx context.Context, afterKey string, limit int) ([]models.FileBlob, error)
+}
+
+// IJobCacheStorage – amal natijalari keshi
+type IJobCacheStorage interface {
+	Get(ctx context.Context, key string) (*models.JobCacheEntry, error)
+	Create(ctx context.Context, entry *models.JobCacheEntry) (bool, error)
+	Hit(ctx context.Context, key string) error
+	Delete(ctx context.Context, filter models.JobCacheFilter) ([]models.JobCacheEntry, error)
+	Stats(ctx context.Context) ([]models.JobCacheTypeStat, error)
+}
+
+// IQuotaStorage – tariflar, foydalanuvchi kvotalari va egallangan joy
+type IQuotaStorage interface {
+	GetPlans(ctx context.Context) ([]models.QuotaPlan, error)
+	GetEffective(ctx context.Context, userID string) (models.UserQuota, error)
+	GetUsage(ctx context.Context, userID string) (models.StorageUsage, error)
+	Upsert(ctx context.Context, override models.UserQuotaOverride) error
+	Delete(ctx context.Context, userID string) (bool, error)
+}
+
+// IUploadStorage – bo'laklab yuklanayotgan fayllar holati
+type IUploadStorage interface {
+	Create(ctx context.Context, upload *models.Upload) error
+	GetByID(ctx context.Context, id string) (*models.Upload, error)
+	AppendChunk(ctx context.Context, id string, offset, size int64, key string, expiresAt time.Time) (bool, error)
+	ClaimFinalize(ctx context.Context, id string, staleBefore time.Time) (bool, error)
+	ReleaseFinalize(ctx context.Context, id string) error
+	Complete(ctx context.Context, id, fileID string, expiresAt time.Time) error
+	Delete(ctx context.Context, id string) (bool, error)
+	GetExpired(ctx context.Context, before time.Time, limit int) ([]models.Upload, error)
+}
+
+// IWebhookDeliveryStorage – job webhook yuborishlari logi
+type IWebhookDeliveryStorage interface {
+	Create(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetByJobID(ctx context.Context, jobID string) ([]models.WebhookDelivery, error)
 }
 
 type IStatStorage interface {
@@ -165,65 +196,15 @@ type IStatStorage interface {
 type ILogService interface {
 	GetLogsByJobID(ctx context.Context, jobID string) ([]models.Log, error)
 }
-type IJPGToPDFStorage interface {
-	Create(ctx context.Context, job *models.JPGToPDFJob) error
-	GetByID(ctx context.Context, id string) (*models.JPGToPDFJob, error)
-	UpdateStatusAndOutput(ctx context.Context, id, status, outputFileID string) error
-}
-
-type IInspectStorage interface {
-	Create(ctx context.Context, job *models.InspectJob) error
-	GetByID(ctx context.Context, id string) (*models.InspectJob, error)
-}
-
 type ISharedLinkStorage interface {
 	Create(ctx context.Context, req *models.SharedLink) error
 	GetByToken(ctx context.Context, token string) (*models.SharedLink, error)
+	DeleteExpired(ctx context.Context) (int64, error)
 }
 
-type AddHeaderFooterStorage interface {
-	Create(ctx context.Context, job *models.AddHeaderFooterJob) error
-	Update(ctx context.Context, job *models.AddHeaderFooterJob) error
-	GetByID(ctx context.Context, id string) (*models.AddHeaderFooterJob, error)
-}
-
-type IDetectBlankPagesStorage interface {
-	Create(ctx context.Context, job *models.DetectBlankPagesJob) error
-	Update(ctx context.Context, job *models.DetectBlankPagesJob) error
-	GetByID(ctx context.Context, id string) (*models.DetectBlankPagesJob, error)
-}
-
-type IQRCodeStorage interface {
-	Create(ctx context.Context, job *models.QRCodeJob) error
-	Update(ctx context.Context, job *models.QRCodeJob) error
-	GetByID(ctx context.Context, id string) (*models.QRCodeJob, error)
-}
-
-type IPDFToWordStorage interface {
-	Create(ctx context.Context, job *models.PDFToWordJob) error
-	GetByID(ctx context.Context, id string) (*models.PDFToWordJob, error)
-	Update(ctx context.Context, job *models.PDFToWordJob) error
-}
-type IWordToPDFStorage interface {
-	Create(ctx context.Context, job *models.WordToPDFJob) error
-	GetByID(ctx context.Context, id string) (*models.WordToPDFJob, error)
-	Update(ctx context.Context, job *models.WordToPDFJob) error
-}
-
-type IExcelToPDFStorage interface {
-	Create(ctx context.Context, job *models.ExcelToPDFJob) error
-	GetByID(ctx context.Context, id string) (*models.ExcelToPDFJob, error)
-	Update(ctx context.Context, job *models.ExcelToPDFJob) error
-}
-
-type IPowerPointToPDFStorage interface {
-	Create(ctx context.Context, job *models.PowerPointToPDFJob) error
-	GetByID(ctx context.Context, id string) (*models.PowerPointToPDFJob, error)
-	Update(ctx context.Context, job *models.PowerPointToPDFJob) error
-}
-
-type IHTMLToPDFStorage interface {
-	Create(ctx context.Context, job *models.HTMLToPDFJob) error
-	GetByID(ctx context.Context, id string) (*models.HTMLToPDFJob, error)
-	Update(ctx context.Context, job *models.HTMLToPDFJob) error
+// ICleanupRunStorage – saqlash muddati bo'yicha tozalashlar tarixi
+type ICleanupRunStorage interface {
+	Create(ctx context.Context, run *models.CleanupRun) error
+	Finish(ctx context.Context, run *models.CleanupRun) error
+	GetList(ctx context.Context, limit int) ([]models.CleanupRun, error)
 }